@@ -7,31 +7,230 @@ It is largely unmodified from the original XGB package that I forked.
 */
 
 import (
+	"crypto/cipher"
+	"crypto/des"
 	"encoding/binary"
 	"errors"
 	"io"
+	"net"
 	"os"
+	"sync"
+	"time"
 )
 
-// readAuthority reads the X authority file for the DISPLAY.
-// If hostname == "" or hostname == "localhost",
-// then use the system's hostname (as returned by os.Hostname) instead.
-func readAuthority(hostname, display string) (
-	name string, data []byte, err error) {
+// As per /usr/include/X11/Xauth.h.
+const (
+	// AuthFamilyLocal is the family of authority entries for connections
+	// over a local (Unix domain) socket. The address of such entries is
+	// a hostname.
+	AuthFamilyLocal = 256
+
+	// AuthFamilyWild is the family of authority entries that match any
+	// address.
+	AuthFamilyWild = 65535
+)
 
-	// b is a scratch buffer to use and should be at least 256 bytes long
-	// (i.e. it should be able to hold a hostname).
-	b := make([]byte, 256)
+// AuthEntry is a single entry in an X authority file.
+type AuthEntry struct {
+	Family  uint16
+	Address string
+	Number  string // the display number, e.g., "0"
+	Name    string // the name of the authorization protocol
+	Data    []byte
+}
 
-	// As per /usr/include/X11/Xauth.h.
-	const familyLocal = 256
+// ParseXauthority reads every entry of an X authority file (usually
+// ~/.Xauthority) from 'r'.
+func ParseXauthority(r io.Reader) ([]AuthEntry, error) {
+	var entries []AuthEntry
+	for {
+		var family uint16
+		err := binary.Read(r, binary.BigEndian, &family)
+		if err == io.EOF {
+			return entries, nil
+		} else if err != nil {
+			return nil, err
+		}
 
-	if len(hostname) == 0 || hostname == "localhost" {
-		hostname, err = os.Hostname()
-		if err != nil {
-			return "", nil, err
+		entry := AuthEntry{Family: family}
+		if entry.Address, err = getString(r); err != nil {
+			return nil, err
+		}
+		if entry.Number, err = getString(r); err != nil {
+			return nil, err
+		}
+		if entry.Name, err = getString(r); err != nil {
+			return nil, err
+		}
+		if entry.Data, err = getBytes(r); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	panic("unreachable")
+}
+
+// LookupAuthority finds the first entry in 'entries' that can be used to
+// connect to display number 'display' on 'host'.
+// If host == "" or host == "localhost", then the system's hostname (as
+// returned by os.Hostname) is used instead. Like Xlib, entries with an
+// empty display number match any display.
+func LookupAuthority(entries []AuthEntry, host, display string) (
+	AuthEntry, bool) {
+
+	if len(host) == 0 || host == "localhost" {
+		if hostname, err := os.Hostname(); err == nil {
+			host = hostname
+		}
+	}
+
+	for _, entry := range entries {
+		switch {
+		case entry.Family == AuthFamilyWild:
+		case entry.Family == AuthFamilyLocal && entry.Address == host:
+		default:
+			continue
+		}
+		if len(entry.Number) == 0 || entry.Number == display {
+			return entry, true
+		}
+	}
+	return AuthEntry{}, false
+}
+
+// AuthHandler implements an authorization protocol used in the connection
+// setup handshake, like MIT-MAGIC-COOKIE-1.
+type AuthHandler interface {
+	// AuthData computes the authorization data to send to the X server.
+	// 'data' is the data found in the authority entry, and 'local' is the
+	// local address of the connection to the X server. 'local' is nil when
+	// the connection is not a net.Conn.
+	AuthData(data []byte, local net.Addr) ([]byte, error)
+}
+
+var (
+	authLock     sync.Mutex
+	authHandlers = map[string]AuthHandler{
+		"MIT-MAGIC-COOKIE-1":  mitMagicCookie{},
+		"XDM-AUTHORIZATION-1": xdmAuthorization{},
+	}
+)
+
+// RegisterAuthHandler makes the authorization protocol called 'name' (as
+// it is named in authority files) available to new connections. It replaces
+// any handler previously registered under that name.
+func RegisterAuthHandler(name string, handler AuthHandler) {
+	authLock.Lock()
+	defer authLock.Unlock()
+
+	authHandlers[name] = handler
+}
+
+// authHandler returns the handler for the authorization protocol 'name'.
+func authHandler(name string) (AuthHandler, bool) {
+	authLock.Lock()
+	defer authLock.Unlock()
+
+	handler, ok := authHandlers[name]
+	return handler, ok
+}
+
+// mitMagicCookie implements MIT-MAGIC-COOKIE-1, where the data from the
+// authority entry is sent to the server as is.
+type mitMagicCookie struct{}
+
+func (mitMagicCookie) AuthData(data []byte, local net.Addr) ([]byte, error) {
+	if len(data) != 16 {
+		return nil, errors.New("MIT-MAGIC-COOKIE-1 needs 16 bytes " +
+			"of data")
+	}
+	return data, nil
+}
+
+// xdmNonce is used to make up an address for XDM-AUTHORIZATION-1 when
+// connecting over a Unix domain socket.
+var xdmNonce struct {
+	sync.Mutex
+	n uint32
+}
+
+// xdmAuthorization implements XDM-AUTHORIZATION-1. The first 8 bytes of
+// the data from the authority entry are sent along with the client's address
+// and the current time, all encrypted with DES using the last 8 bytes of the
+// data as the key.
+// This is modeled after xcb_auth.c in libxcb and Wrap.c in libXdmcp.
+type xdmAuthorization struct{}
+
+func (xdmAuthorization) AuthData(data []byte, local net.Addr) (
+	[]byte, error) {
+
+	if len(data) != 16 {
+		return nil, errors.New("XDM-AUTHORIZATION-1 needs 16 bytes " +
+			"of data")
+	}
+
+	// 8 bytes of data, a 4 byte address, a 2 byte port and a 4 byte
+	// timestamp, all in network byte order and padded to 24 bytes.
+	plain := make([]byte, 24)
+	copy(plain, data[:8])
+	switch addr := local.(type) {
+	case *net.TCPAddr:
+		ip := addr.IP.To4()
+		if ip == nil {
+			return nil, errors.New("XDM-AUTHORIZATION-1 is only " +
+				"supported over IPv4")
 		}
+		copy(plain[8:], ip)
+		binary.BigEndian.PutUint16(plain[12:], uint16(addr.Port))
+	default:
+		// Like libxcb, make up a unique address and use the pid as the
+		// port for local connections.
+		xdmNonce.Lock()
+		nonce := xdmNonce.n
+		xdmNonce.n++
+		xdmNonce.Unlock()
+
+		binary.BigEndian.PutUint32(plain[8:], 0xffffffff-nonce)
+		binary.BigEndian.PutUint16(plain[12:], uint16(os.Getpid()))
+	}
+	binary.BigEndian.PutUint32(plain[14:], uint32(time.Now().Unix()))
+	return xdmWrap(plain, data[8:])
+}
+
+// xdmWrap encrypts 'plain', whose length is a multiple of 8, with the key
+// in 'wrapper', like XdmcpWrap of libXdmcp does.
+func xdmWrap(plain, wrapper []byte) ([]byte, error) {
+	block, err := des.NewCipher(xdmKey(wrapper))
+	if err != nil {
+		return nil, err
+	}
+
+	// Each block is XOR'd with the previous encrypted block before being
+	// encrypted itself, which is CBC with a zero IV.
+	enc := make([]byte, len(plain))
+	cipher.NewCBCEncrypter(block, make([]byte, des.BlockSize)).
+		CryptBlocks(enc, plain)
+	return enc, nil
+}
+
+// xdmKey expands the 56 bit key stored in the last 7 bytes of 'wrapper'
+// into an 8 byte DES key, where the low bit of each byte is a parity bit.
+// (crypto/des ignores parity bits, so they are left unset.)
+func xdmKey(wrapper []byte) []byte {
+	key := make([]byte, 8)
+	for i := 0; i < 7; i++ {
+		c := (wrapper[i]<<uint(7-i) | wrapper[i+1]>>uint(i+1)) & 0x7f
+		key[i] = c << 1
 	}
+	key[7] = (wrapper[7] & 0x7f) << 1
+	return key
+}
+
+// readAuthority reads the X authority file for the DISPLAY.
+// If hostname == "" or hostname == "localhost",
+// then use the system's hostname (as returned by os.Hostname) instead.
+func readAuthority(hostname, display string) (
+	name string, data []byte, err error) {
 
 	fname := os.Getenv("XAUTHORITY")
 	if len(fname) == 0 {
@@ -49,57 +248,45 @@ func readAuthority(hostname, display string) (
 	}
 	defer r.Close()
 
-	for {
-		var family uint16
-		if err := binary.Read(r, binary.BigEndian, &family); err != nil {
-			return "", nil, err
-		}
-
-		addr, err := getString(r, b)
-		if err != nil {
-			return "", nil, err
-		}
-
-		disp, err := getString(r, b)
-		if err != nil {
-			return "", nil, err
-		}
-
-		name0, err := getString(r, b)
-		if err != nil {
-			return "", nil, err
-		}
-
-		data0, err := getBytes(r, b)
-		if err != nil {
-			return "", nil, err
-		}
+	entries, err := ParseXauthority(r)
+	if err != nil {
+		return "", nil, err
+	}
 
-		if family == familyLocal && addr == hostname && disp == display {
-			return name0, data0, nil
-		}
+	entry, ok := LookupAuthority(entries, hostname, display)
+	if !ok {
+		err = errors.New("no authority entry found in " + fname)
+		return "", nil, err
 	}
-	panic("unreachable")
+	return entry.Name, entry.Data, nil
 }
 
-func getBytes(r io.Reader, b []byte) ([]byte, error) {
+func getBytes(r io.Reader) ([]byte, error) {
 	var n uint16
 	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
-		return nil, err
-	} else if n > uint16(len(b)) {
-		return nil, errors.New("bytes too long for buffer")
+		return nil, unexpectedEOF(err)
 	}
 
-	if _, err := io.ReadFull(r, b[0:n]); err != nil {
-		return nil, err
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, unexpectedEOF(err)
 	}
-	return b[0:n], nil
+	return b, nil
 }
 
-func getString(r io.Reader, b []byte) (string, error) {
-	b, err := getBytes(r, b)
+func getString(r io.Reader) (string, error) {
+	b, err := getBytes(r)
 	if err != nil {
 		return "", err
 	}
 	return string(b), nil
 }
+
+// unexpectedEOF turns io.EOF into io.ErrUnexpectedEOF, since running out of
+// data in the middle of an entry means the authority file is truncated.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,173 @@
+package xgb
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/des"
+	"encoding/binary"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testEntries is written to an in-memory authority file by xauthority.
+var testEntries = []AuthEntry{
+	{AuthFamilyLocal, "otherhost", "0", "MIT-MAGIC-COOKIE-1", []byte("a")},
+	{AuthFamilyLocal, "myhost", "1", "MIT-MAGIC-COOKIE-1", []byte("b")},
+	{AuthFamilyLocal, "myhost", "", "MIT-MAGIC-COOKIE-1", []byte("c")},
+	{AuthFamilyWild, "", "2", "XDM-AUTHORIZATION-1", []byte("d")},
+}
+
+// TestParseXauthority writes a few entries to an authority file and makes
+// sure they are read back unchanged.
+func TestParseXauthority(t *testing.T) {
+	buf := xauthority(testEntries)
+	entries, err := ParseXauthority(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatalf("ParseXauthority: %s", err)
+	}
+	if !reflect.DeepEqual(entries, testEntries) {
+		t.Fatalf("Read entries\n%v\nbut expected\n%v",
+			entries, testEntries)
+	}
+}
+
+// TestParseXauthorityTruncated makes sure that an authority file that ends
+// in the middle of an entry is reported as an error.
+func TestParseXauthorityTruncated(t *testing.T) {
+	buf := xauthority(testEntries)
+	_, err := ParseXauthority(bytes.NewReader(buf[:len(buf)-1]))
+	if err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected io.ErrUnexpectedEOF but got '%v'.", err)
+	}
+}
+
+// TestLookupAuthority checks which entry is picked for a few different
+// hosts and displays.
+func TestLookupAuthority(t *testing.T) {
+	tests := []struct {
+		host, display string
+		data          string // "" if no entry should be found
+	}{
+		{"otherhost", "0", "a"},
+		{"otherhost", "1", ""},
+		{"myhost", "1", "b"},
+		{"myhost", "0", "c"},
+		{"anyhost", "2", "d"},
+	}
+	for _, test := range tests {
+		entry, ok := LookupAuthority(testEntries,
+			test.host, test.display)
+		switch {
+		case ok && len(test.data) == 0:
+			t.Errorf("%s:%s: expected no entry, but got %v",
+				test.host, test.display, entry)
+		case !ok && len(test.data) > 0:
+			t.Errorf("%s:%s: expected an entry, but found none",
+				test.host, test.display)
+		case ok && string(entry.Data) != test.data:
+			t.Errorf("%s:%s: expected data %q, but got %q",
+				test.host, test.display, test.data, entry.Data)
+		}
+	}
+}
+
+// xauthority encodes 'entries' in the format of an X authority file.
+func xauthority(entries []AuthEntry) []byte {
+	var buf bytes.Buffer
+	field := func(b []byte) {
+		binary.Write(&buf, binary.BigEndian, uint16(len(b)))
+		buf.Write(b)
+	}
+	for _, entry := range entries {
+		binary.Write(&buf, binary.BigEndian, entry.Family)
+		field([]byte(entry.Address))
+		field([]byte(entry.Number))
+		field([]byte(entry.Name))
+		field(entry.Data)
+	}
+	return buf.Bytes()
+}
+
+// TestXDMAuthorization encrypts XDM-AUTHORIZATION-1 data for a TCP
+// connection, then decrypts it again and checks its contents.
+func TestXDMAuthorization(t *testing.T) {
+	data := []byte("authdataDESkey!!")
+	local := &net.TCPAddr{IP: net.IPv4(192, 168, 1, 2), Port: 6000}
+
+	start := uint32(time.Now().Unix())
+	enc, err := xdmAuthorization{}.AuthData(data, local)
+	if err != nil {
+		t.Fatalf("AuthData: %s", err)
+	}
+	if len(enc) != 24 {
+		t.Fatalf("Expected 24 bytes of auth data, but got %d.",
+			len(enc))
+	}
+
+	block, err := des.NewCipher(xdmKey(data[8:]))
+	if err != nil {
+		t.Fatalf("NewCipher: %s", err)
+	}
+	plain := make([]byte, len(enc))
+	cipher.NewCBCDecrypter(block, make([]byte, des.BlockSize)).
+		CryptBlocks(plain, enc)
+
+	if !bytes.Equal(plain[:8], data[:8]) {
+		t.Fatalf("Expected decrypted data %q but got %q",
+			data[:8], plain[:8])
+	}
+	if !bytes.Equal(plain[8:14], []byte{192, 168, 1, 2, 0x17, 0x70}) {
+		t.Fatalf("Wrong address and port: % x", plain[8:14])
+	}
+	stamp := binary.BigEndian.Uint32(plain[14:])
+	if stamp < start || stamp > uint32(time.Now().Unix()) {
+		t.Fatalf("Timestamp %d is not the current time.", stamp)
+	}
+}
+
+// TestXDMKey checks that the 56 bit key is spread over the high 7 bits of
+// each byte of the DES key.
+func TestXDMKey(t *testing.T) {
+	key := xdmKey([]byte{0xff, 0xff, 0, 0, 0, 0, 0, 0x7f})
+	want := []byte{0xfe, 0x80, 0, 0, 0, 0, 0, 0xfe}
+	if !bytes.Equal(key, want) {
+		t.Fatalf("Expected key % x but got % x", want, key)
+	}
+}
+
+// TestXDMWrapLibXdmcp checks the key and the encrypted data against what
+// _XdmcpWrapperToOddParity and XdmcpWrap of libXdmcp give for the same
+// input. The top bit of the last byte of the wrapper is set, since it is
+// dropped from the last byte of the key.
+func TestXDMWrapLibXdmcp(t *testing.T) {
+	wrapper := []byte{0x00, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xff}
+	plain := append([]byte("authdata"),
+		192, 168, 1, 2, 0x17, 0x70, 0x5f, 0x5e, 0x10, 0x00,
+		0, 0, 0, 0, 0, 0)
+
+	// libXdmcp sets the parity bits, which crypto/des ignores.
+	libKey := []byte{0x13, 0x1a, 0x15, 0xce, 0x89, 0xd5, 0xf2, 0xff}
+	key := xdmKey(wrapper)
+	for i := range libKey {
+		if key[i] != libKey[i]&^1 {
+			t.Fatalf("Expected key % x (without parity bits), but "+
+				"got % x", libKey, key)
+		}
+	}
+
+	want := []byte{
+		0x8d, 0x54, 0x56, 0xf1, 0x25, 0x64, 0x89, 0x10,
+		0xe1, 0xd6, 0x03, 0x4f, 0xc1, 0xa2, 0xe5, 0xab,
+		0xc7, 0x66, 0x98, 0x55, 0x31, 0x7c, 0x10, 0x01,
+	}
+	enc, err := xdmWrap(plain, wrapper)
+	if err != nil {
+		t.Fatalf("xdmWrap: %s", err)
+	}
+	if !bytes.Equal(enc, want) {
+		t.Fatalf("Expected\n% x\nbut got\n% x", want, enc)
+	}
+}
@@ -0,0 +1,129 @@
+package xgb
+
+import (
+	"net"
+)
+
+// BatchWriter collects the requests made in the function given to Batch.
+// It is used just like NewCookie and NewRequest on a Conn.
+type BatchWriter struct {
+	conn    *Conn
+	bufs    [][]byte
+	cookies []*Cookie
+	sent    chan error
+}
+
+// NewCookie is just like NewCookie on the Conn the batch is made on.
+func (b *BatchWriter) NewCookie(checked, reply bool) *Cookie {
+	return b.conn.NewCookie(checked, reply)
+}
+
+// NewRequest adds a request to the batch. Like NewRequest on a Conn, the
+// sequence number is added to 'cookie' just before it is sent to X, so
+// 'cookie' should not be used until Batch has returned.
+func (b *BatchWriter) NewRequest(buf []byte, cookie *Cookie) {
+	b.bufs = append(b.bufs, buf)
+	b.cookies = append(b.cookies, cookie)
+}
+
+// Batch calls 'fill' to collect a number of requests, and then sends them to
+// the X server in a single write (instead of one per request), without any
+// other request in between. The requests get consecutive sequence numbers in
+// the order they were added to the batch.
+//
+// Batch returns once every request has been written, even in buffered mode,
+// along with the error from writing them, if any. (Errors from the X server
+// are reported through the cookies as usual.) If the connection to the X
+// server is closed or lost, it returns the reason why.
+// If the cookie buffer fills up in the middle of a very large batch, or
+// a request in it is the first one to need BIG-REQUESTS, the requests are
+// written in more than one go.
+func (c *Conn) Batch(fill func(*BatchWriter)) error {
+	b := &BatchWriter{conn: c}
+	fill(b)
+	if len(b.bufs) == 0 {
+		return nil
+	}
+
+	b.sent = make(chan error, 1)
+	done := c.currentDone()
+	c.queueRequest(&request{batch: b})
+	select {
+	case err := <-b.sent:
+		return err
+	case <-done:
+		return c.stopReason()
+	}
+}
+
+// sendBatch queues the cookies of batch 'b' and writes its requests.
+// It returns false if the connection was stopped in the mean time.
+func (c *Conn) sendBatch(done chan struct{}, b *BatchWriter) bool {
+	var bufs net.Buffers
+	for i, cookie := range b.cookies {
+		// The round trips to enable BIG-REQUESTS can't complete until
+		// the server has seen the requests queued so far either.
+		if c.needBigRequests(len(b.bufs[i])) {
+			if err := c.writeBuffers(done, bufs); err != nil {
+				b.fail(i, err)
+				return true
+			}
+			bufs = nil
+			if !c.enableBigRequests(done) {
+				b.fail(i, c.stopErr)
+				return false
+			}
+		}
+		buf, err := c.fitRequest(b.bufs[i])
+		if err != nil {
+			cookie.fail(err)
+			continue
+		}
+
+		// The round trip to clear out the cookie buffer can't complete
+		// until the server has seen the requests queued so far.
+		if len(c.cookieChan) == cookieBuffer-1 {
+			if err := c.writeBuffers(done, bufs); err != nil {
+				b.fail(i, err)
+				return true
+			}
+			bufs = nil
+			if !c.syncCookies(done) {
+				b.fail(i, c.stopErr)
+				return false
+			}
+		}
+
+		if !c.queueCookie(done, cookie, buf) {
+			b.fail(i, c.stopErr)
+			return false
+		}
+		bufs = append(bufs, buf)
+	}
+
+	err := c.writeBuffers(done, bufs)
+	if err == nil && c.buffered.Load() {
+		err = c.bufw.Flush()
+		c.writeFailed(done, err)
+	}
+	b.sent <- err
+	return true
+}
+
+// fail fails the cookies of the batch starting at index 'start', which were
+// never queued, and reports 'err' to Batch. (Cookies that were queued are
+// failed when the connection is stopped.)
+func (b *BatchWriter) fail(start int, err error) {
+	for _, cookie := range b.cookies[start:] {
+		cookie.fail(err)
+	}
+	b.sent <- err
+}
+
+// writeBuffers is like writeBuffer, but writes all of 'bufs' at once and
+// returns the error, if any.
+func (c *Conn) writeBuffers(done chan struct{}, bufs net.Buffers) error {
+	_, err := bufs.WriteTo(c.writer())
+	c.writeFailed(done, err)
+	return err
+}
@@ -0,0 +1,142 @@
+package xgb
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// TestBatch sends a batch of requests and makes sure they arrive in order,
+// with consecutive sequence numbers.
+func TestBatch(t *testing.T) {
+	display, conns := setupServer(t)
+	c := newTestConn(t, display)
+
+	var cookies []*Cookie
+	err := c.Batch(func(b *BatchWriter) {
+		for i := 0; i < 3; i++ {
+			cookie := b.NewCookie(false, false)
+			buf := noOperationRequest()
+			buf[1] = byte(i) // unused, but lets us tell them apart
+			b.NewRequest(buf, cookie)
+			cookies = append(cookies, cookie)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Batch: %s", err)
+	}
+
+	for i, cookie := range cookies {
+		if cookie.Sequence != uint16(i+1) {
+			t.Fatalf("Expected request %d to have sequence "+
+				"number %d, but it has %d.",
+				i, i+1, cookie.Sequence)
+		}
+	}
+
+	server := <-conns
+	buf := make([]byte, 12)
+	server.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := io.ReadFull(server, buf); err != nil {
+		t.Fatalf("Reading batch: %s", err)
+	}
+	for i := 0; i < 3; i++ {
+		if buf[i*4] != 127 || buf[i*4+1] != byte(i) {
+			t.Fatalf("Request %d was not sent in order: % x",
+				i, buf)
+		}
+	}
+}
+
+// TestBatchBuffered makes sure a batch made in buffered mode is written by
+// the time Batch returns, and that Batch fails once the connection is closed.
+func TestBatchBuffered(t *testing.T) {
+	display, conns := setupServer(t)
+	c := newTestConn(t, display)
+	server := <-conns
+
+	c.SetBuffered(true)
+	err := c.Batch(func(b *BatchWriter) {
+		b.NewRequest(noOperationRequest(), b.NewCookie(false, false))
+	})
+	if err != nil {
+		t.Fatalf("Batch: %s", err)
+	}
+	buf := make([]byte, 4)
+	server.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := io.ReadFull(server, buf); err != nil {
+		t.Fatalf("Reading batch: %s", err)
+	}
+
+	c.Close()
+	err = c.Batch(func(b *BatchWriter) {
+		b.NewRequest(noOperationRequest(), b.NewCookie(false, false))
+	})
+	if err != errClosed {
+		t.Fatalf("Expected '%v', but got %v.", errClosed, err)
+	}
+}
+
+// TestBatchLarge sends a batch with more requests than fit in the cookie
+// buffer, which forces Batch to make round trips in between.
+func TestBatchLarge(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	go replyServer(<-conns)
+
+	n := 3 * cookieBuffer
+	err = c.Batch(func(b *BatchWriter) {
+		for i := 0; i < n; i++ {
+			cookie := b.NewCookie(false, false)
+			b.NewRequest(noOperationRequest(), cookie)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Batch: %s", err)
+	}
+
+	// Make sure everything still works after the batch.
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(c.getInputFocusRequest(), cookie)
+	if _, err := cookie.Reply(); err != nil {
+		t.Fatalf("Reply: %s", err)
+	}
+}
+
+// noOperationRequest returns a NoOperation request, which has no reply.
+func noOperationRequest() []byte {
+	buf := make([]byte, 4)
+	buf[0] = 127
+	Put16(buf[2:], 1)
+	return buf
+}
+
+// replyServer reads requests from 'conn' and answers every GetInputFocus
+// request with an empty reply. (Other requests are assumed to have no reply.)
+func replyServer(conn net.Conn) {
+	head := make([]byte, 4)
+	for seq := uint16(1); ; seq++ {
+		if _, err := io.ReadFull(conn, head); err != nil {
+			return
+		}
+		rest := int(Get16(head[2:]))*4 - 4
+		if _, err := io.ReadFull(conn, make([]byte, rest)); err != nil {
+			return
+		}
+
+		if head[0] != 43 {
+			continue
+		}
+		reply := make([]byte, 32)
+		reply[0] = 1
+		Put16(reply[2:], seq)
+		if _, err := conn.Write(reply); err != nil {
+			return
+		}
+	}
+}
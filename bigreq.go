@@ -0,0 +1,137 @@
+package xgb
+
+import (
+	"errors"
+)
+
+// ErrRequestTooLong is the error checked cookies fail with when their request
+// is longer than the X server accepts, even with the BIG-REQUESTS extension
+// (if it is available at all).
+var ErrRequestTooLong = errors.New("the request is longer than the X " +
+	"server accepts")
+
+// bigReqName is the name of the BIG-REQUESTS extension, as used by the bigreq
+// package.
+const bigReqName = "BIG-REQUESTS"
+
+// maxRegularLength is the maximum length of a request without BIG-REQUESTS,
+// in 4-byte units, since it has to fit in the 16 bit length field.
+const maxRegularLength = 0xffff
+
+// MaxRequestSize returns the maximum length of a request the X server
+// accepts, in 4-byte units. That is the length allowed by BIG-REQUESTS if the
+// X server supports it, and Setup.MaximumRequestLength otherwise. Since
+// BIG-REQUESTS is only enabled when it is needed, MaxRequestSize may have to
+// wait for it to be enabled. If the connection to the X server is closed or
+// lost, it returns 0.
+func (c *Conn) MaxRequestSize() uint32 {
+	req := &request{maxLength: make(chan uint32, 1)}
+	done := c.currentDone()
+	select {
+	case c.reqChan <- req:
+	case <-done:
+		return 0
+	}
+	select {
+	case length := <-req.maxLength:
+		return length
+	case <-done:
+		return 0
+	}
+}
+
+// MaxRequestSizeBytes is like MaxRequestSize, but returns the length in
+// bytes.
+func (c *Conn) MaxRequestSizeBytes() int64 {
+	return int64(c.MaxRequestSize()) * 4
+}
+
+// needBigRequests returns whether a request of 'size' bytes is too long for
+// the X server, and BIG-REQUESTS hasn't been tried yet on this connection.
+func (c *Conn) needBigRequests(size int) bool {
+	return !c.bigReqQueried && size/4 > int(c.maxRequestLength)
+}
+
+// enableBigRequests enables the BIG-REQUESTS extension, and raises the
+// maximum request length to the one it allows. If the extension isn't
+// available, nothing changes. It returns false if the connection was stopped
+// in the mean time.
+//
+// It is only called by sendRequests, the first time a request is too long.
+// libxcb also waits for a request that needs BIG-REQUESTS before enabling it,
+// which saves two round trips when connecting.
+func (c *Conn) enableBigRequests(done chan struct{}) bool {
+	c.bigReqQueried = true
+
+	reply, ok := c.roundTrip(done, c.queryExtensionRequest(bigReqName))
+	if !ok {
+		return false
+	}
+	if reply == nil || reply[8] != 1 {
+		return true
+	}
+
+	// Enable has no arguments.
+	buf := make([]byte, 4)
+	buf[0] = reply[9]
+	buf[1] = 0 // request opcode
+	Put16(buf[2:], 1)
+	reply, ok = c.roundTrip(done, buf)
+	if !ok {
+		return false
+	}
+	if reply != nil {
+		c.maxRequestLength = Get32(reply[8:])
+		c.bigRequests = true
+	}
+	return true
+}
+
+// fitRequest returns the request in 'buf' in a form the X server accepts.
+// That is 'buf' itself if it is short enough, or 'buf' encoded as a big
+// request if BIG-REQUESTS is enabled. Otherwise, it returns
+// ErrRequestTooLong.
+func (c *Conn) fitRequest(buf []byte) ([]byte, error) {
+	length := len(buf) / 4
+	if !c.bigRequests || length <= maxRegularLength {
+		if length > int(c.maxRequestLength) {
+			return nil, ErrRequestTooLong
+		}
+		return buf, nil
+	}
+
+	// A big request has a length field of zero, followed by the length of
+	// the request as 32 bits, which makes it 4 bytes longer.
+	if length+1 > int(c.maxRequestLength) {
+		return nil, ErrRequestTooLong
+	}
+	big := make([]byte, len(buf)+4)
+	copy(big, buf[:2])
+	Put32(big[4:], uint32(length+1))
+	copy(big[8:], buf[4:])
+	return big, nil
+}
+
+// roundTrip writes the request in 'buf', which must have a reply, and waits
+// for it. The reply is nil if the X server sent an error instead. Like
+// syncCookies, it circumvents the request channel, and returns false if the
+// connection was stopped in the mean time.
+func (c *Conn) roundTrip(done chan struct{}, buf []byte) ([]byte, bool) {
+	if len(c.cookieChan) == cookieBuffer-1 && !c.syncCookies(done) {
+		return nil, false
+	}
+	cookie := c.NewCookie(true, true)
+	if !c.sendCookie(done, cookie, buf) {
+		return nil, false
+	}
+	c.flushWriter(done)
+
+	select {
+	case reply := <-cookie.replyChan:
+		return reply, true
+	case <-cookie.errorChan:
+		return nil, true
+	case <-done:
+		return nil, false
+	}
+}
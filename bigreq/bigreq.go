@@ -19,7 +19,7 @@ func Init(c *xgb.Conn) error {
 	case err != nil:
 		return err
 	case !reply.Present:
-		return xgb.Errorf("No extension named BIG-REQUESTS could be found on on the server.")
+		return xproto.ExtNotAvailableError{Name: "BIG-REQUESTS", Reply: reply}
 	}
 
 	xgb.ExtLock.Lock()
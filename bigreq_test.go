@@ -0,0 +1,145 @@
+package xgb
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+// TestBigRequest sends a request that is too long for the 16 bit length
+// field, and makes sure it is sent as a big request once BIG-REQUESTS has
+// been enabled.
+func TestBigRequest(t *testing.T) {
+	display, conns := setupServer(t)
+	c := newTestConn(t, display)
+
+	requests := make(chan []byte, 10)
+	go bigReqServer(t, <-conns, true, requests)
+
+	buf := noOperationRequest()
+	buf = append(buf, make([]byte, 4*maxRegularLength)...)
+	c.NewRequest(buf, c.NewCookie(false, false))
+	c.NewRequest(c.getInputFocusRequest(), c.NewCookie(false, false))
+
+	req := <-requests
+	if len(req) != len(buf)+4 || Get16(req[2:]) != 0 ||
+		Get32(req[4:]) != uint32(len(req)/4) {
+
+		t.Fatalf("Expected a big request of %d bytes, but got % x...",
+			len(buf)+4, req[:8])
+	}
+	if req[0] != 127 || !bytes.Equal(req[8:], buf[4:]) {
+		t.Fatalf("The big request has the wrong contents.")
+	}
+	if req := <-requests; req[0] != 43 {
+		t.Fatalf("Expected a GetInputFocus request, but got % x", req)
+	}
+}
+
+// TestRequestTooLong makes sure a request that is too long fails when
+// BIG-REQUESTS isn't available, and that later requests are still sent.
+func TestRequestTooLong(t *testing.T) {
+	display, conns := setupServer(t)
+	c := newTestConn(t, display)
+
+	requests := make(chan []byte, 10)
+	go bigReqServer(t, <-conns, false, requests)
+
+	buf := noOperationRequest()
+	buf = append(buf, make([]byte, 4*maxRegularLength)...)
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(buf, cookie)
+	c.NewRequest(c.getInputFocusRequest(), c.NewCookie(false, false))
+
+	if err := <-cookie.errorChan; err != ErrRequestTooLong {
+		t.Fatalf("Expected ErrRequestTooLong, but got '%v'.", err)
+	}
+	if req := <-requests; req[0] != 43 {
+		t.Fatalf("Expected a GetInputFocus request, but got % x", req)
+	}
+}
+
+// TestMaxRequestSize makes sure MaxRequestSize enables BIG-REQUESTS, and
+// returns the maximum request length it allows.
+func TestMaxRequestSize(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+
+	go bigReqServer(t, <-conns, true, make(chan []byte, 10))
+	if size := c.MaxRequestSize(); size != 0x3fffff {
+		t.Fatalf("Expected a maximum request size of %d, but got %d.",
+			0x3fffff, size)
+	}
+	if size := c.MaxRequestSizeBytes(); size != 4*0x3fffff {
+		t.Fatalf("Expected a maximum request size of %d bytes, "+
+			"but got %d.", 4*0x3fffff, size)
+	}
+}
+
+// TestMaxRequestSizeNoBigRequests makes sure MaxRequestSize returns the
+// maximum request length from the setup information when BIG-REQUESTS isn't
+// available.
+func TestMaxRequestSizeNoBigRequests(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+
+	go bigReqServer(t, <-conns, false, make(chan []byte, 10))
+	if size := c.MaxRequestSize(); size != 0xffff {
+		t.Fatalf("Expected a maximum request size of %d, but got %d.",
+			0xffff, size)
+	}
+}
+
+// bigReqServer answers the QueryExtension request for BIG-REQUESTS on
+// 'conn', saying whether it is 'present', and the Enable request if it is.
+// The requests that follow are sent to 'requests'.
+func bigReqServer(t *testing.T, conn net.Conn, present bool,
+	requests chan<- []byte) {
+
+	req, err := readRequest(conn)
+	if err != nil || req[0] != 98 ||
+		string(req[8:8+len(bigReqName)]) != bigReqName {
+
+		t.Errorf("Expected a QueryExtension request for %s, "+
+			"but got % x (%v)", bigReqName, req, err)
+		return
+	}
+	reply := make([]byte, 32)
+	reply[0] = 1
+	Put16(reply[2:], 1)
+	if present {
+		reply[8] = 1
+		reply[9] = 133 // major opcode
+	}
+	conn.Write(reply)
+
+	if present {
+		req, err = readRequest(conn)
+		if err != nil || !bytes.Equal(req, []byte{133, 0, 1, 0}) {
+			t.Errorf("Expected an Enable request, but got % x (%v)",
+				req, err)
+			return
+		}
+		reply = make([]byte, 32)
+		reply[0] = 1
+		Put16(reply[2:], 2)
+		Put32(reply[8:], 0x3fffff) // maximum request length
+		conn.Write(reply)
+	}
+
+	for {
+		req, err := readRequest(conn)
+		if err != nil {
+			return
+		}
+		requests <- req
+	}
+}
@@ -0,0 +1,30 @@
+package xgb
+
+import "sync"
+
+// Cache returns the cache that goes with 'key' on the connection, which is
+// empty the first time. It lets packages like xproto remember what the X
+// server told them (e.g., the atoms interned by xproto.InternAtomCached)
+// without a field of their own in Conn.
+//
+// Like a context key, 'key' should be a value of an unexported type of the
+// package using the cache, so that no other package can get at it. Since
+// what the X server said may no longer hold after it restarts, Reconnect
+// starts every cache over.
+func (c *Conn) Cache(key interface{}) *sync.Map {
+	c = c.socket()
+	if cache, ok := c.caches.Load(key); ok {
+		return cache.(*sync.Map)
+	}
+	cache, _ := c.caches.LoadOrStore(key, new(sync.Map))
+	return cache.(*sync.Map)
+}
+
+// clearCaches starts every cache returned by Cache over. A lookup that was
+// in flight stores its result in the old cache, where it is never seen.
+func (c *Conn) clearCaches() {
+	c.caches.Range(func(key, _ interface{}) bool {
+		c.caches.Delete(key)
+		return true
+	})
+}
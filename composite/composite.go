@@ -20,7 +20,7 @@ func Init(c *xgb.Conn) error {
 	case err != nil:
 		return err
 	case !reply.Present:
-		return xgb.Errorf("No extension named Composite could be found on on the server.")
+		return xproto.ExtNotAvailableError{Name: "Composite", Reply: reply}
 	}
 
 	xgb.ExtLock.Lock()
@@ -78,7 +78,7 @@ type QueryVersionCookie struct {
 // QueryVersion sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryVersionCookie.Reply()
 func QueryVersion(c *xgb.Conn, ClientMajorVersion uint32, ClientMinorVersion uint32) QueryVersionCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'QueryVersion' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -89,7 +89,7 @@ func QueryVersion(c *xgb.Conn, ClientMajorVersion uint32, ClientMinorVersion uin
 // QueryVersionUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryVersionUnchecked(c *xgb.Conn, ClientMajorVersion uint32, ClientMinorVersion uint32) QueryVersionCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'QueryVersion' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -150,7 +150,7 @@ func queryVersionRequest(c *xgb.Conn, ClientMajorVersion uint32, ClientMinorVers
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["COMPOSITE"]
+	buf[b] = c.Extensions["Composite"]
 	b += 1
 
 	buf[b] = 0 // request opcode
@@ -176,7 +176,7 @@ type RedirectWindowCookie struct {
 // RedirectWindow sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func RedirectWindow(c *xgb.Conn, Window xproto.Window, Update byte) RedirectWindowCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'RedirectWindow' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -187,7 +187,7 @@ func RedirectWindow(c *xgb.Conn, Window xproto.Window, Update byte) RedirectWind
 // RedirectWindowChecked sends a checked request.
 // If an error occurs, it can be retrieved using RedirectWindowCookie.Check()
 func RedirectWindowChecked(c *xgb.Conn, Window xproto.Window, Update byte) RedirectWindowCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'RedirectWindow' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -208,7 +208,7 @@ func redirectWindowRequest(c *xgb.Conn, Window xproto.Window, Update byte) []byt
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["COMPOSITE"]
+	buf[b] = c.Extensions["Composite"]
 	b += 1
 
 	buf[b] = 1 // request opcode
@@ -236,7 +236,7 @@ type RedirectSubwindowsCookie struct {
 // RedirectSubwindows sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func RedirectSubwindows(c *xgb.Conn, Window xproto.Window, Update byte) RedirectSubwindowsCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'RedirectSubwindows' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -247,7 +247,7 @@ func RedirectSubwindows(c *xgb.Conn, Window xproto.Window, Update byte) Redirect
 // RedirectSubwindowsChecked sends a checked request.
 // If an error occurs, it can be retrieved using RedirectSubwindowsCookie.Check()
 func RedirectSubwindowsChecked(c *xgb.Conn, Window xproto.Window, Update byte) RedirectSubwindowsCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'RedirectSubwindows' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -268,7 +268,7 @@ func redirectSubwindowsRequest(c *xgb.Conn, Window xproto.Window, Update byte) [
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["COMPOSITE"]
+	buf[b] = c.Extensions["Composite"]
 	b += 1
 
 	buf[b] = 2 // request opcode
@@ -296,7 +296,7 @@ type UnredirectWindowCookie struct {
 // UnredirectWindow sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func UnredirectWindow(c *xgb.Conn, Window xproto.Window, Update byte) UnredirectWindowCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'UnredirectWindow' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -307,7 +307,7 @@ func UnredirectWindow(c *xgb.Conn, Window xproto.Window, Update byte) Unredirect
 // UnredirectWindowChecked sends a checked request.
 // If an error occurs, it can be retrieved using UnredirectWindowCookie.Check()
 func UnredirectWindowChecked(c *xgb.Conn, Window xproto.Window, Update byte) UnredirectWindowCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'UnredirectWindow' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -328,7 +328,7 @@ func unredirectWindowRequest(c *xgb.Conn, Window xproto.Window, Update byte) []b
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["COMPOSITE"]
+	buf[b] = c.Extensions["Composite"]
 	b += 1
 
 	buf[b] = 3 // request opcode
@@ -356,7 +356,7 @@ type UnredirectSubwindowsCookie struct {
 // UnredirectSubwindows sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func UnredirectSubwindows(c *xgb.Conn, Window xproto.Window, Update byte) UnredirectSubwindowsCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'UnredirectSubwindows' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -367,7 +367,7 @@ func UnredirectSubwindows(c *xgb.Conn, Window xproto.Window, Update byte) Unredi
 // UnredirectSubwindowsChecked sends a checked request.
 // If an error occurs, it can be retrieved using UnredirectSubwindowsCookie.Check()
 func UnredirectSubwindowsChecked(c *xgb.Conn, Window xproto.Window, Update byte) UnredirectSubwindowsCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'UnredirectSubwindows' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -388,7 +388,7 @@ func unredirectSubwindowsRequest(c *xgb.Conn, Window xproto.Window, Update byte)
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["COMPOSITE"]
+	buf[b] = c.Extensions["Composite"]
 	b += 1
 
 	buf[b] = 4 // request opcode
@@ -416,7 +416,7 @@ type CreateRegionFromBorderClipCookie struct {
 // CreateRegionFromBorderClip sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func CreateRegionFromBorderClip(c *xgb.Conn, Region xfixes.Region, Window xproto.Window) CreateRegionFromBorderClipCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'CreateRegionFromBorderClip' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -427,7 +427,7 @@ func CreateRegionFromBorderClip(c *xgb.Conn, Region xfixes.Region, Window xproto
 // CreateRegionFromBorderClipChecked sends a checked request.
 // If an error occurs, it can be retrieved using CreateRegionFromBorderClipCookie.Check()
 func CreateRegionFromBorderClipChecked(c *xgb.Conn, Region xfixes.Region, Window xproto.Window) CreateRegionFromBorderClipCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'CreateRegionFromBorderClip' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -448,7 +448,7 @@ func createRegionFromBorderClipRequest(c *xgb.Conn, Region xfixes.Region, Window
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["COMPOSITE"]
+	buf[b] = c.Extensions["Composite"]
 	b += 1
 
 	buf[b] = 5 // request opcode
@@ -474,7 +474,7 @@ type NameWindowPixmapCookie struct {
 // NameWindowPixmap sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func NameWindowPixmap(c *xgb.Conn, Window xproto.Window, Pixmap xproto.Pixmap) NameWindowPixmapCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'NameWindowPixmap' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -485,7 +485,7 @@ func NameWindowPixmap(c *xgb.Conn, Window xproto.Window, Pixmap xproto.Pixmap) N
 // NameWindowPixmapChecked sends a checked request.
 // If an error occurs, it can be retrieved using NameWindowPixmapCookie.Check()
 func NameWindowPixmapChecked(c *xgb.Conn, Window xproto.Window, Pixmap xproto.Pixmap) NameWindowPixmapCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'NameWindowPixmap' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -506,7 +506,7 @@ func nameWindowPixmapRequest(c *xgb.Conn, Window xproto.Window, Pixmap xproto.Pi
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["COMPOSITE"]
+	buf[b] = c.Extensions["Composite"]
 	b += 1
 
 	buf[b] = 6 // request opcode
@@ -532,7 +532,7 @@ type GetOverlayWindowCookie struct {
 // GetOverlayWindow sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetOverlayWindowCookie.Reply()
 func GetOverlayWindow(c *xgb.Conn, Window xproto.Window) GetOverlayWindowCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'GetOverlayWindow' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -543,7 +543,7 @@ func GetOverlayWindow(c *xgb.Conn, Window xproto.Window) GetOverlayWindowCookie
 // GetOverlayWindowUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetOverlayWindowUnchecked(c *xgb.Conn, Window xproto.Window) GetOverlayWindowCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'GetOverlayWindow' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -600,7 +600,7 @@ func getOverlayWindowRequest(c *xgb.Conn, Window xproto.Window) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["COMPOSITE"]
+	buf[b] = c.Extensions["Composite"]
 	b += 1
 
 	buf[b] = 7 // request opcode
@@ -623,7 +623,7 @@ type ReleaseOverlayWindowCookie struct {
 // ReleaseOverlayWindow sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ReleaseOverlayWindow(c *xgb.Conn, Window xproto.Window) ReleaseOverlayWindowCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'ReleaseOverlayWindow' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -634,7 +634,7 @@ func ReleaseOverlayWindow(c *xgb.Conn, Window xproto.Window) ReleaseOverlayWindo
 // ReleaseOverlayWindowChecked sends a checked request.
 // If an error occurs, it can be retrieved using ReleaseOverlayWindowCookie.Check()
 func ReleaseOverlayWindowChecked(c *xgb.Conn, Window xproto.Window) ReleaseOverlayWindowCookie {
-	if _, ok := c.Extensions["COMPOSITE"]; !ok {
+	if _, ok := c.Extensions["Composite"]; !ok {
 		panic("Cannot issue request 'ReleaseOverlayWindow' using the uninitialized extension 'Composite'. composite.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -655,7 +655,7 @@ func releaseOverlayWindowRequest(c *xgb.Conn, Window xproto.Window) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["COMPOSITE"]
+	buf[b] = c.Extensions["Composite"]
 	b += 1
 
 	buf[b] = 8 // request opcode
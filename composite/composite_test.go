@@ -0,0 +1,36 @@
+package composite
+
+import (
+	"os"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+)
+
+// TestQueryVersion is a smoke test for the Composite extension: it asks the
+// X server in DISPLAY for the version of the extension, and is skipped when
+// DISPLAY isn't set.
+func TestQueryVersion(t *testing.T) {
+	if len(os.Getenv("DISPLAY")) == 0 {
+		t.Skip("DISPLAY is not set")
+	}
+	X, err := xgb.NewConn()
+	if err != nil {
+		t.Fatalf("NewConn: %s", err)
+	}
+	defer X.Close()
+	if err := Init(X); err != nil {
+		t.Skipf("Composite is not available: %s", err)
+	}
+
+	reply, err := QueryVersion(X, 0, 4).Reply()
+	if err != nil {
+		t.Fatalf("QueryVersion: %s", err)
+	}
+	// The server returns the highest version supported by both sides, so
+	// it can't be newer than what we asked for.
+	if reply.MajorVersion != 0 || reply.MinorVersion > 4 {
+		t.Fatalf("Asked for version 0.4, but got %d.%d",
+			reply.MajorVersion, reply.MinorVersion)
+	}
+}
@@ -8,6 +8,7 @@ This code is largely unmodified from the original XGB package that I forked.
 */
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -15,34 +16,184 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// ConnErrorCode says why a connection to the X server could not be made.
+type ConnErrorCode int
+
+const (
+	// ConnErrorBadDisplay means that the display string was empty or could
+	// not be parsed.
+	ConnErrorBadDisplay ConnErrorCode = iota + 1
+
+	// ConnErrorIOFailed means that connecting to the X server or talking to
+	// it during the setup handshake failed.
+	ConnErrorIOFailed
+
+	// ConnErrorAuthUnsupported means that the authority file specifies an
+	// authorization protocol that XGB doesn't know how to speak.
+	ConnErrorAuthUnsupported
+
+	// ConnErrorRefused means that the X server refused the connection,
+	// usually because authorization failed.
+	ConnErrorRefused
+
+	// ConnErrorVersionMismatch means that the X server doesn't speak
+	// version 11.0 of the X protocol.
+	ConnErrorVersionMismatch
+)
+
+// ConnError is the type of error returned by NewConn and friends when
+// a connection to the X server cannot be established. Use errors.As to
+// retrieve it and inspect Code.
+type ConnError struct {
+	Code ConnErrorCode
+	Msg  string
+	Err  error // the underlying error, if any
+}
+
+func (err ConnError) Error() string {
+	if err.Err != nil {
+		return err.Msg + ": " + err.Err.Error()
+	}
+	return err.Msg
+}
+
+// Unwrap returns the underlying error, if any.
+func (err ConnError) Unwrap() error {
+	return err.Err
+}
+
 // connect connects to the X server given in the 'display' string,
 // and does all the necessary setup handshaking.
 // If 'display' is empty it will be taken from os.Getenv("DISPLAY").
 // Note that you should read and understand the "Connection Setup" of the
 // X Protocol Reference Manual before changing this function:
 // http://goo.gl/4zGQg
-func (c *Conn) connect(display string) error {
-	err := c.dial(display)
+func (c *Conn) connect(ctx context.Context, display string) error {
+	err := c.dial(ctx, display)
 	if err != nil {
 		return err
 	}
 
-	// Get authentication data
-	authName, authData, err := readAuthority(c.host, c.display)
-	noauth := false
+	// The handshake blocks on reads from the server, so make sure it gives
+	// up when 'ctx' does. Named pipes aren't a net.Conn and have no
+	// deadlines, so they are closed instead (and can't be used with TLS).
+	var finish func() error
+	if netConn, ok := c.conn.(net.Conn); ok {
+		finish = watchContext(ctx, netConn)
+		if c.tlsConfig != nil {
+			err = c.startTLS(netConn)
+		}
+	} else {
+		finish = closeOnCancel(ctx, c.conn)
+	}
+	if err == nil {
+		err = c.handshake()
+	}
+	ctxErr := finish()
 	if err != nil {
-		logger.Printf("Could not get authority info: %v", err)
-		logger.Println("Trying connection without authority info...")
+		c.conn.Close()
+		if ctxErr != nil {
+			return handshakeFailed(ctxErr)
+		}
+	}
+	return err
+}
+
+// handshakeFailed wraps an I/O error that occurred during the setup
+// handshake.
+func handshakeFailed(err error) error {
+	return ConnError{ConnErrorIOFailed, "setup handshake failed", err}
+}
+
+// watchContext makes blocking I/O on 'conn' respect the deadline and
+// cancellation of 'ctx'. The returned function must be called once the I/O
+// is done. It clears the deadline again and returns ctx.Err().
+func watchContext(ctx context.Context, conn net.Conn) func() error {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			// A deadline in the past unblocks any pending I/O.
+			conn.SetDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	return func() error {
+		close(done)
+		<-stopped
+		conn.SetDeadline(time.Time{})
+		return ctx.Err()
+	}
+}
+
+// closeOnCancel is like watchContext, but for connections that don't support
+// deadlines: it closes 'conn' when 'ctx' is done before the returned function
+// is called.
+func closeOnCancel(ctx context.Context, conn io.Closer) func() error {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	return func() error {
+		close(done)
+		<-stopped
+		return ctx.Err()
+	}
+}
+
+// handshake sends the connection setup request over an established
+// connection and reads the server's response.
+func (c *Conn) handshake() error {
+	// Get authentication data, unless it was given to WithAuthority.
+	// There is no display to look it up for on a connection given to
+	// NewConnNet.
+	var authName string
+	var authData []byte
+	var err error
+	noauth := c.netGiven
+	if c.opts.authGiven {
+		authName, authData = c.opts.authName, c.opts.authData
+		noauth = len(authName) == 0
+	} else if !noauth {
+		authName, authData, err = readAuthority(c.host, c.display)
+	}
+	if err != nil {
+		c.log().Printf("Could not get authority info: %v", err)
+		c.log().Println("Trying connection without authority info...")
 		authName = ""
 		authData = []byte{}
 		noauth = true
 	}
 
-	// Assume that the authentication protocol is "MIT-MAGIC-COOKIE-1".
-	if !noauth && (authName != "MIT-MAGIC-COOKIE-1" || len(authData) != 16) {
-		return errors.New("unsupported auth protocol " + authName)
+	if !noauth {
+		handler, ok := authHandler(authName)
+		if !ok {
+			return ConnError{Code: ConnErrorAuthUnsupported,
+				Msg: "unsupported auth protocol " + authName}
+		}
+
+		var local net.Addr
+		if netConn, ok := c.conn.(net.Conn); ok {
+			local = netConn.LocalAddr()
+		}
+		authData, err = handler.AuthData(authData, local)
+		if err != nil {
+			return ConnError{ConnErrorAuthUnsupported,
+				"cannot use auth protocol " + authName, err}
+		}
 	}
 
 	buf := make([]byte, 12+Pad(len(authName))+Pad(len(authData)))
@@ -56,12 +207,12 @@ func (c *Conn) connect(display string) error {
 	copy(buf[12:], []byte(authName))
 	copy(buf[12+Pad(len(authName)):], authData)
 	if _, err = c.conn.Write(buf); err != nil {
-		return err
+		return handshakeFailed(err)
 	}
 
 	head := make([]byte, 8)
 	if _, err = io.ReadFull(c.conn, head[0:8]); err != nil {
-		return err
+		return handshakeFailed(err)
 	}
 	code := head[0]
 	reasonLen := head[1]
@@ -70,19 +221,22 @@ func (c *Conn) connect(display string) error {
 	dataLen := Get16(head[6:])
 
 	if major != 11 || minor != 0 {
-		return fmt.Errorf("x protocol version mismatch: %d.%d", major, minor)
+		return ConnError{Code: ConnErrorVersionMismatch,
+			Msg: fmt.Sprintf("x protocol version mismatch: %d.%d",
+				major, minor)}
 	}
 
 	buf = make([]byte, int(dataLen)*4+8, int(dataLen)*4+8)
 	copy(buf, head)
 	if _, err = io.ReadFull(c.conn, buf[8:]); err != nil {
-		return err
+		return handshakeFailed(err)
 	}
 
 	if code == 0 {
 		reason := buf[8 : 8+reasonLen]
-		return fmt.Errorf("x protocol authentication refused: %s",
-			string(reason))
+		return ConnError{Code: ConnErrorRefused,
+			Msg: "x protocol authentication refused: " +
+				string(reason)}
 	}
 
 	// Unfortunately, it isn't really feasible to read the setup bytes here,
@@ -93,82 +247,59 @@ func (c *Conn) connect(display string) error {
 	// But also read stuff that we *need* to get started.
 	c.setupResourceIdBase = Get32(buf[12:])
 	c.setupResourceIdMask = Get32(buf[16:])
+	c.maxRequestLength = uint32(Get16(buf[26:]))
+	c.bigReqQueried = false
+	c.bigRequests = false
 
 	return nil
 }
 
+// dialPipe opens the named pipe of a "pipe" display. Tests replace it, since
+// named pipes only exist on Windows.
+var dialPipe = dialNamedPipe
+
 // dial initializes the actual net connection with X.
-func (c *Conn) dial(display string) error {
+func (c *Conn) dial(ctx context.Context, display string) error {
 	if len(display) == 0 {
 		display = os.Getenv("DISPLAY")
 	}
 
-	display0 := display
-	if len(display) == 0 {
-		return errors.New("empty display string")
-	}
-
-	colonIdx := strings.LastIndex(display, ":")
-	if colonIdx < 0 {
-		return errors.New("bad display string: " + display0)
-	}
-
-	var protocol, socket string
-
-	if display[0] == '/' {
-		socket = display[0:colonIdx]
-	} else {
-		slashIdx := strings.LastIndex(display, "/")
-		if slashIdx >= 0 {
-			protocol = display[0:slashIdx]
-			c.host = display[slashIdx+1 : colonIdx]
-		} else {
-			c.host = display[0:colonIdx]
-		}
-	}
-
-	display = display[colonIdx+1 : len(display)]
-	if len(display) == 0 {
-		return errors.New("bad display string: " + display0)
-	}
-
-	var scr string
-	dotIdx := strings.LastIndex(display, ".")
-	if dotIdx < 0 {
-		c.display = display[0:]
-	} else {
-		c.display = display[0:dotIdx]
-		scr = display[dotIdx+1:]
+	spec, err := ParseDisplay(display)
+	if err != nil {
+		return err
 	}
+	c.host = spec.Host
+	c.display = strconv.Itoa(spec.Number)
+	c.DisplayNumber = spec.Number
+	c.DefaultScreen = spec.Screen
 
-	var err error
-	c.DisplayNumber, err = strconv.Atoi(c.display)
-	if err != nil || c.DisplayNumber < 0 {
-		return errors.New("bad display string: " + display0)
-	}
+	// Connect to server
+	d := c.opts.dialer
+	if spec.Protocol == "pipe" {
+		c.conn, err = dialPipe(ctx, spec.SocketPath)
+	} else if len(spec.SocketPath) != 0 {
+		c.conn, err = d.DialContext(ctx, "unix", spec.SocketPath)
 
-	if len(scr) != 0 {
-		c.DefaultScreen, err = strconv.Atoi(scr)
-		if err != nil {
-			return errors.New("bad display string: " + display0)
-		}
-	}
+		// Some environments (systemd user sessions, containers) put
+		// the socket in $XDG_RUNTIME_DIR instead. Only fall back when
+		// the default socket in /tmp doesn't exist, so that other
+		// failures are still reported.
+		rundir := os.Getenv("XDG_RUNTIME_DIR")
+		if errors.Is(err, os.ErrNotExist) && len(rundir) > 0 &&
+			strings.HasPrefix(spec.SocketPath, "/tmp/.X11-unix/") {
 
-	// Connect to server
-	if len(socket) != 0 {
-		c.conn, err = net.Dial("unix", socket+":"+c.display)
-	} else if len(c.host) != 0 {
-		if protocol == "" {
-			protocol = "tcp"
+			c.conn, err = d.DialContext(ctx,
+				"unix", rundir+"/X"+c.display)
 		}
-		c.conn, err = net.Dial(protocol,
-			c.host+":"+strconv.Itoa(6000+c.DisplayNumber))
 	} else {
-		c.conn, err = net.Dial("unix", "/tmp/.X11-unix/X"+c.display)
+		c.conn, err = d.DialContext(ctx, spec.Protocol,
+			net.JoinHostPort(spec.Host,
+				strconv.Itoa(6000+spec.Number)))
 	}
 
 	if err != nil {
-		return errors.New("cannot connect to " + display0 + ": " + err.Error())
+		return ConnError{ConnErrorIOFailed,
+			"cannot connect to " + display, err}
 	}
 	return nil
 }
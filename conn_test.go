@@ -0,0 +1,379 @@
+package xgb
+
+/*
+	Tests for the connection handshake.
+
+	Unlike the tests in xproto, these don't need a running X server.
+	Instead, they listen on a Unix socket in a temporary directory and act
+	as (a very stupid) X server themselves.
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// TestNewConnContextTimeout connects to a server that never answers the
+// setup request and makes sure the handshake gives up when the context
+// times out.
+func TestNewConnContextTimeout(t *testing.T) {
+	display, accepted := stalledServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(),
+		100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := NewConnContext(ctx, display)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded but got '%v'.", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("NewConnContext took %s to give up.", elapsed)
+	}
+	<-accepted
+}
+
+// TestNewConnContextCancel does the same as TestNewConnContextTimeout, but
+// cancels the context explicitly instead of relying on a deadline.
+func TestNewConnContextCancel(t *testing.T) {
+	display, accepted := stalledServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-accepted
+		cancel()
+	}()
+
+	_, err := NewConnContext(ctx, display)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled but got '%v'.", err)
+	}
+}
+
+// TestConnErrorBadDisplay makes sure that malformed display strings are
+// reported with ConnErrorBadDisplay.
+func TestConnErrorBadDisplay(t *testing.T) {
+	t.Setenv("DISPLAY", "")
+	for _, display := range []string{"", "nocolon", "host:", ":x", ":0.x"} {
+		_, err := NewConnDisplay(display)
+		checkConnError(t, err, ConnErrorBadDisplay)
+	}
+}
+
+// TestConnErrorRefused responds to the setup request with a failure and
+// makes sure it is reported with ConnErrorRefused.
+func TestConnErrorRefused(t *testing.T) {
+	reason := "No protocol specified"
+	resp := make([]byte, 8+Pad(len(reason)))
+	resp[0] = 0 // failed
+	resp[1] = byte(len(reason))
+	Put16(resp[2:], 11)
+	Put16(resp[6:], uint16((len(resp)-8)/4))
+	copy(resp[8:], reason)
+
+	_, err := NewConnDisplay(cannedServer(t, resp))
+	checkConnError(t, err, ConnErrorRefused)
+}
+
+// TestConnErrorVersionMismatch responds to the setup request with an
+// unsupported protocol version.
+func TestConnErrorVersionMismatch(t *testing.T) {
+	resp := make([]byte, 8)
+	resp[0] = 1 // success
+	Put16(resp[2:], 12)
+
+	_, err := NewConnDisplay(cannedServer(t, resp))
+	checkConnError(t, err, ConnErrorVersionMismatch)
+}
+
+// TestNewConnNet connects over a pipe, and makes sure the setup information
+// is read and no authorization data is sent. Reconnect can't be used then.
+func TestNewConnNet(t *testing.T) {
+	client, server := net.Pipe()
+	authLen := make(chan int, 1)
+	go func() {
+		head := make([]byte, 12)
+		if _, err := io.ReadFull(server, head); err != nil {
+			return
+		}
+		authLen <- int(Get16(head[6:])) + int(Get16(head[8:]))
+		server.Write(setupResponse())
+		io.Copy(io.Discard, server)
+	}()
+
+	c, err := NewConnNet(client)
+	if err != nil {
+		t.Fatalf("NewConnNet: %s", err)
+	}
+	defer c.Close()
+	if n := <-authLen; n != 0 {
+		t.Fatalf("Expected no authorization data, but got %d bytes.", n)
+	}
+	if id, err := c.NewId(); err != nil || id != 0x200001 {
+		t.Fatalf("Expected the id 0x200001, but got (%#x, %v).",
+			id, err)
+	}
+	if err := c.Reconnect(context.Background()); err != errNoDisplay {
+		t.Fatalf("Expected '%v', but got '%v'.", errNoDisplay, err)
+	}
+}
+
+// TestDialIPv6 connects to a server listening on the IPv6 loopback address,
+// given in brackets in the display string.
+func TestDialIPv6(t *testing.T) {
+	t.Setenv("XAUTHORITY", t.TempDir()+"/nonexistent")
+
+	// Find a display number whose port is free.
+	var l net.Listener
+	var err error
+	number := 90
+	for ; number < 100; number++ {
+		l, err = net.Listen("tcp", fmt.Sprintf("[::1]:%d", 6000+number))
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		t.Skip("Cannot listen on the IPv6 loopback address.")
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		readSetupRequest(conn)
+		conn.Write(setupResponse())
+		io.Copy(io.Discard, conn)
+	}()
+
+	c, err := NewConnDisplay(fmt.Sprintf("[::1]:%d.0", number))
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	c.Close()
+}
+
+// TestDialAbstract connects to a server listening on an abstract Unix domain
+// socket.
+func TestDialAbstract(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Abstract sockets only exist on Linux.")
+	}
+	t.Setenv("XAUTHORITY", t.TempDir()+"/nonexistent")
+
+	path := fmt.Sprintf("/xgb-test-%d/X3", os.Getpid())
+	l, err := net.Listen("unix", "@"+path)
+	if err != nil {
+		t.Fatalf("Listen: %s", err)
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		readSetupRequest(conn)
+		conn.Write(setupResponse())
+		io.Copy(io.Discard, conn)
+	}()
+
+	c, err := NewConnDisplay("abstract:" + path)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	if c.DisplayNumber != 3 {
+		t.Fatalf("Expected display 3, but got %d.", c.DisplayNumber)
+	}
+}
+
+// TestDialPipe connects to a "pipe" display through a connection that is
+// only an io.ReadWriteCloser, like the named pipes on Windows, and makes sure
+// the handshake still gives up when the context is canceled.
+func TestDialPipe(t *testing.T) {
+	t.Setenv("XAUTHORITY", t.TempDir()+"/nonexistent")
+
+	servers := make(chan net.Conn, 2)
+	dialPipe = func(ctx context.Context,
+		path string) (io.ReadWriteCloser, error) {
+		client, server := net.Pipe()
+		servers <- server
+		return struct{ io.ReadWriteCloser }{client}, nil
+	}
+	defer func() { dialPipe = dialNamedPipe }()
+
+	go func() {
+		conn := <-servers
+		defer conn.Close()
+		readSetupRequest(conn)
+		conn.Write(setupResponse())
+		io.Copy(io.Discard, conn)
+	}()
+	c, err := NewConnDisplay("pipe:X0")
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		conn := <-servers
+		defer conn.Close()
+		readSetupRequest(conn)
+		cancel()
+	}()
+	_, err = NewConnContext(ctx, "pipe:X0")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled but got '%v'.", err)
+	}
+}
+
+// checkConnError fails the current test if 'err' isn't a ConnError with
+// the given code.
+func checkConnError(t *testing.T, err error, code ConnErrorCode) {
+	t.Helper()
+
+	var connErr ConnError
+	if !errors.As(err, &connErr) {
+		t.Fatalf("Expected a ConnError but got '%v' (%T).", err, err)
+	}
+	if connErr.Code != code {
+		t.Fatalf("Expected code %d but got %d (%s).",
+			code, connErr.Code, connErr)
+	}
+}
+
+// cannedServer is like stalledServer, except that it reads the setup
+// request and writes 'resp' in return.
+func cannedServer(t *testing.T, resp []byte) string {
+	display, conns := listen(t)
+	go func() {
+		conn, ok := <-conns
+		if !ok {
+			return
+		}
+		readSetupRequest(conn)
+		conn.Write(resp)
+	}()
+	return display
+}
+
+// readSetupRequest reads a connection setup request from 'conn'.
+func readSetupRequest(conn net.Conn) error {
+	head := make([]byte, 12)
+	if _, err := io.ReadFull(conn, head); err != nil {
+		return err
+	}
+	rest := Pad(int(Get16(head[6:]))) + Pad(int(Get16(head[8:])))
+	_, err := io.ReadFull(conn, make([]byte, rest))
+	return err
+}
+
+// stalledServer listens on a Unix socket and accepts a single connection,
+// but never writes anything to it. It returns a display string that can be
+// used to connect to it, and a channel that is closed once the connection
+// has been accepted.
+func stalledServer(t *testing.T) (string, <-chan struct{}) {
+	display, conns := listen(t)
+	accepted := make(chan struct{})
+	go func() {
+		<-conns
+		close(accepted)
+	}()
+	return display, accepted
+}
+
+// listen listens on a Unix socket in a temporary directory and returns
+// a display string pointing to it. Every connection accepted is sent on the
+// returned channel, and is closed when the test finishes.
+func listen(t testing.TB) (string, <-chan net.Conn) {
+	t.Setenv("XAUTHORITY", t.TempDir()+"/nonexistent")
+	PrintLog = false
+	t.Cleanup(func() { PrintLog = true })
+
+	display := t.TempDir() + "/X:0"
+	l, err := net.Listen("unix", display)
+	if err != nil {
+		t.Fatalf("Listen: %s", err)
+	}
+
+	conns := make(chan net.Conn, 10)
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		l.Close()
+		<-done
+		for conn := range conns {
+			conn.Close()
+		}
+	})
+	go func() {
+		defer close(done)
+		defer close(conns)
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conns <- conn
+		}
+	}()
+	return display, conns
+}
+
+// setupServer is like cannedServer, except that it accepts any number of
+// connections, and successfully completes the setup handshake on each.
+// The server side of each connection is sent on the returned channel.
+func setupServer(t testing.TB) (string, <-chan net.Conn) {
+	display, conns := listen(t)
+	ready := make(chan net.Conn, 10)
+	go func() {
+		defer close(ready)
+		for conn := range conns {
+			if readSetupRequest(conn) != nil {
+				continue
+			}
+			conn.Write(setupResponse())
+			ready <- conn
+		}
+	}()
+	return display, ready
+}
+
+// newTestConn connects to 'display', which is served by one of the servers
+// above. Since those don't answer every request, Close doesn't wait for
+// replies. The connection is closed when the test finishes.
+func newTestConn(t testing.TB, display string) *Conn {
+	t.Helper()
+
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	c.SetCloseTimeout(0)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// setupResponse returns a successful response to the setup request. It is
+// just long enough for the core xgb package to be happy.
+func setupResponse() []byte {
+	resp := make([]byte, 8+12+8)
+	resp[0] = 1 // success
+	Put16(resp[2:], 11)
+	Put16(resp[6:], uint16((len(resp)-8)/4))
+	Put32(resp[12:], 0x200000) // resource id base
+	Put32(resp[16:], 0x1fffff) // resource id mask
+	Put16(resp[26:], 0xffff)   // maximum request length
+	return resp
+}
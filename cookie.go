@@ -9,11 +9,26 @@ import (
 // 'cookie' is most frequently used by embedding it into a more specific
 // kind of cookie, i.e., 'GetInputFocusCookie'.
 type Cookie struct {
-	conn      *Conn
-	Sequence  uint16
-	replyChan chan []byte
-	errorChan chan error
-	pingChan  chan bool
+	conn       *Conn
+	Sequence   uint16
+	seqnumFull uint32 // the full sequence number (see widenSequence)
+	replyChan  chan []byte
+	errorChan  chan error
+	pingChan   chan bool
+
+	// replyFDs is whether file descriptors are passed along with the reply.
+	// If so, readResponses stores them in 'fds' before sending the reply.
+	// See NewCookieFDs.
+	replyFDs bool
+	fds      []int
+
+	// lastReply, if not nil, reports whether a reply is the last one to
+	// a request with several. See NewCookieMulti.
+	lastReply func(reply []byte) bool
+
+	// property, if not nil, is the GetProperty request whose reply is
+	// stored in the property cache. See EnablePropertyCache.
+	property *propertyRequest
 }
 
 // NewCookie creates a new cookie with the correct channels initialized
@@ -63,12 +78,40 @@ func (c *Conn) NewCookie(checked, reply bool) *Cookie {
 	return cookie
 }
 
+// NewCookieMulti is like NewCookie for requests with a reply, except that
+// the X server may send several replies to the request, like it does to
+// RECORD's EnableContext. 'last' is called with each of them, and reports
+// whether it is the last one. Calling Reply again returns the next reply.
+//
+// Unless you're building requests from bytes by hand, this method should
+// not be used.
+func (c *Conn) NewCookieMulti(checked bool,
+	last func(reply []byte) bool) *Cookie {
+
+	cookie := c.NewCookie(checked, true)
+	cookie.lastReply = last
+	return cookie
+}
+
+// fail unblocks anyone waiting for a response to this cookie. Checked
+// cookies are sent 'err', while unchecked cookies are simply pinged.
+func (c *Cookie) fail(err error) {
+	switch {
+	case c.errorChan != nil:
+		c.errorChan <- err
+	case c.pingChan != nil:
+		c.pingChan <- true
+	}
+}
+
 // Reply detects whether this is a checked or unchecked cookie, and calls
 // 'replyChecked' or 'replyUnchecked' appropriately.
 //
 // Unless you're building requests from bytes by hand, this method should
 // not be used.
-func (c Cookie) Reply() ([]byte, error) {
+func (c *Cookie) Reply() ([]byte, error) {
+	c.conn.flushBuffered()
+
 	// checked
 	if c.errorChan != nil {
 		return c.replyChecked()
@@ -83,7 +126,7 @@ func (c Cookie) Reply() ([]byte, error) {
 //
 // Unless you're building requests from bytes by hand, this method should
 // not be used.
-func (c Cookie) replyChecked() ([]byte, error) {
+func (c *Cookie) replyChecked() ([]byte, error) {
 	if c.replyChan == nil {
 		return nil, errors.New("Cannot call 'replyChecked' on a cookie that " +
 			"is not expecting a *reply* or an error.")
@@ -111,7 +154,7 @@ func (c Cookie) replyChecked() ([]byte, error) {
 //
 // Unless you're building requests from bytes by hand, this method should
 // not be used.
-func (c Cookie) replyUnchecked() ([]byte, error) {
+func (c *Cookie) replyUnchecked() ([]byte, error) {
 	if c.replyChan == nil {
 		return nil, errors.New("Cannot call 'replyUnchecked' on a cookie " +
 			"that is not expecting a *reply*.")
@@ -137,7 +180,7 @@ func (c Cookie) replyUnchecked() ([]byte, error) {
 //
 // Unless you're building requests from bytes by hand, this method should
 // not be used.
-func (c Cookie) Check() error {
+func (c *Cookie) Check() error {
 	if c.replyChan != nil {
 		return errors.New("Cannot call 'Check' on a cookie that is " +
 			"expecting a *reply*. Use 'Reply' instead.")
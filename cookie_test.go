@@ -0,0 +1,128 @@
+package xgb
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// lastReplyFlag is the 'last' function of the cookies in these tests: the
+// last reply has its second byte set.
+func lastReplyFlag(reply []byte) bool {
+	return reply[1] == 1
+}
+
+// multiReplyServer answers the first request on 'conn' with 'n' replies,
+// the last one flagged by lastReplyFlag, and the request after it with
+// a single reply.
+func multiReplyServer(conn net.Conn, n int) {
+	head := make([]byte, 4)
+	if _, err := io.ReadFull(conn, head); err != nil {
+		return
+	}
+	for i := 0; i < n; i++ {
+		reply := make([]byte, 32)
+		reply[0] = 1
+		if i == n-1 {
+			reply[1] = 1
+		}
+		Put16(reply[2:], 1)
+		Put32(reply[8:], uint32(i))
+		if _, err := conn.Write(reply); err != nil {
+			return
+		}
+	}
+
+	// Answer the next request.
+	reply := make([]byte, 32)
+	reply[0] = 1
+	if _, err := io.ReadFull(conn, head); err != nil {
+		return
+	}
+	Put16(reply[2:], 2)
+	conn.Write(reply)
+}
+
+// TestNewCookieMulti makes sure that every reply to a request with several
+// gets to its cookie, and that the request after it gets its own.
+func TestNewCookieMulti(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	go multiReplyServer(<-conns, 3)
+
+	cookie := c.NewCookieMulti(true, lastReplyFlag)
+	c.NewRequest(c.getInputFocusRequest(), cookie)
+	for i := 0; i < 3; i++ {
+		reply, err := cookie.Reply()
+		if err != nil {
+			t.Fatalf("Reply %d: %s", i, err)
+		}
+		if n := Get32(reply[8:]); n != uint32(i) {
+			t.Fatalf("Expected reply %d, but got reply %d.", i, n)
+		}
+	}
+
+	next := c.NewCookie(true, true)
+	c.NewRequest(c.getInputFocusRequest(), next)
+	if _, err := next.Reply(); err != nil {
+		t.Fatalf("Reply to the next request: %s", err)
+	}
+}
+
+// TestNewCookieMultiClose makes sure that closing the connection unblocks
+// a request that has had some of its replies, but not the last one.
+func TestNewCookieMultiClose(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+
+	cookie := c.NewCookieMulti(true, func([]byte) bool { return false })
+	c.NewRequest(c.getInputFocusRequest(), cookie)
+	go multiReplyServer(<-conns, 1)
+	if _, err := cookie.Reply(); err != nil {
+		t.Fatalf("Reply: %s", err)
+	}
+
+	errs := make(chan error)
+	go func() {
+		_, err := cookie.Reply()
+		errs <- err
+	}()
+	c.Close()
+	select {
+	case err := <-errs:
+		if err != errClosed {
+			t.Fatalf("Expected '%v', but got '%v'.", errClosed, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Reply did not return after closing the connection.")
+	}
+}
+
+// BenchmarkCheck sends checked requests without a reply, and checks each
+// right away, which makes a round trip to the X server every time.
+func BenchmarkCheck(b *testing.B) {
+	display, conns := setupServer(b)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		b.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	go replyServer(<-conns)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		cookie := c.NewCookie(true, false)
+		c.NewRequest(noOperationRequest(), cookie)
+		if err := cookie.Check(); err != nil {
+			b.Fatalf("Check: %s", err)
+		}
+	}
+}
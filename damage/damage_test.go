@@ -0,0 +1,66 @@
+package damage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// firstEvent is the first event code of DAMAGE on the mock server.
+const firstEvent = 91
+
+// rawEvent is an event sent by the mock server as it is on the wire.
+type rawEvent []byte
+
+func (ev rawEvent) Bytes() []byte      { return append([]byte(nil), ev...) }
+func (ev rawEvent) SequenceId() uint16 { return 0 }
+func (ev rawEvent) String() string     { return "raw event" }
+
+// TestNotifyEvent makes sure a DamageNotify event sent after Init is read as
+// a NotifyEvent.
+func TestNotifyEvent(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	ext := make([]byte, 32)
+	ext[8] = 1           // present
+	ext[9] = 140         // major opcode
+	ext[10] = firstEvent // first event
+	server.Expect(xgbtest.Request{Opcode: 98}).WithData(ext)
+	if err := Init(X); err != nil {
+		t.Fatalf("Init: %s", err)
+	}
+
+	sent := NotifyEvent{
+		Level:    ReportLevelRawRectangles | NotifyMore,
+		Drawable: 0x201,
+		Damage:   0x400001,
+		Area:     xproto.Rectangle{X: 1, Y: 2, Width: 3, Height: 4},
+	}
+	buf := sent.Bytes()
+	buf[0] = firstEvent + Notify
+	if err := server.SendEvent(rawEvent(buf)); err != nil {
+		t.Fatalf("SendEvent: %s", err)
+	}
+
+	ev, err := X.WaitForEventTimeout(5 * time.Second)
+	if err != nil {
+		t.Fatalf("WaitForEventTimeout: %s", err)
+	}
+	got, ok := ev.(NotifyEvent)
+	if !ok {
+		t.Fatalf("Expected a NotifyEvent, but got %v.", ev)
+	}
+	if got.Damage != sent.Damage || got.Area != sent.Area {
+		t.Fatalf("Expected %v, but got %v.", sent, got)
+	}
+	if got.ReportLevel() != ReportLevelRawRectangles || !got.More() {
+		t.Fatalf("Expected level %d with more events, but got %d.",
+			ReportLevelRawRectangles, got.Level)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
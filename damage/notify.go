@@ -0,0 +1,24 @@
+package damage
+
+/*
+	Helpers for reading the level of a NotifyEvent.
+	Unlike the rest of this package, this file is not generated.
+*/
+
+// NotifyMore is the bit of the Level of a NotifyEvent that says more
+// NotifyEvents for the same damage object follow, as with the
+// ReportLevelRawRectangles and ReportLevelDeltaRectangles levels, which
+// report each rectangle in its own event.
+const NotifyMore = 0x80
+
+// ReportLevel returns the report level of the damage object the event is
+// about (e.g., ReportLevelRawRectangles), without the NotifyMore bit.
+func (v NotifyEvent) ReportLevel() byte {
+	return v.Level &^ NotifyMore
+}
+
+// More reports whether more events about the same damage object follow this
+// one. See NotifyMore.
+func (v NotifyEvent) More() bool {
+	return v.Level&NotifyMore != 0
+}
@@ -0,0 +1,181 @@
+package xgb
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DisplaySpec is a parsed display string, as returned by ParseDisplay.
+type DisplaySpec struct {
+	// Protocol is the network used to connect to the X server: "unix" for
+	// local connections (or "pipe" for named pipes), and "tcp" unless
+	// otherwise specified (as in "tcp6/host:0") for remote ones.
+	Protocol string
+
+	// Host is the name or address of the machine the X server runs on. It
+	// is empty for local connections.
+	Host string
+
+	// Number is the display number, e.g., 1 in "host:1.0".
+	Number int
+
+	// Screen is the default screen, e.g., 2 in ":0.2". It is 0 when the
+	// display string doesn't specify one.
+	Screen int
+
+	// SocketPath is the path of the Unix domain socket (or named pipe) of
+	// the X server for local connections, and empty otherwise.
+	SocketPath string
+}
+
+// ParseDisplay parses a display string of the form
+// [protocol/][host]:number[.screen] without connecting to the X server.
+// A display string that starts with a '/' is the path of a Unix domain
+// socket, followed by ':number' and optionally '.screen'.
+// If the host or protocol is "unix", or neither is given, the local socket
+// in /tmp/.X11-unix is used. IPv6 addresses may be used as the host, as in
+// "::1:0", or in brackets, as in "[::1]:0".
+//
+// A display string of the form abstract:path (as used by Xpra) names an
+// abstract Unix domain socket, which only exist on Linux. The display number
+// and screen are taken from the last element of the path if it is of the
+// form Xnumber[.screen] (as in "abstract:/run/user/1000/xpra/X10"), and are
+// 0 otherwise.
+//
+// Likewise, a display string of the form pipe:path (as in
+// "pipe://./pipe/X0") names a named pipe on Windows, whose path is given in
+// SocketPath. The protocol is "pipe" then. A path that doesn't start with
+// two slashes (or backslashes) is the name of a pipe on the local machine,
+// as in "pipe:X0".
+//
+// Unlike NewConnDisplay, ParseDisplay doesn't fall back to $DISPLAY when 's'
+// is empty.
+func ParseDisplay(s string) (DisplaySpec, error) {
+	var spec DisplaySpec
+	if len(s) == 0 {
+		return spec, ConnError{Code: ConnErrorBadDisplay,
+			Msg: "empty display string"}
+	}
+	switch {
+	case strings.HasPrefix(s, abstractPrefix):
+		return parsePath(s, abstractPrefix, "unix", "@")
+	case strings.HasPrefix(s, pipePrefix):
+		return parsePath(s, pipePrefix, "pipe", "")
+	}
+
+	colonIdx := strings.LastIndex(s, ":")
+	if colonIdx < 0 {
+		return spec, badDisplay(s)
+	}
+
+	var socket string
+	if s[0] == '/' {
+		socket = s[0:colonIdx]
+	} else {
+		slashIdx := strings.LastIndex(s[0:colonIdx], "/")
+		if slashIdx >= 0 {
+			spec.Protocol = s[0:slashIdx]
+			spec.Host = s[slashIdx+1 : colonIdx]
+		} else {
+			spec.Host = s[0:colonIdx]
+		}
+
+		// An IPv6 address may be in brackets, as in URLs (RFC 3986).
+		if strings.HasPrefix(spec.Host, "[") {
+			if len(spec.Host) < 3 ||
+				!strings.HasSuffix(spec.Host, "]") {
+
+				return DisplaySpec{}, badDisplay(s)
+			}
+			spec.Host = spec.Host[1 : len(spec.Host)-1]
+		}
+		if strings.ContainsAny(spec.Host, "[]") {
+			return DisplaySpec{}, badDisplay(s)
+		}
+	}
+
+	number, scr := s[colonIdx+1:], ""
+	if dotIdx := strings.LastIndex(number, "."); dotIdx >= 0 {
+		number, scr = number[0:dotIdx], number[dotIdx+1:]
+	}
+
+	var err error
+	spec.Number, err = strconv.Atoi(number)
+	if err != nil || spec.Number < 0 {
+		return DisplaySpec{}, badDisplay(s)
+	}
+	if len(scr) != 0 {
+		spec.Screen, err = strconv.Atoi(scr)
+		if err != nil || spec.Screen < 0 {
+			return DisplaySpec{}, badDisplay(s)
+		}
+	}
+	number = strconv.Itoa(spec.Number)
+
+	switch {
+	case len(socket) != 0:
+		spec.Protocol = "unix"
+		spec.SocketPath = socket + ":" + number
+	case spec.Host == "unix" || spec.Protocol == "unix" ||
+		len(spec.Host) == 0 && len(spec.Protocol) == 0:
+
+		spec.Protocol = "unix"
+		spec.Host = ""
+		spec.SocketPath = "/tmp/.X11-unix/X" + number
+	case len(spec.Protocol) == 0:
+		spec.Protocol = "tcp"
+	}
+	return spec, nil
+}
+
+// abstractPrefix starts display strings that name an abstract Unix domain
+// socket, and pipePrefix those that name a named pipe.
+const (
+	abstractPrefix = "abstract:"
+	pipePrefix     = "pipe:"
+)
+
+// parsePath is ParseDisplay for display strings that name the socket or pipe
+// of the X server directly, which start with 'prefix'. The spec has the
+// protocol 'protocol', and the socket path is the rest of the display
+// string, after 'pathPrefix' (e.g., '@', which is how the net package names
+// abstract sockets, for a leading null byte).
+func parsePath(s, prefix, protocol, pathPrefix string) (DisplaySpec, error) {
+	path := s[len(prefix):]
+	if len(path) == 0 {
+		return DisplaySpec{}, badDisplay(s)
+	}
+	spec := DisplaySpec{Protocol: protocol, SocketPath: pathPrefix + path}
+
+	dir, base := "", path
+	if slashIdx := strings.LastIndexAny(path, `/\`); slashIdx >= 0 {
+		dir, base = path[:slashIdx+1], path[slashIdx+1:]
+	}
+	if !strings.HasPrefix(base, "X") {
+		return spec, nil
+	}
+	number, scr := base[1:], ""
+	if dotIdx := strings.LastIndex(number, "."); dotIdx >= 0 {
+		number, scr = number[0:dotIdx], number[dotIdx+1:]
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 0 {
+		return spec, nil
+	}
+	spec.Number = n
+	spec.SocketPath = pathPrefix + dir + "X" + number
+	if len(scr) != 0 {
+		spec.Screen, err = strconv.Atoi(scr)
+		if err != nil || spec.Screen < 0 {
+			return DisplaySpec{}, badDisplay(s)
+		}
+	}
+	return spec, nil
+}
+
+// badDisplay returns the error used when the display string 'display'
+// cannot be parsed.
+func badDisplay(display string) error {
+	return ConnError{Code: ConnErrorBadDisplay,
+		Msg: "bad display string: " + display}
+}
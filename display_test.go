@@ -0,0 +1,70 @@
+package xgb
+
+import (
+	"testing"
+)
+
+// TestParseDisplay parses a number of valid display strings.
+func TestParseDisplay(t *testing.T) {
+	const sock = "/tmp/.X11-unix/X"
+	const quartz = "/tmp/launch-abc/org.xquartz:0"
+	const xpra = "/run/user/1000/xpra/X10"
+	tests := []struct {
+		display string
+		spec    DisplaySpec
+	}{
+		{":0", DisplaySpec{"unix", "", 0, 0, sock + "0"}},
+		{":1.2", DisplaySpec{"unix", "", 1, 2, sock + "1"}},
+		{"unix:3", DisplaySpec{"unix", "", 3, 0, sock + "3"}},
+		{"unix/:4", DisplaySpec{"unix", "", 4, 0, sock + "4"}},
+		{"host:10", DisplaySpec{"tcp", "host", 10, 0, ""}},
+		{"host:10.1", DisplaySpec{"tcp", "host", 10, 1, ""}},
+		{"x.org:0", DisplaySpec{"tcp", "x.org", 0, 0, ""}},
+		{"tcp/:0", DisplaySpec{"tcp", "", 0, 0, ""}},
+		{"tcp6/host:2.3", DisplaySpec{"tcp6", "host", 2, 3, ""}},
+		{"::1:0", DisplaySpec{"tcp", "::1", 0, 0, ""}},
+		{"fe80::1:5.1", DisplaySpec{"tcp", "fe80::1", 5, 1, ""}},
+		{"tcp6/::1:0", DisplaySpec{"tcp6", "::1", 0, 0, ""}},
+		{"[::1]:0", DisplaySpec{"tcp", "::1", 0, 0, ""}},
+		{"[2001:db8::1]:1.0",
+			DisplaySpec{"tcp", "2001:db8::1", 1, 0, ""}},
+		{"tcp6/[::1]:2", DisplaySpec{"tcp6", "::1", 2, 0, ""}},
+		{"[127.0.0.1]:3", DisplaySpec{"tcp", "127.0.0.1", 3, 0, ""}},
+		{"[::ffff:10.0.0.1]:4.1",
+			DisplaySpec{"tcp", "::ffff:10.0.0.1", 4, 1, ""}},
+		{"abstract:" + xpra,
+			DisplaySpec{"unix", "", 10, 0, "@" + xpra}},
+		{"abstract:/tmp/.X11-unix/X0.1",
+			DisplaySpec{"unix", "", 0, 1, "@/tmp/.X11-unix/X0"}},
+		{"abstract:xpra-socket",
+			DisplaySpec{"unix", "", 0, 0, "@xpra-socket"}},
+		{"pipe://./pipe/X0",
+			DisplaySpec{"pipe", "", 0, 0, "//./pipe/X0"}},
+		{`pipe:\\.\pipe\X1.2`,
+			DisplaySpec{"pipe", "", 1, 2, `\\.\pipe\X1`}},
+		{"pipe:X3", DisplaySpec{"pipe", "", 3, 0, "X3"}},
+		{quartz, DisplaySpec{"unix", "", 0, 0, quartz}},
+		{quartz + ".1", DisplaySpec{"unix", "", 0, 1, quartz}},
+	}
+	for _, test := range tests {
+		spec, err := ParseDisplay(test.display)
+		if err != nil {
+			t.Errorf("%q: %s", test.display, err)
+		} else if spec != test.spec {
+			t.Errorf("%q: expected %+v, but got %+v",
+				test.display, test.spec, spec)
+		}
+	}
+}
+
+// TestParseDisplayBad makes sure that malformed display strings are rejected
+// with ConnErrorBadDisplay.
+func TestParseDisplayBad(t *testing.T) {
+	bad := []string{"", "nocolon", "host:", ":x", ":0.x", ":-1", ":0.-1",
+		"/tmp/launch-abc/:", "[::1:0", "::1]:0", "[::1]x:0", "[]:0",
+		"abstract:", "abstract:/X1.x", "pipe:"}
+	for _, display := range bad {
+		_, err := ParseDisplay(display)
+		checkConnError(t, err, ConnErrorBadDisplay)
+	}
+}
@@ -137,10 +137,10 @@ included in this package, but I am currently unsure of their status. They
 
 What does not work
 
-XKB is the only extension that intentionally does not work, although I suspect
-that GLX also does not work (however, there is Go source code for GLX that
-compiles, unlike XKB). I don't currently have any intention of getting XKB
-working, due to its complexity and my current mental incapacity to test it.
+Only part of XKB works: the xkb package is generated from a subset of
+xkb.xml, with UseExtension, SelectEvents, GetState, GetMap and the StateNotify
+event (see xkb/xkb.xml for what is left out). I suspect that GLX also does
+not work, although there is Go source code for GLX that compiles.
 
 */
 package xgb
@@ -0,0 +1,716 @@
+// Package dri3 is the X client API for the DRI3 extension, which shares
+// direct rendering buffers and fences between clients and the X server as
+// file descriptors.
+//
+// File descriptors are passed along with requests and replies over the
+// Unix domain socket of the connection, which xgbgen doesn't know how to
+// generate. So like the present package, this package was written by hand
+// from dri3proto.h, following the conventions of the generated packages.
+// DRI3 requests can only be used over local connections; on others, they
+// fail with xgb.ErrFDPassingNotSupported.
+package dri3
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/sync"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// MajorVersion and MinorVersion are the version of the DRI3 extension
+// implemented by this package. They should be passed to QueryVersion.
+const (
+	MajorVersion = 1
+	MinorVersion = 2
+)
+
+// Init must be called before using the DRI3 extension.
+//
+// Since the major opcode of the extension may change, Init must be called
+// again after reconnecting (i.e., in a hook given to xgb.Conn.OnReconnect).
+func Init(c *xgb.Conn) error {
+	reply, err := xproto.QueryExtension(c, 4, "DRI3").Reply()
+	switch {
+	case err != nil:
+		return err
+	case !reply.Present:
+		return xproto.ExtNotAvailableError{Name: "DRI3", Reply: reply}
+	}
+
+	xgb.ExtLock.Lock()
+	c.Extensions["DRI3"] = reply.MajorOpcode
+	xgb.ExtLock.Unlock()
+	return nil
+}
+
+// checkInit panics if Init hasn't been called. 'request' is the name of the
+// request about to be sent.
+func checkInit(c *xgb.Conn, request string) {
+	if _, ok := c.Extensions["DRI3"]; !ok {
+		panic("Cannot issue request '" + request + "' using the " +
+			"uninitialized extension 'DRI3'. " +
+			"dri3.Init(connObj) must be called first.")
+	}
+}
+
+// requestHeader writes the header of the DRI3 request with the given
+// opcode to the start of 'buf', which must be as long as the request.
+func requestHeader(c *xgb.Conn, buf []byte, opcode byte) {
+	buf[0] = c.Extensions["DRI3"]
+	buf[1] = opcode
+	xgb.Put16(buf[2:], uint16(len(buf)/4))
+}
+
+// replyFD returns the only file descriptor of a reply, or -1 if the X
+// server didn't send one.
+func replyFD(fds []int) int {
+	if len(fds) == 0 {
+		return -1
+	}
+	return fds[0]
+}
+
+// QueryVersionCookie is a cookie used only for QueryVersion requests.
+type QueryVersionCookie struct {
+	*xgb.Cookie
+}
+
+// QueryVersion sends a checked request.
+// If an error occurs, it will be returned with the reply by calling
+// QueryVersionCookie.Reply()
+func QueryVersion(c *xgb.Conn, MajorVersion uint32,
+	MinorVersion uint32) QueryVersionCookie {
+
+	checkInit(c, "QueryVersion")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(queryVersionRequest(c, MajorVersion, MinorVersion), cookie)
+	return QueryVersionCookie{cookie}
+}
+
+// QueryVersionUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func QueryVersionUnchecked(c *xgb.Conn, MajorVersion uint32,
+	MinorVersion uint32) QueryVersionCookie {
+
+	checkInit(c, "QueryVersion")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(queryVersionRequest(c, MajorVersion, MinorVersion), cookie)
+	return QueryVersionCookie{cookie}
+}
+
+// QueryVersionReply represents the data returned from a QueryVersion request.
+type QueryVersionReply struct {
+	Sequence     uint16 // sequence number of the request for this reply
+	Length       uint32 // number of bytes in this reply
+	MajorVersion uint32
+	MinorVersion uint32
+}
+
+// Reply blocks and returns the reply data for a QueryVersion request.
+func (cook QueryVersionCookie) Reply() (*QueryVersionReply, error) {
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return &QueryVersionReply{
+		Sequence:     xgb.Get16(buf[2:]),
+		Length:       xgb.Get32(buf[4:]),
+		MajorVersion: xgb.Get32(buf[8:]),
+		MinorVersion: xgb.Get32(buf[12:]),
+	}, nil
+}
+
+// queryVersionRequest writes a QueryVersion request to a byte slice.
+func queryVersionRequest(c *xgb.Conn, MajorVersion uint32,
+	MinorVersion uint32) []byte {
+
+	buf := make([]byte, 12)
+	requestHeader(c, buf, 0)
+	xgb.Put32(buf[4:], MajorVersion)
+	xgb.Put32(buf[8:], MinorVersion)
+	return buf
+}
+
+// OpenCookie is a cookie used only for Open requests.
+type OpenCookie struct {
+	*xgb.Cookie
+}
+
+// Open sends a checked request. It opens the direct rendering device of the
+// screen of 'Drawable', using the RandR provider 'Provider' (or the default
+// one, if it is 0).
+// If an error occurs, it will be returned with the reply by calling
+// OpenCookie.Reply()
+func Open(c *xgb.Conn, Drawable xproto.Drawable,
+	Provider uint32) OpenCookie {
+
+	checkInit(c, "Open")
+	cookie := c.NewCookieFDs(true)
+	c.NewRequest(openRequest(c, Drawable, Provider), cookie)
+	return OpenCookie{cookie}
+}
+
+// OpenUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func OpenUnchecked(c *xgb.Conn, Drawable xproto.Drawable,
+	Provider uint32) OpenCookie {
+
+	checkInit(c, "Open")
+	cookie := c.NewCookieFDs(false)
+	c.NewRequest(openRequest(c, Drawable, Provider), cookie)
+	return OpenCookie{cookie}
+}
+
+// OpenReply represents the data returned from a Open request.
+// The caller is responsible for closing DeviceFd.
+type OpenReply struct {
+	Sequence uint16 // sequence number of the request for this reply
+	Length   uint32 // number of bytes in this reply
+	Nfd      byte
+	DeviceFd int
+}
+
+// Reply blocks and returns the reply data for a Open request.
+func (cook OpenCookie) Reply() (*OpenReply, error) {
+	buf, fds, err := cook.Cookie.ReplyFDs()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return &OpenReply{
+		Sequence: xgb.Get16(buf[2:]),
+		Length:   xgb.Get32(buf[4:]),
+		Nfd:      buf[1],
+		DeviceFd: replyFD(fds),
+	}, nil
+}
+
+// openRequest writes a Open request to a byte slice.
+func openRequest(c *xgb.Conn, Drawable xproto.Drawable,
+	Provider uint32) []byte {
+
+	buf := make([]byte, 12)
+	requestHeader(c, buf, 1)
+	xgb.Put32(buf[4:], uint32(Drawable))
+	xgb.Put32(buf[8:], Provider)
+	return buf
+}
+
+// PixmapFromBufferCookie is a cookie used only for PixmapFromBuffer requests.
+type PixmapFromBufferCookie struct {
+	*xgb.Cookie
+}
+
+// PixmapFromBuffer sends an unchecked request. It creates 'Pixmap' on the
+// screen of 'Drawable' from the direct rendering buffer 'PixmapFd', which is
+// closed once it has been sent.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func PixmapFromBuffer(c *xgb.Conn, Pixmap xproto.Pixmap,
+	Drawable xproto.Drawable, Size uint32, Width uint16, Height uint16,
+	Stride uint16, Depth byte, Bpp byte,
+	PixmapFd int) PixmapFromBufferCookie {
+
+	checkInit(c, "PixmapFromBuffer")
+	cookie := c.NewCookie(false, false)
+	c.NewRequestFDs(pixmapFromBufferRequest(c, Pixmap, Drawable, Size,
+		Width, Height, Stride, Depth, Bpp), []int{PixmapFd}, cookie)
+	return PixmapFromBufferCookie{cookie}
+}
+
+// PixmapFromBufferChecked sends a checked request.
+// If an error occurs, it can be retrieved using
+// PixmapFromBufferCookie.Check()
+func PixmapFromBufferChecked(c *xgb.Conn, Pixmap xproto.Pixmap,
+	Drawable xproto.Drawable, Size uint32, Width uint16, Height uint16,
+	Stride uint16, Depth byte, Bpp byte,
+	PixmapFd int) PixmapFromBufferCookie {
+
+	checkInit(c, "PixmapFromBuffer")
+	cookie := c.NewCookie(true, false)
+	c.NewRequestFDs(pixmapFromBufferRequest(c, Pixmap, Drawable, Size,
+		Width, Height, Stride, Depth, Bpp), []int{PixmapFd}, cookie)
+	return PixmapFromBufferCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook PixmapFromBufferCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// pixmapFromBufferRequest writes a PixmapFromBuffer request to a byte slice.
+func pixmapFromBufferRequest(c *xgb.Conn, Pixmap xproto.Pixmap,
+	Drawable xproto.Drawable, Size uint32, Width uint16, Height uint16,
+	Stride uint16, Depth byte, Bpp byte) []byte {
+
+	buf := make([]byte, 24)
+	requestHeader(c, buf, 2)
+	xgb.Put32(buf[4:], uint32(Pixmap))
+	xgb.Put32(buf[8:], uint32(Drawable))
+	xgb.Put32(buf[12:], Size)
+	xgb.Put16(buf[16:], Width)
+	xgb.Put16(buf[18:], Height)
+	xgb.Put16(buf[20:], Stride)
+	buf[22] = Depth
+	buf[23] = Bpp
+	return buf
+}
+
+// BufferFromPixmapCookie is a cookie used only for BufferFromPixmap requests.
+type BufferFromPixmapCookie struct {
+	*xgb.Cookie
+}
+
+// BufferFromPixmap sends a checked request. It returns the direct rendering
+// buffer behind 'Pixmap'.
+// If an error occurs, it will be returned with the reply by calling
+// BufferFromPixmapCookie.Reply()
+func BufferFromPixmap(c *xgb.Conn,
+	Pixmap xproto.Pixmap) BufferFromPixmapCookie {
+
+	checkInit(c, "BufferFromPixmap")
+	cookie := c.NewCookieFDs(true)
+	c.NewRequest(bufferFromPixmapRequest(c, Pixmap), cookie)
+	return BufferFromPixmapCookie{cookie}
+}
+
+// BufferFromPixmapUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func BufferFromPixmapUnchecked(c *xgb.Conn,
+	Pixmap xproto.Pixmap) BufferFromPixmapCookie {
+
+	checkInit(c, "BufferFromPixmap")
+	cookie := c.NewCookieFDs(false)
+	c.NewRequest(bufferFromPixmapRequest(c, Pixmap), cookie)
+	return BufferFromPixmapCookie{cookie}
+}
+
+// BufferFromPixmapReply represents the data returned from a BufferFromPixmap
+// request. The caller is responsible for closing PixmapFd.
+type BufferFromPixmapReply struct {
+	Sequence uint16 // sequence number of the request for this reply
+	Length   uint32 // number of bytes in this reply
+	Nfd      byte
+	Size     uint32
+	Width    uint16
+	Height   uint16
+	Stride   uint16
+	Depth    byte
+	Bpp      byte
+	PixmapFd int
+}
+
+// Reply blocks and returns the reply data for a BufferFromPixmap request.
+func (cook BufferFromPixmapCookie) Reply() (*BufferFromPixmapReply, error) {
+	buf, fds, err := cook.Cookie.ReplyFDs()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return &BufferFromPixmapReply{
+		Sequence: xgb.Get16(buf[2:]),
+		Length:   xgb.Get32(buf[4:]),
+		Nfd:      buf[1],
+		Size:     xgb.Get32(buf[8:]),
+		Width:    xgb.Get16(buf[12:]),
+		Height:   xgb.Get16(buf[14:]),
+		Stride:   xgb.Get16(buf[16:]),
+		Depth:    buf[18],
+		Bpp:      buf[19],
+		PixmapFd: replyFD(fds),
+	}, nil
+}
+
+// bufferFromPixmapRequest writes a BufferFromPixmap request to a byte slice.
+func bufferFromPixmapRequest(c *xgb.Conn, Pixmap xproto.Pixmap) []byte {
+	buf := make([]byte, 8)
+	requestHeader(c, buf, 3)
+	xgb.Put32(buf[4:], uint32(Pixmap))
+	return buf
+}
+
+// FenceFromFDCookie is a cookie used only for FenceFromFD requests.
+type FenceFromFDCookie struct {
+	*xgb.Cookie
+}
+
+// FenceFromFD sends an unchecked request. It creates the SYNC fence 'Fence'
+// on the screen of 'Drawable' from the shared memory fence 'FenceFd', which
+// is closed once it has been sent.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func FenceFromFD(c *xgb.Conn, Drawable xproto.Drawable, Fence sync.Fence,
+	InitiallyTriggered bool, FenceFd int) FenceFromFDCookie {
+
+	checkInit(c, "FenceFromFD")
+	cookie := c.NewCookie(false, false)
+	c.NewRequestFDs(fenceFromFDRequest(c, Drawable, Fence,
+		InitiallyTriggered), []int{FenceFd}, cookie)
+	return FenceFromFDCookie{cookie}
+}
+
+// FenceFromFDChecked sends a checked request.
+// If an error occurs, it can be retrieved using FenceFromFDCookie.Check()
+func FenceFromFDChecked(c *xgb.Conn, Drawable xproto.Drawable,
+	Fence sync.Fence, InitiallyTriggered bool,
+	FenceFd int) FenceFromFDCookie {
+
+	checkInit(c, "FenceFromFD")
+	cookie := c.NewCookie(true, false)
+	c.NewRequestFDs(fenceFromFDRequest(c, Drawable, Fence,
+		InitiallyTriggered), []int{FenceFd}, cookie)
+	return FenceFromFDCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook FenceFromFDCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// fenceFromFDRequest writes a FenceFromFD request to a byte slice.
+func fenceFromFDRequest(c *xgb.Conn, Drawable xproto.Drawable,
+	Fence sync.Fence, InitiallyTriggered bool) []byte {
+
+	buf := make([]byte, 16)
+	requestHeader(c, buf, 4)
+	xgb.Put32(buf[4:], uint32(Drawable))
+	xgb.Put32(buf[8:], uint32(Fence))
+	if InitiallyTriggered {
+		buf[12] = 1
+	}
+	return buf
+}
+
+// FDFromFenceCookie is a cookie used only for FDFromFence requests.
+type FDFromFenceCookie struct {
+	*xgb.Cookie
+}
+
+// FDFromFence sends a checked request. It returns the shared memory fence
+// behind the SYNC fence 'Fence'.
+// If an error occurs, it will be returned with the reply by calling
+// FDFromFenceCookie.Reply()
+func FDFromFence(c *xgb.Conn, Drawable xproto.Drawable,
+	Fence sync.Fence) FDFromFenceCookie {
+
+	checkInit(c, "FDFromFence")
+	cookie := c.NewCookieFDs(true)
+	c.NewRequest(fdFromFenceRequest(c, Drawable, Fence), cookie)
+	return FDFromFenceCookie{cookie}
+}
+
+// FDFromFenceUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func FDFromFenceUnchecked(c *xgb.Conn, Drawable xproto.Drawable,
+	Fence sync.Fence) FDFromFenceCookie {
+
+	checkInit(c, "FDFromFence")
+	cookie := c.NewCookieFDs(false)
+	c.NewRequest(fdFromFenceRequest(c, Drawable, Fence), cookie)
+	return FDFromFenceCookie{cookie}
+}
+
+// FDFromFenceReply represents the data returned from a FDFromFence request.
+// The caller is responsible for closing FenceFd.
+type FDFromFenceReply struct {
+	Sequence uint16 // sequence number of the request for this reply
+	Length   uint32 // number of bytes in this reply
+	Nfd      byte
+	FenceFd  int
+}
+
+// Reply blocks and returns the reply data for a FDFromFence request.
+func (cook FDFromFenceCookie) Reply() (*FDFromFenceReply, error) {
+	buf, fds, err := cook.Cookie.ReplyFDs()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return &FDFromFenceReply{
+		Sequence: xgb.Get16(buf[2:]),
+		Length:   xgb.Get32(buf[4:]),
+		Nfd:      buf[1],
+		FenceFd:  replyFD(fds),
+	}, nil
+}
+
+// fdFromFenceRequest writes a FDFromFence request to a byte slice.
+func fdFromFenceRequest(c *xgb.Conn, Drawable xproto.Drawable,
+	Fence sync.Fence) []byte {
+
+	buf := make([]byte, 12)
+	requestHeader(c, buf, 5)
+	xgb.Put32(buf[4:], uint32(Drawable))
+	xgb.Put32(buf[8:], uint32(Fence))
+	return buf
+}
+
+// GetSupportedModifiersCookie is a cookie used only for GetSupportedModifiers
+// requests.
+type GetSupportedModifiersCookie struct {
+	*xgb.Cookie
+}
+
+// GetSupportedModifiers sends a checked request. It returns the format
+// modifiers supported for buffers of the given depth and bpp, both for
+// 'Window' and for its screen. It is new in version 1.2.
+// If an error occurs, it will be returned with the reply by calling
+// GetSupportedModifiersCookie.Reply()
+func GetSupportedModifiers(c *xgb.Conn, Window xproto.Window, Depth byte,
+	Bpp byte) GetSupportedModifiersCookie {
+
+	checkInit(c, "GetSupportedModifiers")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(getSupportedModifiersRequest(c, Window, Depth, Bpp),
+		cookie)
+	return GetSupportedModifiersCookie{cookie}
+}
+
+// GetSupportedModifiersUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func GetSupportedModifiersUnchecked(c *xgb.Conn, Window xproto.Window,
+	Depth byte, Bpp byte) GetSupportedModifiersCookie {
+
+	checkInit(c, "GetSupportedModifiers")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(getSupportedModifiersRequest(c, Window, Depth, Bpp),
+		cookie)
+	return GetSupportedModifiersCookie{cookie}
+}
+
+// GetSupportedModifiersReply represents the data returned from
+// a GetSupportedModifiers request.
+type GetSupportedModifiersReply struct {
+	Sequence           uint16 // sequence number of the request for this reply
+	Length             uint32 // number of bytes in this reply
+	NumWindowModifiers uint32
+	NumScreenModifiers uint32
+	WindowModifiers    []uint64
+	ScreenModifiers    []uint64
+}
+
+// Reply blocks and returns the reply data for a GetSupportedModifiers
+// request.
+func (cook GetSupportedModifiersCookie) Reply() (*GetSupportedModifiersReply,
+	error) {
+
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	v := &GetSupportedModifiersReply{
+		Sequence:           xgb.Get16(buf[2:]),
+		Length:             xgb.Get32(buf[4:]),
+		NumWindowModifiers: xgb.Get32(buf[8:]),
+		NumScreenModifiers: xgb.Get32(buf[12:]),
+	}
+	b := 32
+	v.WindowModifiers = make([]uint64, v.NumWindowModifiers)
+	for i := range v.WindowModifiers {
+		v.WindowModifiers[i] = xgb.Get64(buf[b:])
+		b += 8
+	}
+	v.ScreenModifiers = make([]uint64, v.NumScreenModifiers)
+	for i := range v.ScreenModifiers {
+		v.ScreenModifiers[i] = xgb.Get64(buf[b:])
+		b += 8
+	}
+	return v, nil
+}
+
+// getSupportedModifiersRequest writes a GetSupportedModifiers request to
+// a byte slice.
+func getSupportedModifiersRequest(c *xgb.Conn, Window xproto.Window,
+	Depth byte, Bpp byte) []byte {
+
+	buf := make([]byte, 12)
+	requestHeader(c, buf, 6)
+	xgb.Put32(buf[4:], uint32(Window))
+	buf[8] = Depth
+	buf[9] = Bpp
+	return buf
+}
+
+// Plane is one of the (up to four) planes of a buffer with a format
+// modifier, as used by PixmapFromBuffers and BuffersFromPixmap.
+type Plane struct {
+	Stride uint32
+	Offset uint32
+}
+
+// PixmapFromBuffersCookie is a cookie used only for PixmapFromBuffers
+// requests.
+type PixmapFromBuffersCookie struct {
+	*xgb.Cookie
+}
+
+// PixmapFromBuffers sends an unchecked request. It is like PixmapFromBuffer,
+// but for buffers with a format modifier and one file descriptor for each of
+// up to four planes. It is new in version 1.2.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func PixmapFromBuffers(c *xgb.Conn, Pixmap xproto.Pixmap,
+	Window xproto.Window, Width uint16, Height uint16, Planes []Plane,
+	Depth byte, Bpp byte, Modifier uint64,
+	Buffers []int) PixmapFromBuffersCookie {
+
+	checkInit(c, "PixmapFromBuffers")
+	cookie := c.NewCookie(false, false)
+	c.NewRequestFDs(pixmapFromBuffersRequest(c, Pixmap, Window, Width,
+		Height, Planes, Depth, Bpp, Modifier, len(Buffers)), Buffers,
+		cookie)
+	return PixmapFromBuffersCookie{cookie}
+}
+
+// PixmapFromBuffersChecked sends a checked request.
+// If an error occurs, it can be retrieved using
+// PixmapFromBuffersCookie.Check()
+func PixmapFromBuffersChecked(c *xgb.Conn, Pixmap xproto.Pixmap,
+	Window xproto.Window, Width uint16, Height uint16, Planes []Plane,
+	Depth byte, Bpp byte, Modifier uint64,
+	Buffers []int) PixmapFromBuffersCookie {
+
+	checkInit(c, "PixmapFromBuffers")
+	cookie := c.NewCookie(true, false)
+	c.NewRequestFDs(pixmapFromBuffersRequest(c, Pixmap, Window, Width,
+		Height, Planes, Depth, Bpp, Modifier, len(Buffers)), Buffers,
+		cookie)
+	return PixmapFromBuffersCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook PixmapFromBuffersCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// pixmapFromBuffersRequest writes a PixmapFromBuffers request to a byte
+// slice. Only the first four of 'Planes' are used.
+func pixmapFromBuffersRequest(c *xgb.Conn, Pixmap xproto.Pixmap,
+	Window xproto.Window, Width uint16, Height uint16, Planes []Plane,
+	Depth byte, Bpp byte, Modifier uint64, NumBuffers int) []byte {
+
+	buf := make([]byte, 64)
+	requestHeader(c, buf, 7)
+	xgb.Put32(buf[4:], uint32(Pixmap))
+	xgb.Put32(buf[8:], uint32(Window))
+	buf[12] = byte(NumBuffers)
+	xgb.Put16(buf[16:], Width)
+	xgb.Put16(buf[18:], Height)
+	for i := 0; i < len(Planes) && i < 4; i++ {
+		xgb.Put32(buf[20+i*8:], Planes[i].Stride)
+		xgb.Put32(buf[24+i*8:], Planes[i].Offset)
+	}
+	buf[52] = Depth
+	buf[53] = Bpp
+	xgb.Put64(buf[56:], Modifier)
+	return buf
+}
+
+// BuffersFromPixmapCookie is a cookie used only for BuffersFromPixmap
+// requests.
+type BuffersFromPixmapCookie struct {
+	*xgb.Cookie
+}
+
+// BuffersFromPixmap sends a checked request. It is like BufferFromPixmap,
+// but returns one file descriptor for each plane of the buffer, along with
+// its format modifier. It is new in version 1.2.
+// If an error occurs, it will be returned with the reply by calling
+// BuffersFromPixmapCookie.Reply()
+func BuffersFromPixmap(c *xgb.Conn,
+	Pixmap xproto.Pixmap) BuffersFromPixmapCookie {
+
+	checkInit(c, "BuffersFromPixmap")
+	cookie := c.NewCookieFDs(true)
+	c.NewRequest(buffersFromPixmapRequest(c, Pixmap), cookie)
+	return BuffersFromPixmapCookie{cookie}
+}
+
+// BuffersFromPixmapUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func BuffersFromPixmapUnchecked(c *xgb.Conn,
+	Pixmap xproto.Pixmap) BuffersFromPixmapCookie {
+
+	checkInit(c, "BuffersFromPixmap")
+	cookie := c.NewCookieFDs(false)
+	c.NewRequest(buffersFromPixmapRequest(c, Pixmap), cookie)
+	return BuffersFromPixmapCookie{cookie}
+}
+
+// BuffersFromPixmapReply represents the data returned from
+// a BuffersFromPixmap request. The caller is responsible for closing
+// Buffers.
+type BuffersFromPixmapReply struct {
+	Sequence uint16 // sequence number of the request for this reply
+	Length   uint32 // number of bytes in this reply
+	Nfd      byte
+	Width    uint16
+	Height   uint16
+	Modifier uint64
+	Depth    byte
+	Bpp      byte
+	Planes   []Plane
+	Buffers  []int
+}
+
+// Reply blocks and returns the reply data for a BuffersFromPixmap request.
+func (cook BuffersFromPixmapCookie) Reply() (*BuffersFromPixmapReply, error) {
+	buf, fds, err := cook.Cookie.ReplyFDs()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	v := &BuffersFromPixmapReply{
+		Sequence: xgb.Get16(buf[2:]),
+		Length:   xgb.Get32(buf[4:]),
+		Nfd:      buf[1],
+		Width:    xgb.Get16(buf[8:]),
+		Height:   xgb.Get16(buf[10:]),
+		Modifier: xgb.Get64(buf[16:]),
+		Depth:    buf[24],
+		Bpp:      buf[25],
+		Buffers:  fds,
+	}
+	n := int(v.Nfd)
+	v.Planes = make([]Plane, n)
+	for i := range v.Planes {
+		v.Planes[i].Stride = xgb.Get32(buf[32+i*4:])
+		v.Planes[i].Offset = xgb.Get32(buf[32+(n+i)*4:])
+	}
+	return v, nil
+}
+
+// buffersFromPixmapRequest writes a BuffersFromPixmap request to a byte
+// slice.
+func buffersFromPixmapRequest(c *xgb.Conn, Pixmap xproto.Pixmap) []byte {
+	buf := make([]byte, 8)
+	requestHeader(c, buf, 8)
+	xgb.Put32(buf[4:], uint32(Pixmap))
+	return buf
+}
@@ -0,0 +1,63 @@
+package dri3
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestPixmapFromBuffersRequest checks the encoding of a PixmapFromBuffers
+// request with two planes.
+func TestPixmapFromBuffersRequest(t *testing.T) {
+	c := &xgb.Conn{Extensions: map[string]byte{"DRI3": 150}}
+	planes := []Plane{{Stride: 256, Offset: 0}, {Stride: 128, Offset: 4096}}
+	buf := pixmapFromBuffersRequest(c, 0x200001, 0x400000, 64, 32, planes,
+		24, 32, 0x0100000000000002, 2)
+
+	want := make([]byte, 64)
+	copy(want, []byte{150, 7, 16, 0, 0x01, 0, 0x20, 0, 0, 0, 0x40, 0, 2})
+	copy(want[16:], []byte{64, 0, 32, 0, 0, 1, 0, 0, 0, 0, 0, 0,
+		128, 0, 0, 0, 0, 0x10, 0, 0})
+	want[52] = 24
+	want[53] = 32
+	copy(want[56:], []byte{2, 0, 0, 0, 0, 0, 0, 1})
+	if !bytes.Equal(buf, want) {
+		t.Fatalf("Expected\n% x\nbut got\n% x", want, buf)
+	}
+}
+
+// TestOpen opens the direct rendering device of the X server in DISPLAY,
+// and is skipped when it isn't set.
+func TestOpen(t *testing.T) {
+	if len(os.Getenv("DISPLAY")) == 0 {
+		t.Skip("DISPLAY is not set")
+	}
+	X, err := xgb.NewConn()
+	if err != nil {
+		t.Fatalf("NewConn: %s", err)
+	}
+	defer X.Close()
+	if err := Init(X); err != nil {
+		t.Skipf("DRI3 is not available: %s", err)
+	}
+	_, err = QueryVersion(X, MajorVersion, MinorVersion).Reply()
+	if err != nil {
+		t.Fatalf("QueryVersion: %s", err)
+	}
+
+	root := xproto.Setup(X).DefaultScreen(X).Root
+	reply, err := Open(X, xproto.Drawable(root), 0).Reply()
+	if err != nil {
+		// Servers without a direct rendering device answer with
+		// a Match error.
+		t.Skipf("Open: %s", err)
+	}
+	if reply.DeviceFd < 0 {
+		t.Fatalf("Open returned no file descriptor.")
+	}
+	os.NewFile(uintptr(reply.DeviceFd), "device").Close()
+}
@@ -0,0 +1,27 @@
+package xgb
+
+// SetErrorHandler makes the connection call 'fn' with the X errors of
+// unchecked requests, instead of returning them from WaitForEvent and
+// PollForEvent along with the events. A nil function puts them back in the
+// event queue. The handler is kept by Reconnect.
+//
+// 'fn' is called by the goroutine that reads responses, so nothing else is
+// read until it returns: it must not block, and must not wait for the reply
+// of a request.
+func (c *Conn) SetErrorHandler(fn func(Error)) {
+	if fn == nil {
+		c.errorHandler.Store(nil)
+		return
+	}
+	c.errorHandler.Store(&fn)
+}
+
+// asyncError gives the error of an unchecked request made on c to the error
+// handler, or puts it in the event queue if there is none.
+func (c *Conn) asyncError(err Error) {
+	if fn := c.errorHandler.Load(); fn != nil {
+		(*fn)(err)
+		return
+	}
+	c.eventChan <- err
+}
@@ -0,0 +1,87 @@
+package xgb
+
+import (
+	"io"
+	"net"
+	"testing"
+)
+
+// seqErrorCode is the code of seqError, which no core error has.
+const seqErrorCode = 200
+
+// seqError is like testError, but knows the request it answers.
+type seqError struct {
+	seq uint16
+}
+
+func (err seqError) SequenceId() uint16 { return err.seq }
+func (err seqError) BadId() uint32      { return 0 }
+func (err seqError) Error() string      { return "seqError" }
+
+// errorServer is like replyServer, but answers every NoOperation request
+// with a seqError.
+func errorServer(conn net.Conn) {
+	head := make([]byte, 4)
+	for seq := uint16(1); ; seq++ {
+		if _, err := io.ReadFull(conn, head); err != nil {
+			return
+		}
+		rest := int(Get16(head[2:]))*4 - 4
+		if _, err := io.ReadFull(conn, make([]byte, rest)); err != nil {
+			return
+		}
+
+		resp := make([]byte, 32)
+		switch head[0] {
+		case 127:
+			resp[1] = seqErrorCode
+		case 43:
+			resp[0] = 1
+		default:
+			continue
+		}
+		Put16(resp[2:], seq)
+		if _, err := conn.Write(resp); err != nil {
+			return
+		}
+	}
+}
+
+// TestSetErrorHandler makes sure the errors of unchecked requests go to the
+// error handler rather than the event queue, and back once it is removed.
+func TestSetErrorHandler(t *testing.T) {
+	NewErrorFuncs[seqErrorCode] = func(buf []byte) Error {
+		return seqError{seq: Get16(buf[2:])}
+	}
+	defer delete(NewErrorFuncs, seqErrorCode)
+
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	go errorServer(<-conns)
+
+	errs := make(chan Error, 1)
+	c.SetErrorHandler(func(err Error) { errs <- err })
+	c.NewRequest(noOperationRequest(), c.NewCookie(false, false))
+	c.Sync()
+	if len(errs) != 1 {
+		t.Fatalf("The error handler wasn't called.")
+	}
+	if ev, err, _ := c.PollForEvent(); ev != nil || err != nil {
+		t.Fatalf("Expected an empty event queue, but got %v, %v.",
+			ev, err)
+	}
+
+	c.SetErrorHandler(nil)
+	c.NewRequest(noOperationRequest(), c.NewCookie(false, false))
+	c.Sync()
+	if _, err, _ := c.PollForEvent(); err != (seqError{seq: 3}) {
+		t.Fatalf("The error wasn't put in the event queue.")
+	}
+	if len(errs) != 1 {
+		t.Fatalf("The removed error handler was called.")
+	}
+}
@@ -0,0 +1,328 @@
+package ewmh
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// The properties of client windows.
+
+// Strut is the space a window (like a panel) reserves at each edge of the
+// screen, as in _NET_WM_STRUT.
+type Strut struct {
+	Left, Right, Top, Bottom uint32
+}
+
+// StrutPartial is like Strut, but also says which part of each edge is
+// reserved, as in _NET_WM_STRUT_PARTIAL. For example, the space reserved at
+// the left edge is the one between the rows LeftStartY and LeftEndY.
+type StrutPartial struct {
+	Left, Right, Top, Bottom uint32
+
+	LeftStartY, LeftEndY     uint32
+	RightStartY, RightEndY   uint32
+	TopStartX, TopEndX       uint32
+	BottomStartX, BottomEndX uint32
+}
+
+// FrameExtents is the size of the frame the window manager puts around
+// a window, as in _NET_FRAME_EXTENTS.
+type FrameExtents struct {
+	Left, Right, Top, Bottom uint32
+}
+
+// Icon is one of the icons of a window, as in _NET_WM_ICON. Data has
+// Width*Height pixels, row by row, each of them 32 bit ARGB.
+type Icon struct {
+	Width, Height uint32
+	Data          []uint32
+}
+
+// AllDesktops is the desktop of windows that are on all of them, in
+// _NET_WM_DESKTOP.
+const AllDesktops = 0xffffffff
+
+// WmName returns the title of 'win', from _NET_WM_NAME.
+func WmName(c *xgb.Conn, win xproto.Window) (string, error) {
+	return getString(c, win, "_NET_WM_NAME")
+}
+
+// SetWmName sets _NET_WM_NAME.
+func SetWmName(c *xgb.Conn, win xproto.Window, name string) error {
+	return setString(c, win, "_NET_WM_NAME", name)
+}
+
+// WmVisibleName returns the title the window manager shows for 'win', if it
+// isn't _NET_WM_NAME, from _NET_WM_VISIBLE_NAME.
+func WmVisibleName(c *xgb.Conn, win xproto.Window) (string, error) {
+	return getString(c, win, "_NET_WM_VISIBLE_NAME")
+}
+
+// SetWmVisibleName sets _NET_WM_VISIBLE_NAME.
+func SetWmVisibleName(c *xgb.Conn, win xproto.Window, name string) error {
+	return setString(c, win, "_NET_WM_VISIBLE_NAME", name)
+}
+
+// WmIconName returns the title of 'win' when it is iconified, from
+// _NET_WM_ICON_NAME.
+func WmIconName(c *xgb.Conn, win xproto.Window) (string, error) {
+	return getString(c, win, "_NET_WM_ICON_NAME")
+}
+
+// SetWmIconName sets _NET_WM_ICON_NAME.
+func SetWmIconName(c *xgb.Conn, win xproto.Window, name string) error {
+	return setString(c, win, "_NET_WM_ICON_NAME", name)
+}
+
+// WmVisibleIconName returns the icon title the window manager shows for
+// 'win', if it isn't _NET_WM_ICON_NAME, from _NET_WM_VISIBLE_ICON_NAME.
+func WmVisibleIconName(c *xgb.Conn, win xproto.Window) (string, error) {
+	return getString(c, win, "_NET_WM_VISIBLE_ICON_NAME")
+}
+
+// SetWmVisibleIconName sets _NET_WM_VISIBLE_ICON_NAME.
+func SetWmVisibleIconName(c *xgb.Conn, win xproto.Window,
+	name string) error {
+
+	return setString(c, win, "_NET_WM_VISIBLE_ICON_NAME", name)
+}
+
+// WmDesktop returns the desktop 'win' is on, from _NET_WM_DESKTOP. It is
+// AllDesktops if the window is on all of them.
+func WmDesktop(c *xgb.Conn, win xproto.Window) (uint32, error) {
+	return getCardinal(c, win, "_NET_WM_DESKTOP")
+}
+
+// SetWmDesktop sets _NET_WM_DESKTOP. Once a window is mapped, clients should
+// use RequestWmDesktop instead.
+func SetWmDesktop(c *xgb.Conn, win xproto.Window, desktop uint32) error {
+	return setCardinals(c, win, "_NET_WM_DESKTOP", "CARDINAL",
+		[]uint32{desktop})
+}
+
+// WmWindowType returns the types of 'win' (e.g., "_NET_WM_WINDOW_TYPE_DOCK"),
+// from _NET_WM_WINDOW_TYPE, the preferred one first.
+func WmWindowType(c *xgb.Conn, win xproto.Window) ([]string, error) {
+	return getAtoms(c, win, "_NET_WM_WINDOW_TYPE")
+}
+
+// SetWmWindowType sets _NET_WM_WINDOW_TYPE.
+func SetWmWindowType(c *xgb.Conn, win xproto.Window, types []string) error {
+	return setAtoms(c, win, "_NET_WM_WINDOW_TYPE", types)
+}
+
+// WmState returns the states of 'win' (e.g., "_NET_WM_STATE_FULLSCREEN"),
+// from _NET_WM_STATE.
+func WmState(c *xgb.Conn, win xproto.Window) ([]string, error) {
+	return getAtoms(c, win, "_NET_WM_STATE")
+}
+
+// SetWmState sets _NET_WM_STATE. Once a window is mapped, clients should use
+// RequestWmState instead.
+func SetWmState(c *xgb.Conn, win xproto.Window, states []string) error {
+	return setAtoms(c, win, "_NET_WM_STATE", states)
+}
+
+// WmAllowedActions returns the actions the window manager allows on 'win'
+// (e.g., "_NET_WM_ACTION_CLOSE"), from _NET_WM_ALLOWED_ACTIONS.
+func WmAllowedActions(c *xgb.Conn, win xproto.Window) ([]string, error) {
+	return getAtoms(c, win, "_NET_WM_ALLOWED_ACTIONS")
+}
+
+// SetWmAllowedActions sets _NET_WM_ALLOWED_ACTIONS.
+func SetWmAllowedActions(c *xgb.Conn, win xproto.Window,
+	actions []string) error {
+
+	return setAtoms(c, win, "_NET_WM_ALLOWED_ACTIONS", actions)
+}
+
+// WmStrut returns the space 'win' reserves at the edges of the screen, from
+// _NET_WM_STRUT.
+func WmStrut(c *xgb.Conn, win xproto.Window) (Strut, error) {
+	v, err := getCardinalsN(c, win, "_NET_WM_STRUT", 4)
+	if err != nil {
+		return Strut{}, err
+	}
+	return Strut{v[0], v[1], v[2], v[3]}, nil
+}
+
+// SetWmStrut sets _NET_WM_STRUT.
+func SetWmStrut(c *xgb.Conn, win xproto.Window, strut Strut) error {
+	return setCardinals(c, win, "_NET_WM_STRUT", "CARDINAL",
+		[]uint32{strut.Left, strut.Right, strut.Top, strut.Bottom})
+}
+
+// WmStrutPartial returns the space 'win' reserves at the edges of the screen,
+// from _NET_WM_STRUT_PARTIAL.
+func WmStrutPartial(c *xgb.Conn, win xproto.Window) (StrutPartial, error) {
+	v, err := getCardinalsN(c, win, "_NET_WM_STRUT_PARTIAL", 12)
+	if err != nil {
+		return StrutPartial{}, err
+	}
+	return StrutPartial{
+		v[0], v[1], v[2], v[3],
+		v[4], v[5], v[6], v[7], v[8], v[9], v[10], v[11],
+	}, nil
+}
+
+// SetWmStrutPartial sets _NET_WM_STRUT_PARTIAL.
+func SetWmStrutPartial(c *xgb.Conn, win xproto.Window,
+	s StrutPartial) error {
+
+	return setCardinals(c, win, "_NET_WM_STRUT_PARTIAL", "CARDINAL",
+		[]uint32{
+			s.Left, s.Right, s.Top, s.Bottom,
+			s.LeftStartY, s.LeftEndY, s.RightStartY, s.RightEndY,
+			s.TopStartX, s.TopEndX, s.BottomStartX, s.BottomEndX,
+		})
+}
+
+// WmIconGeometry returns the area of the icon of 'win' in a taskbar, from
+// _NET_WM_ICON_GEOMETRY.
+func WmIconGeometry(c *xgb.Conn, win xproto.Window) (Rect, error) {
+	v, err := getCardinalsN(c, win, "_NET_WM_ICON_GEOMETRY", 4)
+	if err != nil {
+		return Rect{}, err
+	}
+	return Rect{v[0], v[1], v[2], v[3]}, nil
+}
+
+// SetWmIconGeometry sets _NET_WM_ICON_GEOMETRY.
+func SetWmIconGeometry(c *xgb.Conn, win xproto.Window, r Rect) error {
+	return setCardinals(c, win, "_NET_WM_ICON_GEOMETRY", "CARDINAL",
+		rectValues([]Rect{r}))
+}
+
+// WmIcon returns the icons of 'win', from _NET_WM_ICON.
+func WmIcon(c *xgb.Conn, win xproto.Window) ([]Icon, error) {
+	vals, err := getCardinals(c, win, "_NET_WM_ICON")
+	if err != nil {
+		return nil, err
+	}
+	return icons(vals)
+}
+
+// SetWmIcon sets _NET_WM_ICON.
+func SetWmIcon(c *xgb.Conn, win xproto.Window, icons []Icon) error {
+	var vals []uint32
+	for _, icon := range icons {
+		vals = append(vals, icon.Width, icon.Height)
+		vals = append(vals, icon.Data...)
+	}
+	return setCardinals(c, win, "_NET_WM_ICON", "CARDINAL", vals)
+}
+
+// icons returns the icons in 'vals', each a width and a height followed by
+// that many pixels.
+func icons(vals []uint32) ([]Icon, error) {
+	var icons []Icon
+	for len(vals) > 0 {
+		if len(vals) < 2 {
+			return nil, ErrBadFormat
+		}
+		w, h := vals[0], vals[1]
+		size := uint64(w) * uint64(h)
+		if size > uint64(len(vals)-2) {
+			return nil, ErrBadFormat
+		}
+		icons = append(icons, Icon{w, h, vals[2 : 2+size]})
+		vals = vals[2+size:]
+	}
+	return icons, nil
+}
+
+// WmPid returns the process id of the client that owns 'win', from
+// _NET_WM_PID.
+func WmPid(c *xgb.Conn, win xproto.Window) (uint32, error) {
+	return getCardinal(c, win, "_NET_WM_PID")
+}
+
+// SetWmPid sets _NET_WM_PID.
+func SetWmPid(c *xgb.Conn, win xproto.Window, pid uint32) error {
+	return setCardinals(c, win, "_NET_WM_PID", "CARDINAL", []uint32{pid})
+}
+
+// WmUserTime returns the time of the last user activity in 'win', from
+// _NET_WM_USER_TIME. A time of 0 means the window shouldn't get the focus
+// when it is mapped.
+func WmUserTime(c *xgb.Conn, win xproto.Window) (uint32, error) {
+	return getCardinal(c, win, "_NET_WM_USER_TIME")
+}
+
+// SetWmUserTime sets _NET_WM_USER_TIME.
+func SetWmUserTime(c *xgb.Conn, win xproto.Window, time uint32) error {
+	return setCardinals(c, win, "_NET_WM_USER_TIME", "CARDINAL",
+		[]uint32{time})
+}
+
+// WmUserTimeWindow returns the window that has the _NET_WM_USER_TIME
+// property for 'win', from _NET_WM_USER_TIME_WINDOW.
+func WmUserTimeWindow(c *xgb.Conn,
+	win xproto.Window) (xproto.Window, error) {
+
+	return getWindow(c, win, "_NET_WM_USER_TIME_WINDOW")
+}
+
+// SetWmUserTimeWindow sets _NET_WM_USER_TIME_WINDOW.
+func SetWmUserTimeWindow(c *xgb.Conn, win, timeWin xproto.Window) error {
+	return setWindows(c, win, "_NET_WM_USER_TIME_WINDOW",
+		[]xproto.Window{timeWin})
+}
+
+// FrameExtentsOf returns the size of the frame around 'win', from
+// _NET_FRAME_EXTENTS.
+func FrameExtentsOf(c *xgb.Conn, win xproto.Window) (FrameExtents, error) {
+	v, err := getCardinalsN(c, win, "_NET_FRAME_EXTENTS", 4)
+	if err != nil {
+		return FrameExtents{}, err
+	}
+	return FrameExtents{v[0], v[1], v[2], v[3]}, nil
+}
+
+// SetFrameExtents sets _NET_FRAME_EXTENTS.
+func SetFrameExtents(c *xgb.Conn, win xproto.Window,
+	extents FrameExtents) error {
+
+	return setCardinals(c, win, "_NET_FRAME_EXTENTS", "CARDINAL",
+		[]uint32{extents.Left, extents.Right, extents.Top,
+			extents.Bottom})
+}
+
+// WmOpaqueRegion returns the parts of 'win' that are fully opaque, from
+// _NET_WM_OPAQUE_REGION.
+func WmOpaqueRegion(c *xgb.Conn, win xproto.Window) ([]Rect, error) {
+	vals, err := getCardinals(c, win, "_NET_WM_OPAQUE_REGION")
+	if err != nil {
+		return nil, err
+	}
+	return rects(vals), nil
+}
+
+// SetWmOpaqueRegion sets _NET_WM_OPAQUE_REGION.
+func SetWmOpaqueRegion(c *xgb.Conn, win xproto.Window, region []Rect) error {
+	return setCardinals(c, win, "_NET_WM_OPAQUE_REGION", "CARDINAL",
+		rectValues(region))
+}
+
+// Values of _NET_WM_BYPASS_COMPOSITOR.
+const (
+	BypassCompositorNoPreference = 0
+	BypassCompositorDisable      = 1
+	BypassCompositorNever        = 2
+)
+
+// WmBypassCompositor returns whether 'win' asks the compositor not to
+// composite it (e.g., for a fullscreen game), from
+// _NET_WM_BYPASS_COMPOSITOR.
+func WmBypassCompositor(c *xgb.Conn, win xproto.Window) (uint32, error) {
+	return getCardinal(c, win, "_NET_WM_BYPASS_COMPOSITOR")
+}
+
+// SetWmBypassCompositor sets _NET_WM_BYPASS_COMPOSITOR.
+func SetWmBypassCompositor(c *xgb.Conn, win xproto.Window,
+	bypass uint32) error {
+
+	return setCardinals(c, win, "_NET_WM_BYPASS_COMPOSITOR", "CARDINAL",
+		[]uint32{bypass})
+}
@@ -0,0 +1,200 @@
+// Package ewmh reads and writes the properties defined by the Extended Window
+// Manager Hints (EWMH), version 1.5, and sends the client messages they
+// define. See https://specifications.freedesktop.org/wm-spec/.
+//
+// Properties of the root window (like _NET_ACTIVE_WINDOW) are set by the
+// window manager, and properties of client windows (like _NET_WM_NAME) by the
+// clients, with a few exceptions like _NET_WM_STATE. For each property, there
+// is a function that gets it and one that sets it. Clients shouldn't change
+// most of the root window properties (or _NET_WM_STATE) themselves, but ask
+// the window manager to do so with the Request functions, which send it
+// client messages.
+//
+// Functions that get a property return ErrPropertyNotSet if the window
+// doesn't have it. Atoms are interned with xproto.InternAtomCached, so only
+// the first use of each property makes a round trip for it. Lists of atoms
+// (like _NET_WM_STATE) are given as the names of the atoms.
+//
+// The EWMH builds on the ICCCM, and this package only covers the _NET_
+// properties and messages it adds. The ICCCM properties it relies on (like
+// WM_PROTOCOLS and WM_CLASS) are read and written by the icccm package.
+package ewmh
+
+import (
+	"errors"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// ErrPropertyNotSet is returned by the functions that get a property if the
+// window doesn't have it. It is xproto.ErrPropertyNotSet.
+var ErrPropertyNotSet = xproto.ErrPropertyNotSet
+
+// ErrBadFormat is returned by the functions that get a property if its value
+// doesn't have the size the EWMH defines for it. A value of the wrong type or
+// format gives an xproto.PropertyTypeError instead.
+var ErrBadFormat = errors.New("ewmh: the property has the wrong format")
+
+// getCardinals returns the value of the property 'name' of 'win' as 32 bit
+// numbers (i.e., CARDINAL, WINDOW or ATOM values).
+func getCardinals(c *xgb.Conn, win xproto.Window,
+	name string) ([]uint32, error) {
+
+	atom, err := xproto.InternAtomCached(c, name)
+	if err != nil {
+		return nil, err
+	}
+	return xproto.GetPropertyUint32List(c, win, atom,
+		xproto.GetPropertyTypeAny)
+}
+
+// getCardinal returns the value of the property 'name' of 'win', which is
+// a single 32 bit number.
+func getCardinal(c *xgb.Conn, win xproto.Window,
+	name string) (uint32, error) {
+
+	vals, err := getCardinalsN(c, win, name, 1)
+	if err != nil {
+		return 0, err
+	}
+	return vals[0], nil
+}
+
+// getCardinalsN is like getCardinals, but fails with ErrBadFormat if the
+// property has fewer than 'n' numbers, and only returns the first 'n'.
+func getCardinalsN(c *xgb.Conn, win xproto.Window, name string,
+	n int) ([]uint32, error) {
+
+	vals, err := getCardinals(c, win, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) < n {
+		return nil, ErrBadFormat
+	}
+	return vals[:n], nil
+}
+
+// getWindows returns the value of the property 'name' of 'win', which is
+// a list of windows.
+func getWindows(c *xgb.Conn, win xproto.Window,
+	name string) ([]xproto.Window, error) {
+
+	vals, err := getCardinals(c, win, name)
+	if err != nil {
+		return nil, err
+	}
+	wins := make([]xproto.Window, len(vals))
+	for i, val := range vals {
+		wins[i] = xproto.Window(val)
+	}
+	return wins, nil
+}
+
+// getWindow returns the value of the property 'name' of 'win', which is
+// a single window.
+func getWindow(c *xgb.Conn, win xproto.Window,
+	name string) (xproto.Window, error) {
+
+	val, err := getCardinal(c, win, name)
+	return xproto.Window(val), err
+}
+
+// getAtoms returns the names of the atoms in the property 'name' of 'win'.
+func getAtoms(c *xgb.Conn, win xproto.Window, name string) ([]string, error) {
+	atom, err := xproto.InternAtomCached(c, name)
+	if err != nil {
+		return nil, err
+	}
+	atoms, err := xproto.GetPropertyAtomList(c, win, atom)
+	if err != nil {
+		return nil, err
+	}
+	return xproto.GetAtomNames(c, atoms)
+}
+
+// getString returns the value of the property 'name' of 'win' as a string.
+func getString(c *xgb.Conn, win xproto.Window, name string) (string, error) {
+	atom, err := xproto.InternAtomCached(c, name)
+	if err != nil {
+		return "", err
+	}
+	return xproto.GetPropertyString(c, win, atom)
+}
+
+// getStrings returns the value of the property 'name' of 'win' as a list of
+// strings, each terminated by a null byte.
+func getStrings(c *xgb.Conn, win xproto.Window,
+	name string) ([]string, error) {
+
+	atom, err := xproto.InternAtomCached(c, name)
+	if err != nil {
+		return nil, err
+	}
+	return xproto.GetPropertyStrings(c, win, atom)
+}
+
+// setCardinals replaces the property 'name' of 'win' with 'vals', which are
+// 32 bit values of type 'typ'.
+func setCardinals(c *xgb.Conn, win xproto.Window, name, typ string,
+	vals []uint32) error {
+
+	atoms, err := xproto.InternAtoms(c, []string{name, typ})
+	if err != nil {
+		return err
+	}
+	return xproto.ChangePropertyUint32List(c, win, atoms[name], atoms[typ],
+		vals)
+}
+
+// setWindows replaces the property 'name' of 'win' with 'wins'.
+func setWindows(c *xgb.Conn, win xproto.Window, name string,
+	wins []xproto.Window) error {
+
+	vals := make([]uint32, len(wins))
+	for i, w := range wins {
+		vals[i] = uint32(w)
+	}
+	return setCardinals(c, win, name, "WINDOW", vals)
+}
+
+// setAtoms replaces the property 'name' of 'win' with the atoms named
+// 'names'.
+func setAtoms(c *xgb.Conn, win xproto.Window, name string,
+	names []string) error {
+
+	atoms, err := xproto.InternAtoms(c, names)
+	if err != nil {
+		return err
+	}
+	vals := make([]uint32, len(names))
+	for i, n := range names {
+		vals[i] = uint32(atoms[n])
+	}
+	return setCardinals(c, win, name, "ATOM", vals)
+}
+
+// setString replaces the property 'name' of 'win' with the UTF-8 string 's'.
+func setString(c *xgb.Conn, win xproto.Window, name, s string) error {
+	atoms, err := xproto.InternAtoms(c, []string{name, "UTF8_STRING"})
+	if err != nil {
+		return err
+	}
+	return xproto.ChangePropertyString(c, win, atoms[name],
+		atoms["UTF8_STRING"], s)
+}
+
+// setStrings replaces the property 'name' of 'win' with the UTF-8 strings
+// 'strs', each terminated by a null byte.
+func setStrings(c *xgb.Conn, win xproto.Window, name string,
+	strs []string) error {
+
+	atoms, err := xproto.InternAtoms(c, []string{name, "UTF8_STRING"})
+	if err != nil {
+		return err
+	}
+	return xproto.ChangePropertyStrings(c, win, atoms[name],
+		atoms["UTF8_STRING"], strs)
+}
@@ -0,0 +1,151 @@
+package ewmh
+
+/*
+	Tests for decoding EWMH property values, and for setting and getting
+	properties on a window.
+
+	The latter need a running X server, and are skipped if DISPLAY isn't
+	set.
+*/
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestRects converts rectangles to property values and back.
+func TestRects(t *testing.T) {
+	rs := []Rect{{0, 0, 1920, 1080}, {1920, 0, 1280, 1024}}
+	vals := rectValues(rs)
+	if len(vals) != 8 {
+		t.Fatalf("Expected 8 values, but got %d", len(vals))
+	}
+	if got := rects(vals); !reflect.DeepEqual(got, rs) {
+		t.Fatalf("Expected %v, but got %v", rs, got)
+	}
+
+	// A partial rectangle at the end is ignored.
+	if got := rects(vals[:7]); !reflect.DeepEqual(got, rs[:1]) {
+		t.Fatalf("Expected %v, but got %v", rs[:1], got)
+	}
+}
+
+// TestIcons decodes a _NET_WM_ICON value with two icons, and some that are
+// cut short.
+func TestIcons(t *testing.T) {
+	vals := []uint32{
+		2, 1, 0xff000000, 0xffffffff,
+		1, 1, 0x80ff0000,
+	}
+	want := []Icon{
+		{2, 1, []uint32{0xff000000, 0xffffffff}},
+		{1, 1, []uint32{0x80ff0000}},
+	}
+	got, err := icons(vals)
+	if err != nil {
+		t.Fatalf("icons: %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected %v, but got %v", want, got)
+	}
+
+	for _, bad := range [][]uint32{vals[:1], vals[:3], vals[:5]} {
+		if _, err := icons(bad); err != ErrBadFormat {
+			t.Errorf("icons(%v): expected ErrBadFormat, but got %v",
+				bad, err)
+		}
+	}
+}
+
+// TestWmName sets _NET_WM_NAME of a window and reads it back.
+func TestWmName(t *testing.T) {
+	X, win := newWindow(t)
+
+	if _, err := WmName(X, win); err != ErrPropertyNotSet {
+		t.Fatalf("Expected ErrPropertyNotSet, but got %v", err)
+	}
+	const name = "ewmh — test"
+	if err := SetWmName(X, win, name); err != nil {
+		t.Fatalf("SetWmName: %s", err)
+	}
+	got, err := WmName(X, win)
+	if err != nil {
+		t.Fatalf("WmName: %s", err)
+	}
+	if got != name {
+		t.Fatalf("Expected name %q, but got %q", name, got)
+	}
+}
+
+// TestWmState sets _NET_WM_STATE of a window and reads it back as names.
+func TestWmState(t *testing.T) {
+	X, win := newWindow(t)
+
+	states := []string{"_NET_WM_STATE_ABOVE", "_NET_WM_STATE_STICKY"}
+	if err := SetWmState(X, win, states); err != nil {
+		t.Fatalf("SetWmState: %s", err)
+	}
+	got, err := WmState(X, win)
+	if err != nil {
+		t.Fatalf("WmState: %s", err)
+	}
+	if !reflect.DeepEqual(got, states) {
+		t.Fatalf("Expected states %q, but got %q", states, got)
+	}
+}
+
+// TestWmStrutPartial sets _NET_WM_STRUT_PARTIAL of a window and reads it
+// back.
+func TestWmStrutPartial(t *testing.T) {
+	X, win := newWindow(t)
+
+	strut := StrutPartial{Top: 24, TopStartX: 0, TopEndX: 1919}
+	if err := SetWmStrutPartial(X, win, strut); err != nil {
+		t.Fatalf("SetWmStrutPartial: %s", err)
+	}
+	got, err := WmStrutPartial(X, win)
+	if err != nil {
+		t.Fatalf("WmStrutPartial: %s", err)
+	}
+	if got != strut {
+		t.Fatalf("Expected %+v, but got %+v", strut, got)
+	}
+
+	// It isn't a _NET_WM_STRUT.
+	if _, err := WmStrut(X, win); err != ErrPropertyNotSet {
+		t.Fatalf("Expected ErrPropertyNotSet, but got %v", err)
+	}
+}
+
+// newWindow connects to the X server in DISPLAY and creates an unmapped
+// window, or skips the current test if DISPLAY isn't set. The connection is
+// closed when the test finishes.
+func newWindow(t *testing.T) (*xgb.Conn, xproto.Window) {
+	t.Helper()
+	if len(os.Getenv("DISPLAY")) == 0 {
+		t.Skip("DISPLAY is not set")
+	}
+
+	X, err := xgb.NewConn()
+	if err != nil {
+		t.Fatalf("NewConn: %s", err)
+	}
+	t.Cleanup(func() { X.Close() })
+
+	screen := xproto.Setup(X).DefaultScreen(X)
+	win, err := xproto.NewWindowId(X)
+	if err != nil {
+		t.Fatalf("NewWindowId: %s", err)
+	}
+	err = xproto.CreateWindowChecked(X, screen.RootDepth, win, screen.Root,
+		0, 0, 1, 1, 0, xproto.WindowClassInputOutput, screen.RootVisual,
+		0, nil).Check()
+	if err != nil {
+		t.Fatalf("CreateWindow: %s", err)
+	}
+	return X, win
+}
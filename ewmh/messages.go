@@ -0,0 +1,114 @@
+package ewmh
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// The client messages clients send to the root window to ask the window
+// manager to change things.
+
+// Sources of requests, as in the source indication of the client messages.
+const (
+	SourceNone        = 0
+	SourceApplication = 1
+	SourcePager       = 2
+)
+
+// Actions of RequestWmState.
+const (
+	StateRemove = 0
+	StateAdd    = 1
+	StateToggle = 2
+)
+
+// sendMessage sends a client message of type 'name' about 'win', with the
+// data in 'data', to the root window 'root', the way the EWMH says all of its
+// messages are sent.
+func sendMessage(c *xgb.Conn, root, win xproto.Window, name string,
+	data ...uint32) error {
+
+	atom, err := xproto.InternAtomCached(c, name)
+	if err != nil {
+		return err
+	}
+	vals := make([]uint32, 5)
+	copy(vals, data)
+	ev := xproto.ClientMessageEvent{
+		Format: 32,
+		Window: win,
+		Type:   atom,
+		Data:   xproto.ClientMessageDataUnionData32New(vals),
+	}
+	return xproto.SendEventChecked(c, false, root,
+		xproto.EventMaskSubstructureNotify|
+			xproto.EventMaskSubstructureRedirect,
+		string(ev.Bytes())).Check()
+}
+
+// RequestCurrentDesktop asks the window manager to switch to 'desktop', with
+// a _NET_CURRENT_DESKTOP message. 'time' is the time of the user action that
+// caused it.
+func RequestCurrentDesktop(c *xgb.Conn, root xproto.Window,
+	desktop, time uint32) error {
+
+	return sendMessage(c, root, root, "_NET_CURRENT_DESKTOP", desktop, time)
+}
+
+// RequestActiveWindow asks the window manager to give the focus to 'win',
+// with a _NET_ACTIVE_WINDOW message. 'source' is one of the Source
+// constants, 'time' the time of the user action that caused it, and 'active'
+// the window of the client that has the focus now, if any.
+func RequestActiveWindow(c *xgb.Conn, root, win xproto.Window,
+	source, time uint32, active xproto.Window) error {
+
+	return sendMessage(c, root, win, "_NET_ACTIVE_WINDOW", source, time,
+		uint32(active))
+}
+
+// RequestShowingDesktop asks the window manager to enter or leave the mode
+// where it hides all windows to show the desktop, with a
+// _NET_SHOWING_DESKTOP message.
+func RequestShowingDesktop(c *xgb.Conn, root xproto.Window,
+	showing bool) error {
+
+	return sendMessage(c, root, root, "_NET_SHOWING_DESKTOP",
+		boolValue(showing))
+}
+
+// RequestCloseWindow asks the window manager to close 'win', with
+// a _NET_CLOSE_WINDOW message.
+func RequestCloseWindow(c *xgb.Conn, root, win xproto.Window,
+	source, time uint32) error {
+
+	return sendMessage(c, root, win, "_NET_CLOSE_WINDOW", time, source)
+}
+
+// RequestWmDesktop asks the window manager to move 'win' to 'desktop', which
+// may be AllDesktops, with a _NET_WM_DESKTOP message.
+func RequestWmDesktop(c *xgb.Conn, root, win xproto.Window,
+	desktop, source uint32) error {
+
+	return sendMessage(c, root, win, "_NET_WM_DESKTOP", desktop, source)
+}
+
+// RequestWmState asks the window manager to add, remove or toggle (as in
+// 'action', one of the State constants) one or two states of 'win', with
+// a _NET_WM_STATE message. 'second' may be empty. Changing two states at once
+// is meant for pairs like _NET_WM_STATE_MAXIMIZED_VERT and
+// _NET_WM_STATE_MAXIMIZED_HORZ.
+func RequestWmState(c *xgb.Conn, root, win xproto.Window, action uint32,
+	first, second string, source uint32) error {
+
+	names := []string{first}
+	if second != "" {
+		names = append(names, second)
+	}
+	atoms, err := xproto.InternAtoms(c, names)
+	if err != nil {
+		return err
+	}
+	return sendMessage(c, root, win, "_NET_WM_STATE", action,
+		uint32(atoms[first]), uint32(atoms[second]), source)
+}
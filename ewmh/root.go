@@ -0,0 +1,290 @@
+package ewmh
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// The properties of the root window.
+
+// Geometry is the size of a desktop, as in _NET_DESKTOP_GEOMETRY.
+type Geometry struct {
+	Width, Height uint32
+}
+
+// Point is the position of the top left corner of a viewport, as in
+// _NET_DESKTOP_VIEWPORT.
+type Point struct {
+	X, Y uint32
+}
+
+// Rect is a rectangle, as in _NET_WORKAREA.
+type Rect struct {
+	X, Y          uint32
+	Width, Height uint32
+}
+
+// Orientations and starting corners of a Layout.
+const (
+	OrientationHorizontal = 0
+	OrientationVertical   = 1
+
+	CornerTopLeft     = 0
+	CornerTopRight    = 1
+	CornerBottomRight = 2
+	CornerBottomLeft  = 3
+)
+
+// Layout is the layout of the desktops in a pager, as in _NET_DESKTOP_LAYOUT.
+// Either Columns or Rows may be 0, in which case it follows from the number
+// of desktops.
+type Layout struct {
+	Orientation    uint32
+	Columns, Rows  uint32
+	StartingCorner uint32
+}
+
+// Supported returns the names of the hints the window manager supports, from
+// _NET_SUPPORTED.
+func Supported(c *xgb.Conn, root xproto.Window) ([]string, error) {
+	return getAtoms(c, root, "_NET_SUPPORTED")
+}
+
+// SetSupported sets _NET_SUPPORTED.
+func SetSupported(c *xgb.Conn, root xproto.Window, names []string) error {
+	return setAtoms(c, root, "_NET_SUPPORTED", names)
+}
+
+// ClientList returns the windows managed by the window manager, from
+// _NET_CLIENT_LIST, in the order they were first mapped.
+func ClientList(c *xgb.Conn, root xproto.Window) ([]xproto.Window, error) {
+	return getWindows(c, root, "_NET_CLIENT_LIST")
+}
+
+// SetClientList sets _NET_CLIENT_LIST.
+func SetClientList(c *xgb.Conn, root xproto.Window,
+	wins []xproto.Window) error {
+
+	return setWindows(c, root, "_NET_CLIENT_LIST", wins)
+}
+
+// ClientListStacking returns the windows managed by the window manager, from
+// _NET_CLIENT_LIST_STACKING, from bottom to top.
+func ClientListStacking(c *xgb.Conn,
+	root xproto.Window) ([]xproto.Window, error) {
+
+	return getWindows(c, root, "_NET_CLIENT_LIST_STACKING")
+}
+
+// SetClientListStacking sets _NET_CLIENT_LIST_STACKING.
+func SetClientListStacking(c *xgb.Conn, root xproto.Window,
+	wins []xproto.Window) error {
+
+	return setWindows(c, root, "_NET_CLIENT_LIST_STACKING", wins)
+}
+
+// NumberOfDesktops returns the number of desktops, from
+// _NET_NUMBER_OF_DESKTOPS.
+func NumberOfDesktops(c *xgb.Conn, root xproto.Window) (uint32, error) {
+	return getCardinal(c, root, "_NET_NUMBER_OF_DESKTOPS")
+}
+
+// SetNumberOfDesktops sets _NET_NUMBER_OF_DESKTOPS.
+func SetNumberOfDesktops(c *xgb.Conn, root xproto.Window, n uint32) error {
+	return setCardinals(c, root, "_NET_NUMBER_OF_DESKTOPS", "CARDINAL",
+		[]uint32{n})
+}
+
+// DesktopGeometry returns the size shared by all desktops, from
+// _NET_DESKTOP_GEOMETRY.
+func DesktopGeometry(c *xgb.Conn, root xproto.Window) (Geometry, error) {
+	vals, err := getCardinalsN(c, root, "_NET_DESKTOP_GEOMETRY", 2)
+	if err != nil {
+		return Geometry{}, err
+	}
+	return Geometry{vals[0], vals[1]}, nil
+}
+
+// SetDesktopGeometry sets _NET_DESKTOP_GEOMETRY.
+func SetDesktopGeometry(c *xgb.Conn, root xproto.Window,
+	geom Geometry) error {
+
+	return setCardinals(c, root, "_NET_DESKTOP_GEOMETRY", "CARDINAL",
+		[]uint32{geom.Width, geom.Height})
+}
+
+// DesktopViewport returns the position of the viewport of each desktop, from
+// _NET_DESKTOP_VIEWPORT.
+func DesktopViewport(c *xgb.Conn, root xproto.Window) ([]Point, error) {
+	vals, err := getCardinals(c, root, "_NET_DESKTOP_VIEWPORT")
+	if err != nil {
+		return nil, err
+	}
+	points := make([]Point, len(vals)/2)
+	for i := range points {
+		points[i] = Point{vals[i*2], vals[i*2+1]}
+	}
+	return points, nil
+}
+
+// SetDesktopViewport sets _NET_DESKTOP_VIEWPORT.
+func SetDesktopViewport(c *xgb.Conn, root xproto.Window,
+	points []Point) error {
+
+	vals := make([]uint32, 0, len(points)*2)
+	for _, p := range points {
+		vals = append(vals, p.X, p.Y)
+	}
+	return setCardinals(c, root, "_NET_DESKTOP_VIEWPORT", "CARDINAL", vals)
+}
+
+// CurrentDesktop returns the index of the current desktop, from
+// _NET_CURRENT_DESKTOP.
+func CurrentDesktop(c *xgb.Conn, root xproto.Window) (uint32, error) {
+	return getCardinal(c, root, "_NET_CURRENT_DESKTOP")
+}
+
+// SetCurrentDesktop sets _NET_CURRENT_DESKTOP. Clients should use
+// RequestCurrentDesktop instead.
+func SetCurrentDesktop(c *xgb.Conn, root xproto.Window, desktop uint32) error {
+	return setCardinals(c, root, "_NET_CURRENT_DESKTOP", "CARDINAL",
+		[]uint32{desktop})
+}
+
+// DesktopNames returns the names of the desktops, from _NET_DESKTOP_NAMES.
+// There may be fewer names than desktops.
+func DesktopNames(c *xgb.Conn, root xproto.Window) ([]string, error) {
+	return getStrings(c, root, "_NET_DESKTOP_NAMES")
+}
+
+// SetDesktopNames sets _NET_DESKTOP_NAMES.
+func SetDesktopNames(c *xgb.Conn, root xproto.Window, names []string) error {
+	return setStrings(c, root, "_NET_DESKTOP_NAMES", names)
+}
+
+// ActiveWindow returns the window that has the focus, from
+// _NET_ACTIVE_WINDOW. It is 0 (xproto.WindowNone) if no window has it.
+func ActiveWindow(c *xgb.Conn, root xproto.Window) (xproto.Window, error) {
+	return getWindow(c, root, "_NET_ACTIVE_WINDOW")
+}
+
+// SetActiveWindow sets _NET_ACTIVE_WINDOW. Clients should use
+// RequestActiveWindow instead.
+func SetActiveWindow(c *xgb.Conn, root, win xproto.Window) error {
+	return setWindows(c, root, "_NET_ACTIVE_WINDOW", []xproto.Window{win})
+}
+
+// Workarea returns the area of each desktop that isn't covered by docks and
+// panels, from _NET_WORKAREA.
+func Workarea(c *xgb.Conn, root xproto.Window) ([]Rect, error) {
+	vals, err := getCardinals(c, root, "_NET_WORKAREA")
+	if err != nil {
+		return nil, err
+	}
+	return rects(vals), nil
+}
+
+// SetWorkarea sets _NET_WORKAREA.
+func SetWorkarea(c *xgb.Conn, root xproto.Window, areas []Rect) error {
+	return setCardinals(c, root, "_NET_WORKAREA", "CARDINAL",
+		rectValues(areas))
+}
+
+// SupportingWMCheck returns the window the window manager created to show
+// that it is running, from _NET_SUPPORTING_WM_CHECK. The same property on
+// that window must point to itself, and its _NET_WM_NAME is the name of the
+// window manager.
+func SupportingWMCheck(c *xgb.Conn,
+	win xproto.Window) (xproto.Window, error) {
+
+	return getWindow(c, win, "_NET_SUPPORTING_WM_CHECK")
+}
+
+// SetSupportingWMCheck sets _NET_SUPPORTING_WM_CHECK on 'win', which is
+// either the root window or 'check' itself.
+func SetSupportingWMCheck(c *xgb.Conn, win, check xproto.Window) error {
+	return setWindows(c, win, "_NET_SUPPORTING_WM_CHECK",
+		[]xproto.Window{check})
+}
+
+// VirtualRoots returns the virtual root windows of a window manager that
+// uses them, from _NET_VIRTUAL_ROOTS.
+func VirtualRoots(c *xgb.Conn, root xproto.Window) ([]xproto.Window, error) {
+	return getWindows(c, root, "_NET_VIRTUAL_ROOTS")
+}
+
+// SetVirtualRoots sets _NET_VIRTUAL_ROOTS.
+func SetVirtualRoots(c *xgb.Conn, root xproto.Window,
+	wins []xproto.Window) error {
+
+	return setWindows(c, root, "_NET_VIRTUAL_ROOTS", wins)
+}
+
+// DesktopLayout returns the layout of the desktops, from
+// _NET_DESKTOP_LAYOUT. If the starting corner isn't given, it is
+// CornerTopLeft.
+func DesktopLayout(c *xgb.Conn, root xproto.Window) (Layout, error) {
+	vals, err := getCardinals(c, root, "_NET_DESKTOP_LAYOUT")
+	if err != nil {
+		return Layout{}, err
+	}
+	if len(vals) < 3 {
+		return Layout{}, ErrBadFormat
+	}
+	layout := Layout{Orientation: vals[0], Columns: vals[1],
+		Rows: vals[2], StartingCorner: CornerTopLeft}
+	if len(vals) > 3 {
+		layout.StartingCorner = vals[3]
+	}
+	return layout, nil
+}
+
+// SetDesktopLayout sets _NET_DESKTOP_LAYOUT. It is set by pagers, not by the
+// window manager.
+func SetDesktopLayout(c *xgb.Conn, root xproto.Window, layout Layout) error {
+	return setCardinals(c, root, "_NET_DESKTOP_LAYOUT", "CARDINAL",
+		[]uint32{layout.Orientation, layout.Columns, layout.Rows,
+			layout.StartingCorner})
+}
+
+// ShowingDesktop returns whether the window manager is in the mode where it
+// hides all windows to show the desktop, from _NET_SHOWING_DESKTOP.
+func ShowingDesktop(c *xgb.Conn, root xproto.Window) (bool, error) {
+	val, err := getCardinal(c, root, "_NET_SHOWING_DESKTOP")
+	return val == 1, err
+}
+
+// SetShowingDesktop sets _NET_SHOWING_DESKTOP. Clients should use
+// RequestShowingDesktop instead.
+func SetShowingDesktop(c *xgb.Conn, root xproto.Window, showing bool) error {
+	return setCardinals(c, root, "_NET_SHOWING_DESKTOP", "CARDINAL",
+		[]uint32{boolValue(showing)})
+}
+
+// rects returns the rectangles in 'vals', four numbers each.
+func rects(vals []uint32) []Rect {
+	rects := make([]Rect, len(vals)/4)
+	for i := range rects {
+		v := vals[i*4:]
+		rects[i] = Rect{v[0], v[1], v[2], v[3]}
+	}
+	return rects
+}
+
+// rectValues is the reverse of rects.
+func rectValues(rects []Rect) []uint32 {
+	vals := make([]uint32, 0, len(rects)*4)
+	for _, r := range rects {
+		vals = append(vals, r.X, r.Y, r.Width, r.Height)
+	}
+	return vals
+}
+
+// boolValue returns 1 if 'b' is true, and 0 otherwise.
+func boolValue(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
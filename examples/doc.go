@@ -15,7 +15,13 @@ library, xgbutil: https://github.com/BurntSushi/xgbutil
 
 There are also examples of using the Xinerama and RandR extensions, if you're
 interested in querying information about your active heads. In RandR's case,
-you can also reconfigure your heads, but the example doesn't cover that.
+you can also reconfigure your heads, which the xrandr example does.
+
+Finally, the xtest example uses the XTEST extension to fake input, which is
+handy for automated testing of user interfaces. And the sync-counter example
+uses an alarm of the SYNC extension to wait for a counter of the X server.
+The dpms example prints the power saving state of the monitor, like 'xset q'.
+The shape example uses the SHAPE extension to make a round window.
 
 */
 package documentation
@@ -0,0 +1,73 @@
+// Example dpms shows how to use the DPMS extension to query the power saving
+// state of the monitor, like the DPMS part of 'xset q'.
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/dpms"
+)
+
+// levelNames are the names of the DPMS power levels, as printed by xset.
+var levelNames = map[uint16]string{
+	dpms.DPMSModeOn:      "On",
+	dpms.DPMSModeStandby: "Standby",
+	dpms.DPMSModeSuspend: "Suspend",
+	dpms.DPMSModeOff:     "Off",
+}
+
+func main() {
+	X, err := xgb.NewConn()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer X.Close()
+
+	// Initialize the DPMS extension.
+	// The appropriate 'Init' function must be run for *every*
+	// extension before any of its requests can be used.
+	if err := dpms.Init(X); err != nil {
+		log.Fatal(err)
+	}
+
+	version, err := dpms.GetVersion(X, 1, 1).Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+	capable, err := dpms.Capable(X).Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("DPMS version %d.%d\n", version.ServerMajorVersion,
+		version.ServerMinorVersion)
+	if !capable.Capable {
+		fmt.Println("Server does not have the DPMS Extension")
+		return
+	}
+
+	// The requests are independent, so send them both before waiting for
+	// either reply.
+	timeoutsCookie := dpms.GetTimeouts(X)
+	infoCookie := dpms.Info(X)
+	timeouts, err := timeoutsCookie.Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+	info, err := infoCookie.Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("DPMS (Energy Star):")
+	fmt.Printf("  Standby: %d    Suspend: %d    Off: %d\n",
+		timeouts.StandbyTimeout, timeouts.SuspendTimeout,
+		timeouts.OffTimeout)
+	if !info.State {
+		fmt.Println("  DPMS is Disabled")
+		return
+	}
+	fmt.Println("  DPMS is Enabled")
+	fmt.Printf("  Monitor is %s\n", levelNames[info.PowerLevel])
+}
@@ -0,0 +1,120 @@
+// Example shape shows how to use the SHAPE extension to make a window that
+// isn't a rectangle: a red circle, which goes away when a key is pressed in
+// it (or a mouse button is clicked).
+package main
+
+import (
+	"flag"
+	"log"
+	"math"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/shape"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+var flagRadius int
+
+func init() {
+	flag.IntVar(&flagRadius, "radius", 100, "Radius of the circle.")
+	flag.Parse()
+}
+
+// circle returns the rows of a circle of radius 'r' in a square of side
+// 2*r, as one rectangle per row. They are sorted by Y, and then by X, and
+// each row is a band of its own, as YXBanded ordering wants.
+func circle(r int) []xproto.Rectangle {
+	rects := make([]xproto.Rectangle, 0, 2*r)
+	for y := 0; y < 2*r; y++ {
+		// The distance from the center to the middle of the row.
+		dy := float64(y) + 0.5 - float64(r)
+		half := math.Sqrt(float64(r*r) - dy*dy)
+		x := int(math.Round(float64(r) - half))
+		width := 2*r - 2*x
+		if width <= 0 {
+			continue
+		}
+		rects = append(rects, xproto.Rectangle{
+			X:      int16(x),
+			Y:      int16(y),
+			Width:  uint16(width),
+			Height: 1,
+		})
+	}
+	return rects
+}
+
+func main() {
+	X, err := xgb.NewConn()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer X.Close()
+
+	// Like every extension, SHAPE must be initialized before any of its
+	// requests can be used.
+	if err := shape.Init(X); err != nil {
+		log.Fatal(err)
+	}
+	version, err := shape.QueryVersion(X).Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("SHAPE version %d.%d", version.MajorVersion,
+		version.MinorVersion)
+
+	screen := xproto.Setup(X).DefaultScreen(X)
+	wid, err := xproto.NewWindowId(X)
+	if err != nil {
+		log.Fatal(err)
+	}
+	size := uint16(2 * flagRadius)
+	xproto.CreateWindow(X, screen.RootDepth, wid, screen.Root,
+		0, 0, size, size, 0,
+		xproto.WindowClassInputOutput, screen.RootVisual,
+		xproto.CwBackPixel|xproto.CwEventMask,
+		[]uint32{
+			0xff0000, // red, on the usual TrueColor visuals
+			xproto.EventMaskKeyPress | xproto.EventMaskButtonPress |
+				xproto.EventMaskStructureNotify,
+		})
+
+	// Set the bounding shape of the window (i.e., the part of it that is
+	// drawn, and that gets input) to the circle. The clip shape, which is
+	// the part that can be drawn to, follows it.
+	rects := circle(flagRadius)
+	err = shape.RectanglesChecked(X, shape.SoSet, shape.SkBounding,
+		xproto.ClipOrderingYXBanded, wid, 0, 0, rects).Check()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	extents, err := shape.QueryExtents(X, wid).Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Bounding shape extents: %dx%d at (%d, %d)",
+		extents.BoundingShapeExtentsWidth,
+		extents.BoundingShapeExtentsHeight,
+		extents.BoundingShapeExtentsX, extents.BoundingShapeExtentsY)
+
+	if err := xproto.MapWindowChecked(X, wid).Check(); err != nil {
+		log.Fatal(err)
+	}
+
+	for {
+		ev, xerr := X.WaitForEvent()
+		if ev == nil && xerr == nil {
+			return
+		}
+		if xerr != nil {
+			log.Printf("Error: %s", xerr)
+		}
+		switch ev.(type) {
+		case xproto.KeyPressEvent, xproto.ButtonPressEvent,
+			xproto.DestroyNotifyEvent:
+
+			return
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Example sync-counter shows how to use the SYNC extension to wait for
+// a system counter of the X server to reach a threshold, by setting an alarm
+// on it. By default, it waits for one second of the SERVERTIME counter,
+// which counts milliseconds.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/sync"
+)
+
+var (
+	flagCounter string
+	flagDelta   int64
+)
+
+func init() {
+	flag.StringVar(&flagCounter, "counter", "SERVERTIME",
+		"Name of the system counter to wait for.")
+	flag.Int64Var(&flagDelta, "delta", 1000,
+		"How much the counter must grow.")
+	flag.Parse()
+}
+
+// toInt64 and fromInt64 convert between SYNC's 64 bit integers and Go's.
+func toInt64(v sync.Int64) int64 {
+	return int64(v.Hi)<<32 | int64(v.Lo)
+}
+
+func fromInt64(v int64) sync.Int64 {
+	return sync.Int64{Hi: int32(v >> 32), Lo: uint32(v)}
+}
+
+func main() {
+	X, err := xgb.NewConn()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer X.Close()
+
+	// Initialize the SYNC extension. Besides the usual 'Init', the
+	// protocol requires an Initialize request before any other.
+	if err := sync.Init(X); err != nil {
+		log.Fatal(err)
+	}
+	_, err = sync.Initialize(X, 3, 1).Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Find the counter by name.
+	counters, err := sync.ListSystemCounters(X).Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+	var counter sync.Counter
+	for _, c := range counters.Counters {
+		if c.Name == flagCounter {
+			counter = c.Counter
+		}
+	}
+	if counter == 0 {
+		log.Fatalf("There is no system counter named %s.", flagCounter)
+	}
+
+	current, err := sync.QueryCounter(X, counter).Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+	start := toInt64(current.CounterValue)
+	threshold := fromInt64(start + flagDelta)
+	log.Printf("%s is at %d, waiting for it to reach %d.",
+		flagCounter, start, start+flagDelta)
+
+	// Set an alarm that triggers once the counter is at or above the
+	// threshold. The values must be in the order of their bits in the
+	// value mask, and 64 bit values take two of them (high bits first).
+	alarm, err := sync.NewAlarmId(X)
+	if err != nil {
+		log.Fatal(err)
+	}
+	mask := uint32(sync.CaCounter | sync.CaValueType | sync.CaValue |
+		sync.CaTestType | sync.CaEvents)
+	values := []uint32{
+		uint32(counter),
+		sync.ValuetypeAbsolute,
+		uint32(threshold.Hi), threshold.Lo,
+		sync.TesttypePositiveComparison,
+		1, // send AlarmNotify events
+	}
+	err = sync.CreateAlarmChecked(X, alarm, mask, values).Check()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer sync.DestroyAlarm(X, alarm)
+
+	for {
+		ev, err := X.WaitForEvent()
+		if ev == nil && err == nil {
+			log.Fatal("The connection to the X server was closed.")
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		notify, ok := ev.(sync.AlarmNotifyEvent)
+		if !ok || notify.Alarm != alarm {
+			continue
+		}
+		log.Printf("The alarm triggered with %s at %d.",
+			flagCounter, toInt64(notify.CounterValue))
+		return
+	}
+}
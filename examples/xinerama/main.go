@@ -1,4 +1,9 @@
-// Example xinerama shows how to query the geometry of all active heads.
+// Example xinerama shows how to query the geometry of all active heads. It
+// prints them like 'xdpyinfo -ext XINERAMA' does:
+//
+//	XINERAMA version 1.1 opcode: 141
+//	  head #0: 1920x1080 @ 0,0
+//	  head #1: 1280x1024 @ 1920,0
 package main
 
 import (
@@ -14,6 +19,7 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer X.Close()
 
 	// Initialize the Xinerama extension.
 	// The appropriate 'Init' function must be run for *every*
@@ -23,18 +29,35 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// Tell the server which version we support, and get the one it has.
+	version, err := xinerama.QueryVersion(X, 1, 1).Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("XINERAMA version %d.%d opcode: %d\n",
+		version.Major, version.Minor, X.Extensions["XINERAMA"])
+
+	// Xinerama can be there without being used (e.g., with a single
+	// head); it then has no heads to report.
+	active, err := xinerama.IsActive(X).Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if active.State == 0 {
+		fmt.Println("  Xinerama is inactive.")
+		return
+	}
+
 	// Issue a request to get the screen information.
 	reply, err := xinerama.QueryScreens(X).Reply()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// reply.Number is the number of active heads, while reply.ScreenInfo
-	// is a slice of XineramaScreenInfo containing the rectangle geometry
-	// of each head.
-	fmt.Printf("Number of heads: %d\n", reply.Number)
+	// reply.ScreenInfo is a slice of XineramaScreenInfo containing the
+	// rectangle geometry of each head.
 	for i, screen := range reply.ScreenInfo {
-		fmt.Printf("%d :: X: %d, Y: %d, Width: %d, Height: %d\n",
-			i, screen.XOrg, screen.YOrg, screen.Width, screen.Height)
+		fmt.Printf("  head #%d: %dx%d @ %d,%d\n", i,
+			screen.Width, screen.Height, screen.XOrg, screen.YOrg)
 	}
 }
@@ -0,0 +1,281 @@
+// Example xrandr is a small version of the 'xrandr' command. Without flags,
+// it lists the outputs with their modes, marking the current mode of each
+// with '*' and its preferred modes with '+'. With -output, it changes the mode
+// or rotation of that output, or turns it off:
+//
+//	xrandr -output HDMI-1 -mode 1280x1024 -rotate left
+//	xrandr -output HDMI-1 -off
+//
+// Unlike the real thing, it doesn't change the size of the screen, so modes
+// that don't fit in it fail.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/randr"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+var (
+	flagOutput string
+	flagMode   string
+	flagRotate string
+	flagOff    bool
+)
+
+func init() {
+	flag.StringVar(&flagOutput, "output", "",
+		"Name of the output to change.")
+	flag.StringVar(&flagMode, "mode", "", "Mode to set, as in 1920x1080.")
+	flag.StringVar(&flagRotate, "rotate", "",
+		"Rotation to set: normal, left, inverted or right.")
+	flag.BoolVar(&flagOff, "off", false, "Turn the output off.")
+	flag.Parse()
+}
+
+// rotations are the names of the rotations, as used by 'xrandr'.
+var rotations = map[string]uint16{
+	"normal":   randr.RotationRotate0,
+	"left":     randr.RotationRotate90,
+	"inverted": randr.RotationRotate180,
+	"right":    randr.RotationRotate270,
+}
+
+// connections are the names of the states of the outputs.
+var connections = map[byte]string{
+	randr.ConnectionConnected:    "connected",
+	randr.ConnectionDisconnected: "disconnected",
+	randr.ConnectionUnknown:      "unknown connection",
+}
+
+// screen is what the example knows about the screen.
+type screen struct {
+	X         *xgb.Conn
+	resources *randr.GetScreenResourcesReply
+
+	// modes are the modes of the screen, by id, and modeNames their names.
+	modes     map[randr.Mode]randr.ModeInfo
+	modeNames map[randr.Mode]string
+}
+
+func main() {
+	X, err := xgb.NewConn()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer X.Close()
+
+	// Every extension must be initialized before it can be used.
+	if err := randr.Init(X); err != nil {
+		log.Fatal(err)
+	}
+
+	// GetScreenResources and its friends need RandR 1.2, and the server
+	// must be told which version the client speaks.
+	version, err := randr.QueryVersion(X, 1, 3).Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if version.MajorVersion < 1 ||
+		version.MajorVersion == 1 && version.MinorVersion < 2 {
+
+		log.Fatalf("RandR %d.%d is too old.", version.MajorVersion,
+			version.MinorVersion)
+	}
+
+	root := xproto.Setup(X).DefaultScreen(X).Root
+	resources, err := randr.GetScreenResources(X, root).Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := &screen{
+		X:         X,
+		resources: resources,
+		modes:     make(map[randr.Mode]randr.ModeInfo),
+		modeNames: make(map[randr.Mode]string),
+	}
+
+	// The names of the modes are all in one string, in order.
+	names := resources.Names
+	for _, mode := range resources.Modes {
+		s.modes[randr.Mode(mode.Id)] = mode
+		s.modeNames[randr.Mode(mode.Id)] = string(names[:mode.NameLen])
+		names = names[mode.NameLen:]
+	}
+
+	if flagOutput == "" {
+		s.list()
+		return
+	}
+	if err := s.change(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// list prints the outputs and their modes.
+func (s *screen) list() {
+	for _, output := range s.resources.Outputs {
+		info, err := randr.GetOutputInfo(s.X, output,
+			s.resources.ConfigTimestamp).Reply()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var current randr.Mode
+		fmt.Printf("%s %s", info.Name, connections[info.Connection])
+		if info.Crtc != 0 {
+			crtc, err := randr.GetCrtcInfo(s.X, info.Crtc,
+				s.resources.ConfigTimestamp).Reply()
+			if err != nil {
+				log.Fatal(err)
+			}
+			current = crtc.Mode
+			fmt.Printf(" %dx%d+%d+%d %s", crtc.Width, crtc.Height,
+				crtc.X, crtc.Y, rotationName(crtc.Rotation))
+		}
+		fmt.Printf(" %dmm x %dmm\n", info.MmWidth, info.MmHeight)
+
+		for i, mode := range info.Modes {
+			mark := " "
+			if mode == current {
+				mark = "*"
+			}
+			if i < int(info.NumPreferred) {
+				mark += "+"
+			}
+			fmt.Printf("   %-12s %6.2f%s\n", s.modeNames[mode],
+				refreshRate(s.modes[mode]), mark)
+		}
+	}
+}
+
+// change changes the output named by -output as the other flags say.
+func (s *screen) change() error {
+	var info *randr.GetOutputInfoReply
+	var output randr.Output
+	for _, o := range s.resources.Outputs {
+		i, err := randr.GetOutputInfo(s.X, o,
+			s.resources.ConfigTimestamp).Reply()
+		if err != nil {
+			return err
+		}
+		if string(i.Name) == flagOutput {
+			info, output = i, o
+			break
+		}
+	}
+	if info == nil {
+		return fmt.Errorf("there is no output named %s", flagOutput)
+	}
+
+	// Turning an output off is disabling its CRTC: setting no mode and no
+	// outputs.
+	if flagOff {
+		if info.Crtc == 0 {
+			return nil
+		}
+		return s.setCrtc(info.Crtc, 0, 0, 0, randr.RotationRotate0, nil)
+	}
+
+	// An output that is off needs a CRTC that isn't in use.
+	crtc := info.Crtc
+	var x, y int16
+	var mode randr.Mode
+	var rotation uint16 = randr.RotationRotate0
+	if crtc != 0 {
+		current, err := randr.GetCrtcInfo(s.X, crtc,
+			s.resources.ConfigTimestamp).Reply()
+		if err != nil {
+			return err
+		}
+		x, y, mode, rotation = current.X, current.Y, current.Mode,
+			current.Rotation
+	} else {
+		for _, c := range info.Crtcs {
+			i, err := randr.GetCrtcInfo(s.X, c,
+				s.resources.ConfigTimestamp).Reply()
+			if err != nil {
+				return err
+			}
+			if len(i.Outputs) == 0 {
+				crtc = c
+				break
+			}
+		}
+		if crtc == 0 {
+			return fmt.Errorf("there is no free CRTC for %s",
+				flagOutput)
+		}
+	}
+
+	if flagMode != "" {
+		mode = 0
+		for _, m := range info.Modes {
+			if s.modeNames[m] == flagMode {
+				mode = m
+				break
+			}
+		}
+		if mode == 0 {
+			return fmt.Errorf("%s has no mode %s", flagOutput,
+				flagMode)
+		}
+	}
+	if mode == 0 && len(info.Modes) > 0 {
+		mode = info.Modes[0] // the preferred one, if any
+	}
+	if flagRotate != "" {
+		r, ok := rotations[flagRotate]
+		if !ok {
+			return fmt.Errorf("bad rotation %s", flagRotate)
+		}
+		rotation = r
+	}
+	return s.setCrtc(crtc, x, y, mode, rotation, []randr.Output{output})
+}
+
+// setCrtc sets the configuration of 'crtc', and checks that it worked.
+func (s *screen) setCrtc(crtc randr.Crtc, x, y int16, mode randr.Mode,
+	rotation uint16, outputs []randr.Output) error {
+
+	reply, err := randr.SetCrtcConfig(s.X, crtc, xproto.TimeCurrentTime,
+		s.resources.ConfigTimestamp, x, y, mode, rotation,
+		outputs).Reply()
+	if err != nil {
+		return err
+	}
+	if reply.Status != randr.SetConfigSuccess {
+		return fmt.Errorf("SetCrtcConfig failed with status %d",
+			reply.Status)
+	}
+	return nil
+}
+
+// refreshRate returns the refresh rate of 'mode', in Hz.
+func refreshRate(mode randr.ModeInfo) float64 {
+	vtotal := float64(mode.Vtotal)
+	if mode.ModeFlags&randr.ModeFlagDoubleScan != 0 {
+		vtotal *= 2
+	}
+	if mode.ModeFlags&randr.ModeFlagInterlace != 0 {
+		vtotal /= 2
+	}
+	if mode.Htotal == 0 || vtotal == 0 {
+		return 0
+	}
+	return float64(mode.DotClock) / (float64(mode.Htotal) * vtotal)
+}
+
+// rotationName returns the name of the rotation in 'rotation'.
+func rotationName(rotation uint16) string {
+	for name, r := range rotations {
+		if rotation&r != 0 {
+			return name
+		}
+	}
+	return "normal"
+}
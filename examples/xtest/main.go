@@ -0,0 +1,62 @@
+// Example xtest shows how to use the XTEST extension to fake input, by
+// moving the pointer in a circle around the center of the screen.
+package main
+
+import (
+	"flag"
+	"log"
+	"math"
+	"time"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xproto"
+	"github.com/BurntSushi/xgb/xtest"
+)
+
+var (
+	flagRadius float64
+	flagLaps   int
+)
+
+func init() {
+	flag.Float64Var(&flagRadius, "radius", 200, "Radius of the circle.")
+	flag.IntVar(&flagLaps, "laps", 3, "Number of times to go around.")
+	flag.Parse()
+}
+
+func main() {
+	X, err := xgb.NewConn()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer X.Close()
+
+	// Initialize the XTEST extension.
+	// The appropriate 'Init' function must be run for *every*
+	// extension before any of its requests can be used.
+	if err := xtest.Init(X); err != nil {
+		log.Fatal(err)
+	}
+
+	screen := xproto.Setup(X).DefaultScreen(X)
+	cx := float64(screen.WidthInPixels) / 2
+	cy := float64(screen.HeightInPixels) / 2
+
+	// Move the pointer to a new point of the circle every 10 milliseconds.
+	// FakeMotionEvent is an unchecked request without a reply, so errors
+	// (if any) are reported by WaitForEvent instead.
+	const steps = 360
+	for i := 0; i <= flagLaps*steps; i++ {
+		angle := 2 * math.Pi * float64(i) / steps
+		x := cx + flagRadius*math.Cos(angle)
+		y := cy + flagRadius*math.Sin(angle)
+		xtest.FakeMotionEvent(X, screen.Root, int16(x), int16(y), 0)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Make sure every request was processed before exiting.
+	_, err = xproto.GetInputFocus(X).Reply()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,64 @@
+package xgb
+
+import (
+	"errors"
+)
+
+// ErrFDPassingNotSupported is the error checked cookies of requests made
+// with NewRequestFDs fail with when the connection to the X server can't
+// pass file descriptors. Only local connections over a Unix domain socket
+// can.
+var ErrFDPassingNotSupported = errors.New("connection does not support " +
+	"passing file descriptors")
+
+// NewRequestFDs is like NewRequest, but also passes the file descriptors
+// 'fds' to the X server along with the request. Ownership of 'fds' passes to
+// XGB: they are closed once they have been sent, or when sending them fails.
+//
+// Extensions like DRI3 use this to share buffers and fences with the X
+// server. It should not be used otherwise.
+func (c *Conn) NewRequestFDs(buf []byte, fds []int, cookie *Cookie) {
+	c.queueRequest(&request{buf: buf, cookie: cookie, fds: fds})
+}
+
+// NewCookieFDs is like NewCookie for requests with a reply, except that the
+// X server passes file descriptors along with the reply. They are returned by
+// Cookie.ReplyFDs. The number of file descriptors must be in the second byte
+// of the reply, as in DRI3 and MIT-SHM.
+//
+// Unless you're building requests from bytes by hand, this method should
+// not be used.
+func (c *Conn) NewCookieFDs(checked bool) *Cookie {
+	cookie := c.NewCookie(checked, true)
+	cookie.replyFDs = true
+	return cookie
+}
+
+// ReplyFDs is like Reply, but also returns the file descriptors passed along
+// with the reply. The cookie must have been created with NewCookieFDs, and
+// the caller is responsible for closing the file descriptors.
+//
+// Unless you're building requests from bytes by hand, this method should
+// not be used.
+func (c *Cookie) ReplyFDs() ([]byte, []int, error) {
+	buf, err := c.Reply()
+	if err != nil || buf == nil {
+		return nil, nil, err
+	}
+	return buf, c.fds, nil
+}
+
+// sendCookieFDs is like sendCookie, but also passes 'fds' along with 'buf'.
+func (c *Conn) sendCookieFDs(done chan struct{}, cookie *Cookie, buf []byte,
+	fds []int) bool {
+
+	if !c.queueCookie(done, cookie, buf) {
+		closeFDs(fds)
+		return false
+	}
+
+	// Everything before this request has to be written first.
+	c.flushWriter(done)
+	c.writeFailed(done, c.sendFD(buf, fds))
+	return true
+}
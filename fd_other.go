@@ -0,0 +1,28 @@
+//go:build !unix
+
+package xgb
+
+import (
+	"io"
+)
+
+// Passing file descriptors is only supported on Unix-like systems. See
+// fd_unix.go.
+
+func newReader(conn io.Reader) io.Reader {
+	return conn
+}
+
+func (c *Conn) canPassFDs() bool {
+	return false
+}
+
+func (c *Conn) sendFD(buf []byte, fds []int) error {
+	return ErrFDPassingNotSupported
+}
+
+func (c *Conn) recvFD(n int) []int {
+	return nil
+}
+
+func closeFDs(fds []int) {}
@@ -0,0 +1,168 @@
+//go:build unix
+
+package xgb
+
+import (
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestNewRequestFDs passes one end of a pipe to the server along with
+// a request, and makes sure the server can write to it.
+func TestNewRequestFDs(t *testing.T) {
+	display, conns := setupServer(t)
+	c := newTestConn(t, display)
+
+	r, w := pipe(t)
+	c.NewRequestFDs(c.getInputFocusRequest(), []int{w},
+		c.NewCookie(false, true))
+
+	server := (<-conns).(*net.UnixConn)
+	server.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 4)
+	oob := make([]byte, syscall.CmsgSpace(4))
+	_, oobn, _, _, err := server.ReadMsgUnix(buf, oob)
+	if err != nil {
+		t.Fatalf("ReadMsgUnix: %s", err)
+	}
+	if buf[0] != 43 {
+		t.Fatalf("Expected a GetInputFocus request, but got % x", buf)
+	}
+	fds := parseRights(t, oob[:oobn])
+	if len(fds) != 1 {
+		t.Fatalf("Expected one file descriptor, but got %d.", len(fds))
+	}
+
+	sent := os.NewFile(uintptr(fds[0]), "sent")
+	sent.Write([]byte("hello"))
+	sent.Close()
+	if data, _ := io.ReadAll(r); string(data) != "hello" {
+		t.Fatalf("Expected to read 'hello' from the pipe, but got %q",
+			data)
+	}
+}
+
+// TestReplyFDs sends a reply with a file descriptor, and makes sure
+// ReplyFDs returns it.
+func TestReplyFDs(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+
+	cookie := c.NewCookieFDs(true)
+	c.NewRequest(c.getInputFocusRequest(), cookie)
+
+	r, w := pipe(t)
+	reply := make([]byte, 32)
+	reply[0] = 1
+	reply[1] = 1 // number of file descriptors
+	Put16(reply[2:], 1)
+	server := (<-conns).(*net.UnixConn)
+	if _, err := io.ReadFull(server, make([]byte, 4)); err != nil {
+		t.Fatalf("Could not read the request: %s", err)
+	}
+	rights := syscall.UnixRights(w)
+	if _, _, err := server.WriteMsgUnix(reply, rights, nil); err != nil {
+		t.Fatalf("WriteMsgUnix: %s", err)
+	}
+	syscall.Close(w)
+
+	buf, fds, err := cookie.ReplyFDs()
+	if err != nil {
+		t.Fatalf("ReplyFDs: %s", err)
+	}
+	if len(buf) != 32 || len(fds) != 1 {
+		t.Fatalf("Expected a reply with one file descriptor, but got "+
+			"% x with %v", buf, fds)
+	}
+
+	received := os.NewFile(uintptr(fds[0]), "received")
+	received.Write([]byte("hello"))
+	received.Close()
+	if data, _ := io.ReadAll(r); string(data) != "hello" {
+		t.Fatalf("Expected to read 'hello' from the pipe, but got %q",
+			data)
+	}
+}
+
+// TestNewRequestFDsNotSupported makes sure that passing file descriptors
+// over a connection that isn't a Unix domain socket fails, and closes them.
+func TestNewRequestFDsNotSupported(t *testing.T) {
+	client, server := net.Pipe()
+	defer server.Close()
+	go io.Copy(io.Discard, server)
+
+	c := &Conn{conn: client}
+	c.cookieChan = make(chan *Cookie, cookieBuffer)
+	c.xidChan = make(chan xid, xidBuffer)
+	c.seqChan = make(chan uint32, seqBuffer)
+	c.reqChan = make(chan *request, reqBuffer)
+	c.eventChan = make(chan EventOrError, eventBuffer)
+	c.stopLock.Lock()
+	c.start()
+	c.stopLock.Unlock()
+	defer c.Close()
+
+	r, w := pipe(t)
+	cookie := c.NewCookie(true, false)
+	c.NewRequestFDs(c.getInputFocusRequest(), []int{w}, cookie)
+	// Check would wait for a round trip that never comes.
+	select {
+	case err := <-cookie.errorChan:
+		if err != ErrFDPassingNotSupported {
+			t.Fatalf("Expected ErrFDPassingNotSupported, "+
+				"but got '%v'.", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("The request did not fail.")
+	}
+	if n, err := r.Read(make([]byte, 1)); n != 0 || err != io.EOF {
+		t.Fatalf("Expected the write end of the pipe to be closed, "+
+			"but got (%d, %v).", n, err)
+	}
+}
+
+// pipe returns the two ends of a new pipe. The read end is closed when the
+// test finishes. The write end is a bare file descriptor that can be given
+// away. (The finalizer of an *os.File would close it again later, by which
+// time the same number may be in use by something else.)
+func pipe(t *testing.T) (*os.File, int) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Pipe: %s", err)
+	}
+	t.Cleanup(func() { r.Close() })
+
+	fd, err := syscall.Dup(int(w.Fd()))
+	w.Close()
+	if err != nil {
+		t.Fatalf("Dup: %s", err)
+	}
+	return r, fd
+}
+
+// parseRights returns the file descriptors in the control messages in 'oob'.
+func parseRights(t *testing.T, oob []byte) []int {
+	t.Helper()
+	msgs, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		t.Fatalf("ParseSocketControlMessage: %s", err)
+	}
+	var fds []int
+	for i := range msgs {
+		rights, err := syscall.ParseUnixRights(&msgs[i])
+		if err != nil {
+			t.Fatalf("ParseUnixRights: %s", err)
+		}
+		fds = append(fds, rights...)
+	}
+	return fds
+}
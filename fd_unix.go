@@ -0,0 +1,102 @@
+//go:build unix
+
+package xgb
+
+import (
+	"io"
+	"net"
+	"syscall"
+)
+
+// maxRecvFDs is the largest number of file descriptors that can be received
+// with a single read. No reply carries more than a few.
+const maxRecvFDs = 16
+
+// fdReader reads from the Unix domain socket of a connection to the X server,
+// and keeps the file descriptors passed along with the data (in SCM_RIGHTS
+// control messages) for recvFD.
+type fdReader struct {
+	conn *net.UnixConn
+	oob  []byte
+	fds  []int
+}
+
+// newReader returns what responses from the X server should be read from:
+// an fdReader for Unix domain sockets, and 'conn' itself otherwise.
+func newReader(conn io.Reader) io.Reader {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return conn
+	}
+	oob := make([]byte, syscall.CmsgSpace(maxRecvFDs*4))
+	return &fdReader{conn: unixConn, oob: oob}
+}
+
+func (r *fdReader) Read(buf []byte) (int, error) {
+	n, oobn, _, _, err := r.conn.ReadMsgUnix(buf, r.oob)
+	if n < 0 {
+		// recvmsg's -1 on errors makes it through, which io.Reader
+		// doesn't allow (and bufio.Reader panics on).
+		n = 0
+	}
+	if oobn == 0 {
+		return n, err
+	}
+
+	msgs, perr := syscall.ParseSocketControlMessage(r.oob[:oobn])
+	if perr != nil {
+		logger.Printf("Could not parse control message: %s", perr)
+	}
+	for i := range msgs {
+		fds, perr := syscall.ParseUnixRights(&msgs[i])
+		if perr == nil {
+			r.fds = append(r.fds, fds...)
+		}
+	}
+	return n, err
+}
+
+// canPassFDs returns whether the connection to the X server can pass file
+// descriptors.
+func (c *Conn) canPassFDs() bool {
+	_, ok := c.reader.(*fdReader)
+	return ok
+}
+
+// sendFD writes 'buf' to the Unix domain socket of the connection, passing
+// 'fds' along with its first byte, and closes them. canPassFDs must be true.
+func (c *Conn) sendFD(buf []byte, fds []int) error {
+	defer closeFDs(fds)
+
+	conn := c.reader.(*fdReader).conn
+	n, _, err := conn.WriteMsgUnix(buf, syscall.UnixRights(fds...), nil)
+	if err == nil && n < len(buf) {
+		_, err = conn.Write(buf[n:])
+	}
+	return err
+}
+
+// recvFD returns up to 'n' of the file descriptors received from the X
+// server, in the order they were received. It must only be called by
+// readResponses.
+func (c *Conn) recvFD(n int) []int {
+	r, ok := c.reader.(*fdReader)
+	if !ok {
+		return nil
+	}
+	if n > len(r.fds) {
+		c.log().Printf("Expected %d file descriptors, but only %d "+
+			"were received.", n, len(r.fds))
+		n = len(r.fds)
+	}
+	fds := r.fds[:n:n]
+	r.fds = r.fds[n:]
+	return fds
+}
+
+// closeFDs closes all of 'fds'.
+func closeFDs(fds []int) {
+	for _, fd := range fds {
+		syscall.Close(fd)
+	}
+}
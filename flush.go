@@ -0,0 +1,77 @@
+package xgb
+
+// SetBuffered turns buffered mode on or off. In buffered mode, requests are
+// not written to the X server right away, but collected in a buffer until
+// Flush is called (or the buffer is full), which saves system calls when
+// making many requests in a row. This is how XCB works.
+//
+// Waiting for a reply (or checking a request for errors) flushes the buffer
+// first, so that the wait doesn't last forever. Turning buffered mode off
+// flushes the buffer too.
+func (c *Conn) SetBuffered(buffered bool) {
+	c.flush(&buffered)
+}
+
+// Flush writes every request buffered in buffered mode to the X server.
+// It returns the first error from writing requests since the last successful
+// call to Flush, if any, even if buffered mode is off.
+// If the connection to the X server is closed or lost, then Flush returns
+// the reason why.
+func (c *Conn) Flush() error {
+	return c.flush(nil)
+}
+
+// flushBuffered calls Flush in buffered mode.
+func (c *Conn) flushBuffered() {
+	c = c.socket()
+	if c.buffered.Load() {
+		c.Flush()
+	}
+}
+
+// flush sends a flush request to sendRequests, which is the only goroutine
+// that writes to the write buffer, and waits for it to be handled. If
+// 'buffered' is not nil, buffered mode is set to *buffered afterwards.
+func (c *Conn) flush(buffered *bool) error {
+	req := &request{flush: make(chan error, 1), setBuffered: buffered}
+	done := c.currentDone()
+	select {
+	case c.reqChan <- req:
+	case <-done:
+		return c.stopReason()
+	}
+	select {
+	case err := <-req.flush:
+		return err
+	case <-done:
+		return c.stopReason()
+	}
+}
+
+// stopReason returns the error the connection was last stopped with.
+func (c *Conn) stopReason() error {
+	c = c.socket()
+	c.stopLock.Lock()
+	defer c.stopLock.Unlock()
+
+	return c.stopErr
+}
+
+// flushRequests handles a flush request sent by flush. It is called by
+// sendRequests.
+func (c *Conn) flushRequests(done chan struct{}, req *request) {
+	c.flushWriter(done)
+	if req.setBuffered != nil {
+		c.buffered.Store(*req.setBuffered)
+		req.flush <- nil
+		return
+	}
+
+	req.flush <- c.writeErr
+	c.writeErr = nil
+}
+
+// flushWriter writes out the write buffer.
+func (c *Conn) flushWriter(done chan struct{}) {
+	c.writeFailed(done, c.bufw.Flush())
+}
@@ -0,0 +1,81 @@
+package xgb
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+// TestBuffered makes sure that requests are only written in buffered mode
+// when the buffer is flushed.
+func TestBuffered(t *testing.T) {
+	display, conns := setupServer(t)
+	c := newTestConn(t, display)
+	server := <-conns
+
+	c.SetBuffered(true)
+	c.NewRequest(noOperationRequest(), c.NewCookie(false, false))
+
+	buf := make([]byte, 4)
+	server.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+	if _, err := io.ReadFull(server, buf); err == nil {
+		t.Fatalf("The request was written before flushing.")
+	}
+
+	if err := c.Flush(); err != nil {
+		t.Fatalf("Flush: %s", err)
+	}
+	server.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := io.ReadFull(server, buf); err != nil {
+		t.Fatalf("Reading request after flushing: %s", err)
+	}
+	if buf[0] != 127 {
+		t.Fatalf("Expected a NoOperation request, but got opcode %d.",
+			buf[0])
+	}
+}
+
+// TestBufferedReply makes sure that waiting for a reply in buffered mode
+// doesn't wait forever.
+func TestBufferedReply(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	go replyServer(<-conns)
+
+	c.SetBuffered(true)
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(c.getInputFocusRequest(), cookie)
+
+	replies := make(chan error)
+	go func() {
+		_, err := cookie.Reply()
+		replies <- err
+	}()
+	select {
+	case err := <-replies:
+		if err != nil {
+			t.Fatalf("Reply: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Reply did not return in buffered mode.")
+	}
+}
+
+// TestFlushClosed makes sure that Flush reports a closed connection.
+func TestFlushClosed(t *testing.T) {
+	display, _ := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	c.Close()
+
+	if err := c.Flush(); err != errClosed {
+		t.Fatalf("Expected Flush to fail with '%v', but got '%v'.",
+			errClosed, err)
+	}
+}
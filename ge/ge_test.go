@@ -0,0 +1,21 @@
+package ge
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+)
+
+// TestQueryVersionRequest checks the encoding of a QueryVersion request,
+// using the extension name Init stores the major opcode under.
+func TestQueryVersionRequest(t *testing.T) {
+	c := &xgb.Conn{Extensions: map[string]byte{
+		"Generic Event Extension": 128}}
+	buf := queryVersionRequest(c, 1, 0)
+
+	want := []byte{128, 0, 2, 0, 1, 0, 0, 0}
+	if !bytes.Equal(buf, want) {
+		t.Fatalf("Expected\n% x\nbut got\n% x", want, buf)
+	}
+}
@@ -0,0 +1,160 @@
+package xgb
+
+import (
+	"fmt"
+)
+
+// GenericEventCode is the event code of X Generic Events (as defined by the
+// Generic Event Extension). Extensions like XInput2 use them for events that
+// don't fit in the 32 bytes of a core event.
+const GenericEventCode = 35
+
+// GenericEvent is a generic event there is no handler for. (See
+// RegisterGenericEventHandler.)
+type GenericEvent struct {
+	Sequence  uint16
+	Extension uint8 // the major opcode of the extension that sent the event
+	EventType uint16
+
+	// Data is the rest of the event: the remaining 22 bytes of the 32 byte
+	// header, followed by the additional data.
+	Data []byte
+
+	buf []byte
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v GenericEvent) Bytes() []byte {
+	return v.buf
+}
+
+// String is a rudimentary string representation of GenericEvent.
+func (v GenericEvent) String() string {
+	return fmt.Sprintf("GenericEvent {Sequence: %d, Extension: %d, "+
+		"EventType: %d, len(Data): %d}",
+		v.Sequence, v.Extension, v.EventType, len(v.Data))
+}
+
+// GenericEventHandler decodes a generic event, whose raw bytes (including
+// the 32 byte header) are in 'buf'.
+type GenericEventHandler func(buf []byte) Event
+
+// genericEventKey identifies the kind of a generic event.
+type genericEventKey struct {
+	ext    uint8
+	evtype uint16
+}
+
+// RegisterGenericEventHandler makes 'h' decode the generic events of type
+// 'evtype' sent by the extension with major opcode 'ext' (as found in
+// Extensions after the extension has been initialized). The events it returns
+// are then returned by WaitForEvent instead of a GenericEvent. It replaces any
+// handler previously registered for the same kind of event. If 'h' is nil, the
+// handler is removed.
+//
+// The X server only sends generic events to clients that have told it which
+// version of the Generic Event Extension they support. So the first time a
+// handler is registered on a connection, RegisterGenericEventHandler does
+// that, and waits for the X server to answer.
+//
+// Note that the major opcode of an extension may change when reconnecting, in
+// which case the handler has to be registered again (i.e., in a hook given to
+// OnReconnect).
+func (c *Conn) RegisterGenericEventHandler(ext uint8, evtype uint16,
+	h GenericEventHandler) {
+
+	c = c.socket()
+	if h != nil {
+		c.queryGenericEventVersion()
+	}
+
+	c.genericLock.Lock()
+	defer c.genericLock.Unlock()
+
+	key := genericEventKey{ext, evtype}
+	if h == nil {
+		delete(c.genericHandlers, key)
+		return
+	}
+	if c.genericHandlers == nil {
+		c.genericHandlers =
+			make(map[genericEventKey]GenericEventHandler)
+	}
+	c.genericHandlers[key] = h
+}
+
+// geName is the name of the Generic Event Extension, as used in Extensions
+// and by the ge package.
+const geName = "Generic Event Extension"
+
+// queryGenericEventVersion initializes the Generic Event Extension and sends
+// it a QueryVersion request, unless that has already been done on the current
+// connection. Since RegisterGenericEventHandler has no way of reporting
+// errors, they are only logged; generic events are then not sent by the
+// X server.
+func (c *Conn) queryGenericEventVersion() {
+	c.geLock.Lock()
+	defer c.geLock.Unlock()
+
+	if c.geQueried {
+		return
+	}
+	c.geQueried = true
+
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(c.queryExtensionRequest(geName), cookie)
+	reply, err := cookie.Reply()
+	if err != nil {
+		c.log().Printf("Could not query the %s: %s", geName, err)
+		return
+	}
+	if reply[8] != 1 {
+		c.log().Printf("The %s is not available.", geName)
+		return
+	}
+	ExtLock.Lock()
+	c.Extensions[geName] = reply[9]
+	ExtLock.Unlock()
+
+	cookie = c.NewCookie(true, true)
+	c.NewRequest(c.geQueryVersionRequest(reply[9]), cookie)
+	if _, err := cookie.Reply(); err != nil {
+		c.log().Printf("Could not query the version of the %s: %s",
+			geName, err)
+	}
+}
+
+// geQueryVersionRequest writes the raw bytes of a QueryVersion request of the
+// Generic Event Extension, which has major opcode 'major', to a buffer. It
+// asks for version 1.0, the only one there is.
+// It is duplicated from ge/ge.go.
+func (c *Conn) geQueryVersionRequest(major byte) []byte {
+	buf := make([]byte, 8)
+	buf[0] = major
+	buf[1] = 0 // request opcode
+	Put16(buf[2:], uint16(len(buf)/4))
+	Put16(buf[4:], 1) // client major version
+	Put16(buf[6:], 0) // client minor version
+	return buf
+}
+
+// newGenericEvent decodes the generic event in 'buf' with the handler
+// registered for it, or as a GenericEvent if there is none.
+func (c *Conn) newGenericEvent(buf []byte) Event {
+	v := GenericEvent{
+		Sequence:  Get16(buf[2:]),
+		Extension: buf[1],
+		EventType: Get16(buf[8:]),
+		Data:      buf[10:],
+		buf:       buf,
+	}
+
+	c.genericLock.Lock()
+	h := c.genericHandlers[genericEventKey{v.Extension, v.EventType}]
+	c.genericLock.Unlock()
+
+	if h != nil {
+		return h(buf)
+	}
+	return v
+}
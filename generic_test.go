@@ -0,0 +1,159 @@
+package xgb
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+)
+
+// TestGenericEvent sends two generic events with additional data, one of
+// which has a handler registered for it.
+func TestGenericEvent(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+
+	server := <-conns
+	go geServer(t, server)
+	var handled []byte
+	c.RegisterGenericEventHandler(131, 2, func(buf []byte) Event {
+		handled = buf
+		return testEvent{}
+	})
+
+	extra := []byte("0123456789abcdef")
+	server.Write(genericEvent(131, 1, extra))
+	server.Write(genericEvent(131, 2, extra))
+
+	ev, xerr := c.WaitForEvent()
+	if xerr != nil {
+		t.Fatalf("WaitForEvent: %s", xerr)
+	}
+	gev, ok := ev.(GenericEvent)
+	if !ok {
+		t.Fatalf("Expected a GenericEvent, but got %v (%T).", ev, ev)
+	}
+	if gev.Extension != 131 || gev.EventType != 1 || len(gev.Data) != 38 ||
+		!bytes.Equal(gev.Data[22:], extra) {
+
+		t.Fatalf("Wrong generic event: %v", gev)
+	}
+
+	ev, xerr = c.WaitForEvent()
+	if xerr != nil {
+		t.Fatalf("WaitForEvent: %s", xerr)
+	}
+	if ev != (testEvent{}) {
+		t.Fatalf("Expected the handler to decode the event, "+
+			"but got %v.", ev)
+	}
+	if len(handled) != 48 || !bytes.Equal(handled[32:], extra) {
+		t.Fatalf("The handler got the wrong bytes: %q", handled)
+	}
+}
+
+// genericEvent returns a generic event of type 'evtype' sent by extension
+// 'ext', with 'extra' following the 32 byte header.
+func genericEvent(ext uint8, evtype uint16, extra []byte) []byte {
+	buf := make([]byte, 32+len(extra))
+	buf[0] = GenericEventCode
+	buf[1] = ext
+	Put32(buf[4:], uint32(len(extra)/4))
+	Put16(buf[8:], evtype)
+	copy(buf[32:], extra)
+	return buf
+}
+
+// TestQueryGenericEventVersion makes sure the version of the Generic Event
+// Extension is queried when the first handler is registered, and only then.
+func TestQueryGenericEventVersion(t *testing.T) {
+	display, conns := setupServer(t)
+	c := newTestConn(t, display)
+
+	requests := make(chan []byte, 10)
+	go func() {
+		server := <-conns
+		geServer(t, server)
+		for {
+			req, err := readRequest(server)
+			if err != nil {
+				close(requests)
+				return
+			}
+			requests <- req
+		}
+	}()
+	h := func(buf []byte) Event { return testEvent{} }
+	c.RegisterGenericEventHandler(131, 1, h)
+	c.RegisterGenericEventHandler(131, 2, h)
+
+	if c.Extensions[geName] != 128 {
+		t.Fatalf("Expected the %s to have major opcode 128, "+
+			"but got %d.", geName, c.Extensions[geName])
+	}
+	// Only a round trip of our own may follow.
+	c.NewRequest(c.getInputFocusRequest(), c.NewCookie(false, false))
+	if req := <-requests; req[0] != 43 {
+		t.Fatalf("Expected a GetInputFocus request, but got % x", req)
+	}
+}
+
+// geServer answers the QueryExtension and QueryVersion requests sent by
+// queryGenericEventVersion on 'conn', giving the Generic Event Extension
+// major opcode 128. It fails the test if other requests come first.
+func geServer(t *testing.T, conn net.Conn) {
+	req, err := readRequest(conn)
+	if err != nil || req[0] != 98 ||
+		string(req[8:8+len(geName)]) != geName {
+
+		t.Errorf("Expected a QueryExtension request for the %s, "+
+			"but got % x (%v)", geName, req, err)
+		return
+	}
+	reply := make([]byte, 32)
+	reply[0] = 1
+	Put16(reply[2:], 1)
+	reply[8] = 1   // present
+	reply[9] = 128 // major opcode
+	conn.Write(reply)
+
+	req, err = readRequest(conn)
+	want := []byte{128, 0, 2, 0, 1, 0, 0, 0}
+	if err != nil || !bytes.Equal(req, want) {
+		t.Errorf("Expected the QueryVersion request % x, "+
+			"but got % x (%v)", want, req, err)
+		return
+	}
+	reply = make([]byte, 32)
+	reply[0] = 1
+	Put16(reply[2:], 2)
+	Put16(reply[8:], 1) // major version
+	conn.Write(reply)
+}
+
+// readRequest reads a request from 'conn', which may be a big request, and
+// returns its raw bytes.
+func readRequest(conn net.Conn) ([]byte, error) {
+	head := make([]byte, 8)
+	if _, err := io.ReadFull(conn, head[:4]); err != nil {
+		return nil, err
+	}
+	length, n := int(Get16(head[2:])), 4
+	if length == 0 {
+		if _, err := io.ReadFull(conn, head[4:]); err != nil {
+			return nil, err
+		}
+		length, n = int(Get32(head[4:])), 8
+	}
+
+	req := make([]byte, length*4)
+	copy(req, head[:n])
+	if _, err := io.ReadFull(conn, req[n:]); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
@@ -0,0 +1,161 @@
+package glx
+
+/*
+	Choosing framebuffer configurations from the reply to GetFBConfigs, like
+	glXChooseFBConfig of libGL, which has no request of its own in GLX.
+	Unlike the rest of this package, this file is not generated.
+*/
+
+import (
+	"sort"
+
+	"github.com/BurntSushi/xgb"
+)
+
+// Attributes of framebuffer configurations, as in GLX/glx.h, which are the
+// keys of an FBConfig.
+const (
+	AttribBufferSize     = 2
+	AttribLevel          = 3
+	AttribRGBA           = 4
+	AttribDoublebuffer   = 5
+	AttribStereo         = 6
+	AttribAuxBuffers     = 7
+	AttribRedSize        = 8
+	AttribGreenSize      = 9
+	AttribBlueSize       = 10
+	AttribAlphaSize      = 11
+	AttribDepthSize      = 12
+	AttribStencilSize    = 13
+	AttribAccumRedSize   = 14
+	AttribAccumGreenSize = 15
+	AttribAccumBlueSize  = 16
+	AttribAccumAlphaSize = 17
+	AttribConfigCaveat   = 0x20
+	AttribXVisualType    = 0x22
+	AttribVisualId       = 0x800B
+	AttribDrawableType   = 0x8010
+	AttribRenderType     = 0x8011
+	AttribXRenderable    = 0x8012
+	AttribFBConfigId     = 0x8013
+	AttribSampleBuffers  = 100000
+	AttribSamples        = 100001
+)
+
+// Bits of the AttribDrawableType and AttribRenderType attributes.
+const (
+	WindowBit  = 1
+	PixmapBit  = 2
+	PbufferBit = 4
+
+	RGBABit       = 1
+	ColorIndexBit = 2
+)
+
+// FBConfig is a framebuffer configuration: its attributes and their values.
+type FBConfig map[uint32]uint32
+
+// Id returns the id of the configuration, which is what CreateNewContext
+// and CreateWindow take.
+func (cfg FBConfig) Id() Fbconfig {
+	return Fbconfig(cfg[AttribFBConfigId])
+}
+
+// FBConfigs returns the configurations in the reply to GetFBConfigs. Each of
+// them is NumProperties pairs of an attribute and its value in the property
+// list.
+func FBConfigs(reply *GetFBConfigsReply) []FBConfig {
+	n := int(reply.NumProperties) * 2
+	if n == 0 {
+		return nil
+	}
+
+	configs := make([]FBConfig, 0, reply.NumFbConfigs)
+	list := reply.PropertyList
+	for i := 0; i < int(reply.NumFbConfigs) && len(list) >= n; i++ {
+		cfg := make(FBConfig, reply.NumProperties)
+		for j := 0; j < n; j += 2 {
+			cfg[list[j]] = list[j+1]
+		}
+		configs = append(configs, cfg)
+		list = list[n:]
+	}
+	return configs
+}
+
+// Matches reports whether the configuration has the attributes in 'attribs'.
+// As with glXChooseFBConfig, sizes (like AttribRedSize or AttribSamples) are
+// minimums, AttribDrawableType and AttribRenderType are masks of bits that
+// must all be set, and every other attribute must have the value given.
+// An attribute the configuration doesn't have never matches.
+func (cfg FBConfig) Matches(attribs map[uint32]uint32) bool {
+	for attr, want := range attribs {
+		have, ok := cfg[attr]
+		if !ok {
+			return false
+		}
+		switch attr {
+		case AttribBufferSize, AttribAuxBuffers, AttribRedSize,
+			AttribGreenSize, AttribBlueSize, AttribAlphaSize,
+			AttribDepthSize, AttribStencilSize, AttribAccumRedSize,
+			AttribAccumGreenSize, AttribAccumBlueSize,
+			AttribAccumAlphaSize, AttribSampleBuffers,
+			AttribSamples:
+
+			if have < want {
+				return false
+			}
+		case AttribDrawableType, AttribRenderType:
+			if have&want != want {
+				return false
+			}
+		default:
+			if have != want {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ChooseFBConfig returns the configurations of screen 'screen' that match
+// 'attribs' (see FBConfig.Matches), with those with more color bits first,
+// and then those with smaller buffers, fewer samples and less depth and
+// stencil bits (a simpler order than that of glXChooseFBConfig). It returns
+// nil, and no error, if none match.
+func ChooseFBConfig(c *xgb.Conn, screen int,
+	attribs map[uint32]uint32) ([]FBConfig, error) {
+
+	reply, err := GetFBConfigs(c, uint32(screen)).Reply()
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []FBConfig
+	for _, cfg := range FBConfigs(reply) {
+		if cfg.Matches(attribs) {
+			configs = append(configs, cfg)
+		}
+	}
+	sort.SliceStable(configs, func(i, j int) bool {
+		a, b := configs[i], configs[j]
+		if ca, cb := colorBits(a), colorBits(b); ca != cb {
+			return ca > cb
+		}
+		for _, attr := range []uint32{AttribBufferSize, AttribSamples,
+			AttribDepthSize, AttribStencilSize} {
+
+			if a[attr] != b[attr] {
+				return a[attr] < b[attr]
+			}
+		}
+		return false
+	})
+	return configs, nil
+}
+
+// colorBits returns the number of red, green, blue and alpha bits of 'cfg'.
+func colorBits(cfg FBConfig) uint32 {
+	return cfg[AttribRedSize] + cfg[AttribGreenSize] +
+		cfg[AttribBlueSize] + cfg[AttribAlphaSize]
+}
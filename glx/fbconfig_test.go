@@ -0,0 +1,58 @@
+package glx
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestFBConfigs parses the configurations in a made up reply and matches
+// them against attributes.
+func TestFBConfigs(t *testing.T) {
+	reply := &GetFBConfigsReply{
+		NumFbConfigs:  2,
+		NumProperties: 3,
+		PropertyList: []uint32{
+			AttribFBConfigId, 0x41, AttribRedSize, 8,
+			AttribDrawableType, WindowBit | PixmapBit,
+			AttribFBConfigId, 0x42, AttribRedSize, 5,
+			AttribDrawableType, PbufferBit,
+		},
+	}
+
+	configs := FBConfigs(reply)
+	want := []FBConfig{
+		{AttribFBConfigId: 0x41, AttribRedSize: 8,
+			AttribDrawableType: WindowBit | PixmapBit},
+		{AttribFBConfigId: 0x42, AttribRedSize: 5,
+			AttribDrawableType: PbufferBit},
+	}
+	if !reflect.DeepEqual(configs, want) {
+		t.Fatalf("Expected %v, but got %v.", want, configs)
+	}
+	if id := configs[1].Id(); id != 0x42 {
+		t.Errorf("Expected the id 0x42, but got %#x.", id)
+	}
+
+	tests := []struct {
+		attribs map[uint32]uint32
+		matches []bool
+	}{
+		{nil, []bool{true, true}},
+		{map[uint32]uint32{AttribRedSize: 6}, []bool{true, false}},
+		{map[uint32]uint32{AttribDrawableType: WindowBit},
+			[]bool{true, false}},
+		{map[uint32]uint32{AttribFBConfigId: 0x42},
+			[]bool{false, true}},
+		{map[uint32]uint32{AttribDepthSize: 0}, []bool{false, false}},
+	}
+	for _, test := range tests {
+		for i, cfg := range configs {
+			m := cfg.Matches(test.attribs)
+			if m != test.matches[i] {
+				t.Errorf("%v matching config %d: expected %v, "+
+					"but got %v.", test.attribs, i,
+					test.matches[i], m)
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package icccm
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// Flags of WMHints, which say which of its fields are set.
+const (
+	HintInput        = 1 << 0
+	HintState        = 1 << 1
+	HintIconPixmap   = 1 << 2
+	HintIconWindow   = 1 << 3
+	HintIconPosition = 1 << 4
+	HintIconMask     = 1 << 5
+	HintWindowGroup  = 1 << 6
+	HintUrgency      = 1 << 8
+)
+
+// States of a window, as in the InitialState of WMHints.
+const (
+	StateWithdrawn = 0
+	StateNormal    = 1
+	StateIconic    = 3
+)
+
+// WMHints is the value of WM_HINTS.
+type WMHints struct {
+	Flags        uint32
+	Input        bool
+	InitialState uint32
+	IconPixmap   xproto.Pixmap
+	IconWindow   xproto.Window
+	IconX, IconY int32
+	IconMask     xproto.Pixmap
+	WindowGroup  xproto.Window
+}
+
+// Flags of WMNormalHints, which say which of its fields are set. The US flags
+// mean the user chose the position or size, and the P flags that the program
+// did.
+const (
+	SizeHintUSPosition  = 1 << 0
+	SizeHintUSSize      = 1 << 1
+	SizeHintPPosition   = 1 << 2
+	SizeHintPSize       = 1 << 3
+	SizeHintPMinSize    = 1 << 4
+	SizeHintPMaxSize    = 1 << 5
+	SizeHintPResizeInc  = 1 << 6
+	SizeHintPAspect     = 1 << 7
+	SizeHintPBaseSize   = 1 << 8
+	SizeHintPWinGravity = 1 << 9
+)
+
+// WMNormalHints is the value of WM_NORMAL_HINTS. The aspect ratios are
+// fractions, e.g., MinAspectNum/MinAspectDen. X, Y, Width and Height are
+// obsolete, and only kept for old window managers.
+type WMNormalHints struct {
+	Flags                      uint32
+	X, Y                       int32
+	Width, Height              uint32
+	MinWidth, MinHeight        uint32
+	MaxWidth, MaxHeight        uint32
+	WidthInc, HeightInc        uint32
+	MinAspectNum, MinAspectDen uint32
+	MaxAspectNum, MaxAspectDen uint32
+	BaseWidth, BaseHeight      uint32
+	WinGravity                 uint32
+}
+
+// GetWMHints returns the value of WM_HINTS of 'win'.
+func GetWMHints(c *xgb.Conn, win xproto.Window) (WMHints, error) {
+	vals, err := xproto.GetPropertyUint32List(c, win, xproto.AtomWmHints,
+		xproto.AtomWmHints)
+	if err != nil {
+		return WMHints{}, err
+	}
+	return wmHints(vals)
+}
+
+// SetWMHints sets WM_HINTS.
+func SetWMHints(c *xgb.Conn, win xproto.Window, hints WMHints) error {
+	return xproto.ChangePropertyUint32List(c, win, xproto.AtomWmHints,
+		xproto.AtomWmHints, wmHintsValues(hints))
+}
+
+// wmHints decodes the value of WM_HINTS. Clients written for old versions of
+// the ICCCM leave out the window group, which is 0 then.
+func wmHints(vals []uint32) (WMHints, error) {
+	if len(vals) < 8 {
+		return WMHints{}, ErrBadFormat
+	}
+	hints := WMHints{
+		Flags:        vals[0],
+		Input:        vals[1] != 0,
+		InitialState: vals[2],
+		IconPixmap:   xproto.Pixmap(vals[3]),
+		IconWindow:   xproto.Window(vals[4]),
+		IconX:        int32(vals[5]),
+		IconY:        int32(vals[6]),
+		IconMask:     xproto.Pixmap(vals[7]),
+	}
+	if len(vals) > 8 {
+		hints.WindowGroup = xproto.Window(vals[8])
+	}
+	return hints, nil
+}
+
+// wmHintsValues is the reverse of wmHints.
+func wmHintsValues(hints WMHints) []uint32 {
+	var input uint32
+	if hints.Input {
+		input = 1
+	}
+	return []uint32{
+		hints.Flags, input, hints.InitialState,
+		uint32(hints.IconPixmap), uint32(hints.IconWindow),
+		uint32(hints.IconX), uint32(hints.IconY),
+		uint32(hints.IconMask), uint32(hints.WindowGroup),
+	}
+}
+
+// GetWMNormalHints returns the value of WM_NORMAL_HINTS of 'win'.
+func GetWMNormalHints(c *xgb.Conn, win xproto.Window) (WMNormalHints,
+	error) {
+
+	vals, err := xproto.GetPropertyUint32List(c, win,
+		xproto.AtomWmNormalHints, xproto.AtomWmSizeHints)
+	if err != nil {
+		return WMNormalHints{}, err
+	}
+	return wmNormalHints(vals)
+}
+
+// SetWMNormalHints sets WM_NORMAL_HINTS.
+func SetWMNormalHints(c *xgb.Conn, win xproto.Window,
+	hints WMNormalHints) error {
+
+	return xproto.ChangePropertyUint32List(c, win, xproto.AtomWmNormalHints,
+		xproto.AtomWmSizeHints, wmNormalHintsValues(hints))
+}
+
+// wmNormalHints decodes the value of WM_NORMAL_HINTS. Clients written for
+// old versions of the ICCCM leave out the base size and gravity, which are
+// 0 then.
+func wmNormalHints(vals []uint32) (WMNormalHints, error) {
+	if len(vals) < 15 {
+		return WMNormalHints{}, ErrBadFormat
+	}
+	hints := WMNormalHints{
+		Flags:        vals[0],
+		X:            int32(vals[1]),
+		Y:            int32(vals[2]),
+		Width:        vals[3],
+		Height:       vals[4],
+		MinWidth:     vals[5],
+		MinHeight:    vals[6],
+		MaxWidth:     vals[7],
+		MaxHeight:    vals[8],
+		WidthInc:     vals[9],
+		HeightInc:    vals[10],
+		MinAspectNum: vals[11],
+		MinAspectDen: vals[12],
+		MaxAspectNum: vals[13],
+		MaxAspectDen: vals[14],
+	}
+	if len(vals) >= 18 {
+		hints.BaseWidth = vals[15]
+		hints.BaseHeight = vals[16]
+		hints.WinGravity = vals[17]
+	}
+	return hints, nil
+}
+
+// wmNormalHintsValues is the reverse of wmNormalHints.
+func wmNormalHintsValues(h WMNormalHints) []uint32 {
+	return []uint32{
+		h.Flags, uint32(h.X), uint32(h.Y), h.Width, h.Height,
+		h.MinWidth, h.MinHeight, h.MaxWidth, h.MaxHeight,
+		h.WidthInc, h.HeightInc,
+		h.MinAspectNum, h.MinAspectDen, h.MaxAspectNum, h.MaxAspectDen,
+		h.BaseWidth, h.BaseHeight, h.WinGravity,
+	}
+}
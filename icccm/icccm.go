@@ -0,0 +1,118 @@
+// Package icccm reads and writes the client properties defined by the
+// Inter-Client Communication Conventions Manual (ICCCM), and handles the
+// client messages of the WM_PROTOCOLS property. See
+// https://www.x.org/releases/current/doc/xorg-docs/icccm/icccm.html.
+//
+// Functions that get a property return ErrPropertyNotSet if the window
+// doesn't have it. Atoms are interned with xproto.InternAtomCached, so only
+// the first use of each one makes a round trip for it. Protocols (like
+// WM_DELETE_WINDOW) are given as the names of their atoms.
+//
+// See the ewmh package for the window manager hints that extend these.
+package icccm
+
+import (
+	"errors"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// ErrPropertyNotSet is returned by the functions that get a property if the
+// window doesn't have it. It is xproto.ErrPropertyNotSet.
+var ErrPropertyNotSet = xproto.ErrPropertyNotSet
+
+// ErrBadFormat is returned by the functions that get a property if its value
+// doesn't have the size the ICCCM defines for it. A value of the wrong type or
+// format gives an xproto.PropertyTypeError instead.
+var ErrBadFormat = errors.New("icccm: the property has the wrong format")
+
+// GetWMProtocols returns the names of the protocols 'win' takes part in
+// (e.g., "WM_DELETE_WINDOW"), from WM_PROTOCOLS.
+func GetWMProtocols(c *xgb.Conn, win xproto.Window) ([]string, error) {
+	prop, err := xproto.InternAtomCached(c, "WM_PROTOCOLS")
+	if err != nil {
+		return nil, err
+	}
+	atoms, err := xproto.GetPropertyAtomList(c, win, prop)
+	if err != nil {
+		return nil, err
+	}
+	return xproto.GetAtomNames(c, atoms)
+}
+
+// SetWMProtocols sets WM_PROTOCOLS to the protocols named 'protocols'.
+func SetWMProtocols(c *xgb.Conn, win xproto.Window, protocols []string) error {
+	atoms, err := xproto.InternAtoms(c,
+		append([]string{"WM_PROTOCOLS"}, protocols...))
+	if err != nil {
+		return err
+	}
+	vals := make([]uint32, len(protocols))
+	for i, name := range protocols {
+		vals[i] = uint32(atoms[name])
+	}
+	return xproto.ChangePropertyUint32List(c, win, atoms["WM_PROTOCOLS"],
+		xproto.AtomAtom, vals)
+}
+
+// SendProtocol sends the WM_PROTOCOLS client message for 'protocol' (e.g.,
+// "WM_DELETE_WINDOW") to 'win', with the timestamp 'time'. It doesn't check
+// that 'win' takes part in the protocol.
+func SendProtocol(c *xgb.Conn, win xproto.Window, protocol string,
+	time xproto.Timestamp) error {
+
+	atoms, err := xproto.InternAtoms(c, []string{"WM_PROTOCOLS", protocol})
+	if err != nil {
+		return err
+	}
+	ev := xproto.ClientMessageEvent{
+		Format: 32,
+		Window: win,
+		Type:   atoms["WM_PROTOCOLS"],
+		Data: xproto.ClientMessageDataUnionData32New([]uint32{
+			uint32(atoms[protocol]), uint32(time), 0, 0, 0,
+		}),
+	}
+	return xproto.SendEventChecked(c, false, win, xproto.EventMaskNoEvent,
+		string(ev.Bytes())).Check()
+}
+
+// Protocol returns the name of the protocol (e.g., "WM_DELETE_WINDOW") and
+// the timestamp of 'ev', if it is a WM_PROTOCOLS client message. Otherwise,
+// the name is empty.
+func Protocol(c *xgb.Conn, ev xproto.ClientMessageEvent) (string,
+	xproto.Timestamp, error) {
+
+	atom, err := xproto.InternAtomCached(c, "WM_PROTOCOLS")
+	if err != nil || ev.Type != atom || ev.Format != 32 {
+		return "", 0, err
+	}
+	data := ev.Data.Data32
+	reply, err := xproto.GetAtomName(c, xproto.Atom(data[0])).Reply()
+	if err != nil {
+		return "", 0, err
+	}
+	return reply.Name, xproto.Timestamp(data[1]), nil
+}
+
+// GetWMClass returns the instance and class names of 'win', from WM_CLASS.
+func GetWMClass(c *xgb.Conn, win xproto.Window) (instance, class string,
+	err error) {
+
+	strs, err := xproto.GetPropertyStrings(c, win, xproto.AtomWmClass)
+	if err != nil {
+		return "", "", err
+	}
+	if len(strs) != 2 {
+		return "", "", ErrBadFormat
+	}
+	return strs[0], strs[1], nil
+}
+
+// SetWMClass sets WM_CLASS.
+func SetWMClass(c *xgb.Conn, win xproto.Window, instance, class string) error {
+	return xproto.ChangePropertyStrings(c, win, xproto.AtomWmClass,
+		xproto.AtomString, []string{instance, class})
+}
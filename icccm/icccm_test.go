@@ -0,0 +1,142 @@
+package icccm
+
+/*
+	Tests for decoding ICCCM property values, and for setting and getting
+	properties on a window.
+
+	The latter need a running X server, and are skipped if DISPLAY isn't
+	set.
+*/
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestWMHints converts WM_HINTS to property values and back, and decodes
+// the shorter value of old clients.
+func TestWMHints(t *testing.T) {
+	hints := WMHints{
+		Flags:        HintInput | HintState | HintIconPosition,
+		Input:        true,
+		InitialState: StateIconic,
+		IconX:        -10,
+		IconY:        20,
+		WindowGroup:  42,
+	}
+	vals := wmHintsValues(hints)
+	got, err := wmHints(vals)
+	if err != nil {
+		t.Fatalf("wmHints: %s", err)
+	}
+	if got != hints {
+		t.Fatalf("Expected %+v, but got %+v", hints, got)
+	}
+
+	got, err = wmHints(vals[:8])
+	if err != nil {
+		t.Fatalf("wmHints: %s", err)
+	}
+	if got.WindowGroup != 0 || got.IconY != 20 {
+		t.Fatalf("Expected no window group, but got %+v", got)
+	}
+	if _, err := wmHints(vals[:7]); err != ErrBadFormat {
+		t.Fatalf("Expected ErrBadFormat, but got %v", err)
+	}
+}
+
+// TestWMNormalHints converts WM_NORMAL_HINTS to property values and back.
+func TestWMNormalHints(t *testing.T) {
+	hints := WMNormalHints{
+		Flags:      SizeHintPMinSize | SizeHintPResizeInc,
+		MinWidth:   100,
+		MinHeight:  50,
+		WidthInc:   8,
+		HeightInc:  16,
+		WinGravity: 1,
+	}
+	vals := wmNormalHintsValues(hints)
+	if len(vals) != 18 {
+		t.Fatalf("Expected 18 values, but got %d", len(vals))
+	}
+	got, err := wmNormalHints(vals)
+	if err != nil {
+		t.Fatalf("wmNormalHints: %s", err)
+	}
+	if got != hints {
+		t.Fatalf("Expected %+v, but got %+v", hints, got)
+	}
+	if _, err := wmNormalHints(vals[:14]); err != ErrBadFormat {
+		t.Fatalf("Expected ErrBadFormat, but got %v", err)
+	}
+}
+
+// TestWMProtocols sets WM_PROTOCOLS of a window and reads it back.
+func TestWMProtocols(t *testing.T) {
+	X, win := newWindow(t)
+
+	if _, err := GetWMProtocols(X, win); err != ErrPropertyNotSet {
+		t.Fatalf("Expected ErrPropertyNotSet, but got %v", err)
+	}
+	protocols := []string{"WM_DELETE_WINDOW", "WM_TAKE_FOCUS"}
+	if err := SetWMProtocols(X, win, protocols); err != nil {
+		t.Fatalf("SetWMProtocols: %s", err)
+	}
+	got, err := GetWMProtocols(X, win)
+	if err != nil {
+		t.Fatalf("GetWMProtocols: %s", err)
+	}
+	if !reflect.DeepEqual(got, protocols) {
+		t.Fatalf("Expected %q, but got %q", protocols, got)
+	}
+}
+
+// TestWMClass sets WM_CLASS of a window and reads it back.
+func TestWMClass(t *testing.T) {
+	X, win := newWindow(t)
+
+	if err := SetWMClass(X, win, "xgb-test", "XgbTest"); err != nil {
+		t.Fatalf("SetWMClass: %s", err)
+	}
+	instance, class, err := GetWMClass(X, win)
+	if err != nil {
+		t.Fatalf("GetWMClass: %s", err)
+	}
+	if instance != "xgb-test" || class != "XgbTest" {
+		t.Fatalf("Expected xgb-test and XgbTest, but got %s and %s",
+			instance, class)
+	}
+}
+
+// newWindow connects to the X server in DISPLAY and creates an unmapped
+// window, or skips the current test if DISPLAY isn't set. The connection is
+// closed when the test finishes.
+func newWindow(t *testing.T) (*xgb.Conn, xproto.Window) {
+	t.Helper()
+	if len(os.Getenv("DISPLAY")) == 0 {
+		t.Skip("DISPLAY is not set")
+	}
+
+	X, err := xgb.NewConn()
+	if err != nil {
+		t.Fatalf("NewConn: %s", err)
+	}
+	t.Cleanup(func() { X.Close() })
+
+	screen := xproto.Setup(X).DefaultScreen(X)
+	win, err := xproto.NewWindowId(X)
+	if err != nil {
+		t.Fatalf("NewWindowId: %s", err)
+	}
+	err = xproto.CreateWindowChecked(X, screen.RootDepth, win, screen.Root,
+		0, 0, 1, 1, 0, xproto.WindowClassInputOutput, screen.RootVisual,
+		0, nil).Check()
+	if err != nil {
+		t.Fatalf("CreateWindow: %s", err)
+	}
+	return X, win
+}
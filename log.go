@@ -12,32 +12,39 @@ var PrintLog = true
 // output anything.
 type xgblog struct {
 	*log.Logger
+
+	// always is whether the logger ignores PrintLog. See WithLogger.
+	always bool
 }
 
 func newLogger() xgblog {
-	return xgblog{log.New(os.Stderr, "XGB: ", log.Lshortfile)}
+	return xgblog{Logger: log.New(os.Stderr, "XGB: ", log.Lshortfile)}
+}
+
+func (lg xgblog) enabled() bool {
+	return PrintLog || lg.always
 }
 
 func (lg xgblog) Print(v ...interface{}) {
-	if PrintLog {
+	if lg.enabled() {
 		lg.Logger.Print(v...)
 	}
 }
 
 func (lg xgblog) Printf(format string, v ...interface{}) {
-	if PrintLog {
+	if lg.enabled() {
 		lg.Logger.Printf(format, v...)
 	}
 }
 
 func (lg xgblog) Println(v ...interface{}) {
-	if PrintLog {
+	if lg.enabled() {
 		lg.Logger.Println(v...)
 	}
 }
 
 func (lg xgblog) Fatal(v ...interface{}) {
-	if PrintLog {
+	if lg.enabled() {
 		lg.Logger.Fatal(v...)
 	} else {
 		os.Exit(1)
@@ -45,7 +52,7 @@ func (lg xgblog) Fatal(v ...interface{}) {
 }
 
 func (lg xgblog) Fatalf(format string, v ...interface{}) {
-	if PrintLog {
+	if lg.enabled() {
 		lg.Logger.Fatalf(format, v...)
 	} else {
 		os.Exit(1)
@@ -53,7 +60,7 @@ func (lg xgblog) Fatalf(format string, v ...interface{}) {
 }
 
 func (lg xgblog) Fatalln(v ...interface{}) {
-	if PrintLog {
+	if lg.enabled() {
 		lg.Logger.Fatalln(v...)
 	} else {
 		os.Exit(1)
@@ -61,7 +68,7 @@ func (lg xgblog) Fatalln(v ...interface{}) {
 }
 
 func (lg xgblog) Panic(v ...interface{}) {
-	if PrintLog {
+	if lg.enabled() {
 		lg.Logger.Panic(v...)
 	} else {
 		panic("")
@@ -69,7 +76,7 @@ func (lg xgblog) Panic(v ...interface{}) {
 }
 
 func (lg xgblog) Panicf(format string, v ...interface{}) {
-	if PrintLog {
+	if lg.enabled() {
 		lg.Logger.Panicf(format, v...)
 	} else {
 		panic("")
@@ -77,7 +84,7 @@ func (lg xgblog) Panicf(format string, v ...interface{}) {
 }
 
 func (lg xgblog) Panicln(v ...interface{}) {
-	if PrintLog {
+	if lg.enabled() {
 		lg.Logger.Panicln(v...)
 	} else {
 		panic("")
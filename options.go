@@ -0,0 +1,94 @@
+package xgb
+
+import (
+	"context"
+	"log"
+	"net"
+)
+
+// An Option customizes a connection made by NewConnWithOptions.
+type Option func(*connOptions)
+
+// connOptions are the settings of a connection given by Options. Their zero
+// values are the defaults of NewConnDisplay. They are kept by Reconnect.
+type connOptions struct {
+	// authGiven is whether authName and authData replace what would
+	// otherwise be read from the authority file.
+	authGiven bool
+	authName  string
+	authData  []byte
+
+	dialer          net.Dialer
+	readBufferSize  int
+	writeBufferSize int
+	logger          *log.Logger
+}
+
+// NewConnWithOptions is just like NewConnDisplay, but customizes the
+// connection with 'opts':
+//
+//	X, err := xgb.NewConnWithOptions(":1",
+//		xgb.WithDialer(net.Dialer{Timeout: 5 * time.Second}),
+//		xgb.WithLogger(log.New(logFile, "xgb: ", log.LstdFlags)))
+func NewConnWithOptions(display string, opts ...Option) (*Conn, error) {
+	conn := &Conn{displayName: display}
+	for _, opt := range opts {
+		opt(&conn.opts)
+	}
+	return newConn(context.Background(), conn)
+}
+
+// WithAuthority makes the connection authorize itself with the protocol
+// 'name' (e.g., "MIT-MAGIC-COOKIE-1") and 'data', instead of looking them
+// up in the authority file (see $XAUTHORITY). An empty name connects
+// without authorization.
+func WithAuthority(name string, data []byte) Option {
+	return func(opts *connOptions) {
+		opts.authGiven = true
+		opts.authName = name
+		opts.authData = data
+	}
+}
+
+// WithDialer makes the connection dial the X server with 'd', e.g., to set
+// a timeout or the local address. It isn't used for named pipes on
+// Windows.
+func WithDialer(d net.Dialer) Option {
+	return func(opts *connOptions) {
+		opts.dialer = d
+	}
+}
+
+// WithReadBufferSize makes the connection read the responses of the X
+// server through a buffer of 'n' bytes, so that several small ones can be
+// read at once. By default, they are read without a buffer, one at a time.
+func WithReadBufferSize(n int) Option {
+	return func(opts *connOptions) {
+		opts.readBufferSize = n
+	}
+}
+
+// WithWriteBufferSize sets the size of the buffer requests are written to
+// in buffered mode (see SetBuffered), which is 4096 bytes by default.
+func WithWriteBufferSize(n int) Option {
+	return func(opts *connOptions) {
+		opts.writeBufferSize = n
+	}
+}
+
+// WithLogger makes the connection log what goes wrong to 'l', rather than
+// to standard error. Unlike the default logger, 'l' isn't silenced by
+// PrintLog.
+func WithLogger(l *log.Logger) Option {
+	return func(opts *connOptions) {
+		opts.logger = l
+	}
+}
+
+// log returns the logger of the connection.
+func (c *Conn) log() xgblog {
+	if c.opts.logger != nil {
+		return xgblog{Logger: c.opts.logger, always: true}
+	}
+	return logger
+}
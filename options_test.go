@@ -0,0 +1,78 @@
+package xgb
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNewConnWithOptions connects to a fake X server with every option, and
+// checks that the authorization data given is sent and that the logger
+// given is used.
+func TestNewConnWithOptions(t *testing.T) {
+	display, conns := listen(t)
+	cookie := []byte("0123456789abcdef")
+	auth := make(chan []byte, 1)
+	servers := make(chan net.Conn, 1)
+	go func() {
+		conn, ok := <-conns
+		if !ok {
+			return
+		}
+		head := make([]byte, 12)
+		if _, err := io.ReadFull(conn, head); err != nil {
+			return
+		}
+		rest := make([]byte,
+			Pad(int(Get16(head[6:])))+Pad(int(Get16(head[8:]))))
+		if _, err := io.ReadFull(conn, rest); err != nil {
+			return
+		}
+		auth <- append(head, rest...)
+		conn.Write(setupResponse())
+		servers <- conn
+	}()
+
+	var logs bytes.Buffer
+	c, err := NewConnWithOptions(display,
+		WithAuthority("MIT-MAGIC-COOKIE-1", cookie),
+		WithDialer(net.Dialer{Timeout: time.Minute}),
+		WithReadBufferSize(4096),
+		WithWriteBufferSize(64),
+		WithLogger(log.New(&logs, "", 0)))
+	if err != nil {
+		t.Fatalf("NewConnWithOptions: %s", err)
+	}
+	defer c.Close()
+	server := <-servers
+	go replyServer(server)
+
+	req := <-auth
+	name := "MIT-MAGIC-COOKIE-1"
+	if int(Get16(req[6:])) != len(name) ||
+		string(req[12:12+len(name)]) != name ||
+		!bytes.Equal(req[12+Pad(len(name)):][:16], cookie) {
+		t.Fatalf("Expected the authorization given, but got %v.", req)
+	}
+
+	c.SetBuffered(true)
+	if err := c.Ping(); err != nil {
+		t.Fatalf("Ping: %s", err)
+	}
+
+	// Losing the connection is logged.
+	server.Close()
+	var lost ConnectionClosedError
+	if err := c.Ping(); !errors.As(err, &lost) {
+		t.Fatalf("Expected a ConnectionClosedError, but got %v.", err)
+	}
+	if !strings.Contains(logs.String(), "was lost") {
+		t.Fatalf("Expected the logger to be used, but got %q.",
+			logs.String())
+	}
+}
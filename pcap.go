@@ -0,0 +1,122 @@
+package xgb
+
+import (
+	"encoding/binary"
+	"io"
+	"sync"
+	"time"
+)
+
+// linkTypeUser0 is LINKTYPE_USER0, the first of the link types pcap
+// reserves for private use.
+const linkTypeUser0 = 147
+
+// pcapSnapLen is the most bytes of a request or response written to a dump.
+// Longer ones (like big PutImage requests) are cut short, and the length of
+// the whole thing is kept in the record.
+const pcapSnapLen = 262144
+
+// Directions of the packets in a dump, as in their first byte.
+const (
+	pcapToServer   = 0
+	pcapFromServer = 1
+)
+
+// pcapDumper is the Tracer that writes the dump started by DumpTo. It stops
+// writing after the first error.
+type pcapDumper struct {
+	lock   sync.Mutex
+	w      io.Writer
+	failed bool
+}
+
+// DumpTo writes the X protocol traffic on the connection from now on to 'w'
+// as a pcap capture file, which can be opened in Wireshark. Each request,
+// reply, event and error is a packet of link type LINKTYPE_USER0 (147), with
+// the time it was written or read. The first byte of each packet is 0 for
+// requests and 1 for responses, and the X protocol message follows. For
+// Wireshark to decode them, map DLT_USER 0 to the "x11" protocol with
+// a header size of 1 (in the DLT_USER preferences).
+//
+// Only one dump can be going on at a time, and it is unaffected by SetTracer.
+// Calling DumpTo again replaces it, and a nil 'w' ends it. The pcap file
+// header is written right away, and DumpTo returns an error if that fails.
+// Packets are written by the goroutines serving the connection, so 'w'
+// should be fast (e.g., a bufio.Writer, which must then be flushed after the
+// dump ends). Writing stops after the first error.
+func (c *Conn) DumpTo(w io.Writer) error {
+	c = c.socket()
+	var dumper *pcapDumper
+	if w != nil {
+		if err := writePcapHeader(w); err != nil {
+			return err
+		}
+		dumper = &pcapDumper{w: w}
+	}
+
+	c.traceLock.Lock()
+	defer c.traceLock.Unlock()
+
+	c.dumper = dumper
+	c.updateTracer()
+	return nil
+}
+
+// writePcapHeader writes the global header of a pcap file, in little-endian
+// byte order with microsecond timestamps.
+func writePcapHeader(w io.Writer) error {
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint32(header[0:], 0xa1b2c3d4) // magic
+	binary.LittleEndian.PutUint16(header[4:], 2)          // major version
+	binary.LittleEndian.PutUint16(header[6:], 4)          // minor version
+	binary.LittleEndian.PutUint32(header[16:], pcapSnapLen)
+	binary.LittleEndian.PutUint32(header[20:], linkTypeUser0)
+	_, err := w.Write(header)
+	return err
+}
+
+// packet writes a record with 'payload', going in direction 'dir', to the
+// dump.
+func (d *pcapDumper) packet(dir byte, payload []byte) {
+	now := time.Now()
+	length := len(payload) + 1
+	captured := length
+	if captured > pcapSnapLen {
+		captured = pcapSnapLen
+	}
+
+	record := make([]byte, 16+captured)
+	binary.LittleEndian.PutUint32(record[0:], uint32(now.Unix()))
+	binary.LittleEndian.PutUint32(record[4:],
+		uint32(now.Nanosecond()/1000))
+	binary.LittleEndian.PutUint32(record[8:], uint32(captured))
+	binary.LittleEndian.PutUint32(record[12:], uint32(length))
+	record[16] = dir
+	copy(record[17:], payload)
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.failed {
+		return
+	}
+	if _, err := d.w.Write(record); err != nil {
+		d.failed = true
+	}
+}
+
+func (d *pcapDumper) TraceRequest(seq uint16, opcode byte, payload []byte) {
+	d.packet(pcapToServer, payload)
+}
+
+func (d *pcapDumper) TraceReply(seq uint16, payload []byte) {
+	d.packet(pcapFromServer, payload)
+}
+
+func (d *pcapDumper) TraceEvent(payload []byte) {
+	d.packet(pcapFromServer, payload)
+}
+
+func (d *pcapDumper) TraceError(seq uint16, payload []byte) {
+	d.packet(pcapFromServer, payload)
+}
@@ -0,0 +1,73 @@
+package xgb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// TestDumpTo dumps a request without a reply and one with a reply, and
+// parses the capture file.
+func TestDumpTo(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	go replyServer(<-conns)
+
+	var dump bytes.Buffer
+	if err := c.DumpTo(&dump); err != nil {
+		t.Fatalf("DumpTo: %s", err)
+	}
+	c.NewRequest(noOperationRequest(), c.NewCookie(false, false))
+	c.Sync()
+	c.DumpTo(nil)
+	c.Sync()
+
+	buf := dump.Bytes()
+	if len(buf) < 24 {
+		t.Fatalf("Expected a pcap header, but got %d bytes.", len(buf))
+	}
+	le := binary.LittleEndian
+	if le.Uint32(buf) != 0xa1b2c3d4 || le.Uint16(buf[4:]) != 2 ||
+		le.Uint16(buf[6:]) != 4 ||
+		le.Uint32(buf[20:]) != linkTypeUser0 {
+
+		t.Fatalf("Bad pcap header % x.", buf[:24])
+	}
+
+	// The NoOperation and GetInputFocus requests, and the reply.
+	var packets [][]byte
+	for buf = buf[24:]; len(buf) >= 16; {
+		captured := int(le.Uint32(buf[8:]))
+		if length := int(le.Uint32(buf[12:])); length != captured {
+			t.Fatalf("Packet of %d bytes captured as %d.", length,
+				captured)
+		}
+		packets = append(packets, buf[16:16+captured])
+		buf = buf[16+captured:]
+	}
+	if len(buf) != 0 || len(packets) != 3 {
+		t.Fatalf("Expected 3 packets, but got %d (and %d bytes more).",
+			len(packets), len(buf))
+	}
+	want := []struct {
+		dir    byte
+		opcode byte
+		length int
+	}{
+		{pcapToServer, 127, 4},
+		{pcapToServer, 43, 4},
+		{pcapFromServer, 1, 32},
+	}
+	for i, w := range want {
+		p := packets[i]
+		if p[0] != w.dir || p[1] != w.opcode || len(p) != w.length+1 {
+			t.Errorf("Packet %d is (%d, %d, %d bytes), expected "+
+				"(%d, %d, %d bytes).", i, p[0], p[1], len(p)-1,
+				w.dir, w.opcode, w.length)
+		}
+	}
+}
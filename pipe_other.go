@@ -0,0 +1,17 @@
+//go:build !windows
+
+package xgb
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// Named pipes are only supported on Windows. See pipe_windows.go.
+
+func dialNamedPipe(ctx context.Context,
+	path string) (io.ReadWriteCloser, error) {
+
+	return nil, errors.New("named pipes are only supported on Windows")
+}
@@ -0,0 +1,43 @@
+//go:build windows
+
+package xgb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// errorPipeBusy is ERROR_PIPE_BUSY, which opening a named pipe fails with
+// when all its instances are in use.
+const errorPipeBusy syscall.Errno = 231
+
+// dialNamedPipe opens the named pipe 'path' (see ParseDisplay), trying again
+// while it is busy until 'ctx' is done.
+func dialNamedPipe(ctx context.Context,
+	path string) (io.ReadWriteCloser, error) {
+
+	path = filepath.FromSlash(path)
+	if !strings.HasPrefix(path, `\\`) {
+		path = `\\.\pipe\` + path
+	}
+	for {
+		f, err := os.OpenFile(path, os.O_RDWR, 0)
+		if err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, errorPipeBusy) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package present
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/sync"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// Present event types, as in the EventType field of the events.
+const (
+	ConfigureNotify = 0
+	CompleteNotify  = 1
+	IdleNotify      = 2
+)
+
+// eventFuncs maps each Present event type to the function decoding it. They
+// are registered with the connection by Init.
+var eventFuncs = map[uint16]xgb.GenericEventHandler{
+	ConfigureNotify: ConfigureNotifyEventNew,
+	CompleteNotify:  CompleteNotifyEventNew,
+	IdleNotify:      IdleNotifyEventNew,
+}
+
+// ConfigureNotifyEvent is sent when a window selected with
+// EventMaskConfigureNotify changes size or position.
+type ConfigureNotifyEvent struct {
+	Sequence     uint16
+	EventType    uint16
+	Eid          Event
+	Window       xproto.Window
+	X            int16
+	Y            int16
+	Width        uint16
+	Height       uint16
+	OffX         int16
+	OffY         int16
+	PixmapWidth  uint16
+	PixmapHeight uint16
+	PixmapFlags  uint32
+
+	buf []byte
+}
+
+// ConfigureNotifyEventNew constructs a ConfigureNotifyEvent value that
+// implements xgb.Event from a byte slice.
+func ConfigureNotifyEventNew(buf []byte) xgb.Event {
+	return ConfigureNotifyEvent{
+		Sequence:     xgb.Get16(buf[2:]),
+		EventType:    xgb.Get16(buf[8:]),
+		Eid:          Event(xgb.Get32(buf[12:])),
+		Window:       xproto.Window(xgb.Get32(buf[16:])),
+		X:            int16(xgb.Get16(buf[20:])),
+		Y:            int16(xgb.Get16(buf[22:])),
+		Width:        xgb.Get16(buf[24:]),
+		Height:       xgb.Get16(buf[26:]),
+		OffX:         int16(xgb.Get16(buf[28:])),
+		OffY:         int16(xgb.Get16(buf[30:])),
+		PixmapWidth:  xgb.Get16(buf[32:]),
+		PixmapHeight: xgb.Get16(buf[34:]),
+		PixmapFlags:  xgb.Get32(buf[36:]),
+		buf:          buf,
+	}
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v ConfigureNotifyEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the ConfigureNotify event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v ConfigureNotifyEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of ConfigureNotifyEvent.
+func (v ConfigureNotifyEvent) String() string {
+	fieldVals := make([]string, 0, 8)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("Eid: %d", v.Eid))
+	fieldVals = append(fieldVals, xgb.Sprintf("Window: %d", v.Window))
+	fieldVals = append(fieldVals, xgb.Sprintf("X: %d", v.X))
+	fieldVals = append(fieldVals, xgb.Sprintf("Y: %d", v.Y))
+	fieldVals = append(fieldVals, xgb.Sprintf("Width: %d", v.Width))
+	fieldVals = append(fieldVals, xgb.Sprintf("Height: %d", v.Height))
+	fieldVals = append(fieldVals, xgb.Sprintf("PixmapFlags: %d",
+		v.PixmapFlags))
+	return "ConfigureNotify {" + xgb.StringsJoin(fieldVals, ", ") + "}"
+}
+
+// Kinds of CompleteNotifyEvent.
+const (
+	CompleteKindPixmap    = 0
+	CompleteKindNotifyMSC = 1
+)
+
+// Modes of CompleteNotifyEvent, i.e., how a pixmap was presented.
+const (
+	CompleteModeCopy           = 0
+	CompleteModeFlip           = 1
+	CompleteModeSkip           = 2
+	CompleteModeSuboptimalCopy = 3
+)
+
+// CompleteNotifyEvent is sent when a Pixmap or NotifyMSC request completes,
+// to windows selected with EventMaskCompleteNotify.
+type CompleteNotifyEvent struct {
+	Sequence  uint16
+	EventType uint16
+	Kind      byte // one of the CompleteKind* constants
+	Mode      byte // one of the CompleteMode* constants
+	Eid       Event
+	Window    xproto.Window
+	Serial    uint32
+	Ust       uint64 // the system time of the presentation, in microseconds
+	Msc       uint64 // the MSC of the presentation
+
+	buf []byte
+}
+
+// CompleteNotifyEventNew constructs a CompleteNotifyEvent value that
+// implements xgb.Event from a byte slice.
+func CompleteNotifyEventNew(buf []byte) xgb.Event {
+	return CompleteNotifyEvent{
+		Sequence:  xgb.Get16(buf[2:]),
+		EventType: xgb.Get16(buf[8:]),
+		Kind:      buf[10],
+		Mode:      buf[11],
+		Eid:       Event(xgb.Get32(buf[12:])),
+		Window:    xproto.Window(xgb.Get32(buf[16:])),
+		Serial:    xgb.Get32(buf[20:]),
+		Ust:       xgb.Get64(buf[24:]),
+		Msc:       xgb.Get64(buf[32:]),
+		buf:       buf,
+	}
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v CompleteNotifyEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the CompleteNotify event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v CompleteNotifyEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of CompleteNotifyEvent.
+func (v CompleteNotifyEvent) String() string {
+	fieldVals := make([]string, 0, 8)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("Kind: %d", v.Kind))
+	fieldVals = append(fieldVals, xgb.Sprintf("Mode: %d", v.Mode))
+	fieldVals = append(fieldVals, xgb.Sprintf("Eid: %d", v.Eid))
+	fieldVals = append(fieldVals, xgb.Sprintf("Window: %d", v.Window))
+	fieldVals = append(fieldVals, xgb.Sprintf("Serial: %d", v.Serial))
+	fieldVals = append(fieldVals, xgb.Sprintf("Ust: %d", v.Ust))
+	fieldVals = append(fieldVals, xgb.Sprintf("Msc: %d", v.Msc))
+	return "CompleteNotify {" + xgb.StringsJoin(fieldVals, ", ") + "}"
+}
+
+// IdleNotifyEvent is sent when the X server no longer uses a pixmap given to
+// Pixmap, to windows selected with EventMaskIdleNotify.
+type IdleNotifyEvent struct {
+	Sequence  uint16
+	EventType uint16
+	Eid       Event
+	Window    xproto.Window
+	Serial    uint32
+	Pixmap    xproto.Pixmap
+	IdleFence sync.Fence
+
+	buf []byte
+}
+
+// IdleNotifyEventNew constructs an IdleNotifyEvent value that implements
+// xgb.Event from a byte slice.
+func IdleNotifyEventNew(buf []byte) xgb.Event {
+	return IdleNotifyEvent{
+		Sequence:  xgb.Get16(buf[2:]),
+		EventType: xgb.Get16(buf[8:]),
+		Eid:       Event(xgb.Get32(buf[12:])),
+		Window:    xproto.Window(xgb.Get32(buf[16:])),
+		Serial:    xgb.Get32(buf[20:]),
+		Pixmap:    xproto.Pixmap(xgb.Get32(buf[24:])),
+		IdleFence: sync.Fence(xgb.Get32(buf[28:])),
+		buf:       buf,
+	}
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v IdleNotifyEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the IdleNotify event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v IdleNotifyEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of IdleNotifyEvent.
+func (v IdleNotifyEvent) String() string {
+	fieldVals := make([]string, 0, 6)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("Eid: %d", v.Eid))
+	fieldVals = append(fieldVals, xgb.Sprintf("Window: %d", v.Window))
+	fieldVals = append(fieldVals, xgb.Sprintf("Serial: %d", v.Serial))
+	fieldVals = append(fieldVals, xgb.Sprintf("Pixmap: %d", v.Pixmap))
+	fieldVals = append(fieldVals, xgb.Sprintf("IdleFence: %d", v.IdleFence))
+	return "IdleNotify {" + xgb.StringsJoin(fieldVals, ", ") + "}"
+}
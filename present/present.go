@@ -0,0 +1,421 @@
+// Package present is the X client API for the Present extension, which
+// presents the contents of pixmaps in windows in sync with the vertical
+// refresh of the screen.
+//
+// The events of the Present extension are X Generic Events, which xgbgen
+// doesn't know how to generate. So like the xinput2 package, this package
+// was written by hand from presentproto.h, following the conventions of the
+// generated packages.
+package present
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/randr"
+	"github.com/BurntSushi/xgb/sync"
+	"github.com/BurntSushi/xgb/xfixes"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// MajorVersion and MinorVersion are the version of the Present extension
+// implemented by this package. They should be passed to QueryVersion.
+const (
+	MajorVersion = 1
+	MinorVersion = 2
+)
+
+// Init must be called before using the Present extension. It also registers
+// the decoders of Present events with 'c'.
+//
+// Since the major opcode of the extension may change, Init must be called
+// again after reconnecting (i.e., in a hook given to xgb.Conn.OnReconnect).
+func Init(c *xgb.Conn) error {
+	reply, err := xproto.QueryExtension(c, 7, "Present").Reply()
+	switch {
+	case err != nil:
+		return err
+	case !reply.Present:
+		return xproto.ExtNotAvailableError{Name: "Present",
+			Reply: reply}
+	}
+
+	xgb.ExtLock.Lock()
+	c.Extensions["Present"] = reply.MajorOpcode
+	xgb.ExtLock.Unlock()
+
+	for evtype, fun := range eventFuncs {
+		c.RegisterGenericEventHandler(reply.MajorOpcode, evtype, fun)
+	}
+	return nil
+}
+
+// checkInit panics if Init hasn't been called. 'request' is the name of the
+// request about to be sent.
+func checkInit(c *xgb.Conn, request string) {
+	if _, ok := c.Extensions["Present"]; !ok {
+		panic("Cannot issue request '" + request + "' using the " +
+			"uninitialized extension 'Present'. " +
+			"present.Init(connObj) must be called first.")
+	}
+}
+
+// requestHeader writes the header of the Present request with the given
+// opcode to the start of 'buf', which must be as long as the request.
+func requestHeader(c *xgb.Conn, buf []byte, opcode byte) {
+	buf[0] = c.Extensions["Present"]
+	buf[1] = opcode
+	xgb.Put16(buf[2:], uint16(len(buf)/4))
+}
+
+// Event is the id of a selection of Present events, as made by SelectInput.
+type Event uint32
+
+// NewEventId allocates a new id for an Event.
+func NewEventId(c *xgb.Conn) (Event, error) {
+	id, err := c.NewId()
+	if err != nil {
+		return 0, err
+	}
+	return Event(id), nil
+}
+
+// Options of Pixmap.
+const (
+	OptionNone       = 0
+	OptionAsync      = 1 << 0
+	OptionCopy       = 1 << 1
+	OptionUST        = 1 << 2
+	OptionSuboptimal = 1 << 3
+)
+
+// Capabilities, as returned by QueryCapabilities.
+const (
+	CapabilityNone  = 0
+	CapabilityAsync = 1
+	CapabilityFence = 2
+	CapabilityUST   = 4
+)
+
+// Event masks, as used by SelectInput.
+const (
+	EventMaskNoEvent         = 0
+	EventMaskConfigureNotify = 1
+	EventMaskCompleteNotify  = 2
+	EventMaskIdleNotify      = 4
+)
+
+// Notify asks for a CompleteNotifyEvent for 'Window' with serial number
+// 'Serial' when a presentation completes.
+type Notify struct {
+	Window xproto.Window
+	Serial uint32
+}
+
+// QueryVersionCookie is a cookie used only for QueryVersion requests.
+type QueryVersionCookie struct {
+	*xgb.Cookie
+}
+
+// QueryVersion sends a checked request.
+// If an error occurs, it will be returned with the reply by calling
+// QueryVersionCookie.Reply()
+func QueryVersion(c *xgb.Conn, MajorVersion uint32,
+	MinorVersion uint32) QueryVersionCookie {
+
+	checkInit(c, "QueryVersion")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(queryVersionRequest(c, MajorVersion, MinorVersion), cookie)
+	return QueryVersionCookie{cookie}
+}
+
+// QueryVersionUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func QueryVersionUnchecked(c *xgb.Conn, MajorVersion uint32,
+	MinorVersion uint32) QueryVersionCookie {
+
+	checkInit(c, "QueryVersion")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(queryVersionRequest(c, MajorVersion, MinorVersion), cookie)
+	return QueryVersionCookie{cookie}
+}
+
+// QueryVersionReply represents the data returned from a QueryVersion request.
+type QueryVersionReply struct {
+	Sequence     uint16 // sequence number of the request for this reply
+	Length       uint32 // number of bytes in this reply
+	MajorVersion uint32
+	MinorVersion uint32
+}
+
+// Reply blocks and returns the reply data for a QueryVersion request.
+func (cook QueryVersionCookie) Reply() (*QueryVersionReply, error) {
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return &QueryVersionReply{
+		Sequence:     xgb.Get16(buf[2:]),
+		Length:       xgb.Get32(buf[4:]),
+		MajorVersion: xgb.Get32(buf[8:]),
+		MinorVersion: xgb.Get32(buf[12:]),
+	}, nil
+}
+
+// queryVersionRequest writes a QueryVersion request to a byte slice.
+func queryVersionRequest(c *xgb.Conn, MajorVersion uint32,
+	MinorVersion uint32) []byte {
+
+	buf := make([]byte, 12)
+	requestHeader(c, buf, 0)
+	xgb.Put32(buf[4:], MajorVersion)
+	xgb.Put32(buf[8:], MinorVersion)
+	return buf
+}
+
+// PixmapCookie is a cookie used only for Pixmap requests.
+type PixmapCookie struct {
+	*xgb.Cookie
+}
+
+// Pixmap sends an unchecked request. It presents 'Pixmap' in 'Window' once
+// the current MSC (media stream counter) of the CRTC reaches 'TargetMsc', or
+// satisfies 'Remainder' modulo 'Divisor' if it is already past it.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func Pixmap(c *xgb.Conn, Window xproto.Window, Pixmap xproto.Pixmap,
+	Serial uint32, Valid xfixes.Region, Update xfixes.Region, XOff int16,
+	YOff int16, TargetCrtc randr.Crtc, WaitFence sync.Fence,
+	IdleFence sync.Fence, Options uint32, TargetMsc uint64, Divisor uint64,
+	Remainder uint64, Notifies []Notify) PixmapCookie {
+
+	checkInit(c, "Pixmap")
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(pixmapRequest(c, Window, Pixmap, Serial, Valid, Update,
+		XOff, YOff, TargetCrtc, WaitFence, IdleFence, Options,
+		TargetMsc, Divisor, Remainder, Notifies), cookie)
+	return PixmapCookie{cookie}
+}
+
+// PixmapChecked sends a checked request.
+// If an error occurs, it can be retrieved using PixmapCookie.Check()
+func PixmapChecked(c *xgb.Conn, Window xproto.Window, Pixmap xproto.Pixmap,
+	Serial uint32, Valid xfixes.Region, Update xfixes.Region, XOff int16,
+	YOff int16, TargetCrtc randr.Crtc, WaitFence sync.Fence,
+	IdleFence sync.Fence, Options uint32, TargetMsc uint64, Divisor uint64,
+	Remainder uint64, Notifies []Notify) PixmapCookie {
+
+	checkInit(c, "Pixmap")
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(pixmapRequest(c, Window, Pixmap, Serial, Valid, Update,
+		XOff, YOff, TargetCrtc, WaitFence, IdleFence, Options,
+		TargetMsc, Divisor, Remainder, Notifies), cookie)
+	return PixmapCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook PixmapCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// pixmapRequest writes a Pixmap request to a byte slice.
+func pixmapRequest(c *xgb.Conn, Window xproto.Window, Pixmap xproto.Pixmap,
+	Serial uint32, Valid xfixes.Region, Update xfixes.Region, XOff int16,
+	YOff int16, TargetCrtc randr.Crtc, WaitFence sync.Fence,
+	IdleFence sync.Fence, Options uint32, TargetMsc uint64, Divisor uint64,
+	Remainder uint64, Notifies []Notify) []byte {
+
+	buf := make([]byte, 72+len(Notifies)*8)
+	requestHeader(c, buf, 1)
+	xgb.Put32(buf[4:], uint32(Window))
+	xgb.Put32(buf[8:], uint32(Pixmap))
+	xgb.Put32(buf[12:], Serial)
+	xgb.Put32(buf[16:], uint32(Valid))
+	xgb.Put32(buf[20:], uint32(Update))
+	xgb.Put16(buf[24:], uint16(XOff))
+	xgb.Put16(buf[26:], uint16(YOff))
+	xgb.Put32(buf[28:], uint32(TargetCrtc))
+	xgb.Put32(buf[32:], uint32(WaitFence))
+	xgb.Put32(buf[36:], uint32(IdleFence))
+	xgb.Put32(buf[40:], Options)
+	xgb.Put64(buf[48:], TargetMsc)
+	xgb.Put64(buf[56:], Divisor)
+	xgb.Put64(buf[64:], Remainder)
+	for i, notify := range Notifies {
+		xgb.Put32(buf[72+i*8:], uint32(notify.Window))
+		xgb.Put32(buf[76+i*8:], notify.Serial)
+	}
+	return buf
+}
+
+// NotifyMSCCookie is a cookie used only for NotifyMSC requests.
+type NotifyMSCCookie struct {
+	*xgb.Cookie
+}
+
+// NotifyMSC sends an unchecked request. It asks for a CompleteNotifyEvent
+// (of kind CompleteKindNotifyMSC) when the MSC of the CRTC showing 'Window'
+// reaches 'TargetMsc', as in Pixmap.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func NotifyMSC(c *xgb.Conn, Window xproto.Window, Serial uint32,
+	TargetMsc uint64, Divisor uint64, Remainder uint64) NotifyMSCCookie {
+
+	checkInit(c, "NotifyMSC")
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(notifyMSCRequest(c, Window, Serial, TargetMsc, Divisor,
+		Remainder), cookie)
+	return NotifyMSCCookie{cookie}
+}
+
+// NotifyMSCChecked sends a checked request.
+// If an error occurs, it can be retrieved using NotifyMSCCookie.Check()
+func NotifyMSCChecked(c *xgb.Conn, Window xproto.Window, Serial uint32,
+	TargetMsc uint64, Divisor uint64, Remainder uint64) NotifyMSCCookie {
+
+	checkInit(c, "NotifyMSC")
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(notifyMSCRequest(c, Window, Serial, TargetMsc, Divisor,
+		Remainder), cookie)
+	return NotifyMSCCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook NotifyMSCCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// notifyMSCRequest writes a NotifyMSC request to a byte slice.
+func notifyMSCRequest(c *xgb.Conn, Window xproto.Window, Serial uint32,
+	TargetMsc uint64, Divisor uint64, Remainder uint64) []byte {
+
+	buf := make([]byte, 40)
+	requestHeader(c, buf, 2)
+	xgb.Put32(buf[4:], uint32(Window))
+	xgb.Put32(buf[8:], Serial)
+	xgb.Put64(buf[16:], TargetMsc)
+	xgb.Put64(buf[24:], Divisor)
+	xgb.Put64(buf[32:], Remainder)
+	return buf
+}
+
+// SelectInputCookie is a cookie used only for SelectInput requests.
+type SelectInputCookie struct {
+	*xgb.Cookie
+}
+
+// SelectInput sends an unchecked request. It selects the Present events in
+// 'EventMask' (a combination of EventMask* constants) for 'Window'. The
+// selection is identified by 'Eid', which is included in the events, and is
+// removed by selecting EventMaskNoEvent again.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func SelectInput(c *xgb.Conn, Eid Event, Window xproto.Window,
+	EventMask uint32) SelectInputCookie {
+
+	checkInit(c, "SelectInput")
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(selectInputRequest(c, Eid, Window, EventMask), cookie)
+	return SelectInputCookie{cookie}
+}
+
+// SelectInputChecked sends a checked request.
+// If an error occurs, it can be retrieved using SelectInputCookie.Check()
+func SelectInputChecked(c *xgb.Conn, Eid Event, Window xproto.Window,
+	EventMask uint32) SelectInputCookie {
+
+	checkInit(c, "SelectInput")
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(selectInputRequest(c, Eid, Window, EventMask), cookie)
+	return SelectInputCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook SelectInputCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// selectInputRequest writes a SelectInput request to a byte slice.
+func selectInputRequest(c *xgb.Conn, Eid Event, Window xproto.Window,
+	EventMask uint32) []byte {
+
+	buf := make([]byte, 16)
+	requestHeader(c, buf, 3)
+	xgb.Put32(buf[4:], uint32(Eid))
+	xgb.Put32(buf[8:], uint32(Window))
+	xgb.Put32(buf[12:], EventMask)
+	return buf
+}
+
+// QueryCapabilitiesCookie is a cookie used only for QueryCapabilities
+// requests.
+type QueryCapabilitiesCookie struct {
+	*xgb.Cookie
+}
+
+// QueryCapabilities sends a checked request. 'Target' is a window or
+// a randr.Crtc.
+// If an error occurs, it will be returned with the reply by calling
+// QueryCapabilitiesCookie.Reply()
+func QueryCapabilities(c *xgb.Conn, Target uint32) QueryCapabilitiesCookie {
+	checkInit(c, "QueryCapabilities")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(queryCapabilitiesRequest(c, Target), cookie)
+	return QueryCapabilitiesCookie{cookie}
+}
+
+// QueryCapabilitiesUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func QueryCapabilitiesUnchecked(c *xgb.Conn,
+	Target uint32) QueryCapabilitiesCookie {
+
+	checkInit(c, "QueryCapabilities")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(queryCapabilitiesRequest(c, Target), cookie)
+	return QueryCapabilitiesCookie{cookie}
+}
+
+// QueryCapabilitiesReply represents the data returned from
+// a QueryCapabilities request.
+type QueryCapabilitiesReply struct {
+	Sequence     uint16 // sequence number of the request for this reply
+	Length       uint32 // number of bytes in this reply
+	Capabilities uint32 // a combination of the Capability* constants
+}
+
+// Reply blocks and returns the reply data for a QueryCapabilities request.
+func (cook QueryCapabilitiesCookie) Reply() (*QueryCapabilitiesReply,
+	error) {
+
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return &QueryCapabilitiesReply{
+		Sequence:     xgb.Get16(buf[2:]),
+		Length:       xgb.Get32(buf[4:]),
+		Capabilities: xgb.Get32(buf[8:]),
+	}, nil
+}
+
+// queryCapabilitiesRequest writes a QueryCapabilities request to a byte
+// slice.
+func queryCapabilitiesRequest(c *xgb.Conn, Target uint32) []byte {
+	buf := make([]byte, 8)
+	requestHeader(c, buf, 4)
+	xgb.Put32(buf[4:], Target)
+	return buf
+}
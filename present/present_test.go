@@ -0,0 +1,113 @@
+package present
+
+import (
+	"os"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestCompleteNotifyEvent decodes a CompleteNotify event with the handler
+// registered by Init.
+func TestCompleteNotifyEvent(t *testing.T) {
+	buf := make([]byte, 40)
+	buf[0] = xgb.GenericEventCode
+	buf[1] = 140
+	xgb.Put16(buf[2:], 9)
+	xgb.Put32(buf[4:], 2)
+	xgb.Put16(buf[8:], CompleteNotify)
+	buf[10] = CompleteKindPixmap
+	buf[11] = CompleteModeFlip
+	xgb.Put32(buf[12:], 0x200001)
+	xgb.Put32(buf[16:], 0x400000)
+	xgb.Put32(buf[20:], 42)
+	xgb.Put64(buf[24:], 1<<40)
+	xgb.Put64(buf[32:], 1000)
+
+	ev, ok := eventFuncs[CompleteNotify](buf).(CompleteNotifyEvent)
+	if !ok {
+		t.Fatalf("The CompleteNotify handler didn't return " +
+			"a CompleteNotifyEvent.")
+	}
+	want := CompleteNotifyEvent{
+		Sequence:  9,
+		EventType: CompleteNotify,
+		Kind:      CompleteKindPixmap,
+		Mode:      CompleteModeFlip,
+		Eid:       0x200001,
+		Window:    0x400000,
+		Serial:    42,
+		Ust:       1 << 40,
+		Msc:       1000,
+		buf:       buf,
+	}
+	if ev.String() != want.String() {
+		t.Fatalf("Expected\n%s\nbut got\n%s", want, ev)
+	}
+}
+
+// TestSelectInput selects Present events for a new window on the X server
+// in DISPLAY, and is skipped when it isn't set.
+func TestSelectInput(t *testing.T) {
+	if len(os.Getenv("DISPLAY")) == 0 {
+		t.Skip("DISPLAY is not set")
+	}
+	X, err := xgb.NewConn()
+	if err != nil {
+		t.Fatalf("NewConn: %s", err)
+	}
+	defer X.Close()
+	if err := Init(X); err != nil {
+		t.Skipf("Present is not available: %s", err)
+	}
+	_, err = QueryVersion(X, MajorVersion, MinorVersion).Reply()
+	if err != nil {
+		t.Fatalf("QueryVersion: %s", err)
+	}
+
+	screen := xproto.Setup(X).DefaultScreen(X)
+	win, err := xproto.NewWindowId(X)
+	if err != nil {
+		t.Fatalf("NewWindowId: %s", err)
+	}
+	err = xproto.CreateWindowChecked(X, screen.RootDepth, win, screen.Root,
+		0, 0, 10, 10, 0, xproto.WindowClassInputOutput,
+		screen.RootVisual, 0, nil).Check()
+	if err != nil {
+		t.Fatalf("CreateWindow: %s", err)
+	}
+	defer xproto.DestroyWindow(X, win)
+
+	eid, err := NewEventId(X)
+	if err != nil {
+		t.Fatalf("NewEventId: %s", err)
+	}
+	mask := uint32(EventMaskCompleteNotify | EventMaskIdleNotify)
+	if err := SelectInputChecked(X, eid, win, mask).Check(); err != nil {
+		t.Fatalf("SelectInput: %s", err)
+	}
+
+	// Ask for a CompleteNotify event right away (the MSC is already past
+	// 0), and wait for it to be delivered through WaitForEvent.
+	NotifyMSC(X, win, 1, 0, 0, 0)
+	for {
+		ev, err := X.WaitForEvent()
+		if err != nil {
+			t.Fatalf("WaitForEvent: %s", err)
+		}
+		if ev == nil {
+			t.Fatalf("The connection was closed.")
+		}
+		if complete, ok := ev.(CompleteNotifyEvent); ok {
+			if complete.Kind != CompleteKindNotifyMSC ||
+				complete.Serial != 1 || complete.Eid != eid {
+
+				t.Fatalf("Wrong CompleteNotify event: %s",
+					complete)
+			}
+			return
+		}
+	}
+}
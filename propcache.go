@@ -0,0 +1,195 @@
+package xgb
+
+import (
+	"sync"
+)
+
+// The major opcodes of the requests that cachedProperty looks at.
+const (
+	destroyWindowOpcode    = 4
+	changePropertyOpcode   = 18
+	deletePropertyOpcode   = 19
+	getPropertyOpcode      = 20
+	rotatePropertiesOpcode = 114
+)
+
+// propertyNotify is the event code of PropertyNotify events, which
+// invalidate cached properties.
+const propertyNotify = 28
+
+// propertyKey identifies a property of a window in the property cache.
+type propertyKey struct {
+	window, atom uint32
+}
+
+// propertyRequest is a GetProperty request whose reply is to be stored in
+// the property cache. 'args' are the raw type, long-offset and long-length
+// arguments of the request, which the reply depends on too. 'gen' is the
+// generation of the property when the request was made.
+type propertyRequest struct {
+	key  propertyKey
+	args [12]byte
+	gen  uint64
+}
+
+// propertyEntry is a cached GetProperty reply to the request with 'args'.
+type propertyEntry struct {
+	args  [12]byte
+	reply []byte
+}
+
+// propertyCache maps properties to the last reply to a GetProperty request
+// for them. See EnablePropertyCache.
+//
+// 'gens' holds the generation of every property a GetProperty request has
+// been made for, which goes up each time the property is invalidated. The
+// reply to a request made before that has the value from before the change,
+// so it isn't cached. Properties that aren't in 'gens' anymore (e.g., once
+// their window is destroyed) have no reply cached either.
+type propertyCache struct {
+	lock    sync.RWMutex
+	entries map[propertyKey]propertyEntry
+	gens    map[propertyKey]uint64
+}
+
+// EnablePropertyCache makes the connection remember the replies to
+// GetProperty requests, so that asking for the same property of the same
+// window again (with the same type, offset and length) is answered without
+// a round trip to the X server. Entries are dropped when a PropertyNotify
+// event for the property arrives, and when a request made on the connection
+// changes or deletes it, or destroys the window.
+//
+// Since the X server only sends PropertyNotify events to clients that ask
+// for them, PropertyChange events must be selected on every window whose
+// properties are read while the cache is enabled. Otherwise, cached values
+// go stale. Only requests made with NewRequest (as xproto.GetProperty does)
+// use the cache, which is safe for use by any number of goroutines. The cache
+// is emptied by Reconnect, since windows don't survive it.
+func (c *Conn) EnablePropertyCache() {
+	c = c.socket()
+	c.propCache.CompareAndSwap(nil, &propertyCache{
+		entries: make(map[propertyKey]propertyEntry),
+		gens:    make(map[propertyKey]uint64),
+	})
+}
+
+// cachedProperty answers the request in 'buf' from the property cache, if
+// it is enabled and the request is a GetProperty request it has the reply
+// to. It returns whether it did, in which case the request must not be sent.
+// Otherwise, 'cookie' is marked to have its reply cached.
+//
+// Requests that change properties or destroy windows drop their properties
+// from the cache right away. So a GetProperty request made after them is
+// sent to the X server (which handles requests in order) instead of being
+// answered with the old value.
+func (c *Conn) cachedProperty(buf []byte, cookie *Cookie) bool {
+	cache := c.propCache.Load()
+	if cache == nil || len(buf) < 8 {
+		return false
+	}
+	window := Get32(buf[4:])
+
+	switch buf[0] {
+	case destroyWindowOpcode, rotatePropertiesOpcode:
+		cache.invalidateWindow(window)
+		return false
+	case changePropertyOpcode, deletePropertyOpcode, getPropertyOpcode:
+		if len(buf) < 12 {
+			return false
+		}
+	default:
+		return false
+	}
+	key := propertyKey{window, Get32(buf[8:])}
+	if buf[0] != getPropertyOpcode || buf[1] != 0 { // or with delete set
+		cache.invalidate(key)
+		return false
+	}
+	if len(buf) < 24 || cookie.replyChan == nil {
+		return false
+	}
+
+	req := &propertyRequest{key: key}
+	copy(req.args[:], buf[12:24])
+	cache.lock.RLock()
+	entry, ok := cache.entries[key]
+	cache.lock.RUnlock()
+	if ok && entry.args == req.args {
+		cookie.replyChan <- append([]byte(nil), entry.reply...)
+		return true
+	}
+
+	cache.lock.Lock()
+	req.gen = cache.gens[key]
+	cache.gens[key] = req.gen
+	cache.lock.Unlock()
+	cookie.property = req
+	return false
+}
+
+// cacheProperty stores the reply to the GetProperty request 'req' in the
+// property cache, unless the property has been invalidated since the request
+// was made.
+func (c *Conn) cacheProperty(req *propertyRequest, reply []byte) {
+	cache := c.propCache.Load()
+	if cache == nil {
+		return
+	}
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if gen, ok := cache.gens[req.key]; !ok || gen != req.gen {
+		return
+	}
+	cache.entries[req.key] = propertyEntry{
+		args:  req.args,
+		reply: append([]byte(nil), reply...),
+	}
+}
+
+// invalidateProperty drops the property a PropertyNotify event in 'buf' is
+// about from the property cache.
+func (c *Conn) invalidateProperty(buf []byte) {
+	if cache := c.propCache.Load(); cache != nil {
+		cache.invalidate(propertyKey{Get32(buf[4:]), Get32(buf[8:])})
+	}
+}
+
+// invalidate drops the entry for 'key', and moves the property on to its
+// next generation.
+func (cache *propertyCache) invalidate(key propertyKey) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	delete(cache.entries, key)
+	if gen, ok := cache.gens[key]; ok {
+		cache.gens[key] = gen + 1
+	}
+}
+
+// invalidateWindow drops the entries and generations of every property of
+// 'window'.
+func (cache *propertyCache) invalidateWindow(window uint32) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	for key := range cache.entries {
+		if key.window == window {
+			delete(cache.entries, key)
+		}
+	}
+	for key := range cache.gens {
+		if key.window == window {
+			delete(cache.gens, key)
+		}
+	}
+}
+
+// clear drops every entry and generation.
+func (cache *propertyCache) clear() {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.entries = make(map[propertyKey]propertyEntry)
+	cache.gens = make(map[propertyKey]uint64)
+}
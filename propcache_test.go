@@ -0,0 +1,209 @@
+package xgb
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+)
+
+// TestPropertyCache reads the same property three times, and makes sure
+// that only the first and the one after a PropertyNotify event go to the
+// X server.
+func TestPropertyCache(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	c.EnablePropertyCache()
+
+	requests := make(chan []byte, 10)
+	server := <-conns
+	go propertyServer(server, requests)
+
+	getProperty := func(value string) {
+		t.Helper()
+		cookie := c.NewCookie(true, true)
+		c.NewRequest(getPropertyRequest(0x400001, 39), cookie)
+		reply, err := cookie.Reply()
+		if err != nil {
+			t.Fatalf("GetProperty: %s", err)
+		}
+		if got := string(reply[32:36]); got != value {
+			t.Fatalf("Expected the value %q, but got %q.",
+				value, got)
+		}
+	}
+	getProperty("val1")
+	getProperty("val1")
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 GetProperty request, but %d were sent.",
+			len(requests))
+	}
+
+	event := make([]byte, 32)
+	event[0] = propertyNotify
+	Put32(event[4:], 0x400001)
+	Put32(event[8:], 39)
+	server.Write(event)
+	// The reply to a round trip comes after the event.
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(c.getInputFocusRequest(), cookie)
+	if _, err := cookie.Reply(); err != nil {
+		t.Fatalf("GetInputFocus: %s", err)
+	}
+	getProperty("val2")
+	if len(requests) != 3 {
+		t.Fatalf("Expected 2 GetProperty requests and a GetInputFocus "+
+			"request, but %d were sent.", len(requests))
+	}
+}
+
+// TestPropertyCacheChangeProperty makes sure a ChangeProperty request drops
+// the property from the cache before the X server has even seen it.
+func TestPropertyCacheChangeProperty(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	c.EnablePropertyCache()
+
+	requests := make(chan []byte, 10)
+	go propertyServer(<-conns, requests)
+
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(getPropertyRequest(0x400001, 39), cookie)
+	if _, err := cookie.Reply(); err != nil {
+		t.Fatalf("GetProperty: %s", err)
+	}
+
+	change := make([]byte, 24)
+	change[0] = changePropertyOpcode
+	Put16(change[2:], 6)
+	Put32(change[4:], 0x400001)
+	Put32(change[8:], 39)
+	c.NewRequest(change, c.NewCookie(false, false))
+
+	cookie = c.NewCookie(true, true)
+	c.NewRequest(getPropertyRequest(0x400001, 39), cookie)
+	if _, err := cookie.Reply(); err != nil {
+		t.Fatalf("GetProperty: %s", err)
+	}
+	for _, opcode := range []byte{20, 18, 20} {
+		select {
+		case req := <-requests:
+			if req[0] != opcode {
+				t.Fatalf("Expected a request with opcode %d, "+
+					"but got % x", opcode, req)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected a request with opcode %d.", opcode)
+		}
+	}
+}
+
+// TestPropertyCacheCopies makes sure the cache hands out copies of the
+// replies it keeps.
+func TestPropertyCacheCopies(t *testing.T) {
+	c := &Conn{}
+	c.EnablePropertyCache()
+	cookie := c.NewCookie(true, true)
+	if c.cachedProperty(getPropertyRequest(1, 2), cookie) {
+		t.Fatalf("The request was answered from the empty cache.")
+	}
+	reply := []byte("reply")
+	c.cacheProperty(cookie.property, reply)
+	reply[0] = 'X'
+
+	cookie = c.NewCookie(true, true)
+	if !c.cachedProperty(getPropertyRequest(1, 2), cookie) {
+		t.Fatalf("Expected the request to be answered from the cache.")
+	}
+	got := <-cookie.replyChan
+	if !bytes.Equal(got, []byte("reply")) {
+		t.Fatalf("Expected the cached reply %q, but got %q.",
+			"reply", got)
+	}
+}
+
+// TestPropertyCacheStaleReply makes sure the reply to a GetProperty request
+// isn't cached when the property was changed, or its window destroyed,
+// after the request was made (i.e., the reply has the old value).
+func TestPropertyCacheStaleReply(t *testing.T) {
+	c := &Conn{}
+	c.EnablePropertyCache()
+
+	change := make([]byte, 24)
+	change[0] = changePropertyOpcode
+	Put32(change[4:], 1)
+	Put32(change[8:], 2)
+	destroy := make([]byte, 8)
+	destroy[0] = destroyWindowOpcode
+	Put32(destroy[4:], 1)
+
+	for _, req := range [][]byte{change, destroy} {
+		cookie := c.NewCookie(true, true)
+		c.cachedProperty(getPropertyRequest(1, 2), cookie)
+		c.cachedProperty(req, c.NewCookie(false, false))
+		c.cacheProperty(cookie.property, []byte("old"))
+
+		cookie = c.NewCookie(true, true)
+		if c.cachedProperty(getPropertyRequest(1, 2), cookie) {
+			t.Fatalf("The reply from before the request with "+
+				"opcode %d was cached.", req[0])
+		}
+	}
+}
+
+// getPropertyRequest returns a GetProperty request for the whole value of
+// property 'atom' of 'window', of any type.
+func getPropertyRequest(window, atom uint32) []byte {
+	buf := make([]byte, 24)
+	buf[0] = getPropertyOpcode
+	Put16(buf[2:], 6)
+	Put32(buf[4:], window)
+	Put32(buf[8:], atom)
+	Put32(buf[20:], 0xffffffff)
+	return buf
+}
+
+// propertyServer sends the requests read from 'conn' to 'requests', and
+// answers GetProperty requests with 4 bytes of STRING data: "val" followed by
+// the number of GetProperty requests so far. GetInputFocus requests get an
+// empty reply.
+func propertyServer(conn net.Conn, requests chan<- []byte) {
+	n := byte(0)
+	for seq := uint16(1); ; seq++ {
+		req, err := readRequest(conn)
+		if err != nil {
+			return
+		}
+		requests <- req
+		if req[0] == 43 {
+			reply := make([]byte, 32)
+			reply[0] = 1
+			Put16(reply[2:], seq)
+			conn.Write(reply)
+		}
+		if req[0] != getPropertyOpcode {
+			continue
+		}
+
+		n++
+		reply := make([]byte, 36)
+		reply[0] = 1
+		reply[1] = 8 // format
+		Put16(reply[2:], seq)
+		Put32(reply[4:], 1)
+		Put32(reply[8:], 31) // STRING
+		Put32(reply[16:], 4)
+		copy(reply[32:], []byte{'v', 'a', 'l', '0' + n})
+		if _, err := conn.Write(reply); err != nil {
+			return
+		}
+	}
+}
@@ -20,7 +20,7 @@ func Init(c *xgb.Conn) error {
 	case err != nil:
 		return err
 	case !reply.Present:
-		return xgb.Errorf("No extension named RANDR could be found on on the server.")
+		return xproto.ExtNotAvailableError{Name: "RANDR", Reply: reply}
 	}
 
 	xgb.ExtLock.Lock()
@@ -0,0 +1,176 @@
+package xgb
+
+import (
+	"context"
+	"errors"
+)
+
+// errReconnecting is sent to cookies still waiting for a response when
+// Reconnect tears down the connection they were sent over.
+var errReconnecting = errors.New("the request was lost when reconnecting " +
+	"to the X server")
+
+// errNoDisplay is returned by Reconnect for connections made with
+// NewConnNet.
+var errNoDisplay = errors.New("cannot reconnect without a display to " +
+	"connect to")
+
+// OnReconnect registers a function that is called every time Reconnect has
+// successfully connected to the X server again. Since resources do not
+// survive a new connection, this is where windows, pixmaps, etc. should be
+// re-created. Hooks are run in the order they were registered, and the first
+// error returned by a hook is returned by Reconnect.
+func (c *Conn) OnReconnect(hook func(*Conn) error) {
+	c = c.socket()
+	c.reconnectLock.Lock()
+	defer c.reconnectLock.Unlock()
+
+	c.reconnectHooks = append(c.reconnectHooks, hook)
+}
+
+// Reconnect closes the current connection to the X server (if it hasn't
+// been lost already) and connects to the same display again. This is
+// useful to survive a restart of the X server. It fails once Close has been
+// called.
+//
+// Cookies still waiting for a response from the old connection fail, as do
+// requests made after it was lost and before Reconnect was called. Requests
+// made while reconnecting are sent once the new connection has been
+// established, or fail if it can't be. Every extension initialized before
+// is initialized again, and then the hooks registered with OnReconnect are
+// run.
+//
+// Note that the setup information (i.e., SetupBytes) is replaced, so it
+// should be parsed again with xproto.Setup. Also note that sequence numbers
+// start over, and that the caches returned by Cache (e.g., that of
+// xproto.InternAtomCached) are emptied, since the X server may have been
+// restarted.
+func (c *Conn) Reconnect(ctx context.Context) error {
+	if c.parent != nil {
+		return errVirtualReconnect
+	}
+	c.reconnectLock.Lock()
+	defer c.reconnectLock.Unlock()
+
+	if c.netGiven {
+		return errNoDisplay
+	}
+	if c.isClosed() {
+		return errClosed
+	}
+	c.stop(c.currentDone(), errReconnecting)
+	c.setFailErr(nil)
+	if err := c.connect(ctx, c.displayName); err != nil {
+		// The requests made in the mean time won't be sent after all.
+		reason := c.stopReason()
+		if c.isClosed() {
+			reason = errClosed
+		}
+		c.setFailErr(reason)
+		return err
+	}
+
+	c.stopLock.Lock()
+	if c.closed {
+		c.stopLock.Unlock()
+		c.conn.Close()
+		c.setFailErr(errClosed)
+		return errClosed
+	}
+	c.start()
+	c.stopLock.Unlock()
+
+	c.geLock.Lock()
+	c.geQueried = false
+	c.geLock.Unlock()
+	if cache := c.propCache.Load(); cache != nil {
+		cache.clear()
+	}
+	c.clearCaches()
+	c.idLock.Lock()
+	c.spareIds = nil
+	c.idLock.Unlock()
+	c.reconnectVirtuals()
+
+	if err := c.reinitExtensions(); err != nil {
+		return err
+	}
+	for _, hook := range c.reconnectHooks {
+		if err := hook(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isClosed returns whether Close has been called.
+func (c *Conn) isClosed() bool {
+	c.stopLock.Lock()
+	defer c.stopLock.Unlock()
+
+	return c.closed
+}
+
+// reinitExtensions does what the Init function of each extension package
+// does for every extension that has been initialized so far, since the
+// opcodes assigned to extensions may be different on the new connection.
+func (c *Conn) reinitExtensions() error {
+	ExtLock.Lock()
+	names := make([]string, 0, len(c.Extensions))
+	for name := range c.Extensions {
+		names = append(names, name)
+	}
+	ExtLock.Unlock()
+
+	for _, name := range names {
+		cookie := c.NewCookie(true, true)
+		c.NewRequest(c.queryExtensionRequest(name), cookie)
+		reply, err := cookie.Reply()
+		if err != nil {
+			return err
+		}
+
+		present, major, firstEvent, firstError :=
+			reply[8] == 1, reply[9], reply[10], reply[11]
+		if !present {
+			return Errorf("The %s extension is no longer "+
+				"available.", name)
+		}
+
+		ExtLock.Lock()
+		c.Extensions[name] = major
+		for evNum, fun := range NewExtEventFuncs[name] {
+			NewEventFuncs[int(firstEvent)+evNum] = fun
+		}
+		for errNum, fun := range NewExtErrorFuncs[name] {
+			NewErrorFuncs[int(firstError)+errNum] = fun
+		}
+		ExtLock.Unlock()
+	}
+	return nil
+}
+
+// queryExtensionRequest writes the raw bytes of a QueryExtension request
+// to a buffer.
+// It is duplicated from xproto/xproto.go.
+func (c *Conn) queryExtensionRequest(name string) []byte {
+	size := Pad(8 + len(name))
+	b := 0
+	buf := make([]byte, size)
+
+	buf[b] = 98 // request opcode
+	b += 1
+
+	b += 1                         // padding
+	Put16(buf[b:], uint16(size/4)) // write request size in 4-byte units
+	b += 2
+
+	Put16(buf[b:], uint16(len(name)))
+	b += 2
+
+	b += 2 // padding
+
+	copy(buf[b:], name)
+
+	return buf
+}
@@ -0,0 +1,240 @@
+package xgb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// TestReconnect kills the connection from the server side, makes sure
+// WaitForEvent notices, and then reconnects.
+func TestReconnect(t *testing.T) {
+	display, conns := setupServer(t)
+	c := newTestConn(t, display)
+
+	type cacheKey struct{}
+	c.Cache(cacheKey{}).Store("atom", uint32(300))
+	if _, ok := c.Cache(cacheKey{}).Load("atom"); !ok {
+		t.Fatalf("The cache lost what was stored in it.")
+	}
+
+	hooks := 0
+	c.OnReconnect(func(*Conn) error {
+		hooks++
+		return nil
+	})
+
+	// Make sure WaitForEvent tells us when the server is gone.
+	(<-conns).Close()
+	evs := make(chan Event)
+	go func() {
+		ev, _ := c.WaitForEvent()
+		evs <- ev
+	}()
+	select {
+	case ev := <-evs:
+		if ev != nil {
+			t.Fatalf("Expected no event, but got %s", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("WaitForEvent did not return after the server quit.")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := c.Reconnect(ctx); err != nil {
+		t.Fatalf("Reconnect: %s", err)
+	}
+	if hooks != 1 {
+		t.Fatalf("Expected the reconnect hook to run once, but it "+
+			"ran %d times.", hooks)
+	}
+	if _, ok := c.Cache(cacheKey{}).Load("atom"); ok {
+		t.Fatalf("Reconnect didn't empty the cache.")
+	}
+
+	// Requests must go out over the new connection, with sequence numbers
+	// starting over.
+	server := <-conns
+	c.NewRequest(c.getInputFocusRequest(), c.NewCookie(false, false))
+	buf := make([]byte, 4)
+	server.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := io.ReadFull(server, buf); err != nil {
+		t.Fatalf("Reading request from the new connection: %s", err)
+	}
+	if buf[0] != 43 {
+		t.Fatalf("Expected a GetInputFocus request, but got opcode %d.",
+			buf[0])
+	}
+}
+
+// TestCloseFailsCookies makes sure that closing the connection unblocks
+// requests still waiting for a reply.
+func TestCloseFailsCookies(t *testing.T) {
+	display, _ := setupServer(t)
+	c := newTestConn(t, display)
+
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(c.getInputFocusRequest(), cookie)
+	errs := make(chan error)
+	go func() {
+		_, err := cookie.Reply()
+		errs <- err
+	}()
+
+	// Give the request a chance to be sent before closing.
+	time.Sleep(10 * time.Millisecond)
+	c.Close()
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Fatalf("Expected an error from a cookie that never " +
+				"got a reply.")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Reply did not return after closing the connection.")
+	}
+}
+
+// TestCloseDrains makes sure that Close waits for the replies to requests
+// in flight, and gives up on a server that doesn't answer in time.
+func TestCloseDrains(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	go replyServer(<-conns)
+
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(c.getInputFocusRequest(), cookie)
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+	if _, err := cookie.Reply(); err != nil {
+		t.Fatalf("The request in flight failed: %s", err)
+	}
+
+	// This server never answers.
+	display, _ = setupServer(t)
+	c, err = NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	c.SetCloseTimeout(50 * time.Millisecond)
+	cookie = c.NewCookie(true, true)
+	c.NewRequest(c.getInputFocusRequest(), cookie)
+	if err := c.Close(); err != errCloseTimeout {
+		t.Fatalf("Expected Close to time out, but got %v.", err)
+	}
+	if _, err := cookie.Reply(); err == nil {
+		t.Fatalf("A request that never got a reply succeeded.")
+	}
+}
+
+// returnsSoon fails the test if 'fn' doesn't return within five seconds.
+func returnsSoon(t *testing.T, what string, fn func()) {
+	t.Helper()
+	returned := make(chan struct{})
+	go func() {
+		defer close(returned)
+		fn()
+	}()
+	select {
+	case <-returned:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("%s did not return.", what)
+	}
+}
+
+// TestRequestsAfterClose makes sure requests made once the connection is
+// closed fail with the reason, instead of waiting for a reply that never
+// comes.
+func TestRequestsAfterClose(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	go replyServer(<-conns)
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+
+	returnsSoon(t, "Reply after Close", func() {
+		cookie := c.NewCookie(true, true)
+		c.NewRequest(c.getInputFocusRequest(), cookie)
+		if _, err := cookie.Reply(); err != errClosed {
+			t.Errorf("Expected '%v', but got %v.", errClosed, err)
+		}
+	})
+	returnsSoon(t, "NewId after Close", func() {
+		if _, err := c.NewId(); err != errClosed {
+			t.Errorf("Expected '%v', but got %v.", errClosed, err)
+		}
+	})
+}
+
+// TestRequestsAfterLoss is like TestRequestsAfterClose, for a connection the
+// X server dropped.
+func TestRequestsAfterLoss(t *testing.T) {
+	display, conns := setupServer(t)
+	c := newTestConn(t, display)
+
+	(<-conns).Close()
+	if ev, err := c.WaitForEventTimeout(5 * time.Second); ev != nil ||
+		err == nil || err == ErrTimeout {
+
+		t.Fatalf("Expected the connection to be lost, but got "+
+			"(%v, %v).", ev, err)
+	}
+
+	var lost ConnectionClosedError
+	returnsSoon(t, "Reply after the connection was lost", func() {
+		cookie := c.NewCookie(true, true)
+		c.NewRequest(c.getInputFocusRequest(), cookie)
+		if _, err := cookie.Reply(); !errors.As(err, &lost) {
+			t.Errorf("Expected a ConnectionClosedError, "+
+				"but got %v.", err)
+		}
+	})
+	returnsSoon(t, "Sync after the connection was lost", c.Sync)
+	returnsSoon(t, "NewId after the connection was lost", func() {
+		if _, err := c.NewId(); !errors.As(err, &lost) {
+			t.Errorf("Expected a ConnectionClosedError, "+
+				"but got %v.", err)
+		}
+	})
+}
+
+// TestCloseIsFinal makes sure a connection that was lost before being
+// closed can't be reconnected, and that requests made afterwards fail with
+// the error saying it was closed.
+func TestCloseIsFinal(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	(<-conns).Close()
+	c.WaitForEventTimeout(5 * time.Second)
+	var lost ConnectionClosedError
+	if err := c.Close(); !errors.As(err, &lost) {
+		t.Fatalf("Expected a ConnectionClosedError, but got %v.", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := c.Reconnect(ctx); err != errClosed {
+		t.Fatalf("Expected '%v', but got %v.", errClosed, err)
+	}
+	returnsSoon(t, "Reply after Close", func() {
+		cookie := c.NewCookie(true, true)
+		c.NewRequest(c.getInputFocusRequest(), cookie)
+		if _, err := cookie.Reply(); err != errClosed {
+			t.Errorf("Expected '%v', but got %v.", errClosed, err)
+		}
+	})
+}
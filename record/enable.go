@@ -0,0 +1,61 @@
+package record
+
+/*
+	EnableContextCallback, which delivers every reply to an EnableContext
+	request. The generated EnableContext only handles the first one, since
+	xgbgen doesn't know that the X server keeps sending replies until the
+	context is disabled. Unlike the rest of this package, this file is not
+	generated.
+*/
+
+import (
+	"github.com/BurntSushi/xgb"
+)
+
+// Categories of the replies to EnableContext, as in the Category field of
+// EnableContextReply.
+const (
+	CategoryFromServer    = 0
+	CategoryFromClient    = 1
+	CategoryClientStarted = 2
+	CategoryClientDied    = 3
+	CategoryStartOfData   = 4
+	CategoryEndOfData     = 5
+)
+
+// EnableContextCallback enables 'Context', and calls 'callback' with every
+// reply the X server sends as protocol is recorded. The first reply has
+// CategoryStartOfData, and the last one, sent once the context is disabled,
+// has CategoryEndOfData. EnableContextCallback returns after the last
+// reply, or when an error occurs.
+//
+// The X server doesn't process any other requests on 'c' while the context
+// is enabled, so 'c' should be a connection dedicated to recording.
+// DisableContext must be sent on another connection. Since the callback is
+// called from the goroutine running EnableContextCallback, it is usually
+// run in a goroutine of its own.
+func EnableContextCallback(c *xgb.Conn, Context Context,
+	callback func(*EnableContextReply)) error {
+
+	if _, ok := c.Extensions["RECORD"]; !ok {
+		panic("Cannot issue request 'EnableContext' using the " +
+			"uninitialized extension 'RECORD'. " +
+			"record.Init(connObj) must be called first.")
+	}
+	cookie := c.NewCookieMulti(true, func(buf []byte) bool {
+		return buf[1] == CategoryEndOfData
+	})
+	c.NewRequest(enableContextRequest(c, Context), cookie)
+
+	for {
+		buf, err := cookie.Reply()
+		if err != nil {
+			return err
+		}
+		reply := enableContextReply(buf)
+		callback(reply)
+		if reply.Category == CategoryEndOfData {
+			return nil
+		}
+	}
+}
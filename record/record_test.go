@@ -0,0 +1,88 @@
+package record
+
+import (
+	"os"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestEnableContextCallback records the core requests of another
+// connection to the X server in DISPLAY, and is skipped when it isn't set.
+func TestEnableContextCallback(t *testing.T) {
+	if len(os.Getenv("DISPLAY")) == 0 {
+		t.Skip("DISPLAY is not set")
+	}
+	ctl, err := xgb.NewConn()
+	if err != nil {
+		t.Fatalf("NewConn: %s", err)
+	}
+	defer ctl.Close()
+	data, err := xgb.NewConn()
+	if err != nil {
+		t.Fatalf("NewConn: %s", err)
+	}
+	defer data.Close()
+	for _, c := range []*xgb.Conn{ctl, data} {
+		if err := Init(c); err != nil {
+			t.Skipf("RECORD is not available: %s", err)
+		}
+	}
+	if _, err := QueryVersion(ctl, 1, 13).Reply(); err != nil {
+		t.Fatalf("QueryVersion: %s", err)
+	}
+
+	context, err := NewContextId(ctl)
+	if err != nil {
+		t.Fatalf("NewContextId: %s", err)
+	}
+	ranges := []Range{{CoreRequests: Range8{First: 1, Last: 127}}}
+	err = CreateContextChecked(ctl, context, 0, 1, 1,
+		[]ClientSpec{CsFutureClients}, ranges).Check()
+	if err != nil {
+		t.Fatalf("CreateContext: %s", err)
+	}
+	defer FreeContext(ctl, context)
+
+	categories := make(chan byte, 100)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- EnableContextCallback(data, context,
+			func(reply *EnableContextReply) {
+				select {
+				case categories <- reply.Category:
+				default:
+				}
+			})
+	}()
+	if category := <-categories; category != CategoryStartOfData {
+		t.Fatalf("Expected the first reply to start the data, but "+
+			"it has category %d.", category)
+	}
+
+	// Make a request on a new connection, and stop recording.
+	other, err := xgb.NewConn()
+	if err != nil {
+		t.Fatalf("NewConn: %s", err)
+	}
+	xproto.GetInputFocus(other).Reply()
+	other.Close()
+	if err := DisableContextChecked(ctl, context).Check(); err != nil {
+		t.Fatalf("DisableContext: %s", err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("EnableContextCallback: %s", err)
+	}
+
+	seen := false
+	for len(categories) > 0 {
+		if <-categories == CategoryFromClient {
+			seen = true
+		}
+	}
+	if !seen {
+		t.Fatalf("No requests were recorded.")
+	}
+}
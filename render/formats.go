@@ -0,0 +1,91 @@
+package render
+
+/*
+	Finding picture formats in the reply to QueryPictFormats, like
+	XRenderFindStandardFormat and XRenderFindVisualFormat of libXrender.
+	Unlike the rest of this package, this file is not generated.
+*/
+
+import (
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// StandardFormat is one of the picture formats every RENDER implementation
+// has. See FindStandardFormat.
+type StandardFormat int
+
+const (
+	// PictStandardARGB32 has 8 bits of alpha, red, green and blue, in
+	// that order from the most significant bit.
+	PictStandardARGB32 StandardFormat = iota
+
+	// PictStandardRGB24 is like PictStandardARGB32 without alpha, at
+	// depth 24.
+	PictStandardRGB24
+
+	// PictStandardA8, PictStandardA4 and PictStandardA1 only have alpha,
+	// of 8, 4 and 1 bits.
+	PictStandardA8
+	PictStandardA4
+	PictStandardA1
+)
+
+// standardFormats describe the standard formats, by StandardFormat.
+var standardFormats = []Pictforminfo{
+	PictStandardARGB32: {Type: PictTypeDirect, Depth: 32,
+		Direct: Directformat{
+			RedShift: 16, RedMask: 0xff,
+			GreenShift: 8, GreenMask: 0xff,
+			BlueShift: 0, BlueMask: 0xff,
+			AlphaShift: 24, AlphaMask: 0xff,
+		}},
+	PictStandardRGB24: {Type: PictTypeDirect, Depth: 24,
+		Direct: Directformat{
+			RedShift: 16, RedMask: 0xff,
+			GreenShift: 8, GreenMask: 0xff,
+			BlueShift: 0, BlueMask: 0xff,
+		}},
+	PictStandardA8: {Type: PictTypeDirect, Depth: 8,
+		Direct: Directformat{AlphaMask: 0xff}},
+	PictStandardA4: {Type: PictTypeDirect, Depth: 4,
+		Direct: Directformat{AlphaMask: 0x0f}},
+	PictStandardA1: {Type: PictTypeDirect, Depth: 1,
+		Direct: Directformat{AlphaMask: 0x01}},
+}
+
+// FindStandardFormat returns the format in 'formats' (the Formats of
+// a QueryPictFormatsReply) that is the standard format 'std'. It returns
+// false if there is none.
+func FindStandardFormat(formats []Pictforminfo,
+	std StandardFormat) (Pictformat, bool) {
+
+	if std < 0 || int(std) >= len(standardFormats) {
+		return 0, false
+	}
+	want := standardFormats[std]
+	for _, format := range formats {
+		if format.Type == want.Type && format.Depth == want.Depth &&
+			format.Direct == want.Direct {
+
+			return format.Id, true
+		}
+	}
+	return 0, false
+}
+
+// FindVisualFormat returns the format of pictures of windows with the visual
+// 'visual', as given in 'reply'. It returns false if the visual isn't there.
+func FindVisualFormat(reply *QueryPictFormatsReply,
+	visual xproto.Visualid) (Pictformat, bool) {
+
+	for _, screen := range reply.Screens {
+		for _, depth := range screen.Depths {
+			for _, v := range depth.Visuals {
+				if v.Visual == visual {
+					return v.Format, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
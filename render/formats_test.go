@@ -0,0 +1,53 @@
+package render
+
+import (
+	"testing"
+)
+
+// TestFindFormat finds standard and visual formats in a made up reply.
+func TestFindFormat(t *testing.T) {
+	reply := &QueryPictFormatsReply{
+		Formats: []Pictforminfo{
+			{Id: 30, Type: PictTypeDirect, Depth: 8,
+				Direct: Directformat{AlphaMask: 0xff}},
+			{Id: 31, Type: PictTypeIndexed, Depth: 8},
+			{Id: 32, Type: PictTypeDirect, Depth: 32,
+				Direct: Directformat{
+					RedShift: 16, RedMask: 0xff,
+					GreenShift: 8, GreenMask: 0xff,
+					BlueMask:   0xff,
+					AlphaShift: 24, AlphaMask: 0xff,
+				}},
+		},
+		Screens: []Pictscreen{{Depths: []Pictdepth{
+			{Depth: 32, Visuals: []Pictvisual{{0x21, 32}}},
+		}}},
+	}
+
+	tests := []struct {
+		std    StandardFormat
+		format Pictformat
+		ok     bool
+	}{
+		{PictStandardARGB32, 32, true},
+		{PictStandardA8, 30, true},
+		{PictStandardRGB24, 0, false},
+		{StandardFormat(42), 0, false},
+	}
+	for _, test := range tests {
+		format, ok := FindStandardFormat(reply.Formats, test.std)
+		if format != test.format || ok != test.ok {
+			t.Errorf("Standard format %d: expected (%d, %v), but "+
+				"got (%d, %v).", test.std, test.format, test.ok,
+				format, ok)
+		}
+	}
+
+	if format, ok := FindVisualFormat(reply, 0x21); format != 32 || !ok {
+		t.Errorf("Expected the format 32, but got (%d, %v).",
+			format, ok)
+	}
+	if _, ok := FindVisualFormat(reply, 0x22); ok {
+		t.Errorf("Found a format for a visual that isn't there.")
+	}
+}
@@ -0,0 +1,111 @@
+package xgb
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// RequestLogEntry is a request kept in the log turned on by EnableRequestLog.
+type RequestLogEntry struct {
+	// Sequence is the full sequence number of the request. The low 16 bits
+	// are those of the responses to it (e.g., Error.SequenceId).
+	Sequence uint32
+
+	// Opcode is the major opcode of the request, and Data the byte after
+	// it, which is the minor opcode of the requests of extensions.
+	Opcode byte
+	Data   byte
+
+	// Time is when the request was written.
+	Time time.Time
+}
+
+// EnableRequestLog makes the connection keep the last 'size' requests
+// written to the X server, to find out which request caused an X error
+// after the fact (see RequestLog). A size of 0 turns the log off. Enabling
+// the log again empties it.
+//
+// The log doesn't slow down writing requests much, since it takes no locks.
+func (c *Conn) EnableRequestLog(size int) {
+	c = c.socket()
+	if size <= 0 {
+		c.requestLog.Store(nil)
+		return
+	}
+	c.requestLog.Store(&requestLog{slots: make([]requestLogSlot, size)})
+}
+
+// RequestLog returns the requests in the log, oldest first, or nil if it
+// isn't turned on. See EnableRequestLog. It can be called while requests
+// are being made, in which case the entries the goroutine writing requests
+// overwrites in the mean time are left out. Sequence numbers start over
+// after Reconnect, but the log is kept.
+func (c *Conn) RequestLog() []RequestLogEntry {
+	c = c.socket()
+	if l := c.requestLog.Load(); l != nil {
+		return l.snapshot()
+	}
+	return nil
+}
+
+// requestLog is the ring buffer of the requests in the log. It is written
+// by the goroutine writing requests only, and read by any.
+type requestLog struct {
+	written atomic.Uint64 // the number of requests logged so far
+	slots   []requestLogSlot
+}
+
+// requestLogSlot is an entry of the log. 'version' is odd while the entry is
+// being written, and goes up by two every time it is, so readers can tell
+// that it changed under them.
+type requestLogSlot struct {
+	version  atomic.Uint64
+	sequence atomic.Uint32
+	opcodes  atomic.Uint32 // Opcode, and Data shifted by 8
+	time     atomic.Int64  // in nanoseconds since the Unix epoch
+}
+
+// add logs the request 'buf', whose sequence number is 'seq'.
+func (l *requestLog) add(seq uint32, buf []byte) {
+	n := l.written.Load()
+	slot := &l.slots[n%uint64(len(l.slots))]
+	slot.version.Add(1)
+	slot.sequence.Store(seq)
+	slot.opcodes.Store(uint32(buf[0]) | uint32(buf[1])<<8)
+	slot.time.Store(time.Now().UnixNano())
+	slot.version.Add(1)
+	l.written.Store(n + 1)
+}
+
+// snapshot returns the entries of the log, oldest first.
+func (l *requestLog) snapshot() []RequestLogEntry {
+	size := uint64(len(l.slots))
+	n := l.written.Load()
+	first := uint64(0)
+	if n > size {
+		first = n - size
+	}
+
+	entries := make([]RequestLogEntry, 0, n-first)
+	for i := first; i < n; i++ {
+		// The slot holds request 'i' once it has been written i/size+1
+		// times, and until it is written again.
+		slot := &l.slots[i%size]
+		version := 2 * (i/size + 1)
+		if slot.version.Load() != version {
+			continue
+		}
+		opcodes := slot.opcodes.Load()
+		entry := RequestLogEntry{
+			Sequence: slot.sequence.Load(),
+			Opcode:   byte(opcodes),
+			Data:     byte(opcodes >> 8),
+			Time:     time.Unix(0, slot.time.Load()),
+		}
+		if slot.version.Load() != version {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,59 @@
+package xgb
+
+import (
+	"testing"
+)
+
+// TestRequestLog makes more requests than the log has room for, while
+// reading the log, and makes sure the last ones are in it.
+func TestRequestLog(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	go replyServer(<-conns)
+
+	if entries := c.RequestLog(); entries != nil {
+		t.Fatalf("Expected no log, but got %v.", entries)
+	}
+	c.EnableRequestLog(3)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			c.RequestLog()
+		}
+	}()
+	var last uint16
+	for i := 0; i < 5; i++ {
+		cookie := c.NewCookie(true, true)
+		c.NewRequest(c.getInputFocusRequest(), cookie)
+		if _, err := cookie.Reply(); err != nil {
+			t.Fatalf("Reply: %s", err)
+		}
+		last = cookie.Sequence
+	}
+	<-done
+
+	entries := c.RequestLog()
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, but got %v.", entries)
+	}
+	for i, entry := range entries {
+		want := uint32(last) - uint32(2-i)
+		if entry.Sequence != want || entry.Opcode != 43 ||
+			entry.Time.IsZero() {
+
+			t.Errorf("Expected GetInputFocus request %d, "+
+				"but got %+v.", want, entry)
+		}
+	}
+
+	c.EnableRequestLog(0)
+	if entries := c.RequestLog(); entries != nil {
+		t.Fatalf("Expected no log, but got %v.", entries)
+	}
+}
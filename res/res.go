@@ -19,7 +19,7 @@ func Init(c *xgb.Conn) error {
 	case err != nil:
 		return err
 	case !reply.Present:
-		return xgb.Errorf("No extension named X-Resource could be found on on the server.")
+		return xproto.ExtNotAvailableError{Name: "X-Resource", Reply: reply}
 	}
 
 	xgb.ExtLock.Lock()
@@ -180,7 +180,7 @@ type QueryVersionCookie struct {
 // QueryVersion sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryVersionCookie.Reply()
 func QueryVersion(c *xgb.Conn, ClientMajor byte, ClientMinor byte) QueryVersionCookie {
-	if _, ok := c.Extensions["X-RESOURCE"]; !ok {
+	if _, ok := c.Extensions["X-Resource"]; !ok {
 		panic("Cannot issue request 'QueryVersion' using the uninitialized extension 'X-Resource'. res.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -191,7 +191,7 @@ func QueryVersion(c *xgb.Conn, ClientMajor byte, ClientMinor byte) QueryVersionC
 // QueryVersionUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryVersionUnchecked(c *xgb.Conn, ClientMajor byte, ClientMinor byte) QueryVersionCookie {
-	if _, ok := c.Extensions["X-RESOURCE"]; !ok {
+	if _, ok := c.Extensions["X-Resource"]; !ok {
 		panic("Cannot issue request 'QueryVersion' using the uninitialized extension 'X-Resource'. res.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -249,7 +249,7 @@ func queryVersionRequest(c *xgb.Conn, ClientMajor byte, ClientMinor byte) []byte
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["X-RESOURCE"]
+	buf[b] = c.Extensions["X-Resource"]
 	b += 1
 
 	buf[b] = 0 // request opcode
@@ -275,7 +275,7 @@ type QueryClientsCookie struct {
 // QueryClients sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryClientsCookie.Reply()
 func QueryClients(c *xgb.Conn) QueryClientsCookie {
-	if _, ok := c.Extensions["X-RESOURCE"]; !ok {
+	if _, ok := c.Extensions["X-Resource"]; !ok {
 		panic("Cannot issue request 'QueryClients' using the uninitialized extension 'X-Resource'. res.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -286,7 +286,7 @@ func QueryClients(c *xgb.Conn) QueryClientsCookie {
 // QueryClientsUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryClientsUnchecked(c *xgb.Conn) QueryClientsCookie {
-	if _, ok := c.Extensions["X-RESOURCE"]; !ok {
+	if _, ok := c.Extensions["X-Resource"]; !ok {
 		panic("Cannot issue request 'QueryClients' using the uninitialized extension 'X-Resource'. res.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -347,7 +347,7 @@ func queryClientsRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["X-RESOURCE"]
+	buf[b] = c.Extensions["X-Resource"]
 	b += 1
 
 	buf[b] = 1 // request opcode
@@ -367,7 +367,7 @@ type QueryClientResourcesCookie struct {
 // QueryClientResources sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryClientResourcesCookie.Reply()
 func QueryClientResources(c *xgb.Conn, Xid uint32) QueryClientResourcesCookie {
-	if _, ok := c.Extensions["X-RESOURCE"]; !ok {
+	if _, ok := c.Extensions["X-Resource"]; !ok {
 		panic("Cannot issue request 'QueryClientResources' using the uninitialized extension 'X-Resource'. res.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -378,7 +378,7 @@ func QueryClientResources(c *xgb.Conn, Xid uint32) QueryClientResourcesCookie {
 // QueryClientResourcesUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryClientResourcesUnchecked(c *xgb.Conn, Xid uint32) QueryClientResourcesCookie {
-	if _, ok := c.Extensions["X-RESOURCE"]; !ok {
+	if _, ok := c.Extensions["X-Resource"]; !ok {
 		panic("Cannot issue request 'QueryClientResources' using the uninitialized extension 'X-Resource'. res.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -439,7 +439,7 @@ func queryClientResourcesRequest(c *xgb.Conn, Xid uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["X-RESOURCE"]
+	buf[b] = c.Extensions["X-Resource"]
 	b += 1
 
 	buf[b] = 2 // request opcode
@@ -462,7 +462,7 @@ type QueryClientPixmapBytesCookie struct {
 // QueryClientPixmapBytes sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryClientPixmapBytesCookie.Reply()
 func QueryClientPixmapBytes(c *xgb.Conn, Xid uint32) QueryClientPixmapBytesCookie {
-	if _, ok := c.Extensions["X-RESOURCE"]; !ok {
+	if _, ok := c.Extensions["X-Resource"]; !ok {
 		panic("Cannot issue request 'QueryClientPixmapBytes' using the uninitialized extension 'X-Resource'. res.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -473,7 +473,7 @@ func QueryClientPixmapBytes(c *xgb.Conn, Xid uint32) QueryClientPixmapBytesCooki
 // QueryClientPixmapBytesUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryClientPixmapBytesUnchecked(c *xgb.Conn, Xid uint32) QueryClientPixmapBytesCookie {
-	if _, ok := c.Extensions["X-RESOURCE"]; !ok {
+	if _, ok := c.Extensions["X-Resource"]; !ok {
 		panic("Cannot issue request 'QueryClientPixmapBytes' using the uninitialized extension 'X-Resource'. res.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -531,7 +531,7 @@ func queryClientPixmapBytesRequest(c *xgb.Conn, Xid uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["X-RESOURCE"]
+	buf[b] = c.Extensions["X-Resource"]
 	b += 1
 
 	buf[b] = 3 // request opcode
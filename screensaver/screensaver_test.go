@@ -0,0 +1,41 @@
+package screensaver
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+)
+
+// TestNotifyEvent decodes a ScreenSaverNotify event as laid out in
+// saverproto.h, and encodes it back.
+func TestNotifyEvent(t *testing.T) {
+	buf := make([]byte, 32)
+	buf[1] = StateOn
+	xgb.Put16(buf[2:], 12)
+	xgb.Put32(buf[4:], 123456)
+	xgb.Put32(buf[8:], 0x100)
+	xgb.Put32(buf[12:], 0x200001)
+	buf[16] = KindInternal
+	buf[17] = 1
+
+	ev := NotifyEventNew(buf).(NotifyEvent)
+	want := NotifyEvent{
+		Sequence: 12,
+		State:    StateOn,
+		Time:     123456,
+		Root:     0x100,
+		Window:   0x200001,
+		Kind:     KindInternal,
+		Forced:   true,
+	}
+	if ev != want {
+		t.Fatalf("Expected\n%s\nbut got\n%s", want, ev)
+	}
+
+	// Bytes doesn't write the sequence number.
+	xgb.Put16(buf[2:], 0)
+	if b := ev.Bytes(); !bytes.Equal(b, buf) {
+		t.Fatalf("Expected\n% x\nbut got\n% x", buf, b)
+	}
+}
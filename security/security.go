@@ -0,0 +1,484 @@
+// Package security is the X client API for the SECURITY extension, which
+// separates trusted clients from untrusted ones. Untrusted clients (like
+// those started by a sandbox) can't see or touch the resources of trusted
+// clients.
+//
+// xcb-proto has no description of the SECURITY extension, so this package
+// was written by hand from securproto.h, following the conventions of the
+// generated packages.
+package security
+
+import (
+	"time"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// MajorVersion and MinorVersion are the version of the SECURITY extension
+// implemented by this package. They should be passed to QueryVersion.
+const (
+	MajorVersion = 1
+	MinorVersion = 0
+)
+
+// Init must be called before using the SECURITY extension.
+//
+// Since the major opcode of the extension may change, Init must be called
+// again after reconnecting (i.e., in a hook given to xgb.Conn.OnReconnect).
+func Init(c *xgb.Conn) error {
+	reply, err := xproto.QueryExtension(c, 8, "SECURITY").Reply()
+	switch {
+	case err != nil:
+		return err
+	case !reply.Present:
+		return xproto.ExtNotAvailableError{Name: "SECURITY",
+			Reply: reply}
+	}
+
+	xgb.ExtLock.Lock()
+	c.Extensions["SECURITY"] = reply.MajorOpcode
+	for evNum, fun := range xgb.NewExtEventFuncs["SECURITY"] {
+		xgb.NewEventFuncs[int(reply.FirstEvent)+evNum] = fun
+	}
+	for errNum, fun := range xgb.NewExtErrorFuncs["SECURITY"] {
+		xgb.NewErrorFuncs[int(reply.FirstError)+errNum] = fun
+	}
+	xgb.ExtLock.Unlock()
+
+	return nil
+}
+
+func init() {
+	xgb.NewExtEventFuncs["SECURITY"] = map[int]xgb.NewEventFun{
+		AuthorizationRevoked: AuthorizationRevokedEventNew,
+	}
+	xgb.NewExtErrorFuncs["SECURITY"] = map[int]xgb.NewErrorFun{
+		BadBadAuthorization:         BadAuthorizationErrorNew,
+		BadBadAuthorizationProtocol: BadAuthorizationProtocolErrorNew,
+	}
+}
+
+// checkInit panics if Init hasn't been called. 'request' is the name of the
+// request about to be sent.
+func checkInit(c *xgb.Conn, request string) {
+	if _, ok := c.Extensions["SECURITY"]; !ok {
+		panic("Cannot issue request '" + request + "' using the " +
+			"uninitialized extension 'SECURITY'. " +
+			"security.Init(connObj) must be called first.")
+	}
+}
+
+// requestHeader writes the header of the SECURITY request with the given
+// opcode to the start of 'buf', which must be as long as the request.
+func requestHeader(c *xgb.Conn, buf []byte, opcode byte) {
+	buf[0] = c.Extensions["SECURITY"]
+	buf[1] = opcode
+	xgb.Put16(buf[2:], uint16(len(buf)/4))
+}
+
+// Trust levels of authorizations, as in the value of AttrTrustLevel.
+const (
+	ClientTrusted   = 0
+	ClientUntrusted = 1
+)
+
+// Attributes of authorizations, as in the ValueMask of
+// GenerateAuthorization. The values in ValueList must be in this order.
+const (
+	AttrTimeout    = 1 << 0 // in seconds, 0 for no timeout
+	AttrTrustLevel = 1 << 1
+	AttrGroup      = 1 << 2 // an application group id, or 0
+	AttrEventMask  = 1 << 3
+)
+
+// Event masks, as in the value of AttrEventMask.
+const (
+	EventMaskAuthorizationRevoked = 1 << 0
+)
+
+// AuthorizationRevoked is the event number for
+// a AuthorizationRevokedEvent.
+const AuthorizationRevoked = 0
+
+// AuthorizationRevokedEvent is sent to the client that generated an
+// authorization with EventMaskAuthorizationRevoked when it is revoked,
+// either by RevokeAuthorization or because it timed out.
+type AuthorizationRevokedEvent struct {
+	Sequence uint16
+	AuthId   uint32
+
+	buf []byte
+}
+
+// AuthorizationRevokedEventNew constructs a AuthorizationRevokedEvent value
+// that implements xgb.Event from a byte slice.
+func AuthorizationRevokedEventNew(buf []byte) xgb.Event {
+	return AuthorizationRevokedEvent{
+		Sequence: xgb.Get16(buf[2:]),
+		AuthId:   xgb.Get32(buf[4:]),
+		buf:      buf,
+	}
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v AuthorizationRevokedEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the AuthorizationRevoked
+// event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v AuthorizationRevokedEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of
+// AuthorizationRevokedEvent.
+func (v AuthorizationRevokedEvent) String() string {
+	fieldVals := make([]string, 0, 2)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("AuthId: %d", v.AuthId))
+	return "AuthorizationRevoked {" +
+		xgb.StringsJoin(fieldVals, ", ") + "}"
+}
+
+// BadBadAuthorization is the error number for a BadBadAuthorization.
+const BadBadAuthorization = 0
+
+// BadAuthorizationError is returned by RevokeAuthorization for an
+// authorization id that doesn't exist.
+type BadAuthorizationError struct {
+	Sequence uint16
+	NiceName string
+	BadValue uint32
+}
+
+// BadAuthorizationErrorNew constructs a BadAuthorizationError value that
+// implements xgb.Error from a byte slice.
+func BadAuthorizationErrorNew(buf []byte) xgb.Error {
+	return BadAuthorizationError{
+		Sequence: xgb.Get16(buf[2:]),
+		NiceName: "BadAuthorization",
+		BadValue: xgb.Get32(buf[4:]),
+	}
+}
+
+// SequenceId returns the sequence id attached to the BadBadAuthorization
+// error.
+// This is mostly used internally.
+func (err BadAuthorizationError) SequenceId() uint16 {
+	return err.Sequence
+}
+
+// BadId returns the 'BadValue' number if one exists for the
+// BadBadAuthorization error. If no bad value exists, 0 is returned.
+func (err BadAuthorizationError) BadId() uint32 {
+	return err.BadValue
+}
+
+// Error returns a rudimentary string representation of the
+// BadBadAuthorization error.
+func (err BadAuthorizationError) Error() string {
+	fieldVals := make([]string, 0, 3)
+	fieldVals = append(fieldVals, "NiceName: "+err.NiceName)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", err.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("BadValue: %d", err.BadValue))
+	return "BadBadAuthorization {" + xgb.StringsJoin(fieldVals, ", ") + "}"
+}
+
+// BadBadAuthorizationProtocol is the error number for
+// a BadBadAuthorizationProtocol.
+const BadBadAuthorizationProtocol = 1
+
+// BadAuthorizationProtocolError is returned by GenerateAuthorization for an
+// authorization protocol the X server doesn't support.
+type BadAuthorizationProtocolError struct {
+	Sequence uint16
+	NiceName string
+}
+
+// BadAuthorizationProtocolErrorNew constructs
+// a BadAuthorizationProtocolError value that implements xgb.Error from
+// a byte slice.
+func BadAuthorizationProtocolErrorNew(buf []byte) xgb.Error {
+	return BadAuthorizationProtocolError{
+		Sequence: xgb.Get16(buf[2:]),
+		NiceName: "BadAuthorizationProtocol",
+	}
+}
+
+// SequenceId returns the sequence id attached to the
+// BadBadAuthorizationProtocol error.
+// This is mostly used internally.
+func (err BadAuthorizationProtocolError) SequenceId() uint16 {
+	return err.Sequence
+}
+
+// BadId returns the 'BadValue' number if one exists for the
+// BadBadAuthorizationProtocol error. If no bad value exists, 0 is returned.
+func (err BadAuthorizationProtocolError) BadId() uint32 {
+	return 0
+}
+
+// Error returns a rudimentary string representation of the
+// BadBadAuthorizationProtocol error.
+func (err BadAuthorizationProtocolError) Error() string {
+	fieldVals := make([]string, 0, 2)
+	fieldVals = append(fieldVals, "NiceName: "+err.NiceName)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", err.Sequence))
+	return "BadBadAuthorizationProtocol {" +
+		xgb.StringsJoin(fieldVals, ", ") + "}"
+}
+
+// QueryVersionCookie is a cookie used only for QueryVersion requests.
+type QueryVersionCookie struct {
+	*xgb.Cookie
+}
+
+// QueryVersion sends a checked request.
+// If an error occurs, it will be returned with the reply by calling
+// QueryVersionCookie.Reply()
+func QueryVersion(c *xgb.Conn, MajorVersion uint16,
+	MinorVersion uint16) QueryVersionCookie {
+
+	checkInit(c, "QueryVersion")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(queryVersionRequest(c, MajorVersion, MinorVersion), cookie)
+	return QueryVersionCookie{cookie}
+}
+
+// QueryVersionUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func QueryVersionUnchecked(c *xgb.Conn, MajorVersion uint16,
+	MinorVersion uint16) QueryVersionCookie {
+
+	checkInit(c, "QueryVersion")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(queryVersionRequest(c, MajorVersion, MinorVersion), cookie)
+	return QueryVersionCookie{cookie}
+}
+
+// QueryVersionReply represents the data returned from a QueryVersion request.
+type QueryVersionReply struct {
+	Sequence     uint16 // sequence number of the request for this reply
+	Length       uint32 // number of bytes in this reply
+	MajorVersion uint16
+	MinorVersion uint16
+}
+
+// Reply blocks and returns the reply data for a QueryVersion request.
+func (cook QueryVersionCookie) Reply() (*QueryVersionReply, error) {
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return &QueryVersionReply{
+		Sequence:     xgb.Get16(buf[2:]),
+		Length:       xgb.Get32(buf[4:]),
+		MajorVersion: xgb.Get16(buf[8:]),
+		MinorVersion: xgb.Get16(buf[10:]),
+	}, nil
+}
+
+// queryVersionRequest writes a QueryVersion request to a byte slice.
+func queryVersionRequest(c *xgb.Conn, MajorVersion uint16,
+	MinorVersion uint16) []byte {
+
+	buf := make([]byte, 8)
+	requestHeader(c, buf, 0)
+	xgb.Put16(buf[4:], MajorVersion)
+	xgb.Put16(buf[6:], MinorVersion)
+	return buf
+}
+
+// GenerateAuthorizationCookie is a cookie used only for
+// GenerateAuthorization requests.
+type GenerateAuthorizationCookie struct {
+	*xgb.Cookie
+}
+
+// GenerateAuthorization sends a checked request. It creates a new
+// authorization for the protocol 'AuthProtocolName' (e.g.,
+// "MIT-MAGIC-COOKIE-1"), which other clients can connect with. If
+// 'AuthProtocolData' is empty, the X server generates the data itself.
+// 'ValueList' has one value for each bit set in 'ValueMask' (see the Attr
+// constants).
+// If an error occurs, it will be returned with the reply by calling
+// GenerateAuthorizationCookie.Reply()
+func GenerateAuthorization(c *xgb.Conn, AuthProtocolName string,
+	AuthProtocolData []byte, ValueMask uint32,
+	ValueList []uint32) GenerateAuthorizationCookie {
+
+	checkInit(c, "GenerateAuthorization")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(generateAuthorizationRequest(c, AuthProtocolName,
+		AuthProtocolData, ValueMask, ValueList), cookie)
+	return GenerateAuthorizationCookie{cookie}
+}
+
+// GenerateAuthorizationUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func GenerateAuthorizationUnchecked(c *xgb.Conn, AuthProtocolName string,
+	AuthProtocolData []byte, ValueMask uint32,
+	ValueList []uint32) GenerateAuthorizationCookie {
+
+	checkInit(c, "GenerateAuthorization")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(generateAuthorizationRequest(c, AuthProtocolName,
+		AuthProtocolData, ValueMask, ValueList), cookie)
+	return GenerateAuthorizationCookie{cookie}
+}
+
+// GenerateAuthorizationReply represents the data returned from
+// a GenerateAuthorization request.
+type GenerateAuthorizationReply struct {
+	Sequence   uint16 // sequence number of the request for this reply
+	Length     uint32 // number of bytes in this reply
+	AuthId     uint32
+	DataLength uint16
+	AuthData   []byte // size: xgb.Pad((int(DataLength) * 1))
+}
+
+// Reply blocks and returns the reply data for a GenerateAuthorization
+// request.
+func (cook GenerateAuthorizationCookie) Reply() (*GenerateAuthorizationReply,
+	error) {
+
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	v := &GenerateAuthorizationReply{
+		Sequence:   xgb.Get16(buf[2:]),
+		Length:     xgb.Get32(buf[4:]),
+		AuthId:     xgb.Get32(buf[8:]),
+		DataLength: xgb.Get16(buf[12:]),
+	}
+	v.AuthData = make([]byte, v.DataLength)
+	copy(v.AuthData, buf[32:])
+	return v, nil
+}
+
+// generateAuthorizationRequest writes a GenerateAuthorization request to
+// a byte slice.
+func generateAuthorizationRequest(c *xgb.Conn, AuthProtocolName string,
+	AuthProtocolData []byte, ValueMask uint32, ValueList []uint32) []byte {
+
+	size := 12 + xgb.Pad(len(AuthProtocolName)) +
+		xgb.Pad(len(AuthProtocolData)) + len(ValueList)*4
+	buf := make([]byte, size)
+	requestHeader(c, buf, 1)
+	xgb.Put16(buf[4:], uint16(len(AuthProtocolName)))
+	xgb.Put16(buf[6:], uint16(len(AuthProtocolData)))
+	xgb.Put32(buf[8:], ValueMask)
+
+	b := 12
+	copy(buf[b:], AuthProtocolName)
+	b += xgb.Pad(len(AuthProtocolName))
+	copy(buf[b:], AuthProtocolData)
+	b += xgb.Pad(len(AuthProtocolData))
+	for _, value := range ValueList {
+		xgb.Put32(buf[b:], value)
+		b += 4
+	}
+	return buf
+}
+
+// RevokeAuthorizationCookie is a cookie used only for RevokeAuthorization
+// requests.
+type RevokeAuthorizationCookie struct {
+	*xgb.Cookie
+}
+
+// RevokeAuthorization sends an unchecked request. It revokes an
+// authorization made by GenerateAuthorization, and disconnects the clients
+// that connected with it.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func RevokeAuthorization(c *xgb.Conn,
+	AuthId uint32) RevokeAuthorizationCookie {
+
+	checkInit(c, "RevokeAuthorization")
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(revokeAuthorizationRequest(c, AuthId), cookie)
+	return RevokeAuthorizationCookie{cookie}
+}
+
+// RevokeAuthorizationChecked sends a checked request.
+// If an error occurs, it can be retrieved using
+// RevokeAuthorizationCookie.Check()
+func RevokeAuthorizationChecked(c *xgb.Conn,
+	AuthId uint32) RevokeAuthorizationCookie {
+
+	checkInit(c, "RevokeAuthorization")
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(revokeAuthorizationRequest(c, AuthId), cookie)
+	return RevokeAuthorizationCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook RevokeAuthorizationCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// revokeAuthorizationRequest writes a RevokeAuthorization request to a byte
+// slice.
+func revokeAuthorizationRequest(c *xgb.Conn, AuthId uint32) []byte {
+	buf := make([]byte, 8)
+	requestHeader(c, buf, 2)
+	xgb.Put32(buf[4:], AuthId)
+	return buf
+}
+
+// Authorization is an authorization made by GenerateCookie. Its Name and
+// Data are what a client needs to connect to the X server with it, e.g.,
+// in an entry of an X authority file.
+type Authorization struct {
+	Id   uint32 // the id to revoke it with, see RevokeAuthorization
+	Name string // the name of the authorization protocol
+	Data []byte
+}
+
+// GenerateCookie makes a new MIT-MAGIC-COOKIE-1 authorization. Clients that
+// connect with it are untrusted unless 'trusted' is set.
+//
+// If 'timeout' is not zero, the authorization is revoked once it has not
+// been used by any connected client for that long (rounded up to
+// a second). Otherwise, it lasts until it is revoked with
+// RevokeAuthorization, or the X server resets.
+//
+// This is meant for sandbox launchers, which can pass (an X authority file
+// with) the returned authorization to the clients they start.
+func GenerateCookie(c *xgb.Conn, timeout time.Duration,
+	trusted bool) (*Authorization, error) {
+
+	trustLevel := uint32(ClientUntrusted)
+	if trusted {
+		trustLevel = ClientTrusted
+	}
+	seconds := uint32((timeout + time.Second - 1) / time.Second)
+	reply, err := GenerateAuthorization(c, "MIT-MAGIC-COOKIE-1", nil,
+		AttrTimeout|AttrTrustLevel,
+		[]uint32{seconds, trustLevel}).Reply()
+	if err != nil {
+		return nil, err
+	}
+	return &Authorization{
+		Id:   reply.AuthId,
+		Name: "MIT-MAGIC-COOKIE-1",
+		Data: reply.AuthData,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package security
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/xgb"
+)
+
+// TestGenerateAuthorizationRequest checks the encoding of
+// a GenerateAuthorization request, whose name and data are padded.
+func TestGenerateAuthorizationRequest(t *testing.T) {
+	c := &xgb.Conn{Extensions: map[string]byte{"SECURITY": 135}}
+	buf := generateAuthorizationRequest(c, "MIT-MAGIC-COOKIE-1",
+		[]byte{1, 2, 3}, AttrTimeout|AttrTrustLevel,
+		[]uint32{60, ClientUntrusted})
+
+	want := []byte{135, 1, 11, 0, 18, 0, 3, 0, 3, 0, 0, 0}
+	want = append(want, "MIT-MAGIC-COOKIE-1\x00\x00"...)
+	want = append(want, 1, 2, 3, 0)
+	want = append(want, 60, 0, 0, 0, 1, 0, 0, 0)
+	if !bytes.Equal(buf, want) {
+		t.Fatalf("Expected\n% x\nbut got\n% x", want, buf)
+	}
+}
+
+// TestGenerateCookie makes and revokes an authorization on the X server in
+// DISPLAY, and is skipped when it isn't set.
+func TestGenerateCookie(t *testing.T) {
+	if len(os.Getenv("DISPLAY")) == 0 {
+		t.Skip("DISPLAY is not set")
+	}
+	X, err := xgb.NewConn()
+	if err != nil {
+		t.Fatalf("NewConn: %s", err)
+	}
+	defer X.Close()
+	if err := Init(X); err != nil {
+		t.Skipf("SECURITY is not available: %s", err)
+	}
+	_, err = QueryVersion(X, MajorVersion, MinorVersion).Reply()
+	if err != nil {
+		t.Fatalf("QueryVersion: %s", err)
+	}
+
+	auth, err := GenerateCookie(X, time.Minute, false)
+	if err != nil {
+		t.Fatalf("GenerateCookie: %s", err)
+	}
+	if len(auth.Data) != 16 {
+		t.Fatalf("Expected a 16 byte cookie, but got % x", auth.Data)
+	}
+	if err := RevokeAuthorizationChecked(X, auth.Id).Check(); err != nil {
+		t.Fatalf("RevokeAuthorization: %s", err)
+	}
+}
@@ -0,0 +1,78 @@
+package shm
+
+/*
+	Shared memory segments attached to the X server, which images can be
+	put in and read from without being copied over the connection.
+	Unlike the rest of this package, this file is not generated.
+*/
+
+import (
+	"errors"
+
+	"github.com/BurntSushi/xgb"
+)
+
+// ErrSegmentNotSupported is returned by NewSegment on systems where System V
+// shared memory can't be used from Go without cgo. See segment_sysv.go.
+var ErrSegmentNotSupported = errors.New("shm: System V shared memory is " +
+	"not supported on this system")
+
+// Segment is a System V shared memory segment attached to the X server (with
+// Attach), as made by NewSegment. Requests like PutImage, GetImage and
+// CreatePixmap refer to it by Id, and to the image in it by its offset in
+// Data.
+//
+// Writing to Data while the X server reads from it (e.g., before the reply
+// to a request after PutImage, or the CompletionEvent it sends) changes what
+// it reads.
+type Segment struct {
+	Id   Seg
+	Data []byte
+
+	c     *xgb.Conn
+	shmid int
+}
+
+// NewSegment makes a shared memory segment of 'size' bytes, maps it, and
+// attaches it to the X server, unless 'readOnly' (in which case the X server
+// can't write to it, as in GetImage). The segment is marked for removal right
+// away, so that it goes away when both sides have detached it, even if the
+// program dies. Release it with Close.
+//
+// The X server must be on the same machine. Init must have been called.
+func NewSegment(c *xgb.Conn, size int, readOnly bool) (*Segment, error) {
+	if size <= 0 {
+		return nil, errors.New("shm: the size of a segment must be " +
+			"positive")
+	}
+	id, err := NewSegId(c)
+	if err != nil {
+		return nil, err
+	}
+	shmid, data, err := createSegment(size)
+	if err != nil {
+		return nil, err
+	}
+	seg := &Segment{Id: id, Data: data, c: c, shmid: shmid}
+
+	err = AttachChecked(c, id, uint32(shmid), readOnly).Check()
+	if rmErr := removeSegment(shmid); err == nil {
+		err = rmErr
+	}
+	if err != nil {
+		detachSegment(data)
+		return nil, err
+	}
+	return seg, nil
+}
+
+// Close detaches the segment from the X server, and unmaps it. Data must not
+// be used afterwards.
+func (seg *Segment) Close() error {
+	err := DetachChecked(seg.c, seg.Id).Check()
+	if dtErr := detachSegment(seg.Data); err == nil {
+		err = dtErr
+	}
+	seg.Data = nil
+	return err
+}
@@ -0,0 +1,18 @@
+//go:build !(linux && (amd64 || arm || arm64 || loong64 || mips64 || mips64le || riscv64))
+
+package shm
+
+// The syscall package only has the System V shared memory calls on some
+// systems. See segment_sysv.go.
+
+func createSegment(size int) (int, []byte, error) {
+	return 0, nil, ErrSegmentNotSupported
+}
+
+func removeSegment(shmid int) error {
+	return ErrSegmentNotSupported
+}
+
+func detachSegment(data []byte) error {
+	return ErrSegmentNotSupported
+}
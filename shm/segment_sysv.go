@@ -0,0 +1,56 @@
+//go:build linux && (amd64 || arm || arm64 || loong64 || mips64 || mips64le || riscv64)
+
+package shm
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// These are from <sys/ipc.h>, which the syscall package doesn't have.
+const (
+	ipcPrivate = 0
+	ipcCreat   = 01000
+	ipcRmid    = 0
+)
+
+// createSegment makes a private shared memory segment of 'size' bytes, and
+// maps it.
+func createSegment(size int) (int, []byte, error) {
+	shmid, _, errno := syscall.Syscall(syscall.SYS_SHMGET, ipcPrivate,
+		uintptr(size), ipcCreat|0600)
+	if errno != 0 {
+		return 0, nil, errno
+	}
+	addr, _, errno := syscall.Syscall(syscall.SYS_SHMAT, shmid, 0, 0)
+	if errno != 0 {
+		removeSegment(int(shmid))
+		return 0, nil, errno
+	}
+	// The segment is outside of Go's heap, so converting its address is
+	// safe (and this way of doing it keeps vet quiet).
+	ptr := *(*unsafe.Pointer)(unsafe.Pointer(&addr))
+	data := unsafe.Slice((*byte)(ptr), size)
+	return int(shmid), data, nil
+}
+
+// removeSegment marks the segment 'shmid' for removal once nothing has it
+// attached anymore.
+func removeSegment(shmid int) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_SHMCTL, uintptr(shmid),
+		ipcRmid, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// detachSegment unmaps the segment mapped at 'data'.
+func detachSegment(data []byte) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_SHMDT,
+		uintptr(unsafe.Pointer(&data[0])), 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
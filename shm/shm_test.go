@@ -0,0 +1,118 @@
+package shm
+
+/*
+	Tests for shared memory segments.
+
+	Apart from TestCreateSegment, these need a running X server with the
+	MIT-SHM extension on the same machine, and are skipped if DISPLAY isn't
+	set.
+*/
+
+import (
+	"os"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestCreateSegment makes, writes to, and removes a segment, without the X
+// server.
+func TestCreateSegment(t *testing.T) {
+	shmid, data, err := createSegment(4096)
+	if err == ErrSegmentNotSupported {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Skipf("Cannot make a shared memory segment: %s", err)
+	}
+	if len(data) != 4096 {
+		t.Fatalf("Expected 4096 bytes, but got %d.", len(data))
+	}
+	data[0], data[4095] = 1, 2
+	if err := removeSegment(shmid); err != nil {
+		t.Fatalf("removeSegment: %s", err)
+	}
+	if err := detachSegment(data); err != nil {
+		t.Fatalf("detachSegment: %s", err)
+	}
+}
+
+// imageWidth and imageHeight are the size of the images put in the
+// benchmarks.
+const (
+	imageWidth  = 256
+	imageHeight = 128
+)
+
+// setup connects to the X server, skipping the benchmark if that isn't
+// possible, and makes a pixmap and a graphics context to put images in.
+// The image must be 32 bits per pixel, which is the case unless the depth of
+// the screen is 8 or 16.
+func setup(b *testing.B) (*xgb.Conn, xproto.Pixmap, xproto.Gcontext, byte) {
+	b.Helper()
+	if len(os.Getenv("DISPLAY")) == 0 {
+		b.Skip("DISPLAY is not set")
+	}
+
+	X, err := xgb.NewConn()
+	if err != nil {
+		b.Fatalf("NewConn: %s", err)
+	}
+	b.Cleanup(func() { X.Close() })
+	if err := Init(X); err != nil {
+		b.Skipf("MIT-SHM is not available: %s", err)
+	}
+	screen := xproto.Setup(X).DefaultScreen(X)
+	if screen.RootDepth != 24 && screen.RootDepth != 32 {
+		b.Skipf("The depth of the screen is %d.", screen.RootDepth)
+	}
+
+	pix, err := xproto.NewPixmapId(X)
+	if err != nil {
+		b.Fatal(err)
+	}
+	xproto.CreatePixmap(X, screen.RootDepth, pix,
+		xproto.Drawable(screen.Root), imageWidth, imageHeight)
+	gc, err := xproto.NewGcontextId(X)
+	if err != nil {
+		b.Fatal(err)
+	}
+	xproto.CreateGC(X, gc, xproto.Drawable(pix), 0, nil)
+	return X, pix, gc, screen.RootDepth
+}
+
+// BenchmarkPutImage puts an image in a pixmap the usual way, sending the
+// pixels over the connection.
+func BenchmarkPutImage(b *testing.B) {
+	X, pix, gc, depth := setup(b)
+	data := make([]byte, imageWidth*imageHeight*4)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		xproto.PutImage(X, xproto.ImageFormatZPixmap,
+			xproto.Drawable(pix), gc, imageWidth, imageHeight, 0, 0,
+			0, depth, data)
+	}
+	X.Sync()
+}
+
+// BenchmarkShmPutImage puts the same image through a shared memory segment.
+func BenchmarkShmPutImage(b *testing.B) {
+	X, pix, gc, depth := setup(b)
+	seg, err := NewSegment(X, imageWidth*imageHeight*4, true)
+	if err != nil {
+		b.Skipf("NewSegment: %s", err)
+	}
+	defer seg.Close()
+
+	b.SetBytes(int64(len(seg.Data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		PutImage(X, xproto.Drawable(pix), gc, imageWidth, imageHeight,
+			0, 0, imageWidth, imageHeight, 0, 0, depth,
+			xproto.ImageFormatZPixmap, 0, seg.Id, 0)
+	}
+	X.Sync()
+}
@@ -0,0 +1,63 @@
+package xgb
+
+import (
+	"expvar"
+	"sync/atomic"
+)
+
+// ConnStats are counts of what went over a connection. See Conn.Stats.
+type ConnStats struct {
+	RequestsSent    uint64
+	RepliesReceived uint64
+	EventsReceived  uint64
+	ErrorsReceived  uint64
+
+	// BytesSent and BytesReceived are the lengths of the requests and of
+	// the responses (i.e., replies, events and errors), so they don't
+	// count the setup handshake.
+	BytesSent     uint64
+	BytesReceived uint64
+}
+
+// connStats is where a Conn keeps its ConnStats, updated as requests are
+// sent and responses are read.
+type connStats struct {
+	requests, replies, events, errors atomic.Uint64
+	bytesSent, bytesReceived          atomic.Uint64
+}
+
+// sent counts a request of 'n' bytes.
+func (s *connStats) sent(n int) {
+	s.requests.Add(1)
+	s.bytesSent.Add(uint64(n))
+}
+
+// received counts a response of 'n' bytes, which is one of 'count'.
+func (s *connStats) received(count *atomic.Uint64, n int) {
+	count.Add(1)
+	s.bytesReceived.Add(uint64(n))
+}
+
+// Stats returns how many requests, replies, events and errors have gone over
+// the connection so far, and how many bytes. The counts go on after
+// Reconnect.
+func (c *Conn) Stats() ConnStats {
+	c = c.socket()
+	return ConnStats{
+		RequestsSent:    c.stats.requests.Load(),
+		RepliesReceived: c.stats.replies.Load(),
+		EventsReceived:  c.stats.events.Load(),
+		ErrorsReceived:  c.stats.errors.Load(),
+		BytesSent:       c.stats.bytesSent.Load(),
+		BytesReceived:   c.stats.bytesReceived.Load(),
+	}
+}
+
+// RegisterExpvar publishes the Stats of the connection as the expvar 'name',
+// so that they are served (as JSON) by the expvar package. Like
+// expvar.Publish, it panics if 'name' is already in use.
+func (c *Conn) RegisterExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return c.Stats()
+	}))
+}
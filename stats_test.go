@@ -0,0 +1,44 @@
+package xgb
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+)
+
+// TestStats counts a request without a reply and one with a reply, and
+// makes sure the counts are published by RegisterExpvar.
+func TestStats(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	go replyServer(<-conns)
+
+	c.NewRequest(noOperationRequest(), c.NewCookie(false, false))
+	c.Sync()
+
+	want := ConnStats{
+		RequestsSent:    2,
+		RepliesReceived: 1,
+		BytesSent:       8,
+		BytesReceived:   32,
+	}
+	if got := c.Stats(); got != want {
+		t.Fatalf("Expected the stats %+v, but got %+v.", want, got)
+	}
+
+	c.RegisterExpvar("xgb-test-stats")
+	var published ConnStats
+	err = json.Unmarshal([]byte(expvar.Get("xgb-test-stats").String()),
+		&published)
+	if err != nil {
+		t.Fatalf("Bad expvar: %s", err)
+	}
+	if published != want {
+		t.Fatalf("Expected the expvar %+v, but got %+v.", want,
+			published)
+	}
+}
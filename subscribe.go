@@ -0,0 +1,148 @@
+package xgb
+
+// EventMask is a set of event codes (e.g., xproto.KeyPress), as given to
+// Subscribe. The bit for the event code N is bit N%64 of element N/64. The
+// codes of the events of an extension start at the first event its
+// QueryExtension reply gives.
+//
+// These are not the event masks of the X protocol (like
+// xproto.EventMaskKeyPress), which select the events the X server sends for
+// a window, and often stand for several events.
+type EventMask [2]uint64
+
+// NewEventMask returns the EventMask of the events with codes 'codes'.
+func NewEventMask(codes ...int) EventMask {
+	var mask EventMask
+	for _, code := range codes {
+		code &= 127
+		mask[code/64] |= 1 << uint(code%64)
+	}
+	return mask
+}
+
+// Has returns whether the event with code 'code' is in 'mask'. The bit that
+// marks events sent with SendEvent is ignored.
+func (mask EventMask) Has(code int) bool {
+	code &= 127
+	return mask[code/64]&(1<<uint(code%64)) != 0
+}
+
+// DropPolicy says which event is lost when an event arrives for
+// a subscription whose channel is full. See SetDropPolicy.
+type DropPolicy int32
+
+const (
+	// DropNewest loses the event that just arrived. This is the default.
+	DropNewest DropPolicy = iota
+
+	// DropOldest loses the oldest event in the channel, to make room for
+	// the one that just arrived.
+	DropOldest
+)
+
+// subscription is a channel made by Subscribe, and the events it gets.
+type subscription struct {
+	mask   EventMask
+	events chan Event
+}
+
+// SetDropPolicy sets what happens to the events for a subscription whose
+// channel is full, for every subscription of the connection. Events are never
+// waited on, so a subscriber that is slow to take them doesn't hold up the
+// others (or the replies to requests).
+func (c *Conn) SetDropPolicy(policy DropPolicy) {
+	c.dropPolicy.Store(int32(policy))
+}
+
+// Subscribe returns a channel on which the events in 'mask' are sent. Events
+// that at least one subscription gets are not queued for WaitForEvent,
+// PollForEvent or Events, which get all the other events, and every X error.
+// An event in the mask of several subscriptions is sent to each.
+//
+// The channel has room for a thousand events. When it is full, an event is
+// lost, as set by SetDropPolicy. Subscriptions are kept across Reconnect. The
+// channel is closed by Unsubscribe, or when the connection is closed.
+func (c *Conn) Subscribe(mask EventMask) <-chan Event {
+	c.subsLock.Lock()
+	defer c.subsLock.Unlock()
+
+	sub := &subscription{
+		mask:   mask,
+		events: make(chan Event, subscriptionBuffer),
+	}
+	c.subs = append(c.subs, sub)
+	return sub.events
+}
+
+// Unsubscribe ends the subscription that returned 'events', and closes it.
+// Events the channel still holds can be taken off it afterwards.
+func (c *Conn) Unsubscribe(events <-chan Event) {
+	c.subsLock.Lock()
+	defer c.subsLock.Unlock()
+
+	for i, sub := range c.subs {
+		if sub.events == events {
+			close(sub.events)
+			c.subs = append(c.subs[:i], c.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// deliverEvent sends 'ev', whose code is 'code', to the subscriptions that
+// want it, without blocking. It returns whether there were any.
+func (c *Conn) deliverEvent(code int, ev Event) bool {
+	c.subsLock.RLock()
+	defer c.subsLock.RUnlock()
+
+	policy := DropPolicy(c.dropPolicy.Load())
+	delivered := false
+	for _, sub := range c.subs {
+		if !sub.mask.Has(code) {
+			continue
+		}
+		delivered = true
+		sub.send(ev, policy)
+	}
+
+	c.virtualLock.Lock()
+	defer c.virtualLock.Unlock()
+	for _, v := range c.virtuals {
+		if v.deliverEvent(code, ev) {
+			delivered = true
+		}
+	}
+	return delivered
+}
+
+// send sends 'ev' to the subscription without blocking, losing an event as
+// 'policy' says if the channel is full.
+func (sub *subscription) send(ev Event, policy DropPolicy) {
+	// Each pass either sends the event, or takes an event off the channel
+	// to make room for it, so this ends.
+	for {
+		select {
+		case sub.events <- ev:
+			return
+		default:
+		}
+		if policy != DropOldest {
+			return
+		}
+		select {
+		case <-sub.events:
+		default:
+		}
+	}
+}
+
+// closeSubscriptions closes the channel of every subscription, and ends them.
+func (c *Conn) closeSubscriptions() {
+	c.subsLock.Lock()
+	defer c.subsLock.Unlock()
+
+	for _, sub := range c.subs {
+		close(sub.events)
+	}
+	c.subs = nil
+}
@@ -0,0 +1,121 @@
+package xgb
+
+import (
+	"testing"
+)
+
+// TestEventMask makes sure event masks have the codes they are made of, and
+// ignore the bit that marks events sent with SendEvent.
+func TestEventMask(t *testing.T) {
+	mask := NewEventMask(2, 64, 100)
+	for _, code := range []int{2, 64, 100, 2 | 128} {
+		if !mask.Has(code) {
+			t.Fatalf("The mask doesn't have the event code %d.",
+				code)
+		}
+	}
+	for _, code := range []int{0, 3, 63, 127} {
+		if mask.Has(code) {
+			t.Fatalf("The mask has the event code %d.", code)
+		}
+	}
+}
+
+// testEventN is a stand-in for an event generated by xgbgen that tells
+// events apart.
+type testEventN int
+
+func (testEventN) Bytes() []byte  { return nil }
+func (testEventN) String() string { return "testEventN" }
+
+// TestSubscribe makes sure events are only sent to the subscriptions that
+// want them, and that Unsubscribe closes the channel.
+func TestSubscribe(t *testing.T) {
+	c := &Conn{}
+	keys := c.Subscribe(NewEventMask(2, 3))
+	configure := c.Subscribe(NewEventMask(22))
+	both := c.Subscribe(NewEventMask(2, 22))
+
+	if !c.deliverEvent(2, testEventN(2)) {
+		t.Fatalf("An event with subscribers wasn't delivered.")
+	}
+	if !c.deliverEvent(22, testEventN(22)) {
+		t.Fatalf("An event with subscribers wasn't delivered.")
+	}
+	if c.deliverEvent(12, testEventN(12)) {
+		t.Fatalf("An event without subscribers was delivered.")
+	}
+
+	expect := func(events <-chan Event, want ...int) {
+		t.Helper()
+		for _, n := range want {
+			select {
+			case ev := <-events:
+				if ev != testEventN(n) {
+					t.Fatalf("Expected event %d, "+
+						"but got %v.", n, ev)
+				}
+			default:
+				t.Fatalf("Expected event %d, but got none.", n)
+			}
+		}
+		select {
+		case ev := <-events:
+			t.Fatalf("Expected no more events, but got %v.", ev)
+		default:
+		}
+	}
+	expect(keys, 2)
+	expect(configure, 22)
+	expect(both, 2, 22)
+
+	c.Unsubscribe(keys)
+	if _, ok := <-keys; ok {
+		t.Fatalf("Unsubscribe didn't close the channel.")
+	}
+	if c.deliverEvent(3, testEventN(3)) {
+		t.Fatalf("An event was delivered to an ended subscription.")
+	}
+
+	c.closeSubscriptions()
+	for _, events := range []<-chan Event{configure, both} {
+		if _, ok := <-events; ok {
+			t.Fatalf("The channel wasn't closed with the " +
+				"connection.")
+		}
+	}
+}
+
+// TestDropPolicy fills a subscription, and makes sure the right event is
+// lost with each DropPolicy.
+func TestDropPolicy(t *testing.T) {
+	for _, policy := range []DropPolicy{DropNewest, DropOldest} {
+		c := &Conn{}
+		c.SetDropPolicy(policy)
+		events := c.Subscribe(NewEventMask(2))
+		for i := 0; i <= subscriptionBuffer; i++ {
+			c.deliverEvent(2, testEventN(i))
+		}
+		if n := len(events); n != subscriptionBuffer {
+			t.Fatalf("Expected %d events, but got %d.",
+				subscriptionBuffer, n)
+		}
+
+		first, last := testEventN(0), testEventN(subscriptionBuffer-1)
+		if policy == DropOldest {
+			first, last = 1, subscriptionBuffer
+		}
+		if ev := <-events; ev != first {
+			t.Fatalf("Expected the first event to be %v, "+
+				"but got %v.", first, ev)
+		}
+		var ev Event
+		for len(events) > 0 {
+			ev = <-events
+		}
+		if ev != last {
+			t.Fatalf("Expected the last event to be %v, "+
+				"but got %v.", last, ev)
+		}
+	}
+}
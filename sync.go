@@ -1,14 +1,64 @@
 package xgb
 
+import (
+	"sync"
+	"time"
+)
+
+// syncCookies holds the cookies of the GetInputFocus requests Sync has
+// had the reply to, for the next calls to reuse. Since Cookie.Check calls
+// Sync whenever a request hasn't been answered yet, this saves allocating
+// a cookie and its channels for most Checks.
+//
+// Only these cookies are reused: any other one may still be held (and
+// read from again) by the code that made the request.
+var syncCookies sync.Pool
+
 // Sync sends a round trip request and waits for the response.
 // This forces all pending cookies to be dealt with.
 // You actually shouldn't need to use this like you might with Xlib. Namely,
 // buffers are automatically flushed using Go's channels and round trip requests
 // are forced where appropriate automatically.
 func (c *Conn) Sync() {
-	cookie := c.NewCookie(true, true)
+	cookie, _ := syncCookies.Get().(*Cookie)
+	if cookie == nil {
+		cookie = c.NewCookie(true, true)
+	}
+	cookie.conn = c
 	c.NewRequest(c.getInputFocusRequest(), cookie)
-	cookie.Reply() // wait for the buffer to clear
+
+	// Wait for the buffer to clear. A cookie that got an error instead of
+	// the reply isn't reused, since the request may still be sent after
+	// reconnecting.
+	if _, err := cookie.Reply(); err == nil {
+		cookie.Sequence, cookie.seqnumFull = 0, 0
+		syncCookies.Put(cookie)
+	}
+}
+
+// MeasureRTT sends a GetInputFocus request, which does nothing, and returns
+// how long it took to get the reply: the round trip time to the X server.
+// It can be called while other requests are in flight, but since the X
+// server answers requests in order, the time includes that of handling the
+// requests sent before it. It returns an error if the connection was
+// stopped before the reply came.
+func (c *Conn) MeasureRTT() (time.Duration, error) {
+	start := time.Now()
+	if _, ok := c.syncRequest(c.currentDone(),
+		c.getInputFocusRequest()); !ok {
+
+		return 0, c.stopReason()
+	}
+	return time.Since(start), nil
+}
+
+// Ping checks that the X server is still there, by making a round trip to
+// it (as MeasureRTT does). It returns nil once the X server has answered,
+// and the reason the connection was lost otherwise (e.g.,
+// a ConnectionClosedError).
+func (c *Conn) Ping() error {
+	_, err := c.MeasureRTT()
+	return err
 }
 
 // getInputFocusRequest writes the raw bytes to a buffer.
@@ -0,0 +1,54 @@
+package xgb
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestMeasureRTT measures the round trip time to a server that answers
+// right away, and makes sure MeasureRTT fails once the connection is closed.
+func TestMeasureRTT(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	go replyServer(<-conns)
+
+	// A request without a reply in flight doesn't get in the way.
+	c.NewRequest(noOperationRequest(), c.NewCookie(false, false))
+	rtt, err := c.MeasureRTT()
+	if err != nil {
+		t.Fatalf("MeasureRTT: %s", err)
+	}
+	if rtt <= 0 {
+		t.Fatalf("Expected a positive round trip time, but got %s.",
+			rtt)
+	}
+
+	c.Close()
+	if _, err := c.MeasureRTT(); err == nil {
+		t.Fatalf("MeasureRTT succeeded after Close.")
+	}
+}
+
+// TestPing pings a server that answers, and one that is gone.
+func TestPing(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	server := <-conns
+	go replyServer(server)
+
+	if err := c.Ping(); err != nil {
+		t.Fatalf("Ping: %s", err)
+	}
+	server.Close()
+	var lost ConnectionClosedError
+	if err := c.Ping(); !errors.As(err, &lost) {
+		t.Fatalf("Expected a ConnectionClosedError, but got %v.", err)
+	}
+}
@@ -0,0 +1,38 @@
+package xgb
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+)
+
+// DialTLS is just like NewConnDisplay, but wraps the connection to the X
+// server in TLS (using tls.Client with 'cfg') before the setup handshake.
+// This is useful when the X server is behind a TLS proxy like stunnel.
+// If cfg.ServerName is empty, the host in the display string is used.
+//
+// Since the X server is reached through TLS, RawConn returns the *tls.Conn,
+// which cannot be used with pollers that need a file descriptor.
+func DialTLS(display string, cfg *tls.Config) (*Conn, error) {
+	if cfg == nil {
+		cfg = &tls.Config{}
+	}
+	return newConn(context.Background(),
+		&Conn{displayName: display, tlsConfig: cfg})
+}
+
+// startTLS wraps 'conn' in TLS and completes the TLS handshake.
+func (c *Conn) startTLS(conn net.Conn) error {
+	cfg := c.tlsConfig
+	if len(cfg.ServerName) == 0 {
+		cfg = cfg.Clone()
+		cfg.ServerName = c.host
+	}
+
+	tlsConn := tls.Client(conn, cfg)
+	c.conn = tlsConn
+	if err := tlsConn.Handshake(); err != nil {
+		return ConnError{ConnErrorIOFailed, "TLS handshake failed", err}
+	}
+	return nil
+}
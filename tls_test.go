@@ -0,0 +1,86 @@
+package xgb
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// TestDialTLS completes the setup handshake with a server that only speaks
+// TLS.
+func TestDialTLS(t *testing.T) {
+	cert, pool := testCertificate(t)
+	display := tlsServer(t, cert)
+
+	c, err := DialTLS(display,
+		&tls.Config{RootCAs: pool, ServerName: "xgb.test"})
+	if err != nil {
+		t.Fatalf("DialTLS: %s", err)
+	}
+	defer c.Close()
+
+	if _, ok := c.RawConn().(*tls.Conn); !ok {
+		t.Fatalf("Expected the connection to use TLS, but it is %T.",
+			c.RawConn())
+	}
+}
+
+// TestDialTLSUntrusted makes sure that a server whose certificate can't be
+// verified is rejected.
+func TestDialTLSUntrusted(t *testing.T) {
+	cert, _ := testCertificate(t)
+	display := tlsServer(t, cert)
+
+	_, err := DialTLS(display, &tls.Config{ServerName: "xgb.test"})
+	checkConnError(t, err, ConnErrorIOFailed)
+}
+
+// tlsServer is like setupServer, except that it speaks TLS using 'cert'.
+func tlsServer(t *testing.T, cert tls.Certificate) string {
+	display, conns := listen(t)
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}}
+	go func() {
+		for conn := range conns {
+			tlsConn := tls.Server(conn, cfg)
+			if readSetupRequest(tlsConn) != nil {
+				continue
+			}
+			tlsConn.Write(setupResponse())
+		}
+	}()
+	return display
+}
+
+// testCertificate creates a self-signed certificate for "xgb.test", and
+// returns it along with a pool that trusts it.
+func testCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		DNSNames:     []string{"xgb.test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl,
+		&key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %s", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate: %s", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key},
+		pool
+}
@@ -0,0 +1,228 @@
+package xgb
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Tracer is told about every request written to the X server, and every
+// reply, event and error read from it. See SetTracer. The payloads are the
+// whole requests and responses as they are on the wire, and must not be
+// modified or kept after the method returns.
+//
+// TraceRequest is called by the goroutine that writes requests, and the
+// other methods by the one that reads responses, so a Tracer must be safe
+// for use by two goroutines at once. Both wait for it, so it should be fast.
+type Tracer interface {
+	// TraceRequest is called with each request before it is written,
+	// with its sequence number and major opcode.
+	TraceRequest(seq uint16, opcode byte, payload []byte)
+
+	// TraceReply is called with each reply, and the sequence number of
+	// the request it answers.
+	TraceReply(seq uint16, payload []byte)
+
+	// TraceEvent is called with each event.
+	TraceEvent(payload []byte)
+
+	// TraceError is called with each X error, and the sequence number of
+	// the request that caused it.
+	TraceError(seq uint16, payload []byte)
+}
+
+// SetTracer makes 'tracer' see the X protocol traffic on the connection from
+// now on, including after Reconnect. A nil tracer turns tracing off.
+func (c *Conn) SetTracer(tracer Tracer) {
+	c = c.socket()
+	c.traceLock.Lock()
+	defer c.traceLock.Unlock()
+
+	c.userTracer = tracer
+	c.updateTracer()
+}
+
+// updateTracer makes the tracer used by the connection the one set with
+// SetTracer, the dump started by DumpTo, or both. traceLock must be held.
+func (c *Conn) updateTracer() {
+	var tracer Tracer
+	switch {
+	case c.userTracer != nil && c.dumper != nil:
+		tracer = teeTracer{c.userTracer, c.dumper}
+	case c.userTracer != nil:
+		tracer = c.userTracer
+	case c.dumper != nil:
+		tracer = c.dumper
+	default:
+		c.tracer.Store(nil)
+		return
+	}
+	c.tracer.Store(&tracer)
+}
+
+// loadTracer returns the Tracer the connection uses, or nil.
+func (c *Conn) loadTracer() Tracer {
+	if tracer := c.tracer.Load(); tracer != nil {
+		return *tracer
+	}
+	return nil
+}
+
+// teeTracer is a Tracer that passes everything on to two others.
+type teeTracer struct {
+	a, b Tracer
+}
+
+func (t teeTracer) TraceRequest(seq uint16, opcode byte, payload []byte) {
+	t.a.TraceRequest(seq, opcode, payload)
+	t.b.TraceRequest(seq, opcode, payload)
+}
+
+func (t teeTracer) TraceReply(seq uint16, payload []byte) {
+	t.a.TraceReply(seq, payload)
+	t.b.TraceReply(seq, payload)
+}
+
+func (t teeTracer) TraceEvent(payload []byte) {
+	t.a.TraceEvent(payload)
+	t.b.TraceEvent(payload)
+}
+
+func (t teeTracer) TraceError(seq uint16, payload []byte) {
+	t.a.TraceError(seq, payload)
+	t.b.TraceError(seq, payload)
+}
+
+// printTracer is the Tracer returned by NewPrintTracer.
+type printTracer struct {
+	lock sync.Mutex
+	w    io.Writer
+}
+
+// NewPrintTracer returns a Tracer that writes a line to 'w' for each request
+// and response, saying what it is and how long. For example:
+//
+//	-> request 7: opcode 16 (InternAtom), data 0, 20 bytes
+//	<- reply 7: data 0, 32 bytes
+//	<- event 22 (ConfigureNotify): 32 bytes
+//	<- error 8: code 3 (BadWindow), bad value 0x0, opcode 8.0
+//
+// Requests of extensions (whose opcodes are 128 and up) and their events and
+// errors are only shown with their numbers.
+func NewPrintTracer(w io.Writer) Tracer {
+	return &printTracer{w: w}
+}
+
+func (t *printTracer) printf(format string, args ...interface{}) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	fmt.Fprintf(t.w, format, args...)
+}
+
+func (t *printTracer) TraceRequest(seq uint16, opcode byte, payload []byte) {
+	t.printf("-> request %d: opcode %d%s, data %d, %d bytes\n", seq,
+		opcode, traceName(requestNames, int(opcode)), payload[1],
+		len(payload))
+}
+
+func (t *printTracer) TraceReply(seq uint16, payload []byte) {
+	t.printf("<- reply %d: data %d, %d bytes\n", seq, payload[1],
+		len(payload))
+}
+
+func (t *printTracer) TraceEvent(payload []byte) {
+	code := int(payload[0] & 127)
+	sent := ""
+	if payload[0]&128 != 0 {
+		sent = " (sent)"
+	}
+	t.printf("<- event %d%s%s: %d bytes\n", code,
+		traceName(eventNames, code), sent, len(payload))
+}
+
+func (t *printTracer) TraceError(seq uint16, payload []byte) {
+	t.printf("<- error %d: code %d%s, bad value %#x, opcode %d.%d\n", seq,
+		payload[1], traceName(errorNames, int(payload[1])),
+		Get32(payload[4:]), payload[10], Get16(payload[8:]))
+}
+
+// traceName returns " (name)" if 'names' has a name for 'code', and an
+// empty string otherwise.
+func traceName(names []string, code int) string {
+	if code < len(names) && names[code] != "" {
+		return " (" + names[code] + ")"
+	}
+	return ""
+}
+
+// requestNames are the names of the core requests, by opcode.
+var requestNames = []string{
+	1: "CreateWindow", 2: "ChangeWindowAttributes",
+	3: "GetWindowAttributes", 4: "DestroyWindow",
+	5: "DestroySubwindows", 6: "ChangeSaveSet", 7: "ReparentWindow",
+	8: "MapWindow", 9: "MapSubwindows", 10: "UnmapWindow",
+	11: "UnmapSubwindows", 12: "ConfigureWindow", 13: "CirculateWindow",
+	14: "GetGeometry", 15: "QueryTree", 16: "InternAtom",
+	17: "GetAtomName", 18: "ChangeProperty", 19: "DeleteProperty",
+	20: "GetProperty", 21: "ListProperties", 22: "SetSelectionOwner",
+	23: "GetSelectionOwner", 24: "ConvertSelection", 25: "SendEvent",
+	26: "GrabPointer", 27: "UngrabPointer", 28: "GrabButton",
+	29: "UngrabButton", 30: "ChangeActivePointerGrab",
+	31: "GrabKeyboard", 32: "UngrabKeyboard", 33: "GrabKey",
+	34: "UngrabKey", 35: "AllowEvents", 36: "GrabServer",
+	37: "UngrabServer", 38: "QueryPointer", 39: "GetMotionEvents",
+	40: "TranslateCoordinates", 41: "WarpPointer", 42: "SetInputFocus",
+	43: "GetInputFocus", 44: "QueryKeymap", 45: "OpenFont",
+	46: "CloseFont", 47: "QueryFont", 48: "QueryTextExtents",
+	49: "ListFonts", 50: "ListFontsWithInfo", 51: "SetFontPath",
+	52: "GetFontPath", 53: "CreatePixmap", 54: "FreePixmap",
+	55: "CreateGC", 56: "ChangeGC", 57: "CopyGC", 58: "SetDashes",
+	59: "SetClipRectangles", 60: "FreeGC", 61: "ClearArea",
+	62: "CopyArea", 63: "CopyPlane", 64: "PolyPoint", 65: "PolyLine",
+	66: "PolySegment", 67: "PolyRectangle", 68: "PolyArc",
+	69: "FillPoly", 70: "PolyFillRectangle", 71: "PolyFillArc",
+	72: "PutImage", 73: "GetImage", 74: "PolyText8", 75: "PolyText16",
+	76: "ImageText8", 77: "ImageText16", 78: "CreateColormap",
+	79: "FreeColormap", 80: "CopyColormapAndFree", 81: "InstallColormap",
+	82: "UninstallColormap", 83: "ListInstalledColormaps",
+	84: "AllocColor", 85: "AllocNamedColor", 86: "AllocColorCells",
+	87: "AllocColorPlanes", 88: "FreeColors", 89: "StoreColors",
+	90: "StoreNamedColor", 91: "QueryColors", 92: "LookupColor",
+	93: "CreateCursor", 94: "CreateGlyphCursor", 95: "FreeCursor",
+	96: "RecolorCursor", 97: "QueryBestSize", 98: "QueryExtension",
+	99: "ListExtensions", 100: "ChangeKeyboardMapping",
+	101: "GetKeyboardMapping", 102: "ChangeKeyboardControl",
+	103: "GetKeyboardControl", 104: "Bell", 105: "ChangePointerControl",
+	106: "GetPointerControl", 107: "SetScreenSaver",
+	108: "GetScreenSaver", 109: "ChangeHosts", 110: "ListHosts",
+	111: "SetAccessControl", 112: "SetCloseDownMode", 113: "KillClient",
+	114: "RotateProperties", 115: "ForceScreenSaver",
+	116: "SetPointerMapping", 117: "GetPointerMapping",
+	118: "SetModifierMapping", 119: "GetModifierMapping",
+	127: "NoOperation",
+}
+
+// eventNames are the names of the core events, by code.
+var eventNames = []string{
+	2: "KeyPress", 3: "KeyRelease", 4: "ButtonPress", 5: "ButtonRelease",
+	6: "MotionNotify", 7: "EnterNotify", 8: "LeaveNotify", 9: "FocusIn",
+	10: "FocusOut", 11: "KeymapNotify", 12: "Expose",
+	13: "GraphicsExposure", 14: "NoExposure", 15: "VisibilityNotify",
+	16: "CreateNotify", 17: "DestroyNotify", 18: "UnmapNotify",
+	19: "MapNotify", 20: "MapRequest", 21: "ReparentNotify",
+	22: "ConfigureNotify", 23: "ConfigureRequest", 24: "GravityNotify",
+	25: "ResizeRequest", 26: "CirculateNotify", 27: "CirculateRequest",
+	28: "PropertyNotify", 29: "SelectionClear", 30: "SelectionRequest",
+	31: "SelectionNotify", 32: "ColormapNotify", 33: "ClientMessage",
+	34: "MappingNotify", 35: "GenericEvent",
+}
+
+// errorNames are the names of the core errors, by code.
+var errorNames = []string{
+	1: "BadRequest", 2: "BadValue", 3: "BadWindow", 4: "BadPixmap",
+	5: "BadAtom", 6: "BadCursor", 7: "BadFont", 8: "BadMatch",
+	9: "BadDrawable", 10: "BadAccess", 11: "BadAlloc", 12: "BadColormap",
+	13: "BadGContext", 14: "BadIDChoice", 15: "BadName", 16: "BadLength",
+	17: "BadImplementation",
+}
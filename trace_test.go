@@ -0,0 +1,91 @@
+package xgb
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// traceRecorder is a Tracer that remembers what it was told.
+type traceRecorder struct {
+	lock  sync.Mutex
+	lines []string
+}
+
+func (r *traceRecorder) add(line string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.lines = append(r.lines, line)
+}
+
+func (r *traceRecorder) TraceRequest(seq uint16, opcode byte, buf []byte) {
+	r.add(fmt.Sprintf("request %d %d", seq, opcode))
+}
+
+func (r *traceRecorder) TraceReply(seq uint16, buf []byte) {
+	r.add(fmt.Sprintf("reply %d", seq))
+}
+
+func (r *traceRecorder) TraceEvent(buf []byte)             { r.add("event") }
+func (r *traceRecorder) TraceError(seq uint16, buf []byte) { r.add("error") }
+
+// TestTracer traces a request without a reply and one with a reply, and
+// makes sure nothing is traced once the tracer is removed.
+func TestTracer(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	go replyServer(<-conns)
+
+	r := &traceRecorder{}
+	c.SetTracer(r)
+	c.NewRequest(noOperationRequest(), c.NewCookie(false, false))
+	c.Sync()
+
+	c.SetTracer(nil)
+	c.Sync()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	want := []string{"request 1 127", "request 2 43", "reply 2"}
+	if strings.Join(r.lines, "|") != strings.Join(want, "|") {
+		t.Fatalf("Expected the trace %q, but got %q.", want, r.lines)
+	}
+}
+
+// TestPrintTracer makes sure the print tracer names core requests, events
+// and errors.
+func TestPrintTracer(t *testing.T) {
+	var out bytes.Buffer
+	tracer := NewPrintTracer(&out)
+
+	tracer.TraceRequest(7, 16, make([]byte, 20))
+	reply := make([]byte, 32)
+	reply[0] = 1
+	tracer.TraceReply(7, reply)
+	event := make([]byte, 32)
+	event[0] = 22 | 128
+	tracer.TraceEvent(event)
+	xerr := make([]byte, 32)
+	xerr[1] = 3
+	xerr[10] = 8
+	Put32(xerr[4:], 0x42)
+	tracer.TraceError(8, xerr)
+	tracer.TraceRequest(9, 140, make([]byte, 4))
+
+	want := "-> request 7: opcode 16 (InternAtom), data 0, 20 bytes\n" +
+		"<- reply 7: data 0, 32 bytes\n" +
+		"<- event 22 (ConfigureNotify) (sent): 32 bytes\n" +
+		"<- error 8: code 3 (BadWindow), bad value 0x42, opcode 8.0\n" +
+		"-> request 9: opcode 140, data 0, 4 bytes\n"
+	if out.String() != want {
+		t.Fatalf("Expected the trace\n%s\nbut got\n%s", want,
+			out.String())
+	}
+}
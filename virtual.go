@@ -0,0 +1,185 @@
+package xgb
+
+import "errors"
+
+// virtualIdBlock is how many resource ids a virtual connection reserves at
+// a time from the Conn it was made from.
+const virtualIdBlock = 64
+
+// errVirtualReconnect is returned by Reconnect on a virtual connection.
+var errVirtualReconnect = errors.New("a virtual connection is reconnected " +
+	"along with the connection it was made from")
+
+// VirtualConn is a logical connection to the X server that shares the socket
+// of another Conn. See NewVirtualConn.
+//
+// It embeds a *Conn, so it has the same methods, and that *Conn can be given
+// to xproto and the extension packages like any other (e.g.,
+// xproto.NewWindowId(vc.Conn) gives an id of the virtual connection).
+type VirtualConn struct {
+	*Conn
+}
+
+// NewVirtualConn returns a new virtual connection sharing the socket of c,
+// for programs (like toolkits) that would otherwise open a connection per
+// part of the program. Its requests are sent over c, in order with those of
+// c and of its other virtual connections.
+//
+// A virtual connection has its own resource ids (see NewId), which come from
+// blocks of ids it reserves from c, so no one else sharing the socket is
+// given them. It also has its own subscriptions (see Subscribe), which get
+// the events in their masks; those are then not queued for c. The X server
+// doesn't say who an event is for, though, so WaitForEvent and the like
+// only get the X errors of its own unchecked requests (which c doesn't get),
+// and the reason the socket was closed or lost. Likewise, the handler set
+// with SetErrorHandler only gets the errors of its own requests.
+//
+// The X server numbers the requests of a socket itself, so the cookies of
+// a virtual connection have the sequence numbers of c, but each still gets
+// the response to its own request. Everything else is that of c: the setup
+// information, the extensions initialized, the caches (see Cache), buffered
+// mode, tracing and statistics. Reconnecting c takes its virtual
+// connections along, while Reconnect fails on a virtual connection.
+//
+// Closing a virtual connection leaves c alone: its requests fail from then
+// on, and WaitForEvent returns nil for both the event and the error. Closing
+// c closes its virtual connections too. Calling NewVirtualConn on a virtual
+// connection is the same as calling it on the Conn it was made from.
+func (c *Conn) NewVirtualConn() *VirtualConn {
+	c = c.socket()
+	v := &Conn{
+		parent:        c,
+		DisplayNumber: c.DisplayNumber,
+		DefaultScreen: c.DefaultScreen,
+		SetupBytes:    c.SetupBytes,
+		Extensions:    c.Extensions,
+		reqChan:       c.reqChan,
+		eventChan:     make(chan EventOrError, eventBuffer),
+	}
+
+	c.virtualLock.Lock()
+	defer c.virtualLock.Unlock()
+	if c.isClosed() {
+		v.closeVirtual()
+	} else {
+		c.virtuals = append(c.virtuals, v)
+	}
+	return &VirtualConn{v}
+}
+
+// socket returns the Conn whose socket c uses: c itself, or the Conn it was
+// made from if it's a virtual connection.
+func (c *Conn) socket() *Conn {
+	if c.parent != nil {
+		return c.parent
+	}
+	return c
+}
+
+// virtualXid is newXid for a virtual connection. It takes the next of the
+// resource ids reserved from the parent, reserving more once they run out.
+func (c *Conn) virtualXid() xid {
+	c.failLock.Lock()
+	err := c.failErr
+	c.failLock.Unlock()
+	if err != nil {
+		return xid{id: 0, err: err}
+	}
+
+	if id, ok := c.spareId(); ok {
+		return xid{id: id}
+	}
+
+	// idLock isn't held while waiting for the parent, which may be
+	// reconnecting (see reconnectVirtuals).
+	ids, err := c.parent.GenerateIDs(virtualIdBlock)
+	if err != nil {
+		return xid{id: 0, err: err}
+	}
+	c.idLock.Lock()
+	c.spareIds = append(c.spareIds, ids[1:]...)
+	c.idLock.Unlock()
+	return xid{id: ids[0]}
+}
+
+// closeVirtual closes the virtual connection c: its requests fail from now
+// on, WaitForEvent and the like are told, and its subscriptions are closed.
+// It does nothing if c has been closed already.
+func (c *Conn) closeVirtual() {
+	c.stopLock.Lock()
+	closed := c.closed
+	c.closed = true
+	c.stopLock.Unlock()
+	if closed {
+		return
+	}
+
+	// Unlike setFailErr, this leaves the request queue alone, since it is
+	// that of the parent.
+	c.failLock.Lock()
+	c.failErr = errClosed
+	c.failLock.Unlock()
+	select {
+	case c.eventChan <- errClosed:
+	default:
+		go func() { c.eventChan <- errClosed }()
+	}
+	c.closeSubscriptions()
+}
+
+// removeVirtual forgets the virtual connection 'v' made from c.
+func (c *Conn) removeVirtual(v *Conn) {
+	c.virtualLock.Lock()
+	defer c.virtualLock.Unlock()
+
+	for i, virtual := range c.virtuals {
+		if virtual == v {
+			c.virtuals = append(c.virtuals[:i], c.virtuals[i+1:]...)
+			return
+		}
+	}
+}
+
+// closeVirtuals closes every virtual connection made from c, once c has
+// been closed.
+func (c *Conn) closeVirtuals() {
+	c.virtualLock.Lock()
+	virtuals := c.virtuals
+	c.virtuals = nil
+	c.virtualLock.Unlock()
+
+	for _, v := range virtuals {
+		v.closeVirtual()
+	}
+}
+
+// tellVirtuals queues 'err', the reason the connection to the X server was
+// lost, for WaitForEvent and the like on every virtual connection made from
+// c.
+func (c *Conn) tellVirtuals(err error) {
+	c.virtualLock.Lock()
+	defer c.virtualLock.Unlock()
+
+	for _, v := range c.virtuals {
+		select {
+		case v.eventChan <- err:
+		default:
+			go func(v *Conn) { v.eventChan <- err }(v)
+		}
+	}
+}
+
+// reconnectVirtuals gives the virtual connections made from c the setup
+// information of the new connection to the X server, and drops the resource
+// ids they reserved on the old one. It is called by Reconnect.
+func (c *Conn) reconnectVirtuals() {
+	c.virtualLock.Lock()
+	defer c.virtualLock.Unlock()
+
+	for _, v := range c.virtuals {
+		v.idLock.Lock()
+		v.SetupBytes = c.SetupBytes
+		v.spareIds = nil
+		v.idLock.Unlock()
+	}
+}
@@ -0,0 +1,77 @@
+package xgb
+
+import "testing"
+
+// TestVirtualConn shares a connection between two virtual connections, and
+// makes sure each has its own resource ids, errors and events, and that
+// closing one leaves the others alone.
+func TestVirtualConn(t *testing.T) {
+	NewErrorFuncs[seqErrorCode] = func(buf []byte) Error {
+		return seqError{seq: Get16(buf[2:])}
+	}
+	defer delete(NewErrorFuncs, seqErrorCode)
+
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	go errorServer(<-conns)
+	v1, v2 := c.NewVirtualConn(), c.NewVirtualConn()
+
+	ids := make(map[uint32]bool)
+	for i := 0; i < 2*virtualIdBlock; i++ {
+		for _, conn := range []*Conn{c, v1.Conn, v2.Conn} {
+			id, err := conn.NewId()
+			if err != nil {
+				t.Fatalf("NewId: %s", err)
+			}
+			if ids[id] {
+				t.Fatalf("The id %#x was given out twice.", id)
+			}
+			ids[id] = true
+		}
+	}
+
+	// The error goes to the virtual connection that made the request.
+	v1.NewRequest(noOperationRequest(), v1.NewCookie(false, false))
+	v1.Sync()
+	if _, err, _ := v1.PollForEvent(); err != (seqError{seq: 1}) {
+		t.Fatalf("Expected the error of the request, but got %v.", err)
+	}
+	if ev, err, ok := c.PollForEvent(); ok {
+		t.Fatalf("Expected an empty event queue, but got %v, %v.",
+			ev, err)
+	}
+
+	events := v2.Subscribe(NewEventMask(2))
+	if !c.deliverEvent(2, testEventN(2)) {
+		t.Fatalf("The event wasn't delivered to the subscription.")
+	}
+	if ev := <-events; ev != testEventN(2) {
+		t.Fatalf("Expected the event, but got %v.", ev)
+	}
+
+	v1.Close()
+	cookie := v1.NewCookie(true, true)
+	v1.NewRequest(v1.getInputFocusRequest(), cookie)
+	if _, err := cookie.Reply(); err != errClosed {
+		t.Fatalf("Expected errClosed from the closed virtual "+
+			"connection, but got %v.", err)
+	}
+	if ev, err := v1.WaitForEvent(); ev != nil || err != nil {
+		t.Fatalf("Expected neither an event nor an error, but got "+
+			"%v, %v.", ev, err)
+	}
+	returnsSoon(t, "Sync", v2.Sync)
+
+	c.Close()
+	if _, ok := <-events; ok {
+		t.Fatalf("Closing the connection didn't close the " +
+			"subscription of its virtual connection.")
+	}
+	if _, err := v2.NewId(); err != errClosed {
+		t.Fatalf("Expected errClosed from NewId, but got %v.", err)
+	}
+}
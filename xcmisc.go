@@ -0,0 +1,85 @@
+package xgb
+
+// xcMiscName is the name of the XC-MISC extension, as used by the xcmisc
+// package.
+const xcMiscName = "XC-MISC"
+
+// xidRange asks the XC-MISC extension for a range of resource identifiers
+// that are no longer in use, which generateXIds does once the range given in
+// the setup information is used up. 'major' is the major opcode of XC-MISC.
+// If it is negative, the extension is looked up first, and 'major' is set to
+// its opcode, or to 0 if it isn't available.
+//
+// The range is 'count' identifiers spaced like those made from the
+// resource-id-mask, starting at 'start'. 'count' is 0 if there are no more
+// identifiers, which the X server says (like libxcb expects) by answering
+// with a 'start' of 0 and a 'count' of 1. It returns false if the connection
+// was stopped in the mean time.
+func (c *Conn) xidRange(done chan struct{}, major *int) (start, count uint32,
+	ok bool) {
+
+	if *major < 0 {
+		reply, ok := c.syncRequest(done,
+			c.queryExtensionRequest(xcMiscName))
+		if !ok {
+			return 0, 0, false
+		}
+		*major = 0
+		if reply != nil && reply[8] == 1 {
+			*major = int(reply[9])
+		}
+	}
+	if *major == 0 {
+		return 0, 0, true
+	}
+
+	// GetXIDRange has no arguments.
+	buf := make([]byte, 4)
+	buf[0] = byte(*major)
+	buf[1] = 1 // request opcode
+	Put16(buf[2:], 1)
+	reply, ok := c.syncRequest(done, buf)
+	if !ok || reply == nil {
+		return 0, 0, ok
+	}
+	start, count = Get32(reply[8:]), Get32(reply[12:])
+	if start == 0 && count <= 1 {
+		return 0, 0, true
+	}
+	return start, count, true
+}
+
+// syncRequest sends the request in 'buf', which must have a reply, and waits
+// for it. The reply is nil if the X server sent an error instead. Unlike
+// roundTrip, it goes through the request channel, so it must not be called by
+// sendRequests. It returns false if the connection was stopped in the mean
+// time, without waiting for a response that might only come after
+// reconnecting.
+func (c *Conn) syncRequest(done chan struct{}, buf []byte) ([]byte, bool) {
+	cookie := c.NewCookie(true, true)
+	select {
+	case c.reqChan <- &request{buf: buf, cookie: cookie}:
+	case <-done:
+		return nil, false
+	}
+
+	// Not with flushBuffered: it takes stopLock, which stop holds while
+	// waiting for generateXIds to quit. Nobody waits for the flush either,
+	// since the reply (or 'done') says it happened.
+	if c.socket().buffered.Load() {
+		select {
+		case c.reqChan <- &request{flush: make(chan error, 1)}:
+		case <-done:
+			return nil, false
+		}
+	}
+
+	select {
+	case reply := <-cookie.replyChan:
+		return reply, true
+	case <-cookie.errorChan:
+		return nil, true
+	case <-done:
+		return nil, false
+	}
+}
@@ -0,0 +1,101 @@
+package xgb
+
+import (
+	"net"
+	"testing"
+)
+
+// TestXIDRange generates more than a million resource identifiers on
+// a connection that only has three of its own, so that the rest has to come
+// from XC-MISC, one range of 4096 at a time.
+func TestXIDRange(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the XC-MISC stress test in short mode")
+	}
+	const rangeCount = 4096
+	var ranges [][2]uint32
+	for start := uint32(0x401000); len(ranges)*rangeCount < 1<<20; {
+		ranges = append(ranges, [2]uint32{start, rangeCount})
+		start += rangeCount
+	}
+	c := newTestConn(t, xidServer(t, 0x400000, 0x3, ranges))
+
+	ids, err := c.GenerateIDs(3 + len(ranges)*rangeCount)
+	if err != nil {
+		t.Fatalf("GenerateIDs: %s", err)
+	}
+	for i, id := range ids[:3] {
+		if id != 0x400001+uint32(i) {
+			t.Fatalf("Expected id %d to be %#x, but got %#x.",
+				i, 0x400001+i, id)
+		}
+	}
+	for i, id := range ids[3:] {
+		if id != 0x401000+uint32(i) {
+			t.Fatalf("Expected id %d to be %#x, but got %#x.",
+				i+3, 0x401000+i, id)
+		}
+	}
+	if id, err := c.NewId(); err != errNoMoreIds {
+		t.Fatalf("Expected errNoMoreIds, but got (%#x, %v).", id, err)
+	}
+}
+
+// xidServer accepts a single connection, whose setup information gives it
+// the resource-id-base 'base' and resource-id-mask 'mask'. If 'ranges' is
+// nil, it says XC-MISC isn't available. Otherwise, it answers GetXIDRange
+// requests with the start and count of each of 'ranges' in turn, and then
+// like the X server does once there are no more (with a start of 0 and
+// a count of 1). It returns a display string that can be used to
+// connect to it.
+func xidServer(t *testing.T, base, mask uint32, ranges [][2]uint32) string {
+	display, conns := listen(t)
+	go func() {
+		conn := <-conns
+		if readSetupRequest(conn) != nil {
+			return
+		}
+		resp := setupResponse()
+		Put32(resp[12:], base)
+		Put32(resp[16:], mask)
+		conn.Write(resp)
+		answerXIDRanges(conn, ranges)
+	}()
+	return display
+}
+
+// answerXIDRanges answers the requests on 'conn' like xidServer. XC-MISC gets
+// major opcode 140.
+func answerXIDRanges(conn net.Conn, ranges [][2]uint32) {
+	for seq := uint16(1); ; seq++ {
+		req, err := readRequest(conn)
+		if err != nil {
+			return
+		}
+		reply := make([]byte, 32)
+		reply[0] = 1
+		Put16(reply[2:], seq)
+		switch req[0] {
+		case 98: // QueryExtension
+			if ranges != nil &&
+				string(req[8:8+len(xcMiscName)]) == xcMiscName {
+
+				reply[8] = 1
+				reply[9] = 140
+			}
+		case 140: // GetXIDRange
+			if len(ranges) > 0 {
+				Put32(reply[8:], ranges[0][0])
+				Put32(reply[12:], ranges[0][1])
+				ranges = ranges[1:]
+			} else {
+				Put32(reply[12:], 1)
+			}
+		default:
+			continue
+		}
+		if _, err := conn.Write(reply); err != nil {
+			return
+		}
+	}
+}
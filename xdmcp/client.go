@@ -0,0 +1,263 @@
+package xdmcp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"time"
+)
+
+// The retransmission timeouts recommended by XDMCP: messages are sent
+// again after 2 seconds without an answer, then after twice as long every
+// time, up to 32 seconds.
+const (
+	firstTimeout = 2 * time.Second
+	maxTimeout   = 32 * time.Second
+)
+
+// Client talks to a single display manager, on behalf of a display.
+type Client struct {
+	conn net.Conn
+}
+
+// Dial returns a Client for the display manager at 'manager', a host with
+// an optional port (Port by default).
+func Dial(manager string) (*Client, error) {
+	if _, _, err := net.SplitHostPort(manager); err != nil {
+		manager = net.JoinHostPort(manager, strconv.Itoa(Port))
+	}
+	conn, err := net.Dial("udp", manager)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn}, nil
+}
+
+// Close closes the socket of the client.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Query asks the manager whether it is willing to manage the display,
+// offering the authentication mechanisms 'authNames' (none for null
+// authentication). An UnwillingError is returned if it isn't.
+func (c *Client) Query(ctx context.Context,
+	authNames []string) (*Willing, error) {
+
+	w := newWriter(opQuery)
+	w.arrays(stringsToArrays(authNames))
+
+	var willing *Willing
+	err := c.roundTrip(ctx, w.bytes(), func(op uint16, r *reader) error {
+		switch op {
+		case opWilling:
+			willing = &Willing{
+				AuthenticationName: string(r.array8()),
+				Hostname:           string(r.array8()),
+				Status:             string(r.array8()),
+			}
+		case opUnwilling:
+			hostname := string(r.array8())
+			return UnwillingError{Hostname: hostname,
+				Status: string(r.array8())}
+		default:
+			return errIgnored
+		}
+		return r.err
+	})
+	return willing, err
+}
+
+// ForwardQuery asks the manager to answer the display at 'clientAddress'
+// and 'clientPort' (in network order, as in the packets the display sent)
+// as if it had got a Query from it, offering 'authNames'. This is what
+// a chooser does. The manager answers the display, not the client, so
+// ForwardQuery returns once the message is sent.
+func (c *Client) ForwardQuery(clientAddress, clientPort []byte,
+	authNames []string) error {
+
+	w := newWriter(opForwardQuery)
+	w.array8(clientAddress)
+	w.array8(clientPort)
+	w.arrays(stringsToArrays(authNames))
+	_, err := c.conn.Write(w.bytes())
+	return err
+}
+
+// Request asks the manager to accept the display, as described by 'req',
+// and returns the session it starts for it. A DeclineError is returned if
+// the manager declines.
+func (c *Client) Request(ctx context.Context, req Request) (*Accept, error) {
+	w := newWriter(opRequest)
+	w.card16(req.DisplayNumber)
+	w.card8(byte(len(req.Connections)))
+	for _, conn := range req.Connections {
+		w.card16(conn.Family)
+	}
+	addresses := make([][]byte, len(req.Connections))
+	for i, conn := range req.Connections {
+		addresses[i] = conn.Address
+	}
+	w.arrays(addresses)
+	w.array8([]byte(req.AuthenticationName))
+	w.array8(req.AuthenticationData)
+	w.arrays(stringsToArrays(req.Authorizations))
+	w.array8([]byte(req.ManufacturerDisplayID))
+
+	var accept *Accept
+	err := c.roundTrip(ctx, w.bytes(), func(op uint16, r *reader) error {
+		switch op {
+		case opAccept:
+			accept = &Accept{
+				SessionID:          r.card32(),
+				AuthenticationName: string(r.array8()),
+				AuthenticationData: r.array8(),
+				AuthorizationName:  string(r.array8()),
+				AuthorizationData:  r.array8(),
+			}
+		case opDecline:
+			status := string(r.array8())
+			return DeclineError{Status: status}
+		default:
+			return errIgnored
+		}
+		return r.err
+	})
+	return accept, err
+}
+
+// Manage asks the manager to start the session 'sessionID' (from Accept)
+// on the display 'displayNumber' of class 'class' (which may be empty),
+// by connecting to its X server. The manager doesn't answer over XDMCP
+// when it does, so Manage keeps sending the message, as the protocol asks
+// for, until 'ctx' is done, and then returns nil. It should be canceled
+// once the manager has connected. A RefuseError or a FailedError is
+// returned if the manager gives up first.
+func (c *Client) Manage(ctx context.Context, sessionID uint32,
+	displayNumber uint16, class string) error {
+
+	w := newWriter(opManage)
+	w.card32(sessionID)
+	w.card16(displayNumber)
+	w.array8([]byte(class))
+
+	err := c.roundTrip(ctx, w.bytes(), func(op uint16, r *reader) error {
+		return sessionError(op, r, sessionID)
+	})
+	if err == ctx.Err() {
+		return nil
+	}
+	return err
+}
+
+// KeepAlive asks the manager whether the session 'sessionID' on the
+// display 'displayNumber' is still running.
+func (c *Client) KeepAlive(ctx context.Context, displayNumber uint16,
+	sessionID uint32) (*Alive, error) {
+
+	w := newWriter(opKeepAlive)
+	w.card16(displayNumber)
+	w.card32(sessionID)
+
+	var alive *Alive
+	err := c.roundTrip(ctx, w.bytes(), func(op uint16, r *reader) error {
+		if op != opAlive {
+			return sessionError(op, r, sessionID)
+		}
+		alive = &Alive{SessionRunning: r.card8() != 0,
+			SessionID: r.card32()}
+		return r.err
+	})
+	return alive, err
+}
+
+// errIgnored is returned by the handlers given to roundTrip for messages
+// that aren't answers to the message sent.
+var errIgnored = errors.New("xdmcp: message ignored")
+
+// sessionError returns the error a Refuse or Failed message for the
+// session 'sessionID' stands for, or errIgnored for any other message.
+func sessionError(op uint16, r *reader, sessionID uint32) error {
+	switch op {
+	case opRefuse:
+		if id := r.card32(); r.err == nil && id == sessionID {
+			return RefuseError{SessionID: id}
+		}
+	case opFailed:
+		id := r.card32()
+		status := string(r.array8())
+		if r.err == nil && id == sessionID {
+			return FailedError{SessionID: id, Status: status}
+		}
+	}
+	return errIgnored
+}
+
+// roundTrip sends 'msg' to the manager and calls 'handle' with the
+// messages it gets back, until 'handle' returns something other than
+// errIgnored. 'msg' is sent again when no answer comes in time. The error
+// of 'ctx' is returned if it is done first.
+func (c *Client) roundTrip(ctx context.Context, msg []byte,
+	handle func(op uint16, r *reader) error) error {
+
+	defer c.watchContext(ctx)()
+
+	buf := make([]byte, 65536)
+	for timeout := firstTimeout; ; timeout *= 2 {
+		if timeout > maxTimeout {
+			timeout = maxTimeout
+		}
+		if _, err := c.conn.Write(msg); err != nil {
+			return err
+		}
+		// Checking 'ctx' after setting the deadline makes sure it isn't
+		// set after the one that gives up.
+		c.conn.SetReadDeadline(time.Now().Add(timeout))
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		for {
+			n, err := c.conn.Read(buf)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return ctxErr
+				}
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					break
+				}
+				return err
+			}
+			op, r, err := parseHeader(buf[:n])
+			if err != nil {
+				continue
+			}
+			if err := handle(op, r); err != errIgnored {
+				return err
+			}
+		}
+	}
+}
+
+// watchContext makes reads give up when 'ctx' is done. The returned function
+// must be called once reading is over. It clears the deadline again.
+func (c *Client) watchContext(ctx context.Context) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			// A deadline in the past unblocks the pending read.
+			c.conn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+		c.conn.SetReadDeadline(time.Time{})
+	}
+}
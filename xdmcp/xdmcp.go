@@ -0,0 +1,276 @@
+// Package xdmcp speaks the X Display Manager Control Protocol, over which
+// a display asks a display manager (like xdm, gdm or lightdm) to run a
+// session on it.
+//
+// In XDMCP, the display is the client: the X server (e.g., "Xvfb -query
+// host") finds a willing manager with Query, describes itself with Request,
+// and once it is accepted, asks the manager to connect to it with Manage.
+// It then checks that the session is still running with KeepAlive. This
+// package lets a Go program play the part of the display, e.g., to hand a
+// manager an X server it started itself, or to check which managers are
+// willing:
+//
+//	client, err := xdmcp.Dial("dm.example.com")
+//	...
+//	willing, err := client.Query(ctx, nil)
+//	accept, err := client.Request(ctx, xdmcp.Request{
+//		DisplayNumber:  1,
+//		Connections:    []xdmcp.Connection{{xdmcp.FamilyInternet, ip}},
+//		Authorizations: []string{"MIT-MAGIC-COOKIE-1"},
+//	})
+//	// Until the manager connects to the X server:
+//	err = client.Manage(ctx, accept.SessionID, 1, "MIT-unspecified")
+//
+// The manager still needs an X server to connect to; XDMCP only tells it
+// where one is. Only the null authentication of displays is done by
+// Client, but the names and data of other mechanisms can be passed through.
+//
+// XDMCP isn't part of the X protocol, nor an extension of it: its messages
+// are UDP datagrams, sent to the manager on Port, so this package doesn't use
+// an xgb.Conn at all.
+package xdmcp
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Port is the UDP port display managers listen on.
+const Port = 177
+
+// version is the version of XDMCP in the header of every message.
+const version = 1
+
+// The opcodes of the messages.
+const (
+	opBroadcastQuery = 1
+	opQuery          = 2
+	opIndirectQuery  = 3
+	opForwardQuery   = 4
+	opWilling        = 5
+	opUnwilling      = 6
+	opRequest        = 7
+	opAccept         = 8
+	opDecline        = 9
+	opManage         = 10
+	opRefuse         = 11
+	opFailed         = 12
+	opKeepAlive      = 13
+	opAlive          = 14
+)
+
+// The families of the addresses in Connection.
+const (
+	FamilyInternet  = 0
+	FamilyDECnet    = 1
+	FamilyChaos     = 2
+	FamilyInternet6 = 6
+	FamilyLocal     = 256
+)
+
+// errShortMessage is returned for messages that end before their fields
+// do.
+var errShortMessage = errors.New("xdmcp: message too short")
+
+// Connection is an address the X server of a display can be reached at.
+type Connection struct {
+	Family  uint16 // one of Family*
+	Address []byte // e.g., 4 bytes for FamilyInternet
+}
+
+// Willing is the answer of a manager willing to manage the display, to
+// Query.
+type Willing struct {
+	AuthenticationName string // the mechanism the manager picked
+	Hostname           string
+	Status             string // e.g., the load of the host
+}
+
+// Request describes the display to the manager. See Client.Request.
+type Request struct {
+	DisplayNumber uint16
+	Connections   []Connection
+
+	// AuthenticationName and AuthenticationData authenticate the display
+	// to the manager. Both are usually empty (null authentication).
+	AuthenticationName string
+	AuthenticationData []byte
+
+	// Authorizations are the mechanisms the X server accepts from the
+	// clients the manager starts, e.g., "MIT-MAGIC-COOKIE-1".
+	Authorizations []string
+
+	ManufacturerDisplayID string
+}
+
+// Accept is the answer of a manager that accepted Request. The X server
+// should let the clients of the session in with AuthorizationName and
+// AuthorizationData.
+type Accept struct {
+	SessionID          uint32
+	AuthenticationName string
+	AuthenticationData []byte
+	AuthorizationName  string
+	AuthorizationData  []byte
+}
+
+// Alive is the answer of the manager to KeepAlive.
+type Alive struct {
+	SessionRunning bool
+	SessionID      uint32
+}
+
+// UnwillingError is returned by Client.Query when the manager isn't willing
+// to manage the display.
+type UnwillingError struct {
+	Hostname string
+	Status   string
+}
+
+func (err UnwillingError) Error() string {
+	return "xdmcp: " + err.Hostname + " is unwilling: " + err.Status
+}
+
+// DeclineError is returned by Client.Request when the manager declines it.
+type DeclineError struct {
+	Status string
+}
+
+func (err DeclineError) Error() string {
+	return "xdmcp: request declined: " + err.Status
+}
+
+// RefuseError is returned when the manager refuses Manage (e.g., after the
+// session was accepted with another Request).
+type RefuseError struct {
+	SessionID uint32
+}
+
+func (err RefuseError) Error() string {
+	return "xdmcp: session refused"
+}
+
+// FailedError is returned when the manager couldn't connect to the X
+// server of the display after Manage.
+type FailedError struct {
+	SessionID uint32
+	Status    string
+}
+
+func (err FailedError) Error() string {
+	return "xdmcp: session failed: " + err.Status
+}
+
+// writer builds a message. Every field of XDMCP is in big endian order.
+type writer struct {
+	buf []byte
+}
+
+// newWriter starts a message with the opcode 'op'. Its length is filled in
+// by bytes.
+func newWriter(op uint16) *writer {
+	w := &writer{buf: make([]byte, 6, 64)}
+	binary.BigEndian.PutUint16(w.buf[0:], version)
+	binary.BigEndian.PutUint16(w.buf[2:], op)
+	return w
+}
+
+func (w *writer) card8(v byte) {
+	w.buf = append(w.buf, v)
+}
+
+func (w *writer) card16(v uint16) {
+	w.buf = binary.BigEndian.AppendUint16(w.buf, v)
+}
+
+func (w *writer) card32(v uint32) {
+	w.buf = binary.BigEndian.AppendUint32(w.buf, v)
+}
+
+// array8 writes an ARRAY8, which has a 16-bit length.
+func (w *writer) array8(v []byte) {
+	w.card16(uint16(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+// arrays writes an ARRAYofARRAY8, which has an 8-bit length.
+func (w *writer) arrays(v [][]byte) {
+	w.card8(byte(len(v)))
+	for _, a := range v {
+		w.array8(a)
+	}
+}
+
+// bytes returns the message.
+func (w *writer) bytes() []byte {
+	binary.BigEndian.PutUint16(w.buf[4:], uint16(len(w.buf)-6))
+	return w.buf
+}
+
+// reader reads the fields of a message, after the header. Once a field is
+// cut short, 'err' is set and every read returns zero.
+type reader struct {
+	buf []byte
+	err error
+}
+
+func (r *reader) take(n int) []byte {
+	if r.err != nil || len(r.buf) < n {
+		r.err = errShortMessage
+		return nil
+	}
+	v := r.buf[:n]
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *reader) card8() byte {
+	if v := r.take(1); v != nil {
+		return v[0]
+	}
+	return 0
+}
+
+func (r *reader) card16() uint16 {
+	if v := r.take(2); v != nil {
+		return binary.BigEndian.Uint16(v)
+	}
+	return 0
+}
+
+func (r *reader) card32() uint32 {
+	if v := r.take(4); v != nil {
+		return binary.BigEndian.Uint32(v)
+	}
+	return 0
+}
+
+func (r *reader) array8() []byte {
+	n := r.card16()
+	return append([]byte(nil), r.take(int(n))...)
+}
+
+// parseHeader returns the opcode and the fields of the message 'buf'.
+func parseHeader(buf []byte) (uint16, *reader, error) {
+	if len(buf) < 6 {
+		return 0, nil, errShortMessage
+	}
+	if binary.BigEndian.Uint16(buf) != version {
+		return 0, nil, errors.New("xdmcp: unknown protocol version")
+	}
+	length := int(binary.BigEndian.Uint16(buf[4:]))
+	if len(buf)-6 < length {
+		return 0, nil, errShortMessage
+	}
+	op := binary.BigEndian.Uint16(buf[2:])
+	return op, &reader{buf: buf[6 : 6+length]}, nil
+}
+
+// stringsToArrays converts the names 'names' to ARRAY8s.
+func stringsToArrays(names []string) [][]byte {
+	arrays := make([][]byte, len(names))
+	for i, name := range names {
+		arrays[i] = []byte(name)
+	}
+	return arrays
+}
@@ -0,0 +1,153 @@
+package xdmcp
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakeManager answers the messages sent to it on a UDP socket with
+// 'answer', which returns nil to ignore one. It returns the address of
+// the socket.
+func fakeManager(t *testing.T,
+	answer func(op uint16, r *reader) []byte) string {
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	go func() {
+		buf := make([]byte, 65536)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			op, r, err := parseHeader(buf[:n])
+			if err != nil {
+				continue
+			}
+			if msg := answer(op, r); msg != nil {
+				conn.WriteTo(msg, addr)
+			}
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+// TestClient goes through a session with a fake display manager.
+func TestClient(t *testing.T) {
+	const session = 42
+	requests := make(chan Request, 1)
+	addr := fakeManager(t, func(op uint16, r *reader) []byte {
+		switch op {
+		case opQuery:
+			w := newWriter(opWilling)
+			w.array8(nil)
+			w.array8([]byte("dm.example.com"))
+			w.array8([]byte("load 0.1"))
+			return w.bytes()
+		case opRequest:
+			req := Request{DisplayNumber: r.card16()}
+			for n := r.card8(); n > 0; n-- {
+				req.Connections = append(req.Connections,
+					Connection{Family: r.card16()})
+			}
+			for i := 0; i < int(r.card8()); i++ {
+				req.Connections[i].Address = r.array8()
+			}
+			requests <- req
+			w := newWriter(opAccept)
+			w.card32(session)
+			w.array8(nil)
+			w.array8(nil)
+			w.array8([]byte("MIT-MAGIC-COOKIE-1"))
+			w.array8([]byte("0123456789abcdef"))
+			return w.bytes()
+		case opManage:
+			w := newWriter(opFailed)
+			w.card32(session)
+			w.array8([]byte("cannot connect"))
+			return w.bytes()
+		case opKeepAlive:
+			w := newWriter(opAlive)
+			w.card8(1)
+			w.card32(session)
+			return w.bytes()
+		}
+		return nil
+	})
+
+	c, err := Dial(addr)
+	if err != nil {
+		t.Fatalf("Dial: %s", err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+
+	willing, err := c.Query(ctx, nil)
+	if err != nil {
+		t.Fatalf("Query: %s", err)
+	}
+	if willing.Hostname != "dm.example.com" ||
+		willing.Status != "load 0.1" {
+		t.Fatalf("Expected dm.example.com to be willing, but got %+v.",
+			willing)
+	}
+
+	ip := []byte{127, 0, 0, 1}
+	accept, err := c.Request(ctx, Request{
+		DisplayNumber:  1,
+		Connections:    []Connection{{FamilyInternet, ip}},
+		Authorizations: []string{"MIT-MAGIC-COOKIE-1"},
+	})
+	if err != nil {
+		t.Fatalf("Request: %s", err)
+	}
+	if accept.SessionID != session ||
+		accept.AuthorizationName != "MIT-MAGIC-COOKIE-1" {
+		t.Fatalf("Expected session %d, but got %+v.", session, accept)
+	}
+	req := <-requests
+	if req.DisplayNumber != 1 || len(req.Connections) != 1 ||
+		req.Connections[0].Family != FamilyInternet ||
+		!bytes.Equal(req.Connections[0].Address, ip) {
+		t.Fatalf("Expected display 1 at %v, but the manager got %+v.",
+			ip, req)
+	}
+
+	err = c.Manage(ctx, session, 1, "")
+	if failed, ok := err.(FailedError); !ok || failed.SessionID != session {
+		t.Fatalf("Expected a FailedError, but got %v.", err)
+	}
+
+	alive, err := c.KeepAlive(ctx, 1, session)
+	if err != nil {
+		t.Fatalf("KeepAlive: %s", err)
+	}
+	if !alive.SessionRunning || alive.SessionID != session {
+		t.Fatalf("Expected the session to be running, but got %+v.",
+			alive)
+	}
+}
+
+// TestManageCanceled checks that Manage returns nil when it is canceled,
+// since managers don't answer it when they connect to the display.
+func TestManageCanceled(t *testing.T) {
+	addr := fakeManager(t, func(uint16, *reader) []byte { return nil })
+	c, err := Dial(addr)
+	if err != nil {
+		t.Fatalf("Dial: %s", err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(),
+		50*time.Millisecond)
+	defer cancel()
+	if err := c.Manage(ctx, 42, 1, ""); err != nil {
+		t.Fatalf("Manage: %s", err)
+	}
+}
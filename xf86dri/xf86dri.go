@@ -19,7 +19,7 @@ func Init(c *xgb.Conn) error {
 	case err != nil:
 		return err
 	case !reply.Present:
-		return xgb.Errorf("No extension named XFree86-DRI could be found on on the server.")
+		return xproto.ExtNotAvailableError{Name: "XFree86-DRI", Reply: reply}
 	}
 
 	xgb.ExtLock.Lock()
@@ -140,7 +140,7 @@ type QueryVersionCookie struct {
 // QueryVersion sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryVersionCookie.Reply()
 func QueryVersion(c *xgb.Conn) QueryVersionCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'QueryVersion' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -151,7 +151,7 @@ func QueryVersion(c *xgb.Conn) QueryVersionCookie {
 // QueryVersionUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryVersionUnchecked(c *xgb.Conn) QueryVersionCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'QueryVersion' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -213,7 +213,7 @@ func queryVersionRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-DRI"]
+	buf[b] = c.Extensions["XFree86-DRI"]
 	b += 1
 
 	buf[b] = 0 // request opcode
@@ -233,7 +233,7 @@ type QueryDirectRenderingCapableCookie struct {
 // QueryDirectRenderingCapable sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryDirectRenderingCapableCookie.Reply()
 func QueryDirectRenderingCapable(c *xgb.Conn, Screen uint32) QueryDirectRenderingCapableCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'QueryDirectRenderingCapable' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -244,7 +244,7 @@ func QueryDirectRenderingCapable(c *xgb.Conn, Screen uint32) QueryDirectRenderin
 // QueryDirectRenderingCapableUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryDirectRenderingCapableUnchecked(c *xgb.Conn, Screen uint32) QueryDirectRenderingCapableCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'QueryDirectRenderingCapable' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -302,7 +302,7 @@ func queryDirectRenderingCapableRequest(c *xgb.Conn, Screen uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-DRI"]
+	buf[b] = c.Extensions["XFree86-DRI"]
 	b += 1
 
 	buf[b] = 1 // request opcode
@@ -325,7 +325,7 @@ type OpenConnectionCookie struct {
 // OpenConnection sends a checked request.
 // If an error occurs, it will be returned with the reply by calling OpenConnectionCookie.Reply()
 func OpenConnection(c *xgb.Conn, Screen uint32) OpenConnectionCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'OpenConnection' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -336,7 +336,7 @@ func OpenConnection(c *xgb.Conn, Screen uint32) OpenConnectionCookie {
 // OpenConnectionUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func OpenConnectionUnchecked(c *xgb.Conn, Screen uint32) OpenConnectionCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'OpenConnection' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -409,7 +409,7 @@ func openConnectionRequest(c *xgb.Conn, Screen uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-DRI"]
+	buf[b] = c.Extensions["XFree86-DRI"]
 	b += 1
 
 	buf[b] = 2 // request opcode
@@ -432,7 +432,7 @@ type CloseConnectionCookie struct {
 // CloseConnection sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func CloseConnection(c *xgb.Conn, Screen uint32) CloseConnectionCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'CloseConnection' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -443,7 +443,7 @@ func CloseConnection(c *xgb.Conn, Screen uint32) CloseConnectionCookie {
 // CloseConnectionChecked sends a checked request.
 // If an error occurs, it can be retrieved using CloseConnectionCookie.Check()
 func CloseConnectionChecked(c *xgb.Conn, Screen uint32) CloseConnectionCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'CloseConnection' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -464,7 +464,7 @@ func closeConnectionRequest(c *xgb.Conn, Screen uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-DRI"]
+	buf[b] = c.Extensions["XFree86-DRI"]
 	b += 1
 
 	buf[b] = 3 // request opcode
@@ -487,7 +487,7 @@ type GetClientDriverNameCookie struct {
 // GetClientDriverName sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetClientDriverNameCookie.Reply()
 func GetClientDriverName(c *xgb.Conn, Screen uint32) GetClientDriverNameCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'GetClientDriverName' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -498,7 +498,7 @@ func GetClientDriverName(c *xgb.Conn, Screen uint32) GetClientDriverNameCookie {
 // GetClientDriverNameUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetClientDriverNameUnchecked(c *xgb.Conn, Screen uint32) GetClientDriverNameCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'GetClientDriverName' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -575,7 +575,7 @@ func getClientDriverNameRequest(c *xgb.Conn, Screen uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-DRI"]
+	buf[b] = c.Extensions["XFree86-DRI"]
 	b += 1
 
 	buf[b] = 4 // request opcode
@@ -598,7 +598,7 @@ type CreateContextCookie struct {
 // CreateContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling CreateContextCookie.Reply()
 func CreateContext(c *xgb.Conn, Screen uint32, Visual uint32, Context uint32) CreateContextCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'CreateContext' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -609,7 +609,7 @@ func CreateContext(c *xgb.Conn, Screen uint32, Visual uint32, Context uint32) Cr
 // CreateContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func CreateContextUnchecked(c *xgb.Conn, Screen uint32, Visual uint32, Context uint32) CreateContextCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'CreateContext' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -663,7 +663,7 @@ func createContextRequest(c *xgb.Conn, Screen uint32, Visual uint32, Context uin
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-DRI"]
+	buf[b] = c.Extensions["XFree86-DRI"]
 	b += 1
 
 	buf[b] = 5 // request opcode
@@ -692,7 +692,7 @@ type DestroyContextCookie struct {
 // DestroyContext sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func DestroyContext(c *xgb.Conn, Screen uint32, Context uint32) DestroyContextCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'DestroyContext' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -703,7 +703,7 @@ func DestroyContext(c *xgb.Conn, Screen uint32, Context uint32) DestroyContextCo
 // DestroyContextChecked sends a checked request.
 // If an error occurs, it can be retrieved using DestroyContextCookie.Check()
 func DestroyContextChecked(c *xgb.Conn, Screen uint32, Context uint32) DestroyContextCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'DestroyContext' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -724,7 +724,7 @@ func destroyContextRequest(c *xgb.Conn, Screen uint32, Context uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-DRI"]
+	buf[b] = c.Extensions["XFree86-DRI"]
 	b += 1
 
 	buf[b] = 6 // request opcode
@@ -750,7 +750,7 @@ type CreateDrawableCookie struct {
 // CreateDrawable sends a checked request.
 // If an error occurs, it will be returned with the reply by calling CreateDrawableCookie.Reply()
 func CreateDrawable(c *xgb.Conn, Screen uint32, Drawable uint32) CreateDrawableCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'CreateDrawable' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -761,7 +761,7 @@ func CreateDrawable(c *xgb.Conn, Screen uint32, Drawable uint32) CreateDrawableC
 // CreateDrawableUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func CreateDrawableUnchecked(c *xgb.Conn, Screen uint32, Drawable uint32) CreateDrawableCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'CreateDrawable' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -815,7 +815,7 @@ func createDrawableRequest(c *xgb.Conn, Screen uint32, Drawable uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-DRI"]
+	buf[b] = c.Extensions["XFree86-DRI"]
 	b += 1
 
 	buf[b] = 7 // request opcode
@@ -841,7 +841,7 @@ type DestroyDrawableCookie struct {
 // DestroyDrawable sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func DestroyDrawable(c *xgb.Conn, Screen uint32, Drawable uint32) DestroyDrawableCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'DestroyDrawable' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -852,7 +852,7 @@ func DestroyDrawable(c *xgb.Conn, Screen uint32, Drawable uint32) DestroyDrawabl
 // DestroyDrawableChecked sends a checked request.
 // If an error occurs, it can be retrieved using DestroyDrawableCookie.Check()
 func DestroyDrawableChecked(c *xgb.Conn, Screen uint32, Drawable uint32) DestroyDrawableCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'DestroyDrawable' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -873,7 +873,7 @@ func destroyDrawableRequest(c *xgb.Conn, Screen uint32, Drawable uint32) []byte
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-DRI"]
+	buf[b] = c.Extensions["XFree86-DRI"]
 	b += 1
 
 	buf[b] = 8 // request opcode
@@ -899,7 +899,7 @@ type GetDrawableInfoCookie struct {
 // GetDrawableInfo sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetDrawableInfoCookie.Reply()
 func GetDrawableInfo(c *xgb.Conn, Screen uint32, Drawable uint32) GetDrawableInfoCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'GetDrawableInfo' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -910,7 +910,7 @@ func GetDrawableInfo(c *xgb.Conn, Screen uint32, Drawable uint32) GetDrawableInf
 // GetDrawableInfoUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetDrawableInfoUnchecked(c *xgb.Conn, Screen uint32, Drawable uint32) GetDrawableInfoCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'GetDrawableInfo' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1008,7 +1008,7 @@ func getDrawableInfoRequest(c *xgb.Conn, Screen uint32, Drawable uint32) []byte
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-DRI"]
+	buf[b] = c.Extensions["XFree86-DRI"]
 	b += 1
 
 	buf[b] = 9 // request opcode
@@ -1034,7 +1034,7 @@ type GetDeviceInfoCookie struct {
 // GetDeviceInfo sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetDeviceInfoCookie.Reply()
 func GetDeviceInfo(c *xgb.Conn, Screen uint32) GetDeviceInfoCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'GetDeviceInfo' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1045,7 +1045,7 @@ func GetDeviceInfo(c *xgb.Conn, Screen uint32) GetDeviceInfoCookie {
 // GetDeviceInfoUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetDeviceInfoUnchecked(c *xgb.Conn, Screen uint32) GetDeviceInfoCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'GetDeviceInfo' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1127,7 +1127,7 @@ func getDeviceInfoRequest(c *xgb.Conn, Screen uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-DRI"]
+	buf[b] = c.Extensions["XFree86-DRI"]
 	b += 1
 
 	buf[b] = 10 // request opcode
@@ -1150,7 +1150,7 @@ type AuthConnectionCookie struct {
 // AuthConnection sends a checked request.
 // If an error occurs, it will be returned with the reply by calling AuthConnectionCookie.Reply()
 func AuthConnection(c *xgb.Conn, Screen uint32, Magic uint32) AuthConnectionCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'AuthConnection' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1161,7 +1161,7 @@ func AuthConnection(c *xgb.Conn, Screen uint32, Magic uint32) AuthConnectionCook
 // AuthConnectionUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func AuthConnectionUnchecked(c *xgb.Conn, Screen uint32, Magic uint32) AuthConnectionCookie {
-	if _, ok := c.Extensions["XFREE86-DRI"]; !ok {
+	if _, ok := c.Extensions["XFree86-DRI"]; !ok {
 		panic("Cannot issue request 'AuthConnection' using the uninitialized extension 'XFree86-DRI'. xf86dri.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1215,7 +1215,7 @@ func authConnectionRequest(c *xgb.Conn, Screen uint32, Magic uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-DRI"]
+	buf[b] = c.Extensions["XFree86-DRI"]
 	b += 1
 
 	buf[b] = 11 // request opcode
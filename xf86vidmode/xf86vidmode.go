@@ -19,7 +19,7 @@ func Init(c *xgb.Conn) error {
 	case err != nil:
 		return err
 	case !reply.Present:
-		return xgb.Errorf("No extension named XFree86-VidModeExtension could be found on on the server.")
+		return xproto.ExtNotAvailableError{Name: "XFree86-VidModeExtension", Reply: reply}
 	}
 
 	xgb.ExtLock.Lock()
@@ -557,7 +557,7 @@ type QueryVersionCookie struct {
 // QueryVersion sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryVersionCookie.Reply()
 func QueryVersion(c *xgb.Conn) QueryVersionCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'QueryVersion' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -568,7 +568,7 @@ func QueryVersion(c *xgb.Conn) QueryVersionCookie {
 // QueryVersionUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryVersionUnchecked(c *xgb.Conn) QueryVersionCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'QueryVersion' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -626,7 +626,7 @@ func queryVersionRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 0 // request opcode
@@ -646,7 +646,7 @@ type GetModeLineCookie struct {
 // GetModeLine sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetModeLineCookie.Reply()
 func GetModeLine(c *xgb.Conn, Screen uint16) GetModeLineCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetModeLine' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -657,7 +657,7 @@ func GetModeLine(c *xgb.Conn, Screen uint16) GetModeLineCookie {
 // GetModeLineUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetModeLineUnchecked(c *xgb.Conn, Screen uint16) GetModeLineCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetModeLine' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -766,7 +766,7 @@ func getModeLineRequest(c *xgb.Conn, Screen uint16) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 1 // request opcode
@@ -791,7 +791,7 @@ type ModModeLineCookie struct {
 // ModModeLine sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ModModeLine(c *xgb.Conn, Screen uint32, Hdisplay uint16, Hsyncstart uint16, Hsyncend uint16, Htotal uint16, Hskew uint16, Vdisplay uint16, Vsyncstart uint16, Vsyncend uint16, Vtotal uint16, Flags uint32, Privsize uint32, Private []byte) ModModeLineCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'ModModeLine' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -802,7 +802,7 @@ func ModModeLine(c *xgb.Conn, Screen uint32, Hdisplay uint16, Hsyncstart uint16,
 // ModModeLineChecked sends a checked request.
 // If an error occurs, it can be retrieved using ModModeLineCookie.Check()
 func ModModeLineChecked(c *xgb.Conn, Screen uint32, Hdisplay uint16, Hsyncstart uint16, Hsyncend uint16, Htotal uint16, Hskew uint16, Vdisplay uint16, Vsyncstart uint16, Vsyncend uint16, Vtotal uint16, Flags uint32, Privsize uint32, Private []byte) ModModeLineCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'ModModeLine' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -823,7 +823,7 @@ func modModeLineRequest(c *xgb.Conn, Screen uint32, Hdisplay uint16, Hsyncstart
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 2 // request opcode
@@ -886,7 +886,7 @@ type SwitchModeCookie struct {
 // SwitchMode sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SwitchMode(c *xgb.Conn, Screen uint16, Zoom uint16) SwitchModeCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'SwitchMode' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -897,7 +897,7 @@ func SwitchMode(c *xgb.Conn, Screen uint16, Zoom uint16) SwitchModeCookie {
 // SwitchModeChecked sends a checked request.
 // If an error occurs, it can be retrieved using SwitchModeCookie.Check()
 func SwitchModeChecked(c *xgb.Conn, Screen uint16, Zoom uint16) SwitchModeCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'SwitchMode' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -918,7 +918,7 @@ func switchModeRequest(c *xgb.Conn, Screen uint16, Zoom uint16) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 3 // request opcode
@@ -944,7 +944,7 @@ type GetMonitorCookie struct {
 // GetMonitor sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetMonitorCookie.Reply()
 func GetMonitor(c *xgb.Conn, Screen uint16) GetMonitorCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetMonitor' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -955,7 +955,7 @@ func GetMonitor(c *xgb.Conn, Screen uint16) GetMonitorCookie {
 // GetMonitorUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetMonitorUnchecked(c *xgb.Conn, Screen uint16) GetMonitorCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetMonitor' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1061,7 +1061,7 @@ func getMonitorRequest(c *xgb.Conn, Screen uint16) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 4 // request opcode
@@ -1086,7 +1086,7 @@ type LockModeSwitchCookie struct {
 // LockModeSwitch sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func LockModeSwitch(c *xgb.Conn, Screen uint16, Lock uint16) LockModeSwitchCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'LockModeSwitch' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1097,7 +1097,7 @@ func LockModeSwitch(c *xgb.Conn, Screen uint16, Lock uint16) LockModeSwitchCooki
 // LockModeSwitchChecked sends a checked request.
 // If an error occurs, it can be retrieved using LockModeSwitchCookie.Check()
 func LockModeSwitchChecked(c *xgb.Conn, Screen uint16, Lock uint16) LockModeSwitchCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'LockModeSwitch' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1118,7 +1118,7 @@ func lockModeSwitchRequest(c *xgb.Conn, Screen uint16, Lock uint16) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 5 // request opcode
@@ -1144,7 +1144,7 @@ type GetAllModeLinesCookie struct {
 // GetAllModeLines sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetAllModeLinesCookie.Reply()
 func GetAllModeLines(c *xgb.Conn, Screen uint16) GetAllModeLinesCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetAllModeLines' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1155,7 +1155,7 @@ func GetAllModeLines(c *xgb.Conn, Screen uint16) GetAllModeLinesCookie {
 // GetAllModeLinesUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetAllModeLinesUnchecked(c *xgb.Conn, Screen uint16) GetAllModeLinesCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetAllModeLines' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1216,7 +1216,7 @@ func getAllModeLinesRequest(c *xgb.Conn, Screen uint16) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 6 // request opcode
@@ -1241,7 +1241,7 @@ type AddModeLineCookie struct {
 // AddModeLine sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func AddModeLine(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay uint16, Hsyncstart uint16, Hsyncend uint16, Htotal uint16, Hskew uint16, Vdisplay uint16, Vsyncstart uint16, Vsyncend uint16, Vtotal uint16, Flags uint32, Privsize uint32, AfterDotclock Dotclock, AfterHdisplay uint16, AfterHsyncstart uint16, AfterHsyncend uint16, AfterHtotal uint16, AfterHskew uint16, AfterVdisplay uint16, AfterVsyncstart uint16, AfterVsyncend uint16, AfterVtotal uint16, AfterFlags uint32, Private []byte) AddModeLineCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'AddModeLine' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1252,7 +1252,7 @@ func AddModeLine(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay uint16,
 // AddModeLineChecked sends a checked request.
 // If an error occurs, it can be retrieved using AddModeLineCookie.Check()
 func AddModeLineChecked(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay uint16, Hsyncstart uint16, Hsyncend uint16, Htotal uint16, Hskew uint16, Vdisplay uint16, Vsyncstart uint16, Vsyncend uint16, Vtotal uint16, Flags uint32, Privsize uint32, AfterDotclock Dotclock, AfterHdisplay uint16, AfterHsyncstart uint16, AfterHsyncend uint16, AfterHtotal uint16, AfterHskew uint16, AfterVdisplay uint16, AfterVsyncstart uint16, AfterVsyncend uint16, AfterVtotal uint16, AfterFlags uint32, Private []byte) AddModeLineCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'AddModeLine' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1273,7 +1273,7 @@ func addModeLineRequest(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 7 // request opcode
@@ -1376,7 +1376,7 @@ type DeleteModeLineCookie struct {
 // DeleteModeLine sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func DeleteModeLine(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay uint16, Hsyncstart uint16, Hsyncend uint16, Htotal uint16, Hskew uint16, Vdisplay uint16, Vsyncstart uint16, Vsyncend uint16, Vtotal uint16, Flags uint32, Privsize uint32, Private []byte) DeleteModeLineCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'DeleteModeLine' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1387,7 +1387,7 @@ func DeleteModeLine(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay uint
 // DeleteModeLineChecked sends a checked request.
 // If an error occurs, it can be retrieved using DeleteModeLineCookie.Check()
 func DeleteModeLineChecked(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay uint16, Hsyncstart uint16, Hsyncend uint16, Htotal uint16, Hskew uint16, Vdisplay uint16, Vsyncstart uint16, Vsyncend uint16, Vtotal uint16, Flags uint32, Privsize uint32, Private []byte) DeleteModeLineCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'DeleteModeLine' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1408,7 +1408,7 @@ func deleteModeLineRequest(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdispl
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 8 // request opcode
@@ -1474,7 +1474,7 @@ type ValidateModeLineCookie struct {
 // ValidateModeLine sends a checked request.
 // If an error occurs, it will be returned with the reply by calling ValidateModeLineCookie.Reply()
 func ValidateModeLine(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay uint16, Hsyncstart uint16, Hsyncend uint16, Htotal uint16, Hskew uint16, Vdisplay uint16, Vsyncstart uint16, Vsyncend uint16, Vtotal uint16, Flags uint32, Privsize uint32, Private []byte) ValidateModeLineCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'ValidateModeLine' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1485,7 +1485,7 @@ func ValidateModeLine(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay ui
 // ValidateModeLineUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ValidateModeLineUnchecked(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay uint16, Hsyncstart uint16, Hsyncend uint16, Htotal uint16, Hskew uint16, Vdisplay uint16, Vsyncstart uint16, Vsyncend uint16, Vtotal uint16, Flags uint32, Privsize uint32, Private []byte) ValidateModeLineCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'ValidateModeLine' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1542,7 +1542,7 @@ func validateModeLineRequest(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdis
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 9 // request opcode
@@ -1608,7 +1608,7 @@ type SwitchToModeCookie struct {
 // SwitchToMode sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SwitchToMode(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay uint16, Hsyncstart uint16, Hsyncend uint16, Htotal uint16, Hskew uint16, Vdisplay uint16, Vsyncstart uint16, Vsyncend uint16, Vtotal uint16, Flags uint32, Privsize uint32, Private []byte) SwitchToModeCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'SwitchToMode' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1619,7 +1619,7 @@ func SwitchToMode(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay uint16
 // SwitchToModeChecked sends a checked request.
 // If an error occurs, it can be retrieved using SwitchToModeCookie.Check()
 func SwitchToModeChecked(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay uint16, Hsyncstart uint16, Hsyncend uint16, Htotal uint16, Hskew uint16, Vdisplay uint16, Vsyncstart uint16, Vsyncend uint16, Vtotal uint16, Flags uint32, Privsize uint32, Private []byte) SwitchToModeCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'SwitchToMode' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1640,7 +1640,7 @@ func switchToModeRequest(c *xgb.Conn, Screen uint32, Dotclock Dotclock, Hdisplay
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 10 // request opcode
@@ -1706,7 +1706,7 @@ type GetViewPortCookie struct {
 // GetViewPort sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetViewPortCookie.Reply()
 func GetViewPort(c *xgb.Conn, Screen uint16) GetViewPortCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetViewPort' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1717,7 +1717,7 @@ func GetViewPort(c *xgb.Conn, Screen uint16) GetViewPortCookie {
 // GetViewPortUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetViewPortUnchecked(c *xgb.Conn, Screen uint16) GetViewPortCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetViewPort' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1778,7 +1778,7 @@ func getViewPortRequest(c *xgb.Conn, Screen uint16) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 11 // request opcode
@@ -1803,7 +1803,7 @@ type SetViewPortCookie struct {
 // SetViewPort sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetViewPort(c *xgb.Conn, Screen uint16, X uint32, Y uint32) SetViewPortCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'SetViewPort' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1814,7 +1814,7 @@ func SetViewPort(c *xgb.Conn, Screen uint16, X uint32, Y uint32) SetViewPortCook
 // SetViewPortChecked sends a checked request.
 // If an error occurs, it can be retrieved using SetViewPortCookie.Check()
 func SetViewPortChecked(c *xgb.Conn, Screen uint16, X uint32, Y uint32) SetViewPortCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'SetViewPort' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1835,7 +1835,7 @@ func setViewPortRequest(c *xgb.Conn, Screen uint16, X uint32, Y uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 12 // request opcode
@@ -1866,7 +1866,7 @@ type GetDotClocksCookie struct {
 // GetDotClocks sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetDotClocksCookie.Reply()
 func GetDotClocks(c *xgb.Conn, Screen uint16) GetDotClocksCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetDotClocks' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1877,7 +1877,7 @@ func GetDotClocks(c *xgb.Conn, Screen uint16) GetDotClocksCookie {
 // GetDotClocksUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetDotClocksUnchecked(c *xgb.Conn, Screen uint16) GetDotClocksCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetDotClocks' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1950,7 +1950,7 @@ func getDotClocksRequest(c *xgb.Conn, Screen uint16) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 13 // request opcode
@@ -1975,7 +1975,7 @@ type SetClientVersionCookie struct {
 // SetClientVersion sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetClientVersion(c *xgb.Conn, Major uint16, Minor uint16) SetClientVersionCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'SetClientVersion' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1986,7 +1986,7 @@ func SetClientVersion(c *xgb.Conn, Major uint16, Minor uint16) SetClientVersionC
 // SetClientVersionChecked sends a checked request.
 // If an error occurs, it can be retrieved using SetClientVersionCookie.Check()
 func SetClientVersionChecked(c *xgb.Conn, Major uint16, Minor uint16) SetClientVersionCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'SetClientVersion' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -2007,7 +2007,7 @@ func setClientVersionRequest(c *xgb.Conn, Major uint16, Minor uint16) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 14 // request opcode
@@ -2033,7 +2033,7 @@ type SetGammaCookie struct {
 // SetGamma sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetGamma(c *xgb.Conn, Screen uint16, Red uint32, Green uint32, Blue uint32) SetGammaCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'SetGamma' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -2044,7 +2044,7 @@ func SetGamma(c *xgb.Conn, Screen uint16, Red uint32, Green uint32, Blue uint32)
 // SetGammaChecked sends a checked request.
 // If an error occurs, it can be retrieved using SetGammaCookie.Check()
 func SetGammaChecked(c *xgb.Conn, Screen uint16, Red uint32, Green uint32, Blue uint32) SetGammaCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'SetGamma' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -2065,7 +2065,7 @@ func setGammaRequest(c *xgb.Conn, Screen uint16, Red uint32, Green uint32, Blue
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 15 // request opcode
@@ -2101,7 +2101,7 @@ type GetGammaCookie struct {
 // GetGamma sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetGammaCookie.Reply()
 func GetGamma(c *xgb.Conn, Screen uint16) GetGammaCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetGamma' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2112,7 +2112,7 @@ func GetGamma(c *xgb.Conn, Screen uint16) GetGammaCookie {
 // GetGammaUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetGammaUnchecked(c *xgb.Conn, Screen uint16) GetGammaCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetGamma' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2177,7 +2177,7 @@ func getGammaRequest(c *xgb.Conn, Screen uint16) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 16 // request opcode
@@ -2202,7 +2202,7 @@ type GetGammaRampCookie struct {
 // GetGammaRamp sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetGammaRampCookie.Reply()
 func GetGammaRamp(c *xgb.Conn, Screen uint16, Size uint16) GetGammaRampCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetGammaRamp' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2213,7 +2213,7 @@ func GetGammaRamp(c *xgb.Conn, Screen uint16, Size uint16) GetGammaRampCookie {
 // GetGammaRampUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetGammaRampUnchecked(c *xgb.Conn, Screen uint16, Size uint16) GetGammaRampCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetGammaRamp' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2294,7 +2294,7 @@ func getGammaRampRequest(c *xgb.Conn, Screen uint16, Size uint16) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 17 // request opcode
@@ -2320,7 +2320,7 @@ type SetGammaRampCookie struct {
 // SetGammaRamp sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetGammaRamp(c *xgb.Conn, Screen uint16, Size uint16, Red []uint16, Green []uint16, Blue []uint16) SetGammaRampCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'SetGammaRamp' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -2331,7 +2331,7 @@ func SetGammaRamp(c *xgb.Conn, Screen uint16, Size uint16, Red []uint16, Green [
 // SetGammaRampChecked sends a checked request.
 // If an error occurs, it can be retrieved using SetGammaRampCookie.Check()
 func SetGammaRampChecked(c *xgb.Conn, Screen uint16, Size uint16, Red []uint16, Green []uint16, Blue []uint16) SetGammaRampCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'SetGammaRamp' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -2352,7 +2352,7 @@ func setGammaRampRequest(c *xgb.Conn, Screen uint16, Size uint16, Red []uint16,
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 18 // request opcode
@@ -2396,7 +2396,7 @@ type GetGammaRampSizeCookie struct {
 // GetGammaRampSize sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetGammaRampSizeCookie.Reply()
 func GetGammaRampSize(c *xgb.Conn, Screen uint16) GetGammaRampSizeCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetGammaRampSize' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2407,7 +2407,7 @@ func GetGammaRampSize(c *xgb.Conn, Screen uint16) GetGammaRampSizeCookie {
 // GetGammaRampSizeUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetGammaRampSizeUnchecked(c *xgb.Conn, Screen uint16) GetGammaRampSizeCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetGammaRampSize' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2464,7 +2464,7 @@ func getGammaRampSizeRequest(c *xgb.Conn, Screen uint16) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 19 // request opcode
@@ -2489,7 +2489,7 @@ type GetPermissionsCookie struct {
 // GetPermissions sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetPermissionsCookie.Reply()
 func GetPermissions(c *xgb.Conn, Screen uint16) GetPermissionsCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetPermissions' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2500,7 +2500,7 @@ func GetPermissions(c *xgb.Conn, Screen uint16) GetPermissionsCookie {
 // GetPermissionsUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetPermissionsUnchecked(c *xgb.Conn, Screen uint16) GetPermissionsCookie {
-	if _, ok := c.Extensions["XFREE86-VIDMODEEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XFree86-VidModeExtension"]; !ok {
 		panic("Cannot issue request 'GetPermissions' using the uninitialized extension 'XFree86-VidModeExtension'. xf86vidmode.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2557,7 +2557,7 @@ func getPermissionsRequest(c *xgb.Conn, Screen uint16) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XFREE86-VIDMODEEXTENSION"]
+	buf[b] = c.Extensions["XFree86-VidModeExtension"]
 	b += 1
 
 	buf[b] = 20 // request opcode
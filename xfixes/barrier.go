@@ -0,0 +1,181 @@
+package xfixes
+
+/*
+	Pointer barriers, which came with version 5.0 of XFIXES. xfixes.go was
+	generated from an older xfixes.xml, so their requests are written by
+	hand here.
+	Unlike the rest of this package, this file is not generated.
+*/
+
+import (
+	"errors"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// ErrBarrierNotLine is returned by NewPointerBarrier when the barrier is
+// neither horizontal nor vertical.
+var ErrBarrierNotLine = errors.New("xfixes: a pointer barrier must be " +
+	"a horizontal or vertical line")
+
+// Barrier is the id of a pointer barrier.
+type Barrier uint32
+
+// NewBarrierId allocates the id of a new pointer barrier.
+func NewBarrierId(c *xgb.Conn) (Barrier, error) {
+	id, err := c.NewId()
+	if err != nil {
+		return 0, err
+	}
+	return Barrier(id), nil
+}
+
+// BarrierDirections is a set of the directions in which the pointer can
+// cross a barrier, as BarrierDirections* values ORed together. A barrier
+// blocks the pointer in every other direction.
+type BarrierDirections uint32
+
+const (
+	BarrierDirectionsPositiveX BarrierDirections = 1
+	BarrierDirectionsPositiveY BarrierDirections = 2
+	BarrierDirectionsNegativeX BarrierDirections = 4
+	BarrierDirectionsNegativeY BarrierDirections = 8
+)
+
+// NewPointerBarrier creates a barrier from (x1, y1) to (x2, y2) on the
+// screen of 'win', which the pointer can only cross in 'directions'. It
+// applies to the master pointers 'devices' (XInput device ids), or to all
+// of them if 'devices' is empty. The barrier has to be a horizontal or
+// vertical line, and ErrBarrierNotLine is returned without sending anything
+// if it isn't.
+//
+// Like every other request of XFIXES 5.0, CreatePointerBarrier is only
+// accepted once QueryVersion has been sent with a major version of at
+// least 5.
+func NewPointerBarrier(c *xgb.Conn, win xproto.Window, x1, y1, x2, y2 int16,
+	directions BarrierDirections, devices []uint16) (Barrier, error) {
+
+	if x1 != x2 && y1 != y2 {
+		return 0, ErrBarrierNotLine
+	}
+	barrier, err := NewBarrierId(c)
+	if err != nil {
+		return 0, err
+	}
+	err = CreatePointerBarrierChecked(c, barrier, win, x1, y1, x2, y2,
+		uint32(directions), devices).Check()
+	if err != nil {
+		// The id is still free, unless it is what the X server
+		// says is bad.
+		if _, ok := err.(xproto.IDChoiceError); !ok {
+			c.FreeId(uint32(barrier))
+		}
+		return 0, err
+	}
+	return barrier, nil
+}
+
+// PointerBarrierCookie is a cookie used only for CreatePointerBarrier and
+// DeletePointerBarrier requests.
+type PointerBarrierCookie struct {
+	*xgb.Cookie
+}
+
+// Check returns the error caused by a checked request, if any.
+func (cook PointerBarrierCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// CreatePointerBarrier sends an unchecked CreatePointerBarrier request.
+// 'Directions' are BarrierDirections, and 'Devices' the XInput ids of the
+// master pointers the barrier applies to, or none for all of them.
+func CreatePointerBarrier(c *xgb.Conn, Barrier Barrier, Window xproto.Window,
+	X1, Y1, X2, Y2 int16, Directions uint32,
+	Devices []uint16) PointerBarrierCookie {
+
+	return barrierRequest(c, false, createPointerBarrierRequest(c,
+		Barrier, Window, X1, Y1, X2, Y2, Directions, Devices))
+}
+
+// CreatePointerBarrierChecked is like CreatePointerBarrier, but the error
+// it causes, if any, is returned by Check.
+func CreatePointerBarrierChecked(c *xgb.Conn, Barrier Barrier,
+	Window xproto.Window, X1, Y1, X2, Y2 int16, Directions uint32,
+	Devices []uint16) PointerBarrierCookie {
+
+	return barrierRequest(c, true, createPointerBarrierRequest(c,
+		Barrier, Window, X1, Y1, X2, Y2, Directions, Devices))
+}
+
+// DeletePointerBarrier sends an unchecked DeletePointerBarrier request.
+func DeletePointerBarrier(c *xgb.Conn, Barrier Barrier) PointerBarrierCookie {
+	return barrierRequest(c, false, deletePointerBarrierRequest(c, Barrier))
+}
+
+// DeletePointerBarrierChecked is like DeletePointerBarrier, but the error
+// it causes, if any, is returned by Check.
+func DeletePointerBarrierChecked(c *xgb.Conn,
+	Barrier Barrier) PointerBarrierCookie {
+
+	return barrierRequest(c, true, deletePointerBarrierRequest(c, Barrier))
+}
+
+// barrierRequest sends the request in 'buf'.
+func barrierRequest(c *xgb.Conn, checked bool,
+	buf []byte) PointerBarrierCookie {
+
+	cookie := c.NewCookie(checked, false)
+	c.NewRequest(buf, cookie)
+	return PointerBarrierCookie{cookie}
+}
+
+// createPointerBarrierRequest writes a CreatePointerBarrier request to
+// a byte slice.
+func createPointerBarrierRequest(c *xgb.Conn, Barrier Barrier,
+	Window xproto.Window, X1, Y1, X2, Y2 int16, Directions uint32,
+	Devices []uint16) []byte {
+
+	size := xgb.Pad(28 + 2*len(Devices))
+	buf := make([]byte, size)
+	buf[0] = extOpcode(c, "CreatePointerBarrier")
+	buf[1] = 31 // request opcode
+	xgb.Put16(buf[2:], uint16(size/4))
+	xgb.Put32(buf[4:], uint32(Barrier))
+	xgb.Put32(buf[8:], uint32(Window))
+	xgb.Put16(buf[12:], uint16(X1))
+	xgb.Put16(buf[14:], uint16(Y1))
+	xgb.Put16(buf[16:], uint16(X2))
+	xgb.Put16(buf[18:], uint16(Y2))
+	xgb.Put32(buf[20:], Directions)
+	// 2 bytes of padding
+	xgb.Put16(buf[26:], uint16(len(Devices)))
+	for i, dev := range Devices {
+		xgb.Put16(buf[28+2*i:], dev)
+	}
+	return buf
+}
+
+// deletePointerBarrierRequest writes a DeletePointerBarrier request to a
+// byte slice.
+func deletePointerBarrierRequest(c *xgb.Conn, Barrier Barrier) []byte {
+	buf := make([]byte, 8)
+	buf[0] = extOpcode(c, "DeletePointerBarrier")
+	buf[1] = 32 // request opcode
+	xgb.Put16(buf[2:], 2)
+	xgb.Put32(buf[4:], uint32(Barrier))
+	return buf
+}
+
+// extOpcode returns the major opcode of XFIXES, and panics like the
+// generated requests do if the extension hasn't been initialized.
+func extOpcode(c *xgb.Conn, request string) byte {
+	opcode, ok := c.Extensions["XFIXES"]
+	if !ok {
+		panic("Cannot issue request '" + request + "' using the " +
+			"uninitialized extension 'XFIXES'. " +
+			"xfixes.Init(connObj) must be called first.")
+	}
+	return opcode
+}
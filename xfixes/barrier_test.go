@@ -0,0 +1,55 @@
+package xfixes
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestNewPointerBarrier creates a vertical barrier for one device on the
+// mock server, and makes sure a diagonal one is refused.
+func TestNewPointerBarrier(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	ext := make([]byte, 32)
+	ext[8] = 1   // present
+	ext[9] = 138 // major opcode
+	server.Expect(xgbtest.Request{Opcode: 98}).WithData(ext)
+	if err := Init(X); err != nil {
+		t.Fatalf("Init: %s", err)
+	}
+
+	_, err := NewPointerBarrier(X, 0x100, 0, 0, 10, 10,
+		BarrierDirectionsPositiveX, nil)
+	if err != ErrBarrierNotLine {
+		t.Fatalf("Expected ErrBarrierNotLine, but got %v.", err)
+	}
+
+	body := make([]byte, 28)
+	xgb.Put32(body[4:], 0x100) // window
+	xgb.Put16(body[8:], 100)   // x1
+	xgb.Put16(body[12:], 100)  // x2
+	xgb.Put16(body[14:], 768)  // y2
+	xgb.Put32(body[16:], 1|4)  // directions
+	xgb.Put16(body[22:], 1)    // number of devices
+	xgb.Put16(body[24:], 2)    // device
+	req := server.Expect(xgbtest.Request{Opcode: 138, Data: 31})
+	barrier, err := NewPointerBarrier(X, 0x100, 100, 0, 100, 768,
+		BarrierDirectionsPositiveX|BarrierDirectionsNegativeX,
+		[]uint16{2})
+	if err != nil {
+		t.Fatalf("NewPointerBarrier: %s", err)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+
+	// The barrier id is only known once the request has been made.
+	xgb.Put32(body, uint32(barrier))
+	if got := req.Received()[4:]; string(got) != string(body) {
+		t.Errorf("Expected the request body %x, but got %x.", body, got)
+	}
+}
@@ -0,0 +1,38 @@
+package xfixes
+
+/*
+	Converting the cursor image from GetCursorImage to an image.Image.
+	Unlike the rest of this package, this file is not generated.
+*/
+
+import (
+	"image"
+)
+
+// Image returns the cursor image in the reply, whose bounds are
+// (0, 0)-(Width, Height). The hotspot of the cursor, which is at the pointer
+// position, is at (Xhot, Yhot) in the image.
+//
+// The pixels of the reply are 32-bit ARGB values with premultiplied alpha,
+// like those of an image.RGBA, so they are copied without any conversion.
+func (r *GetCursorImageReply) Image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, int(r.Width), int(r.Height)))
+	for i, argb := range r.CursorImage {
+		if 4*i+3 >= len(img.Pix) {
+			break
+		}
+		pix := img.Pix[4*i : 4*i+4]
+		pix[0] = byte(argb >> 16)
+		pix[1] = byte(argb >> 8)
+		pix[2] = byte(argb)
+		pix[3] = byte(argb >> 24)
+	}
+	return img
+}
+
+// Origin returns where the top left corner of the cursor image is on the
+// screen, which is the pointer position (X, Y) minus the hotspot. This is
+// where a screen recorder draws the image from Image.
+func (r *GetCursorImageReply) Origin() image.Point {
+	return image.Pt(int(r.X)-int(r.Xhot), int(r.Y)-int(r.Yhot))
+}
@@ -0,0 +1,62 @@
+package xfixes
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestGetCursorImage reads a made up cursor image of 2x1 pixels from the
+// mock server.
+func TestGetCursorImage(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	ext := make([]byte, 32)
+	ext[8] = 1   // present
+	ext[9] = 138 // major opcode
+	ext[10] = 87 // first event
+	server.Expect(xgbtest.Request{Opcode: 98}).WithData(ext)
+	if err := Init(X); err != nil {
+		t.Fatalf("Init: %s", err)
+	}
+
+	reply := make([]byte, 32+2*4)
+	xgb.Put16(reply[8:], 100)         // x
+	xgb.Put16(reply[10:], 50)         // y
+	xgb.Put16(reply[12:], 2)          // width
+	xgb.Put16(reply[14:], 1)          // height
+	xgb.Put16(reply[16:], 1)          // xhot
+	xgb.Put32(reply[32:], 0xff102030) // opaque
+	xgb.Put32(reply[36:], 0x80400000) // half transparent
+	server.Expect(xgbtest.Request{Opcode: 138, Data: 4}).WithData(reply)
+
+	cursor, err := GetCursorImage(X).Reply()
+	if err != nil {
+		t.Fatalf("GetCursorImage: %s", err)
+	}
+	if want := image.Pt(99, 50); cursor.Origin() != want {
+		t.Errorf("Expected the origin %v, but got %v.", want,
+			cursor.Origin())
+	}
+
+	img := cursor.Image()
+	if want := image.Rect(0, 0, 2, 1); img.Bounds() != want {
+		t.Fatalf("Expected the bounds %v, but got %v.", want,
+			img.Bounds())
+	}
+	pixels := []color.RGBA{{0x10, 0x20, 0x30, 0xff}, {0x40, 0, 0, 0x80}}
+	for x, want := range pixels {
+		if got := img.RGBAAt(x, 0); got != want {
+			t.Errorf("Pixel %d: expected %v, but got %v.", x, want,
+				got)
+		}
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
@@ -21,7 +21,7 @@ func Init(c *xgb.Conn) error {
 	case err != nil:
 		return err
 	case !reply.Present:
-		return xgb.Errorf("No extension named XFIXES could be found on on the server.")
+		return xproto.ExtNotAvailableError{Name: "XFIXES", Reply: reply}
 	}
 
 	xgb.ExtLock.Lock()
@@ -0,0 +1,119 @@
+package xfixes
+
+/*
+	Tests for XFIXES regions.
+
+	These need a running X server with the XFIXES extension, and are skipped
+	if DISPLAY isn't set.
+*/
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestCreateRegion creates two regions, combines them and fetches the
+// rectangles of the result.
+func TestCreateRegion(t *testing.T) {
+	X := connect(t)
+
+	rect := func(x, y int16, w, h uint16) xproto.Rectangle {
+		return xproto.Rectangle{X: x, Y: y, Width: w, Height: h}
+	}
+	left := newRegion(t, X, []xproto.Rectangle{rect(0, 0, 10, 10)})
+	right := newRegion(t, X, []xproto.Rectangle{rect(10, 0, 10, 10)})
+	both := newRegion(t, X, nil)
+
+	err := UnionRegionChecked(X, left, right, both).Check()
+	if err != nil {
+		t.Fatalf("UnionRegion: %s", err)
+	}
+	reply, err := FetchRegion(X, both).Reply()
+	if err != nil {
+		t.Fatalf("FetchRegion: %s", err)
+	}
+	if want := rect(0, 0, 20, 10); reply.Extents != want {
+		t.Fatalf("Expected extents %v, but got %v", want, reply.Extents)
+	}
+
+	err = IntersectRegionChecked(X, left, right, both).Check()
+	if err != nil {
+		t.Fatalf("IntersectRegion: %s", err)
+	}
+	reply, err = FetchRegion(X, both).Reply()
+	if err != nil {
+		t.Fatalf("FetchRegion: %s", err)
+	}
+	if len(reply.Rectangles) != 0 {
+		t.Fatalf("Expected an empty intersection, but got %v",
+			reply.Rectangles)
+	}
+}
+
+// TestCopyRegion copies a region and makes sure the copy has the same
+// rectangles.
+func TestCopyRegion(t *testing.T) {
+	X := connect(t)
+
+	rects := []xproto.Rectangle{{X: 5, Y: 5, Width: 1, Height: 2}}
+	src := newRegion(t, X, rects)
+	dst := newRegion(t, X, nil)
+	if err := CopyRegionChecked(X, src, dst).Check(); err != nil {
+		t.Fatalf("CopyRegion: %s", err)
+	}
+
+	reply, err := FetchRegion(X, dst).Reply()
+	if err != nil {
+		t.Fatalf("FetchRegion: %s", err)
+	}
+	if !reflect.DeepEqual(reply.Rectangles, rects) {
+		t.Fatalf("Expected rectangles %v, but got %v",
+			rects, reply.Rectangles)
+	}
+}
+
+// connect connects to the X server in DISPLAY and initializes XFIXES, or
+// skips the current test if that isn't possible. The connection is closed
+// when the test finishes.
+func connect(t *testing.T) *xgb.Conn {
+	t.Helper()
+	if len(os.Getenv("DISPLAY")) == 0 {
+		t.Skip("DISPLAY is not set")
+	}
+
+	X, err := xgb.NewConn()
+	if err != nil {
+		t.Fatalf("NewConn: %s", err)
+	}
+	t.Cleanup(func() { X.Close() })
+	if err := Init(X); err != nil {
+		t.Skipf("XFIXES is not available: %s", err)
+	}
+
+	// The server ignores XFIXES requests until the client has told it which
+	// version it supports.
+	if _, err := QueryVersion(X, 5, 0).Reply(); err != nil {
+		t.Fatalf("QueryVersion: %s", err)
+	}
+	return X
+}
+
+// newRegion creates a region from 'rects', which is destroyed when the test
+// finishes.
+func newRegion(t *testing.T, X *xgb.Conn, rects []xproto.Rectangle) Region {
+	t.Helper()
+
+	region, err := NewRegionId(X)
+	if err != nil {
+		t.Fatalf("NewRegionId: %s", err)
+	}
+	if err := CreateRegionChecked(X, region, rects).Check(); err != nil {
+		t.Fatalf("CreateRegion: %s", err)
+	}
+	t.Cleanup(func() { DestroyRegion(X, region) })
+	return region
+}
@@ -1,10 +1,15 @@
 package xgb
 
 import (
+	"bufio"
+	"context"
+	"crypto/tls"
 	"errors"
 	"io"
 	"net"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 var (
@@ -37,6 +42,15 @@ const (
 	// can be made until new ones block. This value seems OK.
 	reqBuffer = 100
 
+	// subscriptionBuffer represents the queue size of the channels
+	// returned by Subscribe. Events are dropped when it is full.
+	subscriptionBuffer = 1000
+
+	// eventsBuffer represents the queue size of the channel returned by
+	// Events. It can be small, since events are queued in the event channel
+	// (see eventBuffer) before being moved to it.
+	eventsBuffer = 100
+
 	// eventBuffer represents the queue size of the number of events or errors
 	// that can be loaded off the wire and not grabbed with WaitForEvent
 	// until reading an event blocks. This value should be big enough to handle
@@ -47,21 +61,167 @@ const (
 // A Conn represents a connection to an X server.
 type Conn struct {
 	host          string
-	conn          net.Conn
+	conn          io.ReadWriteCloser
 	display       string
 	DisplayNumber int
 	DefaultScreen int
 	SetupBytes    []byte
 
+	// displayName is the display string the connection was created with.
+	// It is used to connect again in Reconnect.
+	displayName string
+
+	// netGiven is whether the connection to the X server was given to
+	// NewConnNet, rather than dialed from displayName.
+	netGiven bool
+
+	// tlsConfig, if not nil, is used to wrap the connection to the X
+	// server in TLS. See DialTLS.
+	tlsConfig *tls.Config
+
+	// opts are the settings given to NewConnWithOptions.
+	opts connOptions
+
 	setupResourceIdBase uint32
 	setupResourceIdMask uint32
 
-	eventChan  chan eventOrError
+	eventChan chan EventOrError
+
+	// requeued holds the events and errors DrainEvents has put back at the
+	// front of the queue. They are taken before those in eventChan.
+	requeueLock sync.Mutex
+	requeued    []EventOrError
+
+	// eventsLock protects 'events', the channel returned by Events while
+	// the goroutine feeding it is running.
+	eventsLock sync.Mutex
+	events     chan EventOrError
+
+	// tracer, if not nil, points to the Tracer the connection uses: the
+	// one set with SetTracer ('userTracer'), the dump started by DumpTo
+	// ('dumper'), or both. traceLock protects the latter two.
+	tracer     atomic.Pointer[Tracer]
+	traceLock  sync.Mutex
+	userTracer Tracer
+	dumper     *pcapDumper
+
+	// stats counts what went over the connection. See Stats.
+	stats connStats
+
+	// requestLog, if not nil, is the log of the last requests written. See
+	// EnableRequestLog.
+	requestLog atomic.Pointer[requestLog]
+
+	// errorHandler, if not nil, points to the function asynchronous X
+	// errors are given to instead of the event queue. See SetErrorHandler.
+	errorHandler atomic.Pointer[func(Error)]
+
+	// subsLock protects subs, the subscriptions made with Subscribe.
+	// dropPolicy is the DropPolicy they use when they are full.
+	subsLock   sync.RWMutex
+	subs       []*subscription
+	dropPolicy atomic.Int32
+
+	// genericLock protects genericHandlers. See
+	// RegisterGenericEventHandler.
+	genericLock     sync.Mutex
+	genericHandlers map[genericEventKey]GenericEventHandler
+
+	// geLock serializes the negotiation of the Generic Event Extension,
+	// and protects geQueried, which is whether it has been done on the
+	// current connection. See queryGenericEventVersion.
+	geLock    sync.Mutex
+	geQueried bool
+
 	cookieChan chan *Cookie
 	xidChan    chan xid
-	seqChan    chan uint16
+	seqChan    chan uint32
 	reqChan    chan *request
 
+	// seqnumFull is the full (32 bit) sequence number of the last request
+	// written to the X server. Only its low 16 bits are sent over the wire,
+	// so the read loop uses it to reconstruct the full sequence number of
+	// replies and errors. It is accessed atomically.
+	seqnumFull uint32
+
+	// buffered is whether requests are buffered in 'bufw' until Flush is
+	// called. It is only changed by sendRequests, which is also the only
+	// user of 'bufw' and 'writeErr', the first write error since the last
+	// flush. See SetBuffered.
+	buffered atomic.Bool
+	bufw     *bufio.Writer
+	writeErr error
+
+	// maxRequestLength is the maximum length of a request, in 4-byte
+	// units, as given in the setup information or by BIG-REQUESTS.
+	// bigReqQueried is whether BIG-REQUESTS has been tried on the current
+	// connection, and bigRequests whether it is enabled. They are only
+	// used by sendRequests once the connection is set up. See
+	// enableBigRequests.
+	maxRequestLength uint32
+	bigReqQueried    bool
+	bigRequests      bool
+
+	// reader reads from the connection to the X server. For Unix domain
+	// sockets, it also keeps the file descriptors passed by the X server.
+	// See newReader. 'input' is what readResponses reads from: 'reader',
+	// or a buffer in front of it (see WithReadBufferSize).
+	reader io.Reader
+	input  io.Reader
+
+	// multiCookie is the cookie of a request with several replies (see
+	// NewCookieMulti) that has had some of them, but not the last one yet.
+	// It is only used by readResponses, and by stop once readResponses
+	// has quit.
+	multiCookie *Cookie
+
+	// stopLock protects 'done', 'stopErr' and 'closed'. 'done' is closed
+	// to tell the goroutines serving the current connection to the X server
+	// to quit, and 'running' waits for them to do so. 'stopErr' is the
+	// reason the connection was stopped, and is sent to cookies still
+	// waiting for a response. 'closed' is whether Close has been called,
+	// after which Reconnect refuses to connect again.
+	stopLock sync.Mutex
+	done     chan struct{}
+	running  sync.WaitGroup
+	stopErr  error
+	closed   bool
+
+	// failLock protects failErr, the reason requests fail with once the
+	// connection has been stopped for good: closed, or lost and not being
+	// re-established by Reconnect. It's nil otherwise. See queueRequest.
+	failLock sync.Mutex
+	failErr  error
+
+	// closeTimeout is how long Close waits for the requests in flight to
+	// be answered, as a time.Duration. See SetCloseTimeout.
+	closeTimeout atomic.Int64
+
+	// reconnectLock serializes calls to Reconnect, and protects
+	// reconnectHooks.
+	reconnectLock  sync.Mutex
+	reconnectHooks []func(*Conn) error
+
+	// propCache, if not nil, is the property cache. See
+	// EnablePropertyCache.
+	propCache atomic.Pointer[propertyCache]
+
+	// caches maps the keys given to Cache to their caches (as *sync.Maps).
+	caches sync.Map
+
+	// parent, if not nil, is the Conn whose socket this virtual connection
+	// shares, and virtualLock protects 'virtuals', the virtual connections
+	// made from this one. See NewVirtualConn.
+	parent      *Conn
+	virtualLock sync.Mutex
+	virtuals    []*Conn
+
+	// idLock protects spareIds, the resource ids NewId hands out before
+	// any new one: those given back with FreeId, and for a virtual
+	// connection, those it has reserved from its parent.
+	idLock   sync.Mutex
+	spareIds []uint32
+
 	// Extensions is a map from extension name to major opcode. It should
 	// not be used. It is exported for use in the extension sub-packages.
 	Extensions map[string]byte
@@ -84,34 +244,363 @@ func NewConn() (*Conn, error) {
 //	NewConn("hostname:2.1") -> net.Dial("tcp", "", "hostname:6002")
 //	NewConn("tcp/hostname:1.0") -> net.Dial("tcp", "", "hostname:6001")
 func NewConnDisplay(display string) (*Conn, error) {
-	conn := &Conn{}
+	return NewConnContext(context.Background(), display)
+}
+
+// NewConnContext is just like NewConnDisplay, but gives up on connecting to
+// the X server and completing the setup handshake when 'ctx' is done.
+// Once NewConnContext returns, 'ctx' no longer has any effect on the
+// connection.
+func NewConnContext(ctx context.Context, display string) (*Conn, error) {
+	return newConn(ctx, &Conn{displayName: display})
+}
 
+// newConn connects 'conn' to the X server given by conn.displayName and
+// starts serving it.
+func newConn(ctx context.Context, conn *Conn) (*Conn, error) {
 	// First connect. This reads authority, checks DISPLAY environment
 	// variable, and loads the initial Setup info.
-	err := conn.connect(display)
+	err := conn.connect(ctx, conn.displayName)
 	if err != nil {
 		return nil, err
 	}
+	return conn.serve(), nil
+}
+
+// NewConnNet is like NewConn, but does the setup handshake over 'netConn',
+// which is already connected to an X server (or to something that speaks the
+// X protocol, like the mock server of the xgbtest package). No authorization
+// data is sent, and the connection can't be used with Reconnect, since there
+// is no display to connect to again.
+func NewConnNet(netConn net.Conn) (*Conn, error) {
+	conn := &Conn{conn: netConn, netGiven: true}
+	if err := conn.handshake(); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	return conn.serve(), nil
+}
 
+// serve starts serving 'conn', which has just done the setup handshake.
+func (conn *Conn) serve() *Conn {
 	conn.Extensions = make(map[string]byte)
 
 	conn.cookieChan = make(chan *Cookie, cookieBuffer)
 	conn.xidChan = make(chan xid, xidBuffer)
-	conn.seqChan = make(chan uint16, seqBuffer)
+	conn.seqChan = make(chan uint32, seqBuffer)
 	conn.reqChan = make(chan *request, reqBuffer)
-	conn.eventChan = make(chan eventOrError, eventBuffer)
+	conn.eventChan = make(chan EventOrError, eventBuffer)
+	conn.closeTimeout.Store(int64(defaultCloseTimeout))
+
+	conn.stopLock.Lock()
+	conn.start()
+	conn.stopLock.Unlock()
+
+	return conn
+}
+
+// errClosed is sent to cookies still waiting for a response when the
+// connection is closed.
+var errClosed = errors.New("the connection to the X server was closed")
 
-	go conn.generateXIds()
-	go conn.generateSeqIds()
-	go conn.sendRequests()
-	go conn.readResponses()
+// errCloseTimeout is returned by Close when the X server didn't answer the
+// requests still waiting for a response in time.
+var errCloseTimeout = errors.New("timed out waiting for the X server to " +
+	"answer the requests in flight before closing")
 
-	return conn, nil
+// defaultCloseTimeout is how long Close waits for the requests in flight to
+// be answered, unless SetCloseTimeout says otherwise.
+const defaultCloseTimeout = 5 * time.Second
+
+// Close closes the connection to the X server. The requests made so far are
+// written first (even in buffered mode), and if some cookies are still
+// waiting for a response, Close waits for the X server to answer them, for
+// at most the time set by SetCloseTimeout (five seconds by default).
+// Cookies still waiting for a reply after that fail, and WaitForEvent
+// returns nil for both the event and the error once the events already read
+// have been consumed. The channels returned by Subscribe are closed.
+//
+// Close returns an error if waiting timed out, or if the connection had
+// been lost since it was made (with the reason). The connection is closed
+// either way, for good: requests made afterwards fail with an error saying
+// so, and Reconnect can't be used anymore. So are the virtual connections
+// made from it; closing one of those leaves the connection alone, though
+// (see NewVirtualConn).
+func (c *Conn) Close() error {
+	if c.parent != nil {
+		c.parent.removeVirtual(c)
+		c.closeVirtual()
+		return nil
+	}
+	err := c.drain(c.currentDone())
+
+	// Setting 'closed' first makes sure a Reconnect in progress either
+	// gives up, or has connected by the time the connection is stopped.
+	c.stopLock.Lock()
+	c.closed = true
+	c.stopLock.Unlock()
+	if c.stop(c.currentDone(), errClosed) {
+		select {
+		case c.eventChan <- errClosed:
+		default:
+			go func() { c.eventChan <- errClosed }()
+		}
+	} else if reason := c.stopReason(); reason != errClosed {
+		err = reason
+	}
+	c.setFailErr(errClosed)
+	c.closeSubscriptions()
+	c.closeVirtuals()
+	return err
 }
 
-// Close closes the connection to the X server.
-func (c *Conn) Close() {
+// SetCloseTimeout sets how long Close waits for the X server to answer the
+// requests in flight. With zero or less, it doesn't wait.
+func (c *Conn) SetCloseTimeout(d time.Duration) {
+	c.closeTimeout.Store(int64(d))
+}
+
+// drain writes the requests made so far to the X server, and if cookies are
+// still waiting for a response, waits for the X server to answer them (that
+// is, for the reply to a request sent after them, since the X server answers
+// requests in order). It gives up after the close timeout, returning
+// errCloseTimeout, and does nothing if the connection 'done' has been
+// stopped.
+func (c *Conn) drain(done chan struct{}) error {
+	select {
+	case <-done:
+		return nil
+	default:
+	}
+	timeout := time.Duration(c.closeTimeout.Load())
+	if timeout <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	if len(c.cookieChan) == 0 && len(c.reqChan) == 0 &&
+		!c.buffered.Load() {
+
+		return nil
+	}
+	cookie := c.NewCookie(true, true)
+	select {
+	case c.reqChan <- &request{buf: c.getInputFocusRequest(),
+		cookie: cookie}:
+	case <-done:
+		return nil
+	case <-timer.C:
+		return errCloseTimeout
+	}
+	c.flushBuffered()
+
+	select {
+	case <-cookie.replyChan:
+	case <-cookie.errorChan:
+	case <-done:
+	case <-timer.C:
+		return errCloseTimeout
+	}
+	return nil
+}
+
+// RawConn returns the underlying connection to the X server, or nil if it
+// isn't a net.Conn. It is meant for integration with pollers like epoll or
+// kqueue (e.g., through (*net.UnixConn).SyscallConn).
+//
+// The connection must not be read from or written to directly: XGB is
+// always reading from it in its own goroutine, and doing so will corrupt
+// the stream of replies and events. Note that the connection is replaced
+// by Reconnect.
+func (c *Conn) RawConn() net.Conn {
+	c = c.socket()
+	netConn, _ := c.conn.(net.Conn)
+	return netConn
+}
+
+// start launches the goroutines that serve the connection to the X server.
+// stopLock must be held.
+func (c *Conn) start() {
+	c.done = make(chan struct{})
+	atomic.StoreUint32(&c.seqnumFull, 0)
+	c.bufw = bufio.NewWriterSize(c.conn, c.opts.writeBufferSize)
+	c.writeErr = nil
+	c.reader = newReader(c.conn)
+	c.input = c.reader
+	if c.opts.readBufferSize > 0 {
+		c.input = bufio.NewReaderSize(c.reader, c.opts.readBufferSize)
+	}
+	c.running.Add(4)
+	go c.generateXIds(c.done)
+	go c.generateSeqIds(c.done)
+	go c.sendRequests(c.done)
+	go c.readResponses(c.done)
+}
+
+// currentDone returns the 'done' channel of the current connection.
+func (c *Conn) currentDone() chan struct{} {
+	c = c.socket()
+	c.stopLock.Lock()
+	defer c.stopLock.Unlock()
+
+	return c.done
+}
+
+// stop closes the connection to the X server and waits for the goroutines
+// serving it to quit. Cookies still waiting for a response are sent 'err'.
+// 'done' identifies the connection to stop: if a different connection has
+// been established since (i.e., by Reconnect), or if it has already been
+// stopped, stop does nothing and returns false.
+func (c *Conn) stop(done chan struct{}, err error) bool {
+	c.stopLock.Lock()
+	defer c.stopLock.Unlock()
+
+	if done != c.done {
+		return false
+	}
+	select {
+	case <-done:
+		return false
+	default:
+	}
+
+	c.stopErr = err
+	close(done)
 	c.conn.Close()
+	c.running.Wait()
+
+	// Nothing is reading or writing now, so whatever is left over belongs
+	// to the connection that was just stopped. Requests not sent yet are
+	// kept for the next connection only when Reconnect is making one.
+	if err != errReconnecting {
+		c.setFailErr(err)
+	}
+	if c.multiCookie != nil {
+		c.multiCookie.fail(err)
+		c.multiCookie = nil
+	}
+	for {
+		select {
+		case cookie := <-c.cookieChan:
+			cookie.fail(err)
+		case <-c.xidChan:
+		case <-c.seqChan:
+		default:
+			return true
+		}
+	}
+	panic("unreachable")
+}
+
+// setFailErr sets the reason requests fail with from now on (see
+// queueRequest), and fails the requests queued but not sent yet, unless
+// 'err' is nil.
+func (c *Conn) setFailErr(err error) {
+	c.failLock.Lock()
+	defer c.failLock.Unlock()
+
+	c.failErr = err
+	if err != nil {
+		c.failRequests(err)
+	}
+}
+
+// failRequests fails every request in the request queue with 'err'.
+// failLock must be held.
+func (c *Conn) failRequests(err error) {
+	for {
+		select {
+		case req := <-c.reqChan:
+			req.fail(err)
+		default:
+			return
+		}
+	}
+}
+
+// ConnectionClosedError is the reason given when the connection to the X
+// server is lost: by PollForEvent, WaitForEventTimeout, Events and
+// FlushEvents, to the cookies still waiting for a response, and to the
+// requests made afterwards (and by NewId). Err is what reading from or
+// writing to the connection failed with.
+type ConnectionClosedError struct {
+	Err error
+}
+
+func (err ConnectionClosedError) Error() string {
+	return "the connection to the X server was lost: " + err.Err.Error()
+}
+
+// Unwrap returns Err, so that errors.Is and errors.As see it.
+func (err ConnectionClosedError) Unwrap() error {
+	return err.Err
+}
+
+// ServerClosed returns whether the X server closed the connection (i.e.,
+// reading from it ran into the end of the stream), as opposed to it failing
+// some other way, like a network error.
+func (err ConnectionClosedError) ServerClosed() bool {
+	return errors.Is(err.Err, io.EOF)
+}
+
+// connLost is called by the goroutines serving the connection to the X
+// server when reading from or writing to it fails. Unless the connection is
+// being stopped anyway, it is stopped with a ConnectionClosedError, and
+// WaitForEvent is informed.
+func (c *Conn) connLost(done chan struct{}, cause error) {
+	select {
+	case <-done:
+		return
+	default:
+	}
+
+	c.log().Printf("The connection to the X server was lost: %s", cause)
+	err := ConnectionClosedError{Err: cause}
+	go func() {
+		if c.stop(done, err) {
+			c.tellVirtuals(err)
+			c.eventChan <- err
+		}
+	}()
+}
+
+// ErrDeadlineNotSupported is returned by SetDeadline, SetReadDeadline and
+// SetWriteDeadline when the connection to the X server is not a net.Conn.
+var ErrDeadlineNotSupported = errors.New("connection does not support " +
+	"deadlines")
+
+// SetDeadline sets the read and write deadlines of the underlying
+// connection to the X server. See net.Conn for details.
+// Note that once a deadline is exceeded, reading or writing fails and the
+// connection is no longer usable.
+func (c *Conn) SetDeadline(t time.Time) error {
+	c = c.socket()
+	netConn, ok := c.conn.(net.Conn)
+	if !ok {
+		return ErrDeadlineNotSupported
+	}
+	return netConn.SetDeadline(t)
+}
+
+// SetReadDeadline is like SetDeadline, but only sets the read deadline.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c = c.socket()
+	netConn, ok := c.conn.(net.Conn)
+	if !ok {
+		return ErrDeadlineNotSupported
+	}
+	return netConn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline is like SetDeadline, but only sets the write deadline.
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c = c.socket()
+	netConn, ok := c.conn.(net.Conn)
+	if !ok {
+		return ErrDeadlineNotSupported
+	}
+	return netConn.SetWriteDeadline(t)
 }
 
 // Event is an interface that can contain any of the events returned by the
@@ -159,9 +648,9 @@ var NewErrorFuncs = make(map[int]NewErrorFun)
 // exported for use in the extension sub-packages.
 var NewExtErrorFuncs = make(map[string]map[int]NewErrorFun)
 
-// eventOrError corresponds to values that can be either an event or an
-// error.
-type eventOrError interface{}
+// EventOrError corresponds to values that can be either an Event or an
+// Error. Use a type assertion switch to tell them apart.
+type EventOrError interface{}
 
 // NewId generates a new unused ID for use with requests like CreateWindow.
 // If no new ids can be generated, the id returned is 0 and error is non-nil.
@@ -171,13 +660,67 @@ type eventOrError interface{}
 // e.g., For a window id, use xproto.NewWindowId. For
 // a new pixmap id, use xproto.NewPixmapId. And so on.
 func (c *Conn) NewId() (uint32, error) {
-	xid := <-c.xidChan
+	xid := c.newXid()
 	if xid.err != nil {
 		return 0, xid.err
 	}
 	return xid.id, nil
 }
 
+// GenerateIDs is like NewId, but generates 'count' new unused IDs at once.
+// It is meant for clients that create many resources at once (e.g., windows
+// or pixmaps at startup). If not all of them can be generated, it returns
+// nil and the error.
+// Like NewId, this shouldn't be used directly.
+func (c *Conn) GenerateIDs(count int) ([]uint32, error) {
+	if count < 0 {
+		return nil, Errorf("cannot generate %d resource identifiers",
+			count)
+	}
+	ids := make([]uint32, count)
+	for i := range ids {
+		xid := c.newXid()
+		if xid.err != nil {
+			return nil, xid.err
+		}
+		ids[i] = xid.id
+	}
+	return ids, nil
+}
+
+// FreeId gives back 'id', which NewId (or GenerateIDs) returned, for NewId
+// to return again. It is meant for ids that turned out not to be needed,
+// like that of a resource the X server failed to create. The ids of
+// resources that were created are freed by the X server once the resources
+// are destroyed, and handed out again with the help of XC-MISC once the
+// other ids run out, so they must not be given to FreeId.
+// Like NewId, this shouldn't be used directly.
+func (c *Conn) FreeId(id uint32) {
+	c.idLock.Lock()
+	defer c.idLock.Unlock()
+
+	c.spareIds = append(c.spareIds, id)
+}
+
+// spareId takes an id off spareIds, if there are any.
+func (c *Conn) spareId() (uint32, bool) {
+	c.idLock.Lock()
+	defer c.idLock.Unlock()
+
+	n := len(c.spareIds)
+	if n == 0 {
+		return 0, false
+	}
+	id := c.spareIds[n-1]
+	c.spareIds = c.spareIds[:n-1]
+	return id, true
+}
+
+// errNoMoreIds is the error NewId and GenerateIDs fail with when every
+// resource identifier of the connection is in use.
+var errNoMoreIds = errors.New("There are no more available resource " +
+	"identifiers.")
+
 // xid encapsulates a resource identifier being sent over the Conn.xidChan
 // channel. If no new resource id can be generated, id is set to 0 and a
 // non-nil error is set in xid.err.
@@ -186,13 +729,44 @@ type xid struct {
 	err error
 }
 
+// newXid takes the next id off the Conn.xidChan channel. While Reconnect is
+// re-establishing the connection, it waits for the new one. Once the
+// connection has been stopped for good, the error is the reason why.
+func (c *Conn) newXid() xid {
+	if c.parent != nil {
+		return c.virtualXid()
+	}
+	for {
+		c.failLock.Lock()
+		err := c.failErr
+		c.failLock.Unlock()
+		if err != nil {
+			return xid{id: 0, err: err}
+		}
+		if id, ok := c.spareId(); ok {
+			return xid{id: id}
+		}
+
+		select {
+		case xid := <-c.xidChan:
+			return xid
+		case <-c.currentDone():
+			// Either Reconnect is running, and holds reconnectLock
+			// until it is done, or the connection was lost, and
+			// currentDone waits for stop to set failErr.
+			c.reconnectLock.Lock()
+			c.reconnectLock.Unlock()
+		}
+	}
+}
+
 // generateXids sends new Ids down the channel for NewId to use.
 // generateXids should be run in its own goroutine.
-// This needs to be updated to use the XC Misc extension once we run out of
-// new ids.
+// Once the ids made from the setup information run out, it gets ranges of
+// ids that are no longer in use from the XC-MISC extension.
 // Thanks to libxcb/src/xcb_xid.c. This code is greatly inspired by it.
-func (conn *Conn) generateXIds() {
-	defer close(conn.xidChan)
+func (conn *Conn) generateXIds(done chan struct{}) {
+	defer conn.running.Done()
 
 	// This requires some explanation. From the horse's mouth:
 	// "The resource-id-mask contains a single contiguous set of bits (at least
@@ -213,57 +787,122 @@ func (conn *Conn) generateXIds() {
 	inc := conn.setupResourceIdMask & -conn.setupResourceIdMask
 	max := conn.setupResourceIdMask
 	last := uint32(0)
+	xcMisc := -1 // the major opcode of XC-MISC, once looked up
 	for {
-		// TODO: Use the XC Misc extension to look for released ids.
 		if last > 0 && last >= max-inc+1 {
-			conn.xidChan <- xid{
-				id: 0,
-				err: errors.New("There are no more available resource" +
-					"identifiers."),
+			start, count, ok := conn.xidRange(done, &xcMisc)
+			if !ok {
+				return
 			}
+			if count == 0 {
+				select {
+				case conn.xidChan <- xid{
+					id:  0,
+					err: errNoMoreIds,
+				}:
+				case <-done:
+					return
+				}
+				continue
+			}
+			// Like libxcb, hand out 'start' itself first.
+			last, max = start, start+(count-1)*inc
+		} else {
+			last += inc
 		}
 
-		last += inc
-		conn.xidChan <- xid{
+		select {
+		case conn.xidChan <- xid{
 			id:  last | conn.setupResourceIdBase,
 			err: nil,
+		}:
+		case <-done:
+			return
 		}
 	}
 }
 
 // newSeqId fetches the next sequence id from the Conn.seqChan channel.
-func (c *Conn) newSequenceId() uint16 {
-	return <-c.seqChan
+// It returns false if the connection is stopped in the mean time.
+func (c *Conn) newSequenceId(done chan struct{}) (uint32, bool) {
+	select {
+	case seqid := <-c.seqChan:
+		return seqid, true
+	case <-done:
+		return 0, false
+	}
+	panic("unreachable")
 }
 
 // generateSeqIds returns new sequence ids. It is meant to be run in its
 // own goroutine.
 // A sequence id is generated for *every* request. It's the identifier used
 // to match up replies with requests.
-// Since sequence ids can only be 16 bit integers we start over at zero when it
-// comes time to wrap.
+// Sequence ids are 32 bit integers (that wrap around), even though only the
+// low 16 bits are sent over the wire. See widenSequence.
 // N.B. As long as the cookie buffer is less than 2^16, there are no limitations
 // on the number (or kind) of requests made in sequence.
-func (c *Conn) generateSeqIds() {
-	defer close(c.seqChan)
+func (c *Conn) generateSeqIds(done chan struct{}) {
+	defer c.running.Done()
 
-	seqid := uint16(1)
+	seqid := uint32(1)
 	for {
-		c.seqChan <- seqid
-		if seqid == uint16((1<<16)-1) {
-			seqid = 0
-		} else {
-			seqid++
+		select {
+		case c.seqChan <- seqid:
+		case <-done:
+			return
 		}
+		seqid++
 	}
 }
 
+// widenSequence reconstructs the full sequence number of a reply, error or
+// event from the 16 bit sequence number 'seq' sent by the X server. 'last' is
+// the full sequence number of the last request written. Since the server
+// only responds to requests that have been written, the response belongs to
+// the most recent request at or before 'last' whose low 16 bits are 'seq'.
+func widenSequence(last uint32, seq uint16) uint32 {
+	return last - uint32(uint16(last)-seq)
+}
+
 // request encapsulates a buffer of raw bytes (containing the request data)
 // and a cookie, which when combined represents a single request.
 // The cookie is used to match up the reply/error.
 type request struct {
 	buf    []byte
 	cookie *Cookie
+
+	// batch, if not nil, holds the requests made in a call to Batch. They
+	// are sent instead of 'buf' and 'cookie'.
+	batch *BatchWriter
+
+	// flush, if not nil, means that this is a call to Flush or SetBuffered
+	// instead of a request. See flushRequests.
+	flush       chan error
+	setBuffered *bool
+
+	// fds are passed to the X server along with 'buf'. See NewRequestFDs.
+	fds []int
+
+	// maxLength, if not nil, means that this is a call to MaxRequestSize
+	// instead of a request. The maximum request length is sent on it.
+	maxLength chan uint32
+}
+
+// fail unblocks whoever waits for 'req' to be handled, with 'err' if
+// possible. It is used for requests that are never sent.
+func (req *request) fail(err error) {
+	closeFDs(req.fds)
+	switch {
+	case req.batch != nil:
+		req.batch.fail(0, err)
+	case req.flush != nil:
+		req.flush <- err
+	case req.maxLength != nil:
+		req.maxLength <- 0
+	case req.cookie != nil:
+		req.cookie.fail(err)
+	}
 }
 
 // NewRequest takes the bytes and a cookie of a particular request, constructs
@@ -282,44 +921,208 @@ type request struct {
 // If you're using NewRequest manually, you'll need to use NewCookie to create
 // a new cookie.
 //
+// Requests longer than 262140 bytes, whose length doesn't fit in the length
+// field of 'buf', are sent using the BIG-REQUESTS extension, which is enabled
+// the first time it is needed. A request that is too long for the X server
+// fails with ErrRequestTooLong instead.
+//
 // In all likelihood, you should be able to copy and paste with some minor
 // edits the generated code for the request you want to issue.
 func (c *Conn) NewRequest(buf []byte, cookie *Cookie) {
-	c.reqChan <- &request{buf: buf, cookie: cookie}
+	if c.socket().cachedProperty(buf, cookie) {
+		return
+	}
+	c.queueRequest(&request{buf: buf, cookie: cookie})
+}
+
+// queueRequest hands 'req' to sendRequests. Once the connection has been
+// closed, or lost (unless Reconnect is re-establishing it), 'req' is failed
+// right away instead, with the reason why. Otherwise, requests made while
+// the connection is down wait for the next one.
+func (c *Conn) queueRequest(req *request) {
+	c.failLock.Lock()
+	err := c.failErr
+	c.failLock.Unlock()
+	if err != nil {
+		req.fail(err)
+		return
+	}
+	if c.parent != nil {
+		// The request queue is that of the parent, which takes care
+		// of failing the request if its connection is down.
+		c.parent.queueRequest(req)
+		return
+	}
+
+	c.reqChan <- req
+
+	// If the connection was stopped for good in the mean time, stop may
+	// have emptied the queue before 'req' got into it.
+	c.failLock.Lock()
+	defer c.failLock.Unlock()
+	if c.failErr != nil {
+		c.failRequests(c.failErr)
+	}
 }
 
 // sendRequests is run as a single goroutine that takes requests and writes
 // the bytes to the wire and adds the cookie to the cookie queue.
 // It is meant to be run as its own goroutine.
-func (c *Conn) sendRequests() {
-	defer close(c.reqChan)
-	defer close(c.cookieChan)
+// Requests that are still queued when Reconnect stops the connection are
+// left for the new connection. Otherwise, stop fails them.
+func (c *Conn) sendRequests(done chan struct{}) {
+	defer c.running.Done()
+
+	for {
+		var req *request
+		select {
+		case req = <-c.reqChan:
+		case <-done:
+			return
+		}
+
+		if req.batch != nil {
+			if !c.sendBatch(done, req.batch) {
+				return
+			}
+			continue
+		}
+		if req.flush != nil {
+			c.flushRequests(done, req)
+			continue
+		}
+		if req.maxLength != nil {
+			if !c.bigReqQueried && !c.enableBigRequests(done) {
+				return
+			}
+			req.maxLength <- c.maxRequestLength
+			continue
+		}
+		if len(req.fds) > 0 && !c.canPassFDs() {
+			closeFDs(req.fds)
+			req.cookie.fail(ErrFDPassingNotSupported)
+			continue
+		}
+
+		if c.needBigRequests(len(req.buf)) &&
+			!c.enableBigRequests(done) {
+
+			closeFDs(req.fds)
+			req.cookie.fail(c.stopErr)
+			return
+		}
+		buf, err := c.fitRequest(req.buf)
+		if err != nil {
+			closeFDs(req.fds)
+			req.cookie.fail(err)
+			continue
+		}
 
-	for req := range c.reqChan {
 		// ho there! if the cookie channel is nearly full, force a round
 		// trip to clear out the cookie buffer.
-		// Note that we circumvent the request channel, because we're *in*
-		// the request channel.
-		if len(c.cookieChan) == cookieBuffer-1 {
-			cookie := c.NewCookie(true, true)
-			cookie.Sequence = c.newSequenceId()
-			c.cookieChan <- cookie
-			c.writeBuffer(c.getInputFocusRequest())
-			cookie.Reply() // wait for the buffer to clear
+		if len(c.cookieChan) == cookieBuffer-1 && !c.syncCookies(done) {
+			closeFDs(req.fds)
+			req.cookie.fail(c.stopErr)
+			return
+		}
+
+		var ok bool
+		if len(req.fds) > 0 {
+			ok = c.sendCookieFDs(done, req.cookie, buf, req.fds)
+		} else {
+			ok = c.sendCookie(done, req.cookie, buf)
+		}
+		if !ok {
+			req.cookie.fail(c.stopErr)
+			return
 		}
+	}
+}
+
+// syncCookies makes a round trip request to clear out the cookie buffer, and
+// waits for it to complete. It returns false if the connection was stopped
+// in the mean time.
+// Note that we circumvent the request channel, because we're *in* the request
+// channel.
+func (c *Conn) syncCookies(done chan struct{}) bool {
+	cookie := c.NewCookie(true, true)
+	buf := c.getInputFocusRequest()
+	if !c.sendCookie(done, cookie, buf) {
+		return false
+	}
+	c.flushWriter(done)
+
+	// wait for the buffer to clear
+	select {
+	case <-cookie.replyChan:
+	case <-cookie.errorChan:
+	case <-done:
+		return false
+	}
+	return true
+}
+
+// sendCookie queues 'cookie' and writes 'buf' to the wire. It returns false if
+// the connection was stopped before the cookie could be queued.
+func (c *Conn) sendCookie(done chan struct{}, cookie *Cookie,
+	buf []byte) bool {
+
+	if !c.queueCookie(done, cookie, buf) {
+		return false
+	}
+	c.writeBuffer(done, buf)
+	return true
+}
 
-		req.cookie.Sequence = c.newSequenceId()
-		c.cookieChan <- req.cookie
-		c.writeBuffer(req.buf)
+// queueCookie assigns the next sequence number to 'cookie' and adds it to the
+// cookie queue. The request corresponding to 'cookie', 'buf', must be written
+// next. It returns false if the connection was stopped in the mean time.
+func (c *Conn) queueCookie(done chan struct{}, cookie *Cookie,
+	buf []byte) bool {
+
+	seqid, ok := c.newSequenceId(done)
+	if !ok {
+		return false
+	}
+	cookie.Sequence = uint16(seqid)
+	cookie.seqnumFull = seqid
+	c.cookieChan <- cookie
+	atomic.StoreUint32(&c.seqnumFull, seqid)
+	c.stats.sent(len(buf))
+	if l := c.requestLog.Load(); l != nil {
+		l.add(seqid, buf)
+	}
+	if tracer := c.loadTracer(); tracer != nil {
+		tracer.TraceRequest(cookie.Sequence, buf[0], buf)
 	}
+	return true
 }
 
 // writeBuffer is a convenience function for writing a byte slice to the wire.
-func (c *Conn) writeBuffer(buf []byte) {
-	if _, err := c.conn.Write(buf); err != nil {
-		logger.Printf("Write error: %s", err)
-		logger.Fatal("A write error is unrecoverable. Exiting...")
+func (c *Conn) writeBuffer(done chan struct{}, buf []byte) {
+	_, err := c.writer().Write(buf)
+	c.writeFailed(done, err)
+}
+
+// writer returns where requests should be written to: the write buffer in
+// buffered mode, and the connection to the X server otherwise.
+func (c *Conn) writer() io.Writer {
+	if c.buffered.Load() {
+		return c.bufw
 	}
+	return c.conn
+}
+
+// writeFailed records 'err' (if not nil) for Flush and tells everyone that
+// the connection has been lost.
+func (c *Conn) writeFailed(done chan struct{}, err error) {
+	if err == nil {
+		return
+	}
+	if c.writeErr == nil {
+		c.writeErr = err
+	}
+	c.connLost(done, err)
 }
 
 // readResponses is a goroutine that reads events, errors and
@@ -331,13 +1134,14 @@ func (c *Conn) writeBuffer(buf []byte) {
 // When a reply is read, it is added to the corresponding cookie's reply
 // channel. (It is an error if no such cookie exists in this case.)
 // Finally, cookies that came "before" this reply are always cleaned up.
-func (c *Conn) readResponses() {
-	defer close(c.eventChan)
+func (c *Conn) readResponses(done chan struct{}) {
+	defer c.running.Done()
 
 	var (
 		err        Error
 		event      Event
 		seq        uint16
+		seqFull    uint32
 		replyBytes []byte
 	)
 
@@ -345,19 +1149,26 @@ func (c *Conn) readResponses() {
 		buf := make([]byte, 32)
 		err, event, seq = nil, nil, 0
 
-		if _, err := io.ReadFull(c.conn, buf); err != nil {
-			logger.Printf("Read error: %s", err)
-			logger.Fatal("A read error is unrecoverable. Exiting...")
+		if _, err := io.ReadFull(c.input, buf); err != nil {
+			c.connLost(done, err)
+			return
 		}
+		tracer := c.loadTracer()
 
 		switch buf[0] {
 		case 0: // This is an error
+			c.stats.received(&c.stats.errors, len(buf))
+			if tracer != nil {
+				tracer.TraceError(Get16(buf[2:]), buf)
+			}
+
 			// Use the constructor function for this error (that is auto
 			// generated) by looking it up by the error number.
 			newErrFun, ok := NewErrorFuncs[int(buf[1])]
 			if !ok {
-				logger.Printf("BUG: Could not find error constructor function "+
-					"for error with number %d.", buf[1])
+				c.log().Printf("BUG: Could not find error "+
+					"constructor function for error with "+
+					"number %d.", buf[1])
 				continue
 			}
 			err = newErrFun(buf)
@@ -369,18 +1180,14 @@ func (c *Conn) readResponses() {
 			seq = Get16(buf[2:])
 
 			// check to see if this reply has more bytes to be read
-			size := Get32(buf[4:])
-			if size > 0 {
-				byteCount := 32 + size*4
-				biggerBuf := make([]byte, byteCount)
-				copy(biggerBuf[:32], buf)
-				if _, err := io.ReadFull(c.conn, biggerBuf[32:]); err != nil {
-					logger.Printf("Read error: %s", err)
-					logger.Fatal("A read error is unrecoverable. Exiting...")
-				}
-				replyBytes = biggerBuf
-			} else {
-				replyBytes = buf
+			var err error
+			if replyBytes, err = c.readMore(buf); err != nil {
+				c.connLost(done, err)
+				return
+			}
+			c.stats.received(&c.stats.replies, len(replyBytes))
+			if tracer != nil {
+				tracer.TraceReply(seq, replyBytes)
 			}
 
 			// This reply is sent to its corresponding cookie below.
@@ -391,14 +1198,41 @@ func (c *Conn) readResponses() {
 			// the most significant bit (which is set when it was sent from
 			// a SendEvent request).
 			evNum := int(buf[0] & 127)
-			newEventFun, ok := NewEventFuncs[evNum]
-			if !ok {
-				logger.Printf("BUG: Could not find event construct function "+
-					"for event with number %d.", evNum)
+			if evNum == propertyNotify {
+				c.invalidateProperty(buf)
+			}
+			if evNum == GenericEventCode {
+				// Generic events can be longer than 32
+				// bytes.
+				var err error
+				if buf, err = c.readMore(buf); err != nil {
+					c.connLost(done, err)
+					return
+				}
+			}
+			c.stats.received(&c.stats.events, len(buf))
+			if tracer != nil {
+				tracer.TraceEvent(buf)
+			}
+
+			if evNum == GenericEventCode {
+				// They are decoded by the handlers registered
+				// for them.
+				event = c.newGenericEvent(buf)
+			} else if newEventFun, ok := NewEventFuncs[evNum]; ok {
+				event = newEventFun(buf)
+			} else {
+				c.log().Printf("BUG: Could not find event "+
+					"construct function for event with "+
+					"number %d.", evNum)
 				continue
 			}
 
-			event = newEventFun(buf)
+			// Events someone subscribed to don't go into the
+			// queue.
+			if c.deliverEvent(evNum, event) {
+				continue
+			}
 
 			// Put the event into the queue.
 			// FIXME: I'm not sure if using a goroutine here to guarantee
@@ -423,19 +1257,58 @@ func (c *Conn) readResponses() {
 		// processing an error or a reply, which are both responses to
 		// requests. So all we have to do is find the cookie corresponding
 		// to this error/reply, and send the appropriate data to it.
+		// Cookies are matched on the full sequence number, so that
+		// a cookie 2^16 requests old is never mistaken for the right
+		// one.
+		seqFull = widenSequence(atomic.LoadUint32(&c.seqnumFull), seq)
+
+		// The rest of the replies to a request with several go straight
+		// to its cookie, which has already been taken off cookieChan.
+		if cookie := c.multiCookie; cookie != nil {
+			switch {
+			case cookie.seqnumFull != seqFull:
+				// The X server has moved on to another request.
+				c.log().Printf("Cookie with sequence id %d "+
+					"missed some replies.", cookie.Sequence)
+				c.multiCookie = nil
+				cookie.fail(errMissingReplies)
+			case err != nil:
+				c.multiCookie = nil
+				if cookie.errorChan != nil {
+					cookie.errorChan <- err
+				} else {
+					cookie.conn.asyncError(err)
+					cookie.pingChan <- true
+				}
+				continue
+			default:
+				if !c.sendMultiReply(done, cookie, replyBytes) {
+					return
+				}
+				continue
+			}
+		}
+
 		// In doing so, we make sure that any cookies that came before it
 		// are marked as successful if they are void and checked.
 		// If there's a cookie that requires a reply that is before this
 		// reply, then something is wrong.
-		for cookie := range c.cookieChan {
+		for {
+			var cookie *Cookie
+			select {
+			case cookie = <-c.cookieChan:
+			case <-done:
+				return
+			}
+
 			// This is the cookie we're looking for. Process and break.
-			if cookie.Sequence == seq {
+			if cookie.seqnumFull == seqFull {
 				if err != nil { // this is an error to a request
 					// synchronous processing
 					if cookie.errorChan != nil {
 						cookie.errorChan <- err
 					} else { // asynchronous processing
-						c.eventChan <- err
+						cookie.conn.asyncError(err)
 						// if this is an unchecked reply, ping the cookie too
 						if cookie.pingChan != nil {
 							cookie.pingChan <- true
@@ -443,11 +1316,15 @@ func (c *Conn) readResponses() {
 					}
 				} else { // this is a reply
 					if cookie.replyChan == nil {
-						logger.Printf("Reply with sequence id %d does not "+
-							"have a cookie with a valid reply channel.", seq)
+						c.log().Printf("Reply with "+
+							"sequence id %d does "+
+							"not have a cookie "+
+							"with a valid reply "+
+							"channel.", seq)
 						continue
-					} else {
-						cookie.replyChan <- replyBytes
+					} else if !c.sendReply(done, cookie,
+						replyBytes) {
+						return
 					}
 				}
 				break
@@ -456,14 +1333,17 @@ func (c *Conn) readResponses() {
 			switch {
 			// Checked requests with replies
 			case cookie.replyChan != nil && cookie.errorChan != nil:
-				logger.Printf("Found cookie with sequence id %d that is "+
-					"expecting a reply but will never get it. Currently "+
-					"on sequence number %d", cookie.Sequence, seq)
+				c.log().Printf("Found cookie with sequence "+
+					"id %d that is expecting a reply but "+
+					"will never get it. Currently on "+
+					"sequence number %d", cookie.Sequence,
+					seq)
 			// Unchecked requests with replies
 			case cookie.replyChan != nil && cookie.pingChan != nil:
-				logger.Printf("Found cookie with sequence id %d that is "+
-					"expecting a reply (and not an error) but will never "+
-					"get it. Currently on sequence number %d",
+				c.log().Printf("Found cookie with sequence "+
+					"id %d that is expecting a reply (and "+
+					"not an error) but will never get it. "+
+					"Currently on sequence number %d",
 					cookie.Sequence, seq)
 			// Checked requests without replies
 			case cookie.pingChan != nil && cookie.errorChan != nil:
@@ -475,14 +1355,77 @@ func (c *Conn) readResponses() {
 	}
 }
 
-// processEventOrError takes an eventOrError, type switches on it,
+// sendReply sends a reply to its cookie, along with the file descriptors
+// passed with it, if any. It returns false if the connection is stopped
+// before the reply could be sent.
+func (c *Conn) sendReply(done chan struct{}, cookie *Cookie,
+	reply []byte) bool {
+
+	if cookie.replyFDs {
+		cookie.fds = c.recvFD(int(reply[1]))
+	}
+	if cookie.property != nil {
+		c.cacheProperty(cookie.property, reply)
+	}
+	if cookie.lastReply != nil {
+		return c.sendMultiReply(done, cookie, reply)
+	}
+	cookie.replyChan <- reply
+	return true
+}
+
+// errMissingReplies is sent to the cookie of a request with several replies
+// when the X server moves on to the next request before the last one.
+var errMissingReplies = errors.New("the X server did not send the last " +
+	"reply to a request")
+
+// sendMultiReply sends one of the replies to a request with several to its
+// cookie, and keeps the cookie in multiCookie until it has had the last one.
+// It waits for the reply to be taken, and returns false if the connection is
+// stopped first (which then fails the cookie).
+func (c *Conn) sendMultiReply(done chan struct{}, cookie *Cookie,
+	reply []byte) bool {
+
+	c.multiCookie = cookie
+	select {
+	case cookie.replyChan <- reply:
+		if cookie.lastReply(reply) {
+			c.multiCookie = nil
+		}
+		return true
+	case <-done:
+		return false
+	}
+}
+
+// readMore reads the rest of a reply or generic event, whose first 32 bytes
+// are in 'buf', and whose length beyond those 32 bytes (in 4 byte units) is in
+// bytes 4-7 of 'buf'. It returns the whole reply or event.
+func (c *Conn) readMore(buf []byte) ([]byte, error) {
+	size := Get32(buf[4:])
+	if size == 0 {
+		return buf, nil
+	}
+
+	biggerBuf := make([]byte, 32+int(size)*4)
+	copy(biggerBuf[:32], buf)
+	if _, err := io.ReadFull(c.input, biggerBuf[32:]); err != nil {
+		return nil, err
+	}
+	return biggerBuf, nil
+}
+
+// processEventOrError takes an EventOrError, type switches on it,
 // and returns it in Go idiomatic style.
-func processEventOrError(everr eventOrError) (Event, Error) {
+func processEventOrError(everr EventOrError) (Event, Error) {
 	switch ee := everr.(type) {
 	case Event:
 		return ee, nil
 	case Error:
 		return nil, ee
+	case error:
+		// The connection was closed or lost.
+		return nil, nil
 	default:
 		logger.Printf("Invalid event/error type: %T", everr)
 		return nil, nil
@@ -492,24 +1435,235 @@ func processEventOrError(everr eventOrError) (Event, Error) {
 
 // WaitForEvent returns the next event from the server.
 // It will block until an event is available.
-// WaitForEvent returns either an Event or an Error. (Returning neither or both
-// is a bug.) Note than an Error here is an X error and not an XGB error. That
-// is, X errors are sometimes completely expected (and you may want to ignore
-// them in some cases).
+// WaitForEvent returns either an Event or an Error. Note than an Error here
+// is an X error and not an XGB error. That is, X errors are sometimes
+// completely expected (and you may want to ignore them in some cases).
+// WaitForEvent returns neither when the connection to the X server has been
+// closed or lost. (The other ways of getting events, like PollForEvent, give
+// the reason, which is a ConnectionClosedError if it was lost.)
 func (c *Conn) WaitForEvent() (Event, Error) {
+	if everr, ok := c.requeuedEvent(); ok {
+		return processEventOrError(everr)
+	}
 	return processEventOrError(<-c.eventChan)
 }
 
-// PollForEvent returns the next event from the server if one is available in
-// the internal queue without blocking. Note that unlike WaitForEvent, both
-// Event and Error could be nil. Indeed, they are both nil when the event queue
-// is empty.
-func (c *Conn) PollForEvent() (Event, Error) {
+// PollForEvent returns the next event or error from the server if one is
+// available in the internal queue, without blocking. The boolean is false
+// (and the event and error are nil) when the queue is empty.
+// Like with WaitForEvent, the error is usually an X error (i.e., an Error).
+// It is the reason why instead when the connection to the X server has been
+// closed or lost.
+func (c *Conn) PollForEvent() (Event, error, bool) {
+	if everr, ok := c.requeuedEvent(); ok {
+		ev, err := splitEventOrError(everr)
+		return ev, err, true
+	}
 	select {
 	case everr := <-c.eventChan:
-		return processEventOrError(everr)
+		ev, err := splitEventOrError(everr)
+		return ev, err, true
+	default:
+		return nil, nil, false
+	}
+	panic("unreachable")
+}
+
+// FlushEvents takes every event and error off the internal queue without
+// blocking, and returns them, oldest first. Like the error from PollForEvent,
+// an error in it is usually an X error, or the reason why the connection to
+// the X server has been closed or lost. Events still on their way from the X
+// server (e.g., those caused by a request that has just been sent) may arrive
+// after it returns; use Sync before it to have them too.
+func (c *Conn) FlushEvents() []EventOrError {
+	events := c.takeRequeued()
+	for {
+		select {
+		case everr := <-c.eventChan:
+			events = append(events, everr)
+		default:
+			return events
+		}
+	}
+}
+
+// defaultDrainTimeout is how long DrainEvents waits for more events.
+const defaultDrainTimeout = 100 * time.Millisecond
+
+// DrainEvents takes events off the queue, waiting up to a tenth of a second
+// for more to arrive, until 'until' returns true for one. It then puts every
+// event it took (and the X errors found along the way) back at the front of
+// the queue, in order, ahead of any event that arrived in the mean time, and
+// returns those events, oldest first, the last one being the one 'until'
+// returned true for, if any. This is useful after changing which events
+// a window gets (e.g., with ChangeWindowAttributes), to look at the events
+// selected before the change before dealing with them.
+//
+// With a nil 'until', DrainEvents returns the events that arrive within the
+// timeout. It also stops waiting when the connection to the X server is
+// closed or lost. Use DrainEventsTimeout to wait longer (or shorter).
+//
+// Like WaitForEvent, DrainEvents should not be used along with Events.
+func (c *Conn) DrainEvents(until func(Event) bool) []Event {
+	return c.DrainEventsTimeout(until, defaultDrainTimeout)
+}
+
+// DrainEventsTimeout is like DrainEvents, but waits up to 'timeout' for more
+// events instead of a tenth of a second.
+func (c *Conn) DrainEventsTimeout(until func(Event) bool,
+	timeout time.Duration) []Event {
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var drained []Event
+	var taken []EventOrError
+	defer func() { c.requeue(taken) }()
+	for {
+		everr, ok := c.requeuedEvent()
+		if !ok {
+			select {
+			case everr = <-c.eventChan:
+			case <-timer.C:
+				return drained
+			}
+		}
+		taken = append(taken, everr)
+
+		switch ee := everr.(type) {
+		case Event:
+			drained = append(drained, ee)
+			if until != nil && until(ee) {
+				return drained
+			}
+		case Error:
+		case error:
+			// The connection was closed or lost.
+			return drained
+		}
+	}
+}
+
+// requeue puts 'events' back at the front of the queue, before any other
+// event.
+func (c *Conn) requeue(events []EventOrError) {
+	if len(events) == 0 {
+		return
+	}
+	c.requeueLock.Lock()
+	defer c.requeueLock.Unlock()
+
+	c.requeued = append(events, c.requeued...)
+}
+
+// requeuedEvent takes the first event or error put back by DrainEvents off
+// the queue. It returns false if there are none.
+func (c *Conn) requeuedEvent() (EventOrError, bool) {
+	c.requeueLock.Lock()
+	defer c.requeueLock.Unlock()
+
+	if len(c.requeued) == 0 {
+		return nil, false
+	}
+	everr := c.requeued[0]
+	c.requeued = c.requeued[1:]
+	return everr, true
+}
+
+// takeRequeued takes every event and error put back by DrainEvents off the
+// queue.
+func (c *Conn) takeRequeued() []EventOrError {
+	c.requeueLock.Lock()
+	defer c.requeueLock.Unlock()
+
+	events := c.requeued
+	c.requeued = nil
+	return events
+}
+
+// ErrTimeout is returned by WaitForEventTimeout when no event or error
+// arrived in time.
+var ErrTimeout = errors.New("timed out waiting for an event")
+
+// WaitForEventTimeout is like WaitForEvent, but gives up after 'd', in which
+// case it returns ErrTimeout. Like with PollForEvent, the error is usually an
+// X error (i.e., an Error), or the reason why the connection to the X server
+// has been closed or lost.
+//
+// The connection is left alone (unlike with SetReadDeadline, which breaks
+// it once the deadline is exceeded), so an event that arrives later is
+// returned by the next call.
+func (c *Conn) WaitForEventTimeout(d time.Duration) (Event, error) {
+	if everr, ok := c.requeuedEvent(); ok {
+		return splitEventOrError(everr)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case everr := <-c.eventChan:
+		return splitEventOrError(everr)
+	case <-timer.C:
+		return nil, ErrTimeout
+	}
+}
+
+// splitEventOrError is like processEventOrError, but returns the reason why
+// the connection was closed or lost too.
+func splitEventOrError(everr EventOrError) (Event, error) {
+	switch ee := everr.(type) {
+	case Event:
+		return ee, nil
+	case error:
+		return nil, ee
 	default:
+		logger.Printf("Invalid event/error type: %T", everr)
 		return nil, nil
 	}
+}
+
+// Events returns a channel on which every event and error from the server is
+// sent, as returned by WaitForEvent. This makes it easy to use events in
+// a select statement. The channel is closed when the connection to the X
+// server is closed or lost.
+//
+// The first call to Events starts a goroutine that takes events off the
+// internal queue, so WaitForEvent and PollForEvent should not be used
+// afterwards. Further calls return the same channel, until it is closed. After
+// that (i.e., after Reconnect), a new channel is returned.
+func (c *Conn) Events() <-chan EventOrError {
+	c.eventsLock.Lock()
+	defer c.eventsLock.Unlock()
+
+	if c.events == nil {
+		c.events = make(chan EventOrError, eventsBuffer)
+		go c.sendEvents(c.events)
+	}
+	return c.events
+}
+
+// sendEvents moves events from the internal queue to 'events' until the
+// connection is closed or lost. It is meant to be run in its own goroutine.
+func (c *Conn) sendEvents(events chan EventOrError) {
+	for {
+		everr, ok := c.requeuedEvent()
+		if !ok {
+			everr = <-c.eventChan
+		}
+		switch ee := everr.(type) {
+		case Event:
+			events <- ee
+		case Error:
+			events <- ee
+		case error:
+			c.eventsLock.Lock()
+			c.events = nil
+			c.eventsLock.Unlock()
+			close(events)
+			return
+		default:
+			c.log().Printf("Invalid event/error type: %T", ee)
+		}
+	}
 	panic("unreachable")
 }
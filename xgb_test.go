@@ -0,0 +1,376 @@
+package xgb
+
+import (
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestDeadlineNotSupported makes sure that setting deadlines on a connection
+// that isn't a net.Conn fails gracefully.
+func TestDeadlineNotSupported(t *testing.T) {
+	r, w := io.Pipe()
+	c := &Conn{conn: struct {
+		io.Reader
+		io.WriteCloser
+	}{r, w}}
+
+	deadline := time.Now().Add(time.Second)
+	if err := c.SetDeadline(deadline); err != ErrDeadlineNotSupported {
+		t.Fatalf("SetDeadline: expected ErrDeadlineNotSupported, "+
+			"got %v", err)
+	}
+	if err := c.SetReadDeadline(deadline); err != ErrDeadlineNotSupported {
+		t.Fatalf("SetReadDeadline: expected ErrDeadlineNotSupported, "+
+			"got %v", err)
+	}
+	if err := c.SetWriteDeadline(deadline); err != ErrDeadlineNotSupported {
+		t.Fatalf("SetWriteDeadline: expected ErrDeadlineNotSupported, "+
+			"got %v", err)
+	}
+}
+
+// TestSetReadDeadline makes sure that read deadlines are forwarded to the
+// underlying net.Conn.
+func TestSetReadDeadline(t *testing.T) {
+	client, server := net.Pipe()
+	defer server.Close()
+	c := &Conn{conn: client}
+
+	err := c.SetReadDeadline(time.Now().Add(time.Millisecond))
+	if err != nil {
+		t.Fatalf("SetReadDeadline: %s", err)
+	}
+	_, err = client.Read(make([]byte, 1))
+	if netErr, ok := err.(net.Error); !ok || !netErr.Timeout() {
+		t.Fatalf("Expected a timeout error but got '%v'.", err)
+	}
+}
+
+// TestRawConn makes sure RawConn only returns real network connections.
+func TestRawConn(t *testing.T) {
+	client, server := net.Pipe()
+	defer server.Close()
+	if raw := (&Conn{conn: client}).RawConn(); raw != client {
+		t.Fatalf("Expected RawConn to return %v, but got %v",
+			client, raw)
+	}
+
+	r, w := io.Pipe()
+	c := &Conn{conn: struct {
+		io.Reader
+		io.WriteCloser
+	}{r, w}}
+	if raw := c.RawConn(); raw != nil {
+		t.Fatalf("Expected RawConn to return nil, but got %v", raw)
+	}
+}
+
+// TestWidenSequence reconstructs full sequence numbers around the points
+// where the 16 bit and 32 bit sequence numbers wrap.
+func TestWidenSequence(t *testing.T) {
+	tests := []struct {
+		last uint32
+		seq  uint16
+		full uint32
+	}{
+		{1, 1, 1},
+		{10, 7, 7},
+		{0xffff, 0xffff, 0xffff},
+		{0x10000, 0xffff, 0xffff},
+		{0x10000, 0, 0x10000},
+		{0x10005, 0xfffe, 0xfffe},
+		{0x10005, 3, 0x10003},
+		{0x2fffe, 0xfffe, 0x2fffe},
+		{0, 0xffff, 0xffffffff},
+		{3, 0xfff0, 0xfffffff0},
+	}
+	for _, test := range tests {
+		full := widenSequence(test.last, test.seq)
+		if full != test.full {
+			t.Errorf("widenSequence(%#x, %#x): expected %#x, "+
+				"but got %#x", test.last, test.seq,
+				test.full, full)
+		}
+	}
+}
+
+// TestGenerateIDs generates every resource identifier of a connection with
+// only three of them, and makes sure the fourth fails when XC-MISC isn't
+// available.
+func TestGenerateIDs(t *testing.T) {
+	c, err := NewConnDisplay(xidServer(t, 0x400000, 0x3, nil))
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+
+	ids, err := c.GenerateIDs(3)
+	if err != nil {
+		t.Fatalf("GenerateIDs: %s", err)
+	}
+	want := []uint32{0x400001, 0x400002, 0x400003}
+	if !reflect.DeepEqual(ids, want) {
+		t.Fatalf("Expected the ids %#x, but got %#x.", want, ids)
+	}
+	if ids, err := c.GenerateIDs(1); err != errNoMoreIds {
+		t.Fatalf("Expected errNoMoreIds, but got (%#x, %v).", ids, err)
+	}
+	if id, err := c.NewId(); err != errNoMoreIds {
+		t.Fatalf("Expected errNoMoreIds, but got (%#x, %v).", id, err)
+	}
+}
+
+// testEvent is a stand-in for an event generated by xgbgen.
+type testEvent struct{}
+
+func (testEvent) Bytes() []byte  { return nil }
+func (testEvent) String() string { return "testEvent" }
+
+// TestPollForEvent polls an event queue holding an event and the error sent
+// when the connection is closed.
+func TestPollForEvent(t *testing.T) {
+	c := &Conn{eventChan: make(chan EventOrError, 2)}
+	if ev, err, ok := c.PollForEvent(); ok {
+		t.Fatalf("Expected an empty queue, but got (%v, %v).", ev, err)
+	}
+
+	c.eventChan <- testEvent{}
+	c.eventChan <- errClosed
+	if ev, err, ok := c.PollForEvent(); !ok || ev != (testEvent{}) ||
+		err != nil {
+
+		t.Fatalf("Expected an event, but got (%v, %v, %v).",
+			ev, err, ok)
+	}
+	if ev, err, ok := c.PollForEvent(); !ok || ev != nil ||
+		err != errClosed {
+
+		t.Fatalf("Expected '%v', but got (%v, %v, %v).",
+			errClosed, ev, err, ok)
+	}
+	if ev, err, ok := c.PollForEvent(); ok {
+		t.Fatalf("Expected an empty queue, but got (%v, %v).", ev, err)
+	}
+}
+
+// TestFlushEvents drains an event queue holding an event, an X error and the
+// error sent when the connection is closed.
+func TestFlushEvents(t *testing.T) {
+	c := &Conn{eventChan: make(chan EventOrError, 3)}
+	if events := c.FlushEvents(); len(events) != 0 {
+		t.Fatalf("Expected no events, but got %v.", events)
+	}
+
+	want := []EventOrError{testEvent{}, testError{}, errClosed}
+	for _, everr := range want {
+		c.eventChan <- everr
+	}
+	if events := c.FlushEvents(); !reflect.DeepEqual(events, want) {
+		t.Fatalf("Expected %v, but got %v.", want, events)
+	}
+	if ev, err, ok := c.PollForEvent(); ok {
+		t.Fatalf("Expected an empty queue, but got (%v, %v).", ev, err)
+	}
+}
+
+// TestDrainEvents drains the events of a queue up to the one 'until' stops
+// at, and then up to a timeout, and makes sure they are put back at the front
+// of the queue, in order, along with the X error between them.
+func TestDrainEvents(t *testing.T) {
+	c := &Conn{eventChan: make(chan EventOrError, 5)}
+	for _, everr := range []EventOrError{testEventN(1), testError{},
+		testEventN(2), testEventN(3)} {
+
+		c.eventChan <- everr
+	}
+	events := c.DrainEvents(func(ev Event) bool {
+		return ev == testEventN(2)
+	})
+	drained := []Event{testEventN(1), testEventN(2)}
+	if !reflect.DeepEqual(events, drained) {
+		t.Fatalf("Expected %v, but got %v.", drained, events)
+	}
+
+	// The events drained before come first, even with a timeout.
+	start := time.Now()
+	events = c.DrainEventsTimeout(nil, 10*time.Millisecond)
+	drained = append(drained, testEventN(3))
+	if !reflect.DeepEqual(events, drained) {
+		t.Fatalf("Expected %v, but got %v.", drained, events)
+	}
+	if time.Since(start) < 10*time.Millisecond {
+		t.Fatalf("DrainEvents returned before the timeout.")
+	}
+
+	c.eventChan <- testEventN(4)
+	want := []EventOrError{testEventN(1), testError{}, testEventN(2),
+		testEventN(3), testEventN(4)}
+	if events := c.FlushEvents(); !reflect.DeepEqual(events, want) {
+		t.Fatalf("Expected %v, but got %v.", want, events)
+	}
+
+	// It gives up once the connection is closed.
+	c.eventChan <- errClosed
+	if events := c.DrainEventsTimeout(nil, time.Minute); len(events) != 0 {
+		t.Fatalf("Expected no events, but got %v.", events)
+	}
+}
+
+// TestWaitForEventTimeout waits for an event that is already queued, for one
+// that doesn't come, and for the error sent when the connection is closed.
+func TestWaitForEventTimeout(t *testing.T) {
+	c := &Conn{eventChan: make(chan EventOrError, 2)}
+	c.eventChan <- testEvent{}
+	if ev, err := c.WaitForEventTimeout(time.Second); ev != (testEvent{}) ||
+		err != nil {
+
+		t.Fatalf("Expected an event, but got (%v, %v).", ev, err)
+	}
+
+	start := time.Now()
+	if ev, err := c.WaitForEventTimeout(10 * time.Millisecond); ev != nil ||
+		err != ErrTimeout {
+
+		t.Fatalf("Expected '%v', but got (%v, %v).",
+			ErrTimeout, ev, err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Fatalf("WaitForEventTimeout returned after only %s.", elapsed)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		c.eventChan <- errClosed
+	}()
+	if ev, err := c.WaitForEventTimeout(5 * time.Second); ev != nil ||
+		err != errClosed {
+
+		t.Fatalf("Expected '%v', but got (%v, %v).",
+			errClosed, ev, err)
+	}
+}
+
+// TestConnectionClosedError makes sure that a connection the server closes
+// is reported as a ConnectionClosedError, both to events and to a cookie
+// waiting for a reply.
+func TestConnectionClosedError(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(c.getInputFocusRequest(), cookie)
+	c.Flush()
+
+	// Read the request first, so that closing doesn't reset the connection.
+	server := <-conns
+	io.ReadFull(server, make([]byte, 4))
+	server.Close()
+
+	_, err = c.WaitForEventTimeout(5 * time.Second)
+	var lost ConnectionClosedError
+	if !errors.As(err, &lost) || !lost.ServerClosed() {
+		t.Fatalf("Expected the server to have closed the connection, "+
+			"but got %v.", err)
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("Expected %v to wrap io.EOF.", err)
+	}
+	if _, err := cookie.Reply(); !errors.As(err, &lost) {
+		t.Fatalf("Expected a ConnectionClosedError, but got %v.", err)
+	}
+}
+
+// TestConcurrentRequests makes requests from several goroutines at once,
+// some waiting for a reply and some checked, while the responses are read.
+// It is mostly useful with -race, which checks that the goroutines making
+// requests and the ones serving the connection don't share any state
+// unsynchronized.
+func TestConcurrentRequests(t *testing.T) {
+	display, conns := setupServer(t)
+	c, err := NewConnDisplay(display)
+	if err != nil {
+		t.Fatalf("NewConnDisplay: %s", err)
+	}
+	defer c.Close()
+	go replyServer(<-conns)
+
+	request := func(checked bool) error {
+		if checked {
+			cookie := c.NewCookie(true, false)
+			c.NewRequest(noOperationRequest(), cookie)
+			return cookie.Check()
+		}
+		cookie := c.NewCookie(true, true)
+		c.NewRequest(c.getInputFocusRequest(), cookie)
+		_, err := cookie.Reply()
+		return err
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func(checked bool) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if err := request(checked); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(i%2 == 0)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Request failed: %s", err)
+	}
+}
+
+// testError is a stand-in for an error generated by xgbgen.
+type testError struct{}
+
+func (testError) SequenceId() uint16 { return 0 }
+func (testError) BadId() uint32      { return 0 }
+func (testError) Error() string      { return "testError" }
+
+// TestEvents makes sure that events and errors are sent on the channel
+// returned by Events, and that it is closed when the connection is lost.
+func TestEvents(t *testing.T) {
+	c := &Conn{eventChan: make(chan EventOrError, 3)}
+	c.eventChan <- testEvent{}
+	c.eventChan <- testError{}
+
+	events := c.Events()
+	if c.Events() != events {
+		t.Fatalf("Events returned a different channel the second time.")
+	}
+	if ev := <-events; ev != (testEvent{}) {
+		t.Fatalf("Expected an event, but got %v.", ev)
+	}
+	if err := <-events; err != (testError{}) {
+		t.Fatalf("Expected an error, but got %v.", err)
+	}
+
+	c.eventChan <- errClosed
+	select {
+	case everr, ok := <-events:
+		if ok {
+			t.Fatalf("Expected the channel to be closed, "+
+				"but got %v.", everr)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("The channel was not closed when the connection was.")
+	}
+	if c.Events() == events {
+		t.Fatalf("Events returned a closed channel.")
+	}
+}
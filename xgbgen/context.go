@@ -15,6 +15,10 @@ type Context struct {
 	out      *bytes.Buffer
 }
 
+// now returns the time written in the header of the generated source. The
+// golden file tests replace it, so that their output doesn't change.
+var now = time.Now
+
 func newContext() *Context {
 	return &Context{
 		out: bytes.NewBuffer([]byte{}),
@@ -57,7 +61,7 @@ func (c *Context) Morph(xmlBytes []byte) {
 	c.Putln("")
 	c.Putln("/*")
 	c.Putln("\tThis file was generated by %s.xml on %s.",
-		c.protocol.Name, time.Now().Format("Jan 2 2006 3:04:05pm MST"))
+		c.protocol.Name, now().Format("Jan 2 2006 3:04:05pm MST"))
 	c.Putln("\tThis file is automatically generated. Edit at your peril!")
 	c.Putln("*/")
 	c.Putln("")
@@ -94,8 +98,8 @@ func (c *Context) Morph(xmlBytes []byte) {
 		c.Putln("case err != nil:")
 		c.Putln("return err")
 		c.Putln("case !reply.Present:")
-		c.Putln("return xgb.Errorf(\"No extension named %s could be found on "+
-			"on the server.\")", xname)
+		c.Putln("return xproto.ExtNotAvailableError{"+
+			"Name: \"%s\", Reply: reply}", xname)
 		c.Putln("}")
 		c.Putln("")
 		c.Putln("xgb.ExtLock.Lock()")
@@ -139,6 +143,25 @@ func (c *Context) Morph(xmlBytes []byte) {
 		c.Putln("return &s.Roots[c.DefaultScreen]")
 		c.Putln("}")
 		c.Putln("")
+
+		// Extensions need to report when they aren't supported by the
+		// server. Since every extension imports xproto, the error type
+		// lives here.
+		c.Putln("// ExtNotAvailableError is returned by the Init " +
+			"function of an extension")
+		c.Putln("// package when the X server does not support " +
+			"that extension.")
+		c.Putln("type ExtNotAvailableError struct {")
+		c.Putln("Name string // the X name of the extension, " +
+			"e.g., \"RANDR\"")
+		c.Putln("Reply *QueryExtensionReply")
+		c.Putln("}")
+		c.Putln("")
+		c.Putln("func (err ExtNotAvailableError) Error() string {")
+		c.Putln("return \"No extension named \" + err.Name + " +
+			"\" could be found on the server.\"")
+		c.Putln("}")
+		c.Putln("")
 	}
 
 	// Now write Go source code
@@ -50,6 +50,14 @@ I'd be happy to try and make xgbgen a little more friendly in this regard.
 I did, however, design xgbgen with this in mind, so it shouldn't involve
 anything as serious as a re-design.)
 
+Tests
+
+The XML files in testdata are made up protocol descriptions, each with the Go
+source xgbgen should generate from it in a golden file next to it (e.g.,
+test.go.golden for test.xml). 'go test' checks that the generated source
+hasn't changed. After a change to the generator, 'go test -update' writes
+the new source to the golden files, so the differences can be reviewed.
+
 Why
 
 I wrote xgbgen because I found the existing code generator that was written in
@@ -62,10 +70,13 @@ The core X protocol should be completely working. As far as I know, most
 extensions should work too, although I've only tested (and not much) the
 Xinerama and RandR extensions.
 
-XKB does not work. I don't have any real plans of working on this unless there
-is demand and I have some test cases to work with. (i.e., even if I could get
-something generated for XKB, I don't have the inclination to understand it
-enough to verify that it works.) XKB poses several extremely difficult
+The 'switch' element, which XKB and XInput 2 use for fields that are only
+present in some cases, is supported: see SwitchField in field.go for the Go
+types it becomes, and testdata/switches.xml for examples.
+
+XKB only partly works: xgbgen can't handle all of xkb.xml yet (aligned pads,
+sumof and unions of actions are missing), so the xkb package is generated from
+the subset of it in xkb/xkb.xml. XKB poses several extremely difficult
 problems that XCB also has trouble with. More info on that can be found at
 http://cgit.freedesktop.org/xcb/libxcb/tree/doc/xkb_issues and
 http://cgit.freedesktop.org/xcb/libxcb/tree/doc/xkb_internals.
@@ -62,6 +62,37 @@ func (e *Function) Initialize(p *Protocol) {
 	e.Expr.Initialize(p)
 }
 
+// Conditional is a custom expression not found in the XML. It is Expr when
+// Cond is true (i.e., not zero or false), and 0 otherwise. It is used for
+// the sizes of the bitcases of a switch.
+type Conditional struct {
+	Cond Expression
+	Expr Expression
+}
+
+func (e *Conditional) Concrete() bool {
+	return false
+}
+
+func (e *Conditional) Eval() int {
+	log.Fatalf("Cannot evaluate a 'Conditional'. It is not concrete.")
+	panic("unreachable")
+}
+
+func (e *Conditional) Reduce(prefix string) string {
+	return fmt.Sprintf("func() int { if %s { return %s }; return 0 }()",
+		e.Cond.Reduce(prefix), e.Expr.Reduce(prefix))
+}
+
+func (e *Conditional) String() string {
+	return e.Reduce("")
+}
+
+func (e *Conditional) Initialize(p *Protocol) {
+	e.Cond.Initialize(p)
+	e.Expr.Initialize(p)
+}
+
 // BinaryOp is an expression that performs some operation (defined in the XML
 // file) with Expr1 and Expr2 as operands.
 type BinaryOp struct {
@@ -105,9 +136,9 @@ func (e *BinaryOp) Eval() int {
 		return e.Expr1.Eval() * e.Expr2.Eval()
 	case "/":
 		return e.Expr1.Eval() / e.Expr2.Eval()
-	case "&amp;":
+	case "&":
 		return e.Expr1.Eval() & e.Expr2.Eval()
-	case "&lt;&lt;":
+	case "<<":
 		return int(uint(e.Expr1.Eval()) << uint(e.Expr2.Eval()))
 	}
 
@@ -175,7 +206,20 @@ func (e *UnaryOp) Reduce(prefix string) string {
 	if e.Concrete() {
 		return fmt.Sprintf("%d", e.Eval())
 	}
-	return fmt.Sprintf("(%s (%s))", e.Op, e.Expr.Reduce(prefix))
+
+	// Go spells bitwise complement with '^', and, as with BinaryOp, fields
+	// have to be converted to ints first.
+	op, expr := e.Op, e.Expr
+	if op == "~" {
+		op = "^"
+	}
+	if _, ok := expr.(*FieldRef); ok {
+		expr = &Function{
+			Name: "int",
+			Expr: expr,
+		}
+	}
+	return fmt.Sprintf("(%s%s)", op, expr.Reduce(prefix))
 }
 
 func (e *UnaryOp) String() string {
@@ -233,7 +277,14 @@ func (e *PopCount) Reduce(prefix string) string {
 	if e.Concrete() {
 		return fmt.Sprintf("%d", e.Eval())
 	}
-	return fmt.Sprintf("xgb.PopCount(%s)", e.Expr.Reduce(prefix))
+	expr := e.Expr
+	if _, ok := expr.(*FieldRef); ok {
+		expr = &Function{
+			Name: "int",
+			Expr: expr,
+		}
+	}
+	return fmt.Sprintf("xgb.PopCount(%s)", expr.Reduce(prefix))
 }
 
 func (e *PopCount) String() string {
@@ -339,7 +390,7 @@ func (e *EnumRef) Eval() int {
 }
 
 func (e *EnumRef) Reduce(prefix string) string {
-	return fmt.Sprintf("%s%s", e.EnumKind, e.EnumItem)
+	return fmt.Sprintf("%s%s", e.EnumKind.SrcName(), e.EnumItem)
 }
 
 func (e *EnumRef) String() string {
@@ -280,15 +280,36 @@ func (f *ValueField) Initialize(p *Protocol) {
 }
 
 // SwitchField represents a 'switch' element in the XML protocol description
-// file. It is not currently used. (i.e., it is XKB voodoo.)
+// file. Its fields are in bitcases or cases, which are only present in the
+// wire format when their expressions match the switch expression.
+//
+// The fields of bitcases are flattened into the structure (or request) that
+// has the switch, since more than one bitcase may be present at once, and
+// their fields often refer to fields outside of the switch. (e.g., the
+// lengths of the lists in the bitcases of the XKB GetMap reply.)
+//
+// Only one case can be present though, and different cases often have
+// fields of the same name. So a switch of cases is a field of an interface
+// type (see CaseSwitch), whose value is a struct with the fields of the case
+// that is present.
 type SwitchField struct {
+	Parent   interface{}
 	Name     string
 	Expr     Expression
 	Bitcases []*Bitcase
+
+	// IsCase is true for a switch of cases, and false for one of bitcases.
+	IsCase bool
+
+	// Type is the interface type of a switch of cases, and nil otherwise.
+	Type *CaseSwitch
 }
 
 func (f *SwitchField) SrcName() string {
-	panic("it is illegal to call SrcName on a SwitchField field")
+	if !f.IsCase {
+		panic("it is illegal to call SrcName on a switch of bitcases")
+	}
+	return f.Name
 }
 
 func (f *SwitchField) XmlName() string {
@@ -296,14 +317,57 @@ func (f *SwitchField) XmlName() string {
 }
 
 func (f *SwitchField) SrcType() string {
-	panic("it is illegal to call SrcType on a SwitchField field")
+	if !f.IsCase {
+		panic("it is illegal to call SrcType on a switch of bitcases")
+	}
+	return f.Type.SrcName()
 }
 
-// XXX: This is a bit tricky. The size has to be represented as a non-concrete
-// expression that finds *which* bitcase fields are included, and sums the
-// sizes of those fields.
+// Size is the sum of the sizes of the bitcases that are present, and the size
+// of the value of the field for a switch of cases.
 func (f *SwitchField) Size() Size {
-	return newFixedSize(0)
+	if f.IsCase {
+		return f.Type.Size()
+	}
+
+	size := newFixedSize(0)
+	for _, bitcase := range f.Bitcases {
+		bitcaseSize := newFixedSize(0)
+		for _, field := range bitcase.Fields {
+			bitcaseSize = bitcaseSize.Add(field.Size())
+		}
+		size = size.Add(newExpressionSize(&Conditional{
+			Cond: f.Cond(bitcase),
+			Expr: bitcaseSize.Expression,
+		}))
+	}
+	return size
+}
+
+// Cond returns the expression that is true when 'bitcase' is present.
+func (f *SwitchField) Cond(bitcase *Bitcase) Expression {
+	if f.IsCase {
+		return newBinaryOp("==", f.Expr, bitcase.Expr)
+	}
+	return newBinaryOp("!=",
+		newBinaryOp("&", f.Expr, bitcase.Expr), &Value{v: 0})
+}
+
+// Fields returns the fields of all the bitcases of a switch of bitcases,
+// including those of the bitcases of switches in them.
+func (f *SwitchField) Fields() []Field {
+	var fields []Field
+	for _, bitcase := range f.Bitcases {
+		for _, field := range bitcase.Fields {
+			swtch, ok := field.(*SwitchField)
+			if ok && !swtch.IsCase {
+				fields = append(fields, swtch.Fields()...)
+			} else {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
 }
 
 func (f *SwitchField) Initialize(p *Protocol) {
@@ -311,15 +375,47 @@ func (f *SwitchField) Initialize(p *Protocol) {
 	f.Expr.Initialize(p)
 	for _, bitcase := range f.Bitcases {
 		bitcase.Expr.Initialize(p)
-		for _, field := range bitcase.Fields {
-			field.Initialize(p)
+	}
+	if !f.IsCase {
+		for _, bitcase := range f.Bitcases {
+			for _, field := range bitcase.Fields {
+				field.Initialize(p)
+			}
 		}
+		return
+	}
+
+	// The fields of the cases are initialized with the structs made for
+	// them.
+	f.Type = newCaseSwitch(f, parentName(f.Parent)+f.Name)
+	f.Type.Initialize(p)
+	p.Types = append(p.Types, f.Type)
+}
+
+// parentName returns the Go name of the type of 'parent', which has
+// a switch.
+func parentName(parent interface{}) string {
+	switch parent := parent.(type) {
+	case *Struct:
+		return parent.SrcName()
+	case *Union:
+		return parent.SrcName()
+	case *Event:
+		return parent.EvType()
+	case *Error:
+		return parent.ErrType()
+	case *Request:
+		return parent.SrcName()
+	case *Reply:
+		return parent.request.ReplyTypeName()
 	}
+	log.Panicf("A switch cannot be in a %T.", parent)
+	panic("unreachable")
 }
 
-// Bitcase represents a single bitcase inside a switch expression.
-// It is not currently used. (i.e., it's XKB voodoo.)
+// Bitcase represents a single bitcase (or case) inside a switch expression.
 type Bitcase struct {
+	Name   string
 	Fields []Field
 	Expr   Expression
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 )
 
 // BaseTypeMap is a map from X base types to Go types.
@@ -187,17 +188,93 @@ func (f *ValueField) Write(c *Context, prefix string) {
 }
 
 // Switch field
+// The fields of a switch of bitcases are flattened into its parent, and
+// a switch of cases is a field whose value is one of the types of its cases.
 func (f *SwitchField) Define(c *Context) {
-	c.Putln("// switch field: %s (%s)", f.Name, f.Expr)
-	panic("todo")
+	if f.IsCase {
+		c.Putln("%s %s", f.Name, f.Type.SrcName())
+		return
+	}
+	for _, bitcase := range f.Bitcases {
+		c.Putln("// present if %s", f.Cond(bitcase))
+		for _, field := range bitcase.Fields {
+			field.Define(c)
+		}
+	}
 }
 
 func (f *SwitchField) Read(c *Context, prefix string) {
-	c.Putln("// reading switch field: %s (%s)", f.Name, f.Expr)
-	panic("todo")
+	if !f.IsCase {
+		for _, bitcase := range f.Bitcases {
+			c.Putln("if %s {", f.Cond(bitcase).Reduce(prefix))
+			for i, field := range bitcase.Fields {
+				if i > 0 {
+					c.Putln("")
+				}
+				field.Read(c, prefix)
+			}
+			c.Putln("}")
+		}
+		return
+	}
+
+	c.Putln("switch {")
+	for i, bitcase := range f.Bitcases {
+		typ := f.Type.Cases[i].SrcName()
+		c.Putln("case %s:", f.Cond(bitcase).Reduce(prefix))
+		c.Putln("var value %s", typ)
+		c.Putln("b += %sRead(buf[b:], &value)", typ)
+		c.Putln("%s%s = value", prefix, f.Name)
+	}
+	c.Putln("}")
 }
 
 func (f *SwitchField) Write(c *Context, prefix string) {
-	c.Putln("// writing switch field: %s (%s)", f.Name, f.Expr)
-	panic("todo")
+	if !f.IsCase {
+		for _, bitcase := range f.Bitcases {
+			c.Putln("if %s {", f.Cond(bitcase).Reduce(prefix))
+			for i, field := range bitcase.Fields {
+				if i > 0 {
+					c.Putln("")
+				}
+				field.Write(c, prefix)
+			}
+			c.Putln("}")
+		}
+		return
+	}
+
+	c.Putln("if %s%s != nil {", prefix, f.Name)
+	c.Putln("structBytes := %s%s.Bytes()", prefix, f.Name)
+	c.Putln("copy(buf[b:], structBytes)")
+	c.Putln("b += len(structBytes)")
+	c.Putln("}")
+}
+
+// Switches of cases
+func (cs *CaseSwitch) Define(c *Context) {
+	names := make([]string, len(cs.Cases))
+	for i, s := range cs.Cases {
+		names[i] = s.SrcName()
+	}
+	c.Putln("// %s is the type of the switch '%s' (on %s). Its value "+
+		"is one of: %s.", cs.SrcName(), cs.Field.Name, cs.Field.Expr,
+		strings.Join(names, ", "))
+	c.Putln("type %s interface {", cs.SrcName())
+	c.Putln("Bytes() []byte")
+	c.Putln("}")
+	c.Putln("")
+	c.Putln("// %s computes the size (bytes) of a %s value, which is 0 if "+
+		"it is nil.", cs.SizeName(), cs.SrcName())
+	c.Putln("func %s(v %s) int {", cs.SizeName(), cs.SrcName())
+	c.Putln("if v == nil {")
+	c.Putln("return 0")
+	c.Putln("}")
+	c.Putln("return len(v.Bytes())")
+	c.Putln("}")
+	c.Putln("")
+
+	for _, s := range cs.Cases {
+		s.Define(c)
+	}
 }
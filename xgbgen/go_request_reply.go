@@ -80,7 +80,7 @@ func (r *Request) CheckExt(c *Context) {
 		return
 	}
 	c.Putln("if _, ok := c.Extensions[\"%s\"]; !ok {",
-		strings.ToUpper(c.protocol.ExtXName))
+		c.protocol.ExtXName)
 	c.Putln("panic(\"Cannot issue request '%s' using the uninitialized "+
 		"extension '%s'. %s.Init(connObj) must be called first.\")",
 		r.SrcName(), c.protocol.ExtXName, c.protocol.PkgName())
@@ -156,7 +156,7 @@ func (r *Request) WriteRequest(c *Context) {
 	c.Putln("")
 	if c.protocol.isExt() {
 		c.Putln("buf[b] = c.Extensions[\"%s\"]",
-			strings.ToUpper(c.protocol.ExtXName))
+			c.protocol.ExtXName)
 		c.Putln("b += 1")
 		c.Putln("")
 	}
@@ -183,9 +183,23 @@ func (r *Request) WriteRequest(c *Context) {
 	c.Putln("")
 }
 
+// paramFields returns the fields of the request, with the fields of the
+// bitcases of its switches in place of the switches.
+func (r *Request) paramFields() []Field {
+	fields := make([]Field, 0, len(r.Fields))
+	for _, field := range r.Fields {
+		if swtch, ok := field.(*SwitchField); ok && !swtch.IsCase {
+			fields = append(fields, swtch.Fields()...)
+		} else {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
 func (r *Request) ParamNames() string {
 	names := make([]string, 0, len(r.Fields))
-	for _, field := range r.Fields {
+	for _, field := range r.paramFields() {
 		switch f := field.(type) {
 		case *ValueField:
 			// mofos...
@@ -206,7 +220,7 @@ func (r *Request) ParamNames() string {
 
 func (r *Request) ParamNameTypes() string {
 	nameTypes := make([]string, 0, len(r.Fields))
-	for _, field := range r.Fields {
+	for _, field := range r.paramFields() {
 		switch f := field.(type) {
 		case *ValueField:
 			// mofos...
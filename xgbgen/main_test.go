@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/format"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false,
+	"write the golden files of the tests instead of checking them")
+
+// TestGolden generates Go source from each XML file in testdata, and
+// compares it to the golden file next to it (e.g., test.go.golden for
+// test.xml). Run 'go test -update' to write the golden files after changing
+// the generator, and check the differences before committing them.
+func TestGolden(t *testing.T) {
+	now = func() time.Time {
+		return time.Date(2012, 6, 5, 0, 12, 0, 0, time.UTC)
+	}
+	defer func() { now = time.Now }()
+
+	files, err := filepath.Glob(filepath.Join("testdata", "*.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("No XML files in testdata.")
+	}
+	for _, file := range files {
+		xmlBytes, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := newContext()
+		c.Morph(xmlBytes)
+		src, err := format.Source(c.out.Bytes())
+		if err != nil {
+			t.Fatalf("%s: the generated source is not valid Go: %s",
+				file, err)
+		}
+
+		golden := strings.TrimSuffix(file, ".xml") + ".go.golden"
+		if *update {
+			err := ioutil.WriteFile(golden, src, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(src, want) {
+			t.Errorf("%s: the generated source differs from %s.",
+				file, golden)
+		}
+	}
+}
@@ -122,6 +122,8 @@ func (r *Request) Size(c *Context) Size {
 // Reply encapsulates the fields associated with a 'reply' element.
 type Reply struct {
 	Fields []Field
+
+	request *Request // the request this is the reply to
 }
 
 // Size gets the number of bytes in this request's reply.
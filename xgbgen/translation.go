@@ -14,6 +14,7 @@ package main
 
 import (
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -39,9 +40,16 @@ func (xml *XML) Translate(parent *Protocol) *Protocol {
 		}
 	}
 
-	for xmlName, srcName := range BaseTypeMap {
+	// The base types are added in the same order every time, so that the
+	// same XML always gives the same Go source.
+	baseNames := make([]string, 0, len(BaseTypeMap))
+	for xmlName := range BaseTypeMap {
+		baseNames = append(baseNames, xmlName)
+	}
+	sort.Strings(baseNames)
+	for _, xmlName := range baseNames {
 		newBaseType := &Base{
-			srcName: srcName,
+			srcName: BaseTypeMap[xmlName],
 			xmlName: xmlName,
 			size:    newFixedSize(BaseTypeSizes[xmlName]),
 		}
@@ -206,6 +214,9 @@ func (x *XMLRequest) Translate() *Request {
 	for i, field := range x.Fields {
 		r.Fields[i] = field.Translate(r)
 	}
+	if r.Reply != nil {
+		r.Reply.request = r
+	}
 
 	// Address bug (or legacy code) in QueryTextExtents.
 	// The XML protocol description references 'string_len' in the
@@ -347,13 +358,19 @@ func (x *XMLField) Translate(parent interface{}) Field {
 			ListName: x.ValueListName,
 		}
 	case "switch":
+		if len(x.Bitcases) > 0 && len(x.Cases) > 0 {
+			log.Panicf("The switch '%s' has both bitcases and "+
+				"cases.", x.Name)
+		}
 		swtch := &SwitchField{
-			Name:     x.Name,
-			Expr:     x.Expr.Translate(),
-			Bitcases: make([]*Bitcase, len(x.Bitcases)),
+			Parent: parent,
+			Name:   x.Name,
+			Expr:   x.Expr.Translate(),
+			IsCase: len(x.Cases) > 0,
 		}
-		for i, bitcase := range x.Bitcases {
-			swtch.Bitcases[i] = bitcase.Translate()
+		for _, bitcase := range append(x.Bitcases, x.Cases...) {
+			swtch.Bitcases = append(swtch.Bitcases,
+				bitcase.Translate(parent))
 		}
 		return swtch
 	}
@@ -362,13 +379,16 @@ func (x *XMLField) Translate(parent interface{}) Field {
 	panic("unreachable")
 }
 
-func (x *XMLBitcase) Translate() *Bitcase {
+// Translate translates a bitcase (or case) of a switch in 'parent'. Its
+// fields are translated as fields of 'parent' too.
+func (x *XMLBitcase) Translate(parent interface{}) *Bitcase {
 	b := &Bitcase{
+		Name:   x.Name,
 		Expr:   x.Expr().Translate(),
 		Fields: make([]Field, len(x.Fields)),
 	}
 	for i, field := range x.Fields {
-		b.Fields[i] = field.Translate(b)
+		b.Fields[i] = field.Translate(parent)
 	}
 	return b
 }
@@ -334,18 +334,85 @@ func (s *Struct) Initialize(p *Protocol) {
 	}
 }
 
-// HasList returns whether there is a field in this struct that is a list.
+// HasList returns whether there is a field in this struct that is a list
+// (or a switch, whose size also depends on the values of fields).
 // When true, a more involved calculation is necessary to compute this struct's
 // size.
 func (s *Struct) HasList() bool {
 	for _, field := range s.Fields {
-		if _, ok := field.(*ListField); ok {
+		switch field.(type) {
+		case *ListField, *SwitchField:
 			return true
 		}
 	}
 	return false
 }
 
+// CaseSwitch is the interface type of a switch of cases. (See SwitchField.)
+// It has a struct type for each case, with the fields of that case.
+type CaseSwitch struct {
+	srcName string
+	xmlName string
+	Field   *SwitchField
+	Cases   []*Struct
+}
+
+// newCaseSwitch returns the type for the switch of cases 'f', which is named
+// 'name' (possibly with a package name in front of it).
+func newCaseSwitch(f *SwitchField, name string) *CaseSwitch {
+	if dot := strings.LastIndex(name, "."); dot > -1 {
+		name = name[dot+1:]
+	}
+	cs := &CaseSwitch{
+		xmlName: name,
+		Field:   f,
+		Cases:   make([]*Struct, len(f.Bitcases)),
+	}
+	for i, bitcase := range f.Bitcases {
+		caseName := bitcase.Name
+		if len(caseName) == 0 {
+			if enum, ok := bitcase.Expr.(*EnumRef); ok {
+				caseName = enum.EnumItem
+			} else {
+				caseName = fmt.Sprintf("Case%d", i)
+			}
+		}
+		cs.Cases[i] = &Struct{
+			xmlName: name + splitAndTitle(caseName),
+			Fields:  bitcase.Fields,
+		}
+	}
+	return cs
+}
+
+func (cs *CaseSwitch) SrcName() string {
+	return cs.srcName
+}
+
+func (cs *CaseSwitch) XmlName() string {
+	return cs.xmlName
+}
+
+// SizeName is the name of the function that computes the size of a value of
+// this type.
+func (cs *CaseSwitch) SizeName() string {
+	return cs.srcName + "Size"
+}
+
+func (cs *CaseSwitch) Size() Size {
+	return newExpressionSize(&Function{
+		Name: cs.SizeName(),
+		Expr: &FieldRef{Name: cs.Field.Name},
+	})
+}
+
+func (cs *CaseSwitch) Initialize(p *Protocol) {
+	cs.srcName = TypeSrcName(p, cs)
+	for _, s := range cs.Cases {
+		s.Initialize(p)
+	}
+}
+
 type Union struct {
 	srcName string
 	xmlName string
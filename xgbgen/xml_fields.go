@@ -27,6 +27,7 @@ type XMLField struct {
 
 	// For 'switch' element.
 	Bitcases []*XMLBitcase `xml:"bitcase"`
+	Cases    []*XMLBitcase `xml:"case"`
 
 	// I don't know which elements these are for. The documentation is vague.
 	// They also seem to be completely optional.
@@ -43,7 +44,12 @@ type XMLField struct {
 // siblings, we must exhaustively search for one of them. Essentially,
 // it's the closest thing to a Union I can get to in Go without interfaces.
 // Would an '<expression>' tag have been too much to ask? :-(
+//
+// The same type is used for the 'case' elements of a switch, which are like
+// bitcases, except that the fields are included if the switch's expression is
+// equal to the case's expression.
 type XMLBitcase struct {
+	Name   string      `xml:"name,attr"`
 	Fields []*XMLField `xml:",any"`
 
 	// All the different expressions.
@@ -0,0 +1,151 @@
+package xgbtest
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// keymapNotify is the code of KeymapNotify, the only event without
+// a sequence number.
+const keymapNotify = 11
+
+// RecordedFrame is a request or a response (a reply, an event or an error)
+// of a recorded X session. See Recorder and ReplaySession.
+type RecordedFrame struct {
+	// FromServer is whether the frame is a response rather than
+	// a request.
+	FromServer bool
+
+	// Sequence is the sequence number of the request, or the one in the
+	// response (for events, the one of the last request the X server
+	// processed).
+	Sequence uint16
+
+	// Data is the whole request or response.
+	Data []byte
+}
+
+// Recorder is an xgb.Tracer that records the X session on a connection,
+// for ReplaySession:
+//
+//	rec := new(xgbtest.Recorder)
+//	X.SetTracer(rec)
+//	... // the code under test, against a real X server
+//	X.SetTracer(nil)
+//	recording := rec.Frames()
+//
+// The recording can then be kept with the tests (e.g., in a Go file or in
+// testdata, encoded with encoding/gob).
+type Recorder struct {
+	lock   sync.Mutex
+	frames []RecordedFrame
+}
+
+// Frames returns the frames recorded so far, in the order they were
+// written or read.
+func (r *Recorder) Frames() []RecordedFrame {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]RecordedFrame(nil), r.frames...)
+}
+
+func (r *Recorder) record(fromServer bool, seq uint16, payload []byte) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.frames = append(r.frames, RecordedFrame{
+		FromServer: fromServer,
+		Sequence:   seq,
+		Data:       append([]byte(nil), payload...),
+	})
+}
+
+func (r *Recorder) TraceRequest(seq uint16, opcode byte, payload []byte) {
+	r.record(false, seq, payload)
+}
+
+func (r *Recorder) TraceReply(seq uint16, payload []byte) {
+	r.record(true, seq, payload)
+}
+
+func (r *Recorder) TraceEvent(payload []byte) {
+	seq := uint16(0)
+	if payload[0]&0x7f != keymapNotify {
+		seq = xgb.Get16(payload[2:])
+	}
+	r.record(true, seq, payload)
+}
+
+func (r *Recorder) TraceError(seq uint16, payload []byte) {
+	r.record(true, seq, payload)
+}
+
+// ReplaySession runs 'client' against a mock X server (see NewMockConn)
+// that expects the requests of 'recording', in order and byte for byte,
+// and answers each of them with the responses that followed it. It
+// returns an error if the client didn't make the requests recorded (like
+// MockServer.Verify), or the error of 'client'.
+//
+// 'setup' should give the resource id base and mask of the recorded
+// session, so that the client allocates the same ids. Responses recorded
+// before the first request (e.g., events) are sent as soon as the client
+// is connected.
+func ReplaySession(setup xproto.SetupInfo, recording []RecordedFrame,
+	client func(X *xgb.Conn) error) error {
+
+	X, server := NewMockConn(setup)
+	defer X.Close()
+
+	// The responses go to the last request with their sequence number,
+	// and the events without one to the last request before them.
+	replies := make(map[uint16]*Reply)
+	var last *Reply
+	for i, frame := range recording {
+		data := frame.Data
+		if !frame.FromServer {
+			last = server.Expect(Request{Opcode: data[0],
+				Data: data[1], Body: requestBody(data)})
+			replies[frame.Sequence] = last
+			continue
+		}
+
+		r, ok := replies[frame.Sequence]
+		if data[0]&0x7f == keymapNotify {
+			r, ok = last, last != nil
+		}
+		if !ok {
+			if last != nil {
+				return fmt.Errorf("xgbtest: frame %d "+
+					"answers no request recorded", i)
+			}
+			if err := server.write(data); err != nil {
+				return err
+			}
+			continue
+		}
+		server.lock.Lock()
+		r.recorded = append(r.recorded, append([]byte(nil), data...))
+		server.lock.Unlock()
+	}
+
+	// A request that wasn't recorded explains why the client failed, if
+	// it did.
+	err := client(X)
+	server.lock.Lock()
+	unexpected := server.err
+	server.lock.Unlock()
+	if unexpected != nil {
+		return unexpected
+	}
+	if err != nil {
+		return err
+	}
+	// Requests without a reply may not have been sent yet.
+	X.Sync()
+	return server.Verify()
+}
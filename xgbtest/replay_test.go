@@ -0,0 +1,60 @@
+package xgbtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestReplaySession records a session on a virtual display, and replays
+// it with the same client, and with one that makes other requests.
+func TestReplaySession(t *testing.T) {
+	client := func(name string) func(*xgb.Conn) error {
+		return func(X *xgb.Conn) error {
+			reply, err := xproto.InternAtom(X, false,
+				uint16(len(name)), name).Reply()
+			if err != nil {
+				return err
+			}
+			win, err := xproto.NewWindowId(X)
+			if err != nil {
+				return err
+			}
+			xproto.MapWindow(X, win)
+			data := xproto.ClientMessageDataUnionData32New(
+				make([]uint32, 5))
+			ev := xproto.ClientMessageEvent{Format: 32, Window: win,
+				Type: reply.Atom, Data: data}
+			err = xproto.SendEventChecked(X, false, win, 0,
+				string(ev.Bytes())).Check()
+			if err != nil {
+				return err
+			}
+			if _, err := X.WaitForEvent(); err != nil {
+				return err
+			}
+			return nil
+		}
+	}
+
+	X, _ := NewVirtualDisplay()
+	rec := new(Recorder)
+	X.SetTracer(rec)
+	if err := client("TEST")(X); err != nil {
+		t.Fatalf("Recording: %s", err)
+	}
+	X.SetTracer(nil)
+	X.Close()
+	recording := rec.Frames()
+
+	err := ReplaySession(VirtualSetup, recording, client("TEST"))
+	if err != nil {
+		t.Fatalf("ReplaySession: %s", err)
+	}
+	err = ReplaySession(VirtualSetup, recording, client("OTHER"))
+	if err == nil || !strings.Contains(err.Error(), "unexpected") {
+		t.Fatalf("Expected an unexpected request, but got %v.", err)
+	}
+}
@@ -0,0 +1,156 @@
+package xgbtest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestSetupInfoRead hand-crafts the bytes of a setup response with a single
+// screen, depth and visual, and checks that xproto.SetupInfoRead decodes every
+// field. It lives here rather than with the tests of xproto, which need
+// a running X server, since it doesn't talk to X at all.
+func TestSetupInfoRead(t *testing.T) {
+	vendor := "XGB!"
+	buf := make([]byte, 40+len(vendor)+8+40+8+24)
+
+	// The setup response itself.
+	buf[0] = 1 // success
+	xgb.Put16(buf[2:], 11)
+	xgb.Put16(buf[4:], 0)
+	xgb.Put16(buf[6:], uint16((len(buf)-8)/4))
+	xgb.Put32(buf[8:], 11300000)   // release number
+	xgb.Put32(buf[12:], 0x4000000) // resource id base
+	xgb.Put32(buf[16:], 0x1fffff)  // resource id mask
+	xgb.Put32(buf[20:], 256)       // motion buffer size
+	xgb.Put16(buf[24:], uint16(len(vendor)))
+	xgb.Put16(buf[26:], 65535) // maximum request length
+	buf[28] = 1                // number of roots
+	buf[29] = 1                // number of pixmap formats
+	buf[30] = xproto.ImageOrderLSBFirst
+	buf[31] = xproto.ImageOrderMSBFirst
+	buf[32] = 32 // bitmap scanline unit
+	buf[33] = 32 // bitmap scanline pad
+	buf[34] = 8  // min keycode
+	buf[35] = 255
+	copy(buf[40:], vendor)
+	b := 40 + len(vendor)
+
+	// A single pixmap format.
+	buf[b] = 24
+	buf[b+1] = 32
+	buf[b+2] = 32
+	b += 8
+
+	// A single screen.
+	xgb.Put32(buf[b:], 0x100)      // root
+	xgb.Put32(buf[b+4:], 0x20)     // default colormap
+	xgb.Put32(buf[b+8:], 0xffffff) // white pixel
+	xgb.Put32(buf[b+12:], 0)       // black pixel
+	xgb.Put32(buf[b+16:], xproto.EventMaskPropertyChange)
+	xgb.Put16(buf[b+20:], 1920)
+	xgb.Put16(buf[b+22:], 1080)
+	xgb.Put16(buf[b+24:], 508)
+	xgb.Put16(buf[b+26:], 286)
+	xgb.Put16(buf[b+28:], 1)  // min installed maps
+	xgb.Put16(buf[b+30:], 1)  // max installed maps
+	xgb.Put32(buf[b+32:], 33) // root visual
+	buf[b+36] = xproto.BackingStoreWhenMapped
+	buf[b+37] = 1 // save unders
+	buf[b+38] = 24
+	buf[b+39] = 1 // number of allowed depths
+	b += 40
+
+	// A single depth with a single visual.
+	buf[b] = 24
+	xgb.Put16(buf[b+2:], 1)
+	b += 8
+	xgb.Put32(buf[b:], 33)
+	buf[b+4] = xproto.VisualClassTrueColor
+	buf[b+5] = 8
+	xgb.Put16(buf[b+6:], 256)
+	xgb.Put32(buf[b+8:], 0xff0000)
+	xgb.Put32(buf[b+12:], 0xff00)
+	xgb.Put32(buf[b+16:], 0xff)
+
+	setup := new(xproto.SetupInfo)
+	if n := xproto.SetupInfoRead(buf, setup); n != len(buf) {
+		t.Fatalf("SetupInfoRead read %d bytes, but there are %d.",
+			n, len(buf))
+	}
+
+	// Zero out the slices so that the rest of the fields can be compared
+	// in one go. The slices are checked separately below.
+	roots, formats := setup.Roots, setup.PixmapFormats
+	setup.Roots, setup.PixmapFormats = nil, nil
+	wantSetup := xproto.SetupInfo{
+		Status:                   1,
+		ProtocolMajorVersion:     11,
+		Length:                   uint16((len(buf) - 8) / 4),
+		ReleaseNumber:            11300000,
+		ResourceIdBase:           0x4000000,
+		ResourceIdMask:           0x1fffff,
+		MotionBufferSize:         256,
+		VendorLen:                uint16(len(vendor)),
+		MaximumRequestLength:     65535,
+		RootsLen:                 1,
+		PixmapFormatsLen:         1,
+		ImageByteOrder:           xproto.ImageOrderLSBFirst,
+		BitmapFormatBitOrder:     xproto.ImageOrderMSBFirst,
+		BitmapFormatScanlineUnit: 32,
+		BitmapFormatScanlinePad:  32,
+		MinKeycode:               8,
+		MaxKeycode:               255,
+		Vendor:                   vendor,
+	}
+	if !reflect.DeepEqual(*setup, wantSetup) {
+		t.Fatalf("SetupInfo was decoded as\n%+v\nbut should be\n%+v",
+			*setup, wantSetup)
+	}
+
+	wantFormats := []xproto.Format{
+		{Depth: 24, BitsPerPixel: 32, ScanlinePad: 32},
+	}
+	if !reflect.DeepEqual(formats, wantFormats) {
+		t.Fatalf("PixmapFormats were decoded as %+v but should be %+v",
+			formats, wantFormats)
+	}
+
+	wantRoots := []xproto.ScreenInfo{{
+		Root:                0x100,
+		DefaultColormap:     0x20,
+		WhitePixel:          0xffffff,
+		BlackPixel:          0,
+		CurrentInputMasks:   xproto.EventMaskPropertyChange,
+		WidthInPixels:       1920,
+		HeightInPixels:      1080,
+		WidthInMillimeters:  508,
+		HeightInMillimeters: 286,
+		MinInstalledMaps:    1,
+		MaxInstalledMaps:    1,
+		RootVisual:          33,
+		BackingStores:       xproto.BackingStoreWhenMapped,
+		SaveUnders:          true,
+		RootDepth:           24,
+		AllowedDepthsLen:    1,
+		AllowedDepths: []xproto.DepthInfo{{
+			Depth:      24,
+			VisualsLen: 1,
+			Visuals: []xproto.VisualInfo{{
+				VisualId:        33,
+				Class:           xproto.VisualClassTrueColor,
+				BitsPerRgbValue: 8,
+				ColormapEntries: 256,
+				RedMask:         0xff0000,
+				GreenMask:       0xff00,
+				BlueMask:        0xff,
+			}},
+		}},
+	}}
+	if !reflect.DeepEqual(roots, wantRoots) {
+		t.Fatalf("Roots were decoded as\n%+v\nbut should be\n%+v",
+			roots, wantRoots)
+	}
+}
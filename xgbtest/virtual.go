@@ -0,0 +1,225 @@
+package xgbtest
+
+import (
+	"bufio"
+	"net"
+	"sync"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// VirtualSetup is the setup information of the virtual displays made by
+// NewVirtualDisplay: a single 1024x768 screen of depth 24, with a TrueColor
+// visual.
+var VirtualSetup = xproto.SetupInfo{
+	ProtocolMajorVersion:     11,
+	ResourceIdBase:           0x200000,
+	ImageByteOrder:           xproto.ImageOrderLSBFirst,
+	BitmapFormatBitOrder:     xproto.ImageOrderLSBFirst,
+	BitmapFormatScanlineUnit: 32,
+	BitmapFormatScanlinePad:  32,
+	MinKeycode:               8,
+	MaxKeycode:               255,
+	Vendor:                   "xgbtest",
+	PixmapFormats: []xproto.Format{
+		{Depth: 1, BitsPerPixel: 1, ScanlinePad: 32},
+		{Depth: 24, BitsPerPixel: 32, ScanlinePad: 32},
+	},
+	Roots: []xproto.ScreenInfo{{
+		Root:                0x100,
+		DefaultColormap:     0x20,
+		WhitePixel:          0xffffff,
+		BlackPixel:          0,
+		WidthInPixels:       1024,
+		HeightInPixels:      768,
+		WidthInMillimeters:  270,
+		HeightInMillimeters: 203,
+		MinInstalledMaps:    1,
+		MaxInstalledMaps:    1,
+		RootVisual:          0x21,
+		RootDepth:           24,
+		AllowedDepths: []xproto.DepthInfo{{
+			Depth: 24,
+			Visuals: []xproto.VisualInfo{{
+				VisualId:        0x21,
+				Class:           xproto.VisualClassTrueColor,
+				BitsPerRgbValue: 8,
+				ColormapEntries: 256,
+				RedMask:         0xff0000,
+				GreenMask:       0xff00,
+				BlueMask:        0xff,
+			}},
+		}},
+	}},
+}
+
+// The opcodes of the requests VirtualDisplay answers by itself.
+const (
+	internAtomOpcode     = 16
+	getAtomNameOpcode    = 17
+	sendEventOpcode      = 25
+	queryExtensionOpcode = 98
+	listExtensionsOpcode = 99
+)
+
+// repliedOpcodes are the opcodes of the requests of the core protocol that
+// have a reply.
+var repliedOpcodes = map[byte]bool{
+	3: true, 14: true, 15: true, 16: true, 17: true, 20: true, 21: true,
+	23: true, 26: true, 31: true, 38: true, 39: true, 40: true, 43: true,
+	44: true, 47: true, 48: true, 49: true, 50: true, 52: true, 73: true,
+	83: true, 84: true, 85: true, 86: true, 87: true, 91: true, 92: true,
+	97: true, 98: true, 99: true, 101: true, 103: true, 106: true,
+	108: true, 110: true, 116: true, 117: true, 118: true, 119: true,
+}
+
+// VirtualDisplay is a minimal X server, for integration tests that need
+// a display but don't check the requests made, unlike those using
+// MockServer. It answers the requests it gets by itself:
+//
+//   - Requests without a reply succeed, but are otherwise ignored: no
+//     window is created, and nothing is drawn.
+//   - InternAtom and GetAtomName work, with atoms given out from 69, after
+//     the predefined ones. (The names of the predefined atoms aren't known,
+//     so interning "PRIMARY" gives a new atom.)
+//   - No extension is present, so their Init functions fail.
+//   - SendEvent sends the event back to the client, whatever the
+//     destination and event mask, marked as sent with SendEvent.
+//   - Other requests with a reply get an Implementation error.
+//
+// Events can also be sent to the client with SendEvent.
+type VirtualDisplay struct {
+	conn net.Conn
+
+	// writeLock serializes writes to 'conn'.
+	writeLock sync.Mutex
+
+	// lock protects 'seq', the sequence number of the last request, and
+	// the atoms interned so far, by name and in order.
+	lock      sync.Mutex
+	seq       uint16
+	atoms     map[string]uint32
+	atomNames []string
+}
+
+// firstAtom is the first atom VirtualDisplay gives out. The atoms below are
+// predefined.
+const firstAtom = 69
+
+// NewVirtualDisplay returns a connection to a new virtual display, whose
+// setup information is VirtualSetup, and that display.
+func NewVirtualDisplay() (*xgb.Conn, *VirtualDisplay) {
+	client, serverConn := net.Pipe()
+	d := &VirtualDisplay{conn: serverConn, atoms: make(map[string]uint32)}
+
+	setup := VirtualSetup
+	fillSetup(&setup)
+	go d.serve(setup.Bytes())
+
+	X, err := xgb.NewConnNet(client)
+	if err != nil {
+		panic("xgbtest: the setup handshake failed: " + err.Error())
+	}
+	return X, d
+}
+
+// SendEvent sends the event 'ev' to the client, with the sequence number of
+// the last request.
+func (d *VirtualDisplay) SendEvent(ev xgb.Event) error {
+	buf := ev.Bytes()
+	d.lock.Lock()
+	xgb.Put16(buf[2:], d.seq)
+	d.lock.Unlock()
+	return d.write(buf)
+}
+
+// Close closes the server end of the connection, as if the X server went
+// away.
+func (d *VirtualDisplay) Close() error {
+	return d.conn.Close()
+}
+
+// serve does the setup handshake with 'setup' as the reply, and then answers
+// requests until the connection is closed. It is meant to be run in its own
+// goroutine.
+func (d *VirtualDisplay) serve(setup []byte) {
+	defer d.conn.Close()
+	r := bufio.NewReader(d.conn)
+	if err := readSetupRequest(r); err != nil {
+		return
+	}
+	if err := d.write(setup); err != nil {
+		return
+	}
+
+	for {
+		buf, err := readRequest(r)
+		if err != nil {
+			return
+		}
+		if err := d.answer(buf); err != nil {
+			return
+		}
+	}
+}
+
+// answer sends the answer to the request 'buf', if it has one.
+func (d *VirtualDisplay) answer(buf []byte) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.seq++
+	var resp []byte
+	switch body := requestBody(buf); buf[0] {
+	case internAtomOpcode:
+		name := string(body[4 : 4+int(xgb.Get16(body))])
+		atom, ok := d.atoms[name]
+		if !ok && buf[1] == 0 { // the atom has to be created
+			atom = uint32(firstAtom + len(d.atomNames))
+			d.atoms[name] = atom
+			d.atomNames = append(d.atomNames, name)
+		}
+		reply := make([]byte, 32)
+		xgb.Put32(reply[8:], atom)
+		resp = replyBytes(reply)
+	case getAtomNameOpcode:
+		i := int(xgb.Get32(body)) - firstAtom
+		if i < 0 || i >= len(d.atomNames) {
+			resp = errorBytes(xproto.BadAtom, xgb.Get32(body), buf)
+			break
+		}
+		name := d.atomNames[i]
+		reply := make([]byte, 32+len(name))
+		xgb.Put16(reply[8:], uint16(len(name)))
+		copy(reply[32:], name)
+		resp = replyBytes(reply)
+	case sendEventOpcode:
+		// The event comes after the destination and the event mask.
+		resp = append([]byte(nil), body[8:40]...)
+		resp[0] |= 0x80
+	case queryExtensionOpcode, listExtensionsOpcode:
+		resp = replyBytes(nil) // not present, and none at all
+	case getInputFocusOpcode:
+		resp = replyBytes(nil)
+	default:
+		if repliedOpcodes[buf[0]] {
+			resp = errorBytes(xproto.BadImplementation, 0, buf)
+		}
+	}
+	if resp == nil {
+		return nil
+	}
+	xgb.Put16(resp[2:], d.seq)
+	return d.write(resp)
+}
+
+// write writes 'buf' to the client.
+func (d *VirtualDisplay) write(buf []byte) error {
+	d.writeLock.Lock()
+	defer d.writeLock.Unlock()
+
+	_, err := d.conn.Write(buf)
+	return err
+}
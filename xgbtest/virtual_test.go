@@ -0,0 +1,76 @@
+package xgbtest
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestVirtualDisplay interns atoms on a virtual display, and has it send
+// an event back.
+func TestVirtualDisplay(t *testing.T) {
+	X, _ := NewVirtualDisplay()
+	defer X.Close()
+
+	screen := xproto.Setup(X).DefaultScreen(X)
+	if screen.WidthInPixels != 1024 || screen.RootDepth != 24 {
+		t.Fatalf("Expected the screen of VirtualSetup, but got %+v.",
+			screen)
+	}
+
+	intern := func(name string) xproto.Atom {
+		reply, err := xproto.InternAtom(X, false, uint16(len(name)),
+			name).Reply()
+		if err != nil {
+			t.Fatalf("InternAtom: %s", err)
+		}
+		return reply.Atom
+	}
+	atom := intern("TEST")
+	if again := intern("TEST"); again != atom {
+		t.Fatalf("Expected the atom %d again, but got %d.", atom, again)
+	}
+	reply, err := xproto.GetAtomName(X, atom).Reply()
+	if err != nil || reply.Name != "TEST" {
+		t.Fatalf("Expected the name TEST, but got %+v (%v).",
+			reply, err)
+	}
+
+	// Requests without a reply succeed.
+	win, err := xproto.NewWindowId(X)
+	if err != nil {
+		t.Fatalf("NewWindowId: %s", err)
+	}
+	err = xproto.CreateWindowChecked(X, 0, win, screen.Root, 0, 0, 100,
+		100, 0, xproto.WindowClassInputOutput, 0, 0, nil).Check()
+	if err != nil {
+		t.Fatalf("CreateWindow: %s", err)
+	}
+
+	ev := xproto.ClientMessageEvent{
+		Format: 32,
+		Window: win,
+		Type:   atom,
+		Data: xproto.ClientMessageDataUnionData32New(
+			[]uint32{1, 2, 3, 4, 5}),
+	}
+	err = xproto.SendEventChecked(X, false, win, xproto.EventMaskNoEvent,
+		string(ev.Bytes())).Check()
+	if err != nil {
+		t.Fatalf("SendEvent: %s", err)
+	}
+	got, xerr := X.WaitForEvent()
+	msg, ok := got.(xproto.ClientMessageEvent)
+	if xerr != nil || !ok || msg.Window != win || msg.Type != atom ||
+		msg.Data.Data32[4] != 5 {
+
+		t.Fatalf("Expected the client message sent, but got %v (%v).",
+			got, xerr)
+	}
+
+	// Requests with a reply the display doesn't know fail.
+	_, err = xproto.QueryPointer(X, screen.Root).Reply()
+	if _, ok := err.(xproto.ImplementationError); !ok {
+		t.Fatalf("Expected an ImplementationError, but got %v.", err)
+	}
+}
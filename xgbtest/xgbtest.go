@@ -0,0 +1,424 @@
+// Package xgbtest provides a mock X server for testing X clients without
+// a display.
+//
+// NewMockConn connects an xgb.Conn to a MockServer over an in-memory pipe.
+// Tests tell the server which requests to expect, in order, and how to answer
+// each of them, then run the code under test and call Verify:
+//
+//	X, server := xgbtest.NewMockConn(setup)
+//	defer X.Close()
+//
+//	server.Expect(xgbtest.Request{Opcode: 16}).WithData(reply)
+//	atom, err := xproto.InternAtom(X, false, 4, "TEST").Reply()
+//	...
+//	if err := server.Verify(); err != nil {
+//		t.Fatal(err)
+//	}
+//
+// A request that doesn't match the next expectation makes the server close
+// the connection, so that the code under test doesn't wait forever for
+// a reply, and Verify reports it.
+//
+// Tests that just need a display to run against, without checking the
+// requests made, can use a VirtualDisplay instead, which answers them by
+// itself:
+//
+//	X, display := xgbtest.NewVirtualDisplay()
+//	defer X.Close()
+package xgbtest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// getInputFocusOpcode is the opcode of GetInputFocus, which xgb sends to
+// check requests without a reply (and in xgb.Conn.Sync). The mock server
+// answers it by itself, unless it is expected.
+const getInputFocusOpcode = 43
+
+// Request describes a request the mock server expects.
+type Request struct {
+	// Opcode is the major opcode of the request (e.g., 16 for InternAtom).
+	Opcode byte
+
+	// Data is the second byte of the request, which is the minor opcode of
+	// an extension request, and request specific in the core protocol.
+	Data byte
+
+	// Body, if not nil, is what the request must have after its header
+	// (i.e., after its first 4 bytes, or 8 for a big request).
+	Body []byte
+}
+
+// match returns whether 'buf', the whole request, matches 'req'.
+func (req Request) match(buf []byte) bool {
+	if buf[0] != req.Opcode || buf[1] != req.Data {
+		return false
+	}
+	return req.Body == nil || bytes.Equal(requestBody(buf), req.Body)
+}
+
+// requestBody returns what comes after the header of the request 'buf'.
+func requestBody(buf []byte) []byte {
+	if xgb.Get16(buf[2:]) == 0 { // a big request
+		return buf[8:]
+	}
+	return buf[4:]
+}
+
+// Reply is how the mock server answers an expected request. Without
+// WithData or WithError, it sends nothing back, as for requests without
+// a reply.
+type Reply struct {
+	server *MockServer
+	req    Request
+
+	// These are protected by server.lock.
+	data     []byte
+	more     [][]byte // the replies after data, see WithReplies
+	recorded [][]byte // the responses of ReplaySession, sent as they are
+	errCode  byte
+	badValue uint32
+	received []byte
+}
+
+// WithData makes the mock server answer with the reply 'data'. Its first
+// byte, sequence number and length (bytes 0 to 7, except for byte 1) are
+// filled in by the server. It is padded to 32 bytes, and to a multiple of 4.
+func (r *Reply) WithData(data []byte) *Reply {
+	r.server.lock.Lock()
+	defer r.server.lock.Unlock()
+
+	r.data = append([]byte(nil), data...)
+	return r
+}
+
+// WithReplies is like WithData, but makes the mock server answer with
+// several replies, for requests like ListFontsWithInfo that have one per
+// result.
+func (r *Reply) WithReplies(replies ...[]byte) *Reply {
+	r.WithData(replies[0])
+
+	r.server.lock.Lock()
+	defer r.server.lock.Unlock()
+	for _, data := range replies[1:] {
+		r.more = append(r.more, append([]byte(nil), data...))
+	}
+	return r
+}
+
+// WithError makes the mock server answer with the X error 'code' (e.g.,
+// xproto.BadWindow), about the resource or value 'badValue'.
+func (r *Reply) WithError(code byte, badValue uint32) *Reply {
+	r.server.lock.Lock()
+	defer r.server.lock.Unlock()
+
+	r.errCode = code
+	r.badValue = badValue
+	return r
+}
+
+// Received returns the whole request the server got for this expectation,
+// or nil if it hasn't arrived yet.
+func (r *Reply) Received() []byte {
+	r.server.lock.Lock()
+	defer r.server.lock.Unlock()
+
+	return r.received
+}
+
+// MockServer is the mock X server made by NewMockConn.
+type MockServer struct {
+	conn net.Conn
+
+	// writeLock serializes writes to 'conn'.
+	writeLock sync.Mutex
+
+	// lock protects the fields below, and those of the Replies.
+	// 'expected' are the expectations that haven't been met yet, 'seq' is
+	// the sequence number of the last request, and 'err' is the first
+	// unexpected thing that happened.
+	lock     sync.Mutex
+	expected []*Reply
+	seq      uint16
+	err      error
+}
+
+// NewMockConn returns a connection to a new mock X server, and that server.
+// The setup information is 'setup', with these fields filled in if they are
+// zero: Status, ProtocolMajorVersion, Length, ResourceIdMask (to 0x1fffff),
+// MaximumRequestLength (to 0xffff), and the lengths of the lists and strings
+// in it (like VendorLen and RootsLen).
+//
+// It panics if the setup handshake fails, which only happens if 'setup' is
+// too big for the setup reply.
+func NewMockConn(setup xproto.SetupInfo) (*xgb.Conn, *MockServer) {
+	client, serverConn := net.Pipe()
+	server := &MockServer{conn: serverConn}
+
+	fillSetup(&setup)
+	go server.serve(setup.Bytes())
+
+	X, err := xgb.NewConnNet(client)
+	if err != nil {
+		panic("xgbtest: the setup handshake failed: " + err.Error())
+	}
+	return X, server
+}
+
+// fillSetup fills in the fields of 'setup' that NewMockConn says it does.
+func fillSetup(setup *xproto.SetupInfo) {
+	if setup.Status == 0 {
+		setup.Status = 1
+	}
+	if setup.ProtocolMajorVersion == 0 {
+		setup.ProtocolMajorVersion = 11
+	}
+	if setup.ResourceIdMask == 0 {
+		setup.ResourceIdMask = 0x1fffff
+	}
+	if setup.MaximumRequestLength == 0 {
+		setup.MaximumRequestLength = 0xffff
+	}
+	if setup.VendorLen == 0 {
+		setup.VendorLen = uint16(len(setup.Vendor))
+	}
+	if setup.PixmapFormatsLen == 0 {
+		setup.PixmapFormatsLen = byte(len(setup.PixmapFormats))
+	}
+	if setup.RootsLen == 0 {
+		setup.RootsLen = byte(len(setup.Roots))
+	}
+	roots := make([]xproto.ScreenInfo, len(setup.Roots))
+	for i, root := range setup.Roots {
+		if root.AllowedDepthsLen == 0 {
+			root.AllowedDepthsLen = byte(len(root.AllowedDepths))
+		}
+		depths := make([]xproto.DepthInfo, len(root.AllowedDepths))
+		for j, depth := range root.AllowedDepths {
+			if depth.VisualsLen == 0 {
+				depth.VisualsLen = uint16(len(depth.Visuals))
+			}
+			depths[j] = depth
+		}
+		root.AllowedDepths = depths
+		roots[i] = root
+	}
+	setup.Roots = roots
+	if setup.Length == 0 {
+		setup.Length = uint16((len(setup.Bytes()) - 8) / 4)
+	}
+}
+
+// Expect says that the next request, after those expected already, must
+// match 'req'. The Reply returned says how to answer it.
+func (s *MockServer) Expect(req Request) *Reply {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	r := &Reply{server: s, req: req}
+	s.expected = append(s.expected, r)
+	return r
+}
+
+// SendEvent sends the event 'ev' to the client, with the sequence number of
+// the last request.
+func (s *MockServer) SendEvent(ev xgb.Event) error {
+	buf := ev.Bytes()
+	s.lock.Lock()
+	xgb.Put16(buf[2:], s.seq)
+	s.lock.Unlock()
+	return s.write(buf)
+}
+
+// Verify returns an error if the server got a request it didn't expect, or
+// if some of the expected requests haven't arrived. Since requests without
+// a reply are sent asynchronously, make sure they have been sent (e.g., with
+// xgb.Conn.Sync) before calling it.
+func (s *MockServer) Verify() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	if len(s.expected) > 0 {
+		return fmt.Errorf("xgbtest: %d expected requests did not "+
+			"arrive, the first one with opcode %d",
+			len(s.expected), s.expected[0].req.Opcode)
+	}
+	return nil
+}
+
+// Close closes the server end of the connection, as if the X server went
+// away.
+func (s *MockServer) Close() error {
+	return s.conn.Close()
+}
+
+// serve does the setup handshake with 'setup' as the reply, and then answers
+// requests until the connection is closed. It is meant to be run in its own
+// goroutine.
+func (s *MockServer) serve(setup []byte) {
+	defer s.conn.Close()
+	r := bufio.NewReader(s.conn)
+	if err := readSetupRequest(r); err != nil {
+		return
+	}
+	if err := s.write(setup); err != nil {
+		return
+	}
+
+	for {
+		buf, err := readRequest(r)
+		if err != nil {
+			return
+		}
+		if err := s.answer(buf); err != nil {
+			s.lock.Lock()
+			if s.err == nil {
+				s.err = err
+			}
+			s.lock.Unlock()
+			return
+		}
+	}
+}
+
+// readSetupRequest reads the setup request, which has 12 bytes, followed by
+// the authorization protocol name and data.
+func readSetupRequest(r io.Reader) error {
+	head := make([]byte, 12)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return err
+	}
+	authLen := xgb.Pad(int(xgb.Get16(head[6:]))) +
+		xgb.Pad(int(xgb.Get16(head[8:])))
+	_, err := io.ReadFull(r, make([]byte, authLen))
+	return err
+}
+
+// readRequest reads a whole request, big or not.
+func readRequest(r io.Reader) ([]byte, error) {
+	head := make([]byte, 4)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return nil, err
+	}
+	length := uint32(xgb.Get16(head[2:]))
+	if length == 0 { // a big request
+		ext := make([]byte, 4)
+		if _, err := io.ReadFull(r, ext); err != nil {
+			return nil, err
+		}
+		head = append(head, ext...)
+		length = xgb.Get32(ext)
+	}
+	buf := make([]byte, int(length)*4)
+	copy(buf, head)
+	if _, err := io.ReadFull(r, buf[len(head):]); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// answer checks the request 'buf' against the next expectation, and sends
+// the answer to it. It returns an error if the request wasn't expected, or
+// answering failed.
+func (s *MockServer) answer(buf []byte) error {
+	s.lock.Lock()
+	s.seq++
+	seq := s.seq
+	var r *Reply
+	if len(s.expected) > 0 && s.expected[0].req.match(buf) {
+		r = s.expected[0]
+		s.expected = s.expected[1:]
+		r.received = buf
+	}
+	s.lock.Unlock()
+
+	var resps [][]byte
+	switch {
+	case r == nil && buf[0] == getInputFocusOpcode:
+		resp := make([]byte, 32)
+		resp[0] = 1
+		resps = [][]byte{resp}
+	case r == nil:
+		return fmt.Errorf("xgbtest: unexpected request %d with opcode "+
+			"%d and data %d", seq, buf[0], buf[1])
+	default:
+		s.lock.Lock()
+		resps = r.responses(buf)
+		s.lock.Unlock()
+	}
+	for _, resp := range resps {
+		if resp[0]&0x7f != keymapNotify {
+			xgb.Put16(resp[2:], seq)
+		}
+		if err := s.write(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// responses returns the replies or the error the Reply says to send to the
+// request 'buf', if any.
+func (r *Reply) responses(buf []byte) [][]byte {
+	if r.errCode != 0 {
+		return [][]byte{errorBytes(r.errCode, r.badValue, buf)}
+	}
+	if r.recorded != nil {
+		return r.recorded
+	}
+	if r.data == nil {
+		return nil
+	}
+	resps := [][]byte{replyBytes(r.data)}
+	for _, data := range r.more {
+		resps = append(resps, replyBytes(data))
+	}
+	return resps
+}
+
+// replyBytes returns the reply 'data', padded, with its first byte and
+// length filled in.
+func replyBytes(data []byte) []byte {
+	size := xgb.Pad(len(data))
+	if size < 32 {
+		size = 32
+	}
+	resp := make([]byte, size)
+	copy(resp, data)
+	resp[0] = 1
+	xgb.Put32(resp[4:], uint32((size-32)/4))
+	return resp
+}
+
+// errorBytes returns the X error 'code' about 'badValue', caused by the
+// request 'buf'.
+func errorBytes(code byte, badValue uint32, buf []byte) []byte {
+	resp := make([]byte, 32)
+	resp[1] = code
+	xgb.Put32(resp[4:], badValue)
+	xgb.Put16(resp[8:], uint16(buf[1])) // minor opcode
+	resp[10] = buf[0]                   // major opcode
+	return resp
+}
+
+// write writes 'buf' to the client.
+func (s *MockServer) write(buf []byte) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	_, err := s.conn.Write(buf)
+	return err
+}
@@ -0,0 +1,118 @@
+package xgbtest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// testSetup is the setup information of the mock servers in the tests.
+var testSetup = xproto.SetupInfo{
+	ResourceIdBase: 0x400000,
+	Vendor:         "xgbtest",
+	Roots: []xproto.ScreenInfo{{
+		Root:           0x100,
+		WidthInPixels:  640,
+		HeightInPixels: 480,
+		RootDepth:      24,
+	}},
+}
+
+// TestSetup makes sure the client gets the setup information given to
+// NewMockConn.
+func TestSetup(t *testing.T) {
+	X, _ := NewMockConn(testSetup)
+	defer X.Close()
+
+	setup := xproto.Setup(X)
+	if setup.Vendor != "xgbtest" || len(setup.Roots) != 1 {
+		t.Fatalf("Expected the setup information given, but got %+v.",
+			setup)
+	}
+	if root := setup.DefaultScreen(X); root.Root != 0x100 ||
+		root.WidthInPixels != 640 {
+
+		t.Fatalf("Expected the screen given, but got %+v.", root)
+	}
+	if id, err := X.NewId(); err != nil || id != 0x400001 {
+		t.Fatalf("Expected the id 0x400001, but got (%#x, %v).",
+			id, err)
+	}
+}
+
+// TestExpect answers an InternAtom request with a reply, and a MapWindow
+// request with an error.
+func TestExpect(t *testing.T) {
+	X, server := NewMockConn(testSetup)
+	defer X.Close()
+
+	reply := make([]byte, 32)
+	xgb.Put32(reply[8:], 300) // the atom
+	intern := server.Expect(Request{Opcode: 16}).WithData(reply)
+	server.Expect(Request{Opcode: 8, Body: []byte{1, 2, 0, 0}}).
+		WithError(xproto.BadWindow, 0x201)
+
+	atom, err := xproto.InternAtom(X, false, 4, "TEST").Reply()
+	if err != nil {
+		t.Fatalf("InternAtom: %s", err)
+	}
+	if atom.Atom != 300 {
+		t.Fatalf("Expected the atom 300, but got %d.", atom.Atom)
+	}
+	if got := intern.Received(); !strings.HasSuffix(string(got), "TEST") {
+		t.Fatalf("Expected the name TEST in the request, but got %q.",
+			got)
+	}
+
+	err = xproto.MapWindowChecked(X, 0x201).Check()
+	if _, ok := err.(xproto.WindowError); !ok {
+		t.Fatalf("Expected a BadWindow error, but got %v.", err)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
+
+// TestUnexpected makes sure Verify reports a request that wasn't expected,
+// and one that didn't arrive.
+func TestUnexpected(t *testing.T) {
+	X, server := NewMockConn(testSetup)
+	defer X.Close()
+
+	// GetInputFocus (from Sync) is answered without being expected.
+	X.Sync()
+	server.Expect(Request{Opcode: 16})
+	if err := server.Verify(); err == nil {
+		t.Fatalf("Verify didn't report a missing request.")
+	}
+
+	if _, err := xproto.GetAtomName(X, 1).Reply(); err == nil {
+		t.Fatalf("An unexpected request got a reply.")
+	}
+	if err := server.Verify(); err == nil ||
+		!strings.Contains(err.Error(), "unexpected request") {
+
+		t.Fatalf("Expected an unexpected request, but got %v.", err)
+	}
+}
+
+// TestSendEvent sends an event to the client.
+func TestSendEvent(t *testing.T) {
+	X, server := NewMockConn(testSetup)
+	defer X.Close()
+
+	sent := xproto.MapNotifyEvent{Event: 0x100, Window: 0x201}
+	if err := server.SendEvent(sent); err != nil {
+		t.Fatalf("SendEvent: %s", err)
+	}
+	ev, err := X.WaitForEventTimeout(5 * time.Second)
+	if err != nil {
+		t.Fatalf("WaitForEventTimeout: %s", err)
+	}
+	if got, ok := ev.(xproto.MapNotifyEvent); !ok || got.Window != 0x201 {
+		t.Fatalf("Expected %v, but got %v.", sent, ev)
+	}
+}
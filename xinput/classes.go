@@ -0,0 +1,55 @@
+package xinput
+
+/*
+	Event classes, which select the events of XI 1.x devices in
+	SelectExtensionEvent, like the DeviceKeyPress and similar macros of
+	libXi.
+	Unlike the rest of this package, this file is not generated.
+*/
+
+// classEvents are the events each input class (as in InputClassInfo) has,
+// in the order of their codes, from the EventTypeBase of the class on.
+var classEvents = map[byte][]int{
+	InputClassKey:       {DeviceKeyPress, DeviceKeyRelease},
+	InputClassButton:    {DeviceButtonPress, DeviceButtonRelease},
+	InputClassValuator:  {DeviceMotionNotify},
+	InputClassProximity: {ProximityIn, ProximityOut},
+	InputClassFocus:     {FocusIn, FocusOut},
+	InputClassOther: {DeviceStateNotify, DeviceMappingNotify,
+		ChangeDeviceNotify},
+}
+
+// NewEventClass returns the EventClass of the events with code 'eventType'
+// (as the X server numbers them, e.g., an EventTypeBase of an
+// InputClassInfo) from the device 'deviceId'.
+func NewEventClass(deviceId byte, eventType byte) EventClass {
+	return EventClass(deviceId)<<8 | EventClass(eventType)
+}
+
+// DeviceEventClasses returns the event classes of the events the device
+// 'deviceId' can send, given the classes in the reply to OpenDevice for it.
+// They are keyed by the event numbers of this package (e.g., DeviceKeyPress
+// or ProximityIn), so that the events wanted can be picked out and given to
+// SelectExtensionEvent:
+//
+//	reply, err := xinput.OpenDevice(X, id).Reply()
+//	...
+//	classes := xinput.DeviceEventClasses(id, reply.ClassInfo)
+//	xinput.SelectExtensionEvent(X, win, 2, []xinput.EventClass{
+//		classes[xinput.DeviceButtonPress],
+//		classes[xinput.DeviceMotionNotify],
+//	})
+//
+// Events the device doesn't have are not in the map.
+func DeviceEventClasses(deviceId byte,
+	info []InputClassInfo) map[int]EventClass {
+
+	classes := make(map[int]EventClass)
+	for _, class := range info {
+		for i, event := range classEvents[class.ClassId] {
+			classes[event] = NewEventClass(deviceId,
+				class.EventTypeBase+byte(i))
+		}
+	}
+	return classes
+}
@@ -0,0 +1,27 @@
+package xinput
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestDeviceEventClasses makes the event classes of a tablet with keys,
+// buttons and valuators, whose events start at 70.
+func TestDeviceEventClasses(t *testing.T) {
+	info := []InputClassInfo{
+		{ClassId: InputClassKey, EventTypeBase: 70},
+		{ClassId: InputClassButton, EventTypeBase: 72},
+		{ClassId: InputClassValuator, EventTypeBase: 74},
+		{ClassId: InputClassFeedback, EventTypeBase: 0},
+	}
+	want := map[int]EventClass{
+		DeviceKeyPress:      0x0746,
+		DeviceKeyRelease:    0x0747,
+		DeviceButtonPress:   0x0748,
+		DeviceButtonRelease: 0x0749,
+		DeviceMotionNotify:  0x074a,
+	}
+	if got := DeviceEventClasses(7, info); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected the classes %v, but got %v.", want, got)
+	}
+}
@@ -19,7 +19,7 @@ func Init(c *xgb.Conn) error {
 	case err != nil:
 		return err
 	case !reply.Present:
-		return xgb.Errorf("No extension named XInputExtension could be found on on the server.")
+		return xproto.ExtNotAvailableError{Name: "XInputExtension", Reply: reply}
 	}
 
 	xgb.ExtLock.Lock()
@@ -4582,7 +4582,7 @@ type GetExtensionVersionCookie struct {
 // GetExtensionVersion sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetExtensionVersionCookie.Reply()
 func GetExtensionVersion(c *xgb.Conn, NameLen uint16, Name string) GetExtensionVersionCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetExtensionVersion' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -4593,7 +4593,7 @@ func GetExtensionVersion(c *xgb.Conn, NameLen uint16, Name string) GetExtensionV
 // GetExtensionVersionUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetExtensionVersionUnchecked(c *xgb.Conn, NameLen uint16, Name string) GetExtensionVersionCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetExtensionVersion' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -4662,7 +4662,7 @@ func getExtensionVersionRequest(c *xgb.Conn, NameLen uint16, Name string) []byte
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 1 // request opcode
@@ -4690,7 +4690,7 @@ type ListInputDevicesCookie struct {
 // ListInputDevices sends a checked request.
 // If an error occurs, it will be returned with the reply by calling ListInputDevicesCookie.Reply()
 func ListInputDevices(c *xgb.Conn) ListInputDevicesCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'ListInputDevices' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -4701,7 +4701,7 @@ func ListInputDevices(c *xgb.Conn) ListInputDevicesCookie {
 // ListInputDevicesUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ListInputDevicesUnchecked(c *xgb.Conn) ListInputDevicesCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'ListInputDevices' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -4762,7 +4762,7 @@ func listInputDevicesRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 2 // request opcode
@@ -4782,7 +4782,7 @@ type OpenDeviceCookie struct {
 // OpenDevice sends a checked request.
 // If an error occurs, it will be returned with the reply by calling OpenDeviceCookie.Reply()
 func OpenDevice(c *xgb.Conn, DeviceId byte) OpenDeviceCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'OpenDevice' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -4793,7 +4793,7 @@ func OpenDevice(c *xgb.Conn, DeviceId byte) OpenDeviceCookie {
 // OpenDeviceUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func OpenDeviceUnchecked(c *xgb.Conn, DeviceId byte) OpenDeviceCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'OpenDevice' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -4854,7 +4854,7 @@ func openDeviceRequest(c *xgb.Conn, DeviceId byte) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 3 // request opcode
@@ -4879,7 +4879,7 @@ type CloseDeviceCookie struct {
 // CloseDevice sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func CloseDevice(c *xgb.Conn, DeviceId byte) CloseDeviceCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'CloseDevice' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -4890,7 +4890,7 @@ func CloseDevice(c *xgb.Conn, DeviceId byte) CloseDeviceCookie {
 // CloseDeviceChecked sends a checked request.
 // If an error occurs, it can be retrieved using CloseDeviceCookie.Check()
 func CloseDeviceChecked(c *xgb.Conn, DeviceId byte) CloseDeviceCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'CloseDevice' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -4911,7 +4911,7 @@ func closeDeviceRequest(c *xgb.Conn, DeviceId byte) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 4 // request opcode
@@ -4936,7 +4936,7 @@ type SetDeviceModeCookie struct {
 // SetDeviceMode sends a checked request.
 // If an error occurs, it will be returned with the reply by calling SetDeviceModeCookie.Reply()
 func SetDeviceMode(c *xgb.Conn, DeviceId byte, Mode byte) SetDeviceModeCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SetDeviceMode' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -4947,7 +4947,7 @@ func SetDeviceMode(c *xgb.Conn, DeviceId byte, Mode byte) SetDeviceModeCookie {
 // SetDeviceModeUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetDeviceModeUnchecked(c *xgb.Conn, DeviceId byte, Mode byte) SetDeviceModeCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SetDeviceMode' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -5004,7 +5004,7 @@ func setDeviceModeRequest(c *xgb.Conn, DeviceId byte, Mode byte) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 5 // request opcode
@@ -5032,7 +5032,7 @@ type SelectExtensionEventCookie struct {
 // SelectExtensionEvent sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SelectExtensionEvent(c *xgb.Conn, Window xproto.Window, NumClasses uint16, Classes []EventClass) SelectExtensionEventCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SelectExtensionEvent' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -5043,7 +5043,7 @@ func SelectExtensionEvent(c *xgb.Conn, Window xproto.Window, NumClasses uint16,
 // SelectExtensionEventChecked sends a checked request.
 // If an error occurs, it can be retrieved using SelectExtensionEventCookie.Check()
 func SelectExtensionEventChecked(c *xgb.Conn, Window xproto.Window, NumClasses uint16, Classes []EventClass) SelectExtensionEventCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SelectExtensionEvent' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -5064,7 +5064,7 @@ func selectExtensionEventRequest(c *xgb.Conn, Window xproto.Window, NumClasses u
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 6 // request opcode
@@ -5098,7 +5098,7 @@ type GetSelectedExtensionEventsCookie struct {
 // GetSelectedExtensionEvents sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetSelectedExtensionEventsCookie.Reply()
 func GetSelectedExtensionEvents(c *xgb.Conn, Window xproto.Window) GetSelectedExtensionEventsCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetSelectedExtensionEvents' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -5109,7 +5109,7 @@ func GetSelectedExtensionEvents(c *xgb.Conn, Window xproto.Window) GetSelectedEx
 // GetSelectedExtensionEventsUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetSelectedExtensionEventsUnchecked(c *xgb.Conn, Window xproto.Window) GetSelectedExtensionEventsCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetSelectedExtensionEvents' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -5186,7 +5186,7 @@ func getSelectedExtensionEventsRequest(c *xgb.Conn, Window xproto.Window) []byte
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 7 // request opcode
@@ -5209,7 +5209,7 @@ type ChangeDeviceDontPropagateListCookie struct {
 // ChangeDeviceDontPropagateList sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ChangeDeviceDontPropagateList(c *xgb.Conn, Window xproto.Window, NumClasses uint16, Mode byte, Classes []EventClass) ChangeDeviceDontPropagateListCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'ChangeDeviceDontPropagateList' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -5220,7 +5220,7 @@ func ChangeDeviceDontPropagateList(c *xgb.Conn, Window xproto.Window, NumClasses
 // ChangeDeviceDontPropagateListChecked sends a checked request.
 // If an error occurs, it can be retrieved using ChangeDeviceDontPropagateListCookie.Check()
 func ChangeDeviceDontPropagateListChecked(c *xgb.Conn, Window xproto.Window, NumClasses uint16, Mode byte, Classes []EventClass) ChangeDeviceDontPropagateListCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'ChangeDeviceDontPropagateList' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -5241,7 +5241,7 @@ func changeDeviceDontPropagateListRequest(c *xgb.Conn, Window xproto.Window, Num
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 8 // request opcode
@@ -5278,7 +5278,7 @@ type GetDeviceDontPropagateListCookie struct {
 // GetDeviceDontPropagateList sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetDeviceDontPropagateListCookie.Reply()
 func GetDeviceDontPropagateList(c *xgb.Conn, Window xproto.Window) GetDeviceDontPropagateListCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceDontPropagateList' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -5289,7 +5289,7 @@ func GetDeviceDontPropagateList(c *xgb.Conn, Window xproto.Window) GetDeviceDont
 // GetDeviceDontPropagateListUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetDeviceDontPropagateListUnchecked(c *xgb.Conn, Window xproto.Window) GetDeviceDontPropagateListCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceDontPropagateList' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -5354,7 +5354,7 @@ func getDeviceDontPropagateListRequest(c *xgb.Conn, Window xproto.Window) []byte
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 9 // request opcode
@@ -5377,7 +5377,7 @@ type GetDeviceMotionEventsCookie struct {
 // GetDeviceMotionEvents sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetDeviceMotionEventsCookie.Reply()
 func GetDeviceMotionEvents(c *xgb.Conn, Start xproto.Timestamp, Stop xproto.Timestamp, DeviceId byte) GetDeviceMotionEventsCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceMotionEvents' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -5388,7 +5388,7 @@ func GetDeviceMotionEvents(c *xgb.Conn, Start xproto.Timestamp, Stop xproto.Time
 // GetDeviceMotionEventsUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetDeviceMotionEventsUnchecked(c *xgb.Conn, Start xproto.Timestamp, Stop xproto.Timestamp, DeviceId byte) GetDeviceMotionEventsCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceMotionEvents' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -5453,7 +5453,7 @@ func getDeviceMotionEventsRequest(c *xgb.Conn, Start xproto.Timestamp, Stop xpro
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 10 // request opcode
@@ -5482,7 +5482,7 @@ type ChangeKeyboardDeviceCookie struct {
 // ChangeKeyboardDevice sends a checked request.
 // If an error occurs, it will be returned with the reply by calling ChangeKeyboardDeviceCookie.Reply()
 func ChangeKeyboardDevice(c *xgb.Conn, DeviceId byte) ChangeKeyboardDeviceCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'ChangeKeyboardDevice' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -5493,7 +5493,7 @@ func ChangeKeyboardDevice(c *xgb.Conn, DeviceId byte) ChangeKeyboardDeviceCookie
 // ChangeKeyboardDeviceUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ChangeKeyboardDeviceUnchecked(c *xgb.Conn, DeviceId byte) ChangeKeyboardDeviceCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'ChangeKeyboardDevice' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -5550,7 +5550,7 @@ func changeKeyboardDeviceRequest(c *xgb.Conn, DeviceId byte) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 11 // request opcode
@@ -5575,7 +5575,7 @@ type ChangePointerDeviceCookie struct {
 // ChangePointerDevice sends a checked request.
 // If an error occurs, it will be returned with the reply by calling ChangePointerDeviceCookie.Reply()
 func ChangePointerDevice(c *xgb.Conn, XAxis byte, YAxis byte, DeviceId byte) ChangePointerDeviceCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'ChangePointerDevice' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -5586,7 +5586,7 @@ func ChangePointerDevice(c *xgb.Conn, XAxis byte, YAxis byte, DeviceId byte) Cha
 // ChangePointerDeviceUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ChangePointerDeviceUnchecked(c *xgb.Conn, XAxis byte, YAxis byte, DeviceId byte) ChangePointerDeviceCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'ChangePointerDevice' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -5643,7 +5643,7 @@ func changePointerDeviceRequest(c *xgb.Conn, XAxis byte, YAxis byte, DeviceId by
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 12 // request opcode
@@ -5674,7 +5674,7 @@ type GrabDeviceCookie struct {
 // GrabDevice sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GrabDeviceCookie.Reply()
 func GrabDevice(c *xgb.Conn, GrabWindow xproto.Window, Time xproto.Timestamp, NumClasses uint16, ThisDeviceMode byte, OtherDeviceMode byte, OwnerEvents bool, DeviceId byte, Classes []EventClass) GrabDeviceCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GrabDevice' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -5685,7 +5685,7 @@ func GrabDevice(c *xgb.Conn, GrabWindow xproto.Window, Time xproto.Timestamp, Nu
 // GrabDeviceUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GrabDeviceUnchecked(c *xgb.Conn, GrabWindow xproto.Window, Time xproto.Timestamp, NumClasses uint16, ThisDeviceMode byte, OtherDeviceMode byte, OwnerEvents bool, DeviceId byte, Classes []EventClass) GrabDeviceCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GrabDevice' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -5742,7 +5742,7 @@ func grabDeviceRequest(c *xgb.Conn, GrabWindow xproto.Window, Time xproto.Timest
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 13 // request opcode
@@ -5795,7 +5795,7 @@ type UngrabDeviceCookie struct {
 // UngrabDevice sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func UngrabDevice(c *xgb.Conn, Time xproto.Timestamp, DeviceId byte) UngrabDeviceCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'UngrabDevice' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -5806,7 +5806,7 @@ func UngrabDevice(c *xgb.Conn, Time xproto.Timestamp, DeviceId byte) UngrabDevic
 // UngrabDeviceChecked sends a checked request.
 // If an error occurs, it can be retrieved using UngrabDeviceCookie.Check()
 func UngrabDeviceChecked(c *xgb.Conn, Time xproto.Timestamp, DeviceId byte) UngrabDeviceCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'UngrabDevice' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -5827,7 +5827,7 @@ func ungrabDeviceRequest(c *xgb.Conn, Time xproto.Timestamp, DeviceId byte) []by
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 14 // request opcode
@@ -5853,7 +5853,7 @@ type GrabDeviceKeyCookie struct {
 // GrabDeviceKey sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GrabDeviceKey(c *xgb.Conn, GrabWindow xproto.Window, NumClasses uint16, Modifiers uint16, ModifierDevice byte, GrabbedDevice byte, Key byte, ThisDeviceMode byte, OtherDeviceMode byte, OwnerEvents bool, Classes []EventClass) GrabDeviceKeyCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GrabDeviceKey' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -5864,7 +5864,7 @@ func GrabDeviceKey(c *xgb.Conn, GrabWindow xproto.Window, NumClasses uint16, Mod
 // GrabDeviceKeyChecked sends a checked request.
 // If an error occurs, it can be retrieved using GrabDeviceKeyCookie.Check()
 func GrabDeviceKeyChecked(c *xgb.Conn, GrabWindow xproto.Window, NumClasses uint16, Modifiers uint16, ModifierDevice byte, GrabbedDevice byte, Key byte, ThisDeviceMode byte, OtherDeviceMode byte, OwnerEvents bool, Classes []EventClass) GrabDeviceKeyCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GrabDeviceKey' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -5885,7 +5885,7 @@ func grabDeviceKeyRequest(c *xgb.Conn, GrabWindow xproto.Window, NumClasses uint
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 15 // request opcode
@@ -5944,7 +5944,7 @@ type UngrabDeviceKeyCookie struct {
 // UngrabDeviceKey sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func UngrabDeviceKey(c *xgb.Conn, GrabWindow xproto.Window, Modifiers uint16, ModifierDevice byte, Key byte, GrabbedDevice byte) UngrabDeviceKeyCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'UngrabDeviceKey' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -5955,7 +5955,7 @@ func UngrabDeviceKey(c *xgb.Conn, GrabWindow xproto.Window, Modifiers uint16, Mo
 // UngrabDeviceKeyChecked sends a checked request.
 // If an error occurs, it can be retrieved using UngrabDeviceKeyCookie.Check()
 func UngrabDeviceKeyChecked(c *xgb.Conn, GrabWindow xproto.Window, Modifiers uint16, ModifierDevice byte, Key byte, GrabbedDevice byte) UngrabDeviceKeyCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'UngrabDeviceKey' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -5976,7 +5976,7 @@ func ungrabDeviceKeyRequest(c *xgb.Conn, GrabWindow xproto.Window, Modifiers uin
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 16 // request opcode
@@ -6011,7 +6011,7 @@ type GrabDeviceButtonCookie struct {
 // GrabDeviceButton sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GrabDeviceButton(c *xgb.Conn, GrabWindow xproto.Window, GrabbedDevice byte, ModifierDevice byte, NumClasses uint16, Modifiers uint16, ThisDeviceMode byte, OtherDeviceMode byte, Button byte, OwnerEvents byte, Classes []EventClass) GrabDeviceButtonCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GrabDeviceButton' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -6022,7 +6022,7 @@ func GrabDeviceButton(c *xgb.Conn, GrabWindow xproto.Window, GrabbedDevice byte,
 // GrabDeviceButtonChecked sends a checked request.
 // If an error occurs, it can be retrieved using GrabDeviceButtonCookie.Check()
 func GrabDeviceButtonChecked(c *xgb.Conn, GrabWindow xproto.Window, GrabbedDevice byte, ModifierDevice byte, NumClasses uint16, Modifiers uint16, ThisDeviceMode byte, OtherDeviceMode byte, Button byte, OwnerEvents byte, Classes []EventClass) GrabDeviceButtonCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GrabDeviceButton' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -6043,7 +6043,7 @@ func grabDeviceButtonRequest(c *xgb.Conn, GrabWindow xproto.Window, GrabbedDevic
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 17 // request opcode
@@ -6098,7 +6098,7 @@ type UngrabDeviceButtonCookie struct {
 // UngrabDeviceButton sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func UngrabDeviceButton(c *xgb.Conn, GrabWindow xproto.Window, Modifiers uint16, ModifierDevice byte, Button byte, GrabbedDevice byte) UngrabDeviceButtonCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'UngrabDeviceButton' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -6109,7 +6109,7 @@ func UngrabDeviceButton(c *xgb.Conn, GrabWindow xproto.Window, Modifiers uint16,
 // UngrabDeviceButtonChecked sends a checked request.
 // If an error occurs, it can be retrieved using UngrabDeviceButtonCookie.Check()
 func UngrabDeviceButtonChecked(c *xgb.Conn, GrabWindow xproto.Window, Modifiers uint16, ModifierDevice byte, Button byte, GrabbedDevice byte) UngrabDeviceButtonCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'UngrabDeviceButton' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -6130,7 +6130,7 @@ func ungrabDeviceButtonRequest(c *xgb.Conn, GrabWindow xproto.Window, Modifiers
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 18 // request opcode
@@ -6165,7 +6165,7 @@ type AllowDeviceEventsCookie struct {
 // AllowDeviceEvents sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func AllowDeviceEvents(c *xgb.Conn, Time xproto.Timestamp, Mode byte, DeviceId byte) AllowDeviceEventsCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'AllowDeviceEvents' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -6176,7 +6176,7 @@ func AllowDeviceEvents(c *xgb.Conn, Time xproto.Timestamp, Mode byte, DeviceId b
 // AllowDeviceEventsChecked sends a checked request.
 // If an error occurs, it can be retrieved using AllowDeviceEventsCookie.Check()
 func AllowDeviceEventsChecked(c *xgb.Conn, Time xproto.Timestamp, Mode byte, DeviceId byte) AllowDeviceEventsCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'AllowDeviceEvents' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -6197,7 +6197,7 @@ func allowDeviceEventsRequest(c *xgb.Conn, Time xproto.Timestamp, Mode byte, Dev
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 19 // request opcode
@@ -6226,7 +6226,7 @@ type GetDeviceFocusCookie struct {
 // GetDeviceFocus sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetDeviceFocusCookie.Reply()
 func GetDeviceFocus(c *xgb.Conn, DeviceId byte) GetDeviceFocusCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceFocus' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -6237,7 +6237,7 @@ func GetDeviceFocus(c *xgb.Conn, DeviceId byte) GetDeviceFocusCookie {
 // GetDeviceFocusUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetDeviceFocusUnchecked(c *xgb.Conn, DeviceId byte) GetDeviceFocusCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceFocus' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -6302,7 +6302,7 @@ func getDeviceFocusRequest(c *xgb.Conn, DeviceId byte) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 20 // request opcode
@@ -6327,7 +6327,7 @@ type SetDeviceFocusCookie struct {
 // SetDeviceFocus sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetDeviceFocus(c *xgb.Conn, Focus xproto.Window, Time xproto.Timestamp, RevertTo byte, DeviceId byte) SetDeviceFocusCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SetDeviceFocus' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -6338,7 +6338,7 @@ func SetDeviceFocus(c *xgb.Conn, Focus xproto.Window, Time xproto.Timestamp, Rev
 // SetDeviceFocusChecked sends a checked request.
 // If an error occurs, it can be retrieved using SetDeviceFocusCookie.Check()
 func SetDeviceFocusChecked(c *xgb.Conn, Focus xproto.Window, Time xproto.Timestamp, RevertTo byte, DeviceId byte) SetDeviceFocusCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SetDeviceFocus' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -6359,7 +6359,7 @@ func setDeviceFocusRequest(c *xgb.Conn, Focus xproto.Window, Time xproto.Timesta
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 21 // request opcode
@@ -6391,7 +6391,7 @@ type GetFeedbackControlCookie struct {
 // GetFeedbackControl sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetFeedbackControlCookie.Reply()
 func GetFeedbackControl(c *xgb.Conn, DeviceId byte) GetFeedbackControlCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetFeedbackControl' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -6402,7 +6402,7 @@ func GetFeedbackControl(c *xgb.Conn, DeviceId byte) GetFeedbackControlCookie {
 // GetFeedbackControlUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetFeedbackControlUnchecked(c *xgb.Conn, DeviceId byte) GetFeedbackControlCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetFeedbackControl' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -6459,7 +6459,7 @@ func getFeedbackControlRequest(c *xgb.Conn, DeviceId byte) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 22 // request opcode
@@ -6484,7 +6484,7 @@ type GetDeviceKeyMappingCookie struct {
 // GetDeviceKeyMapping sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetDeviceKeyMappingCookie.Reply()
 func GetDeviceKeyMapping(c *xgb.Conn, DeviceId byte, FirstKeycode KeyCode, Count byte) GetDeviceKeyMappingCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceKeyMapping' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -6495,7 +6495,7 @@ func GetDeviceKeyMapping(c *xgb.Conn, DeviceId byte, FirstKeycode KeyCode, Count
 // GetDeviceKeyMappingUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetDeviceKeyMappingUnchecked(c *xgb.Conn, DeviceId byte, FirstKeycode KeyCode, Count byte) GetDeviceKeyMappingCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceKeyMapping' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -6560,7 +6560,7 @@ func getDeviceKeyMappingRequest(c *xgb.Conn, DeviceId byte, FirstKeycode KeyCode
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 24 // request opcode
@@ -6589,7 +6589,7 @@ type ChangeDeviceKeyMappingCookie struct {
 // ChangeDeviceKeyMapping sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ChangeDeviceKeyMapping(c *xgb.Conn, DeviceId byte, FirstKeycode KeyCode, KeysymsPerKeycode byte, KeycodeCount byte, Keysyms []xproto.Keysym) ChangeDeviceKeyMappingCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'ChangeDeviceKeyMapping' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -6600,7 +6600,7 @@ func ChangeDeviceKeyMapping(c *xgb.Conn, DeviceId byte, FirstKeycode KeyCode, Ke
 // ChangeDeviceKeyMappingChecked sends a checked request.
 // If an error occurs, it can be retrieved using ChangeDeviceKeyMappingCookie.Check()
 func ChangeDeviceKeyMappingChecked(c *xgb.Conn, DeviceId byte, FirstKeycode KeyCode, KeysymsPerKeycode byte, KeycodeCount byte, Keysyms []xproto.Keysym) ChangeDeviceKeyMappingCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'ChangeDeviceKeyMapping' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -6621,7 +6621,7 @@ func changeDeviceKeyMappingRequest(c *xgb.Conn, DeviceId byte, FirstKeycode KeyC
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 25 // request opcode
@@ -6659,7 +6659,7 @@ type GetDeviceModifierMappingCookie struct {
 // GetDeviceModifierMapping sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetDeviceModifierMappingCookie.Reply()
 func GetDeviceModifierMapping(c *xgb.Conn, DeviceId byte) GetDeviceModifierMappingCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceModifierMapping' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -6670,7 +6670,7 @@ func GetDeviceModifierMapping(c *xgb.Conn, DeviceId byte) GetDeviceModifierMappi
 // GetDeviceModifierMappingUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetDeviceModifierMappingUnchecked(c *xgb.Conn, DeviceId byte) GetDeviceModifierMappingCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceModifierMapping' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -6732,7 +6732,7 @@ func getDeviceModifierMappingRequest(c *xgb.Conn, DeviceId byte) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 26 // request opcode
@@ -6757,7 +6757,7 @@ type SetDeviceModifierMappingCookie struct {
 // SetDeviceModifierMapping sends a checked request.
 // If an error occurs, it will be returned with the reply by calling SetDeviceModifierMappingCookie.Reply()
 func SetDeviceModifierMapping(c *xgb.Conn, DeviceId byte, KeycodesPerModifier byte, Keymaps []byte) SetDeviceModifierMappingCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SetDeviceModifierMapping' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -6768,7 +6768,7 @@ func SetDeviceModifierMapping(c *xgb.Conn, DeviceId byte, KeycodesPerModifier by
 // SetDeviceModifierMappingUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetDeviceModifierMappingUnchecked(c *xgb.Conn, DeviceId byte, KeycodesPerModifier byte, Keymaps []byte) SetDeviceModifierMappingCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SetDeviceModifierMapping' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -6825,7 +6825,7 @@ func setDeviceModifierMappingRequest(c *xgb.Conn, DeviceId byte, KeycodesPerModi
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 27 // request opcode
@@ -6856,7 +6856,7 @@ type GetDeviceButtonMappingCookie struct {
 // GetDeviceButtonMapping sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetDeviceButtonMappingCookie.Reply()
 func GetDeviceButtonMapping(c *xgb.Conn, DeviceId byte) GetDeviceButtonMappingCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceButtonMapping' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -6867,7 +6867,7 @@ func GetDeviceButtonMapping(c *xgb.Conn, DeviceId byte) GetDeviceButtonMappingCo
 // GetDeviceButtonMappingUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetDeviceButtonMappingUnchecked(c *xgb.Conn, DeviceId byte) GetDeviceButtonMappingCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceButtonMapping' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -6929,7 +6929,7 @@ func getDeviceButtonMappingRequest(c *xgb.Conn, DeviceId byte) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 28 // request opcode
@@ -6954,7 +6954,7 @@ type SetDeviceButtonMappingCookie struct {
 // SetDeviceButtonMapping sends a checked request.
 // If an error occurs, it will be returned with the reply by calling SetDeviceButtonMappingCookie.Reply()
 func SetDeviceButtonMapping(c *xgb.Conn, DeviceId byte, MapSize byte, Map []byte) SetDeviceButtonMappingCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SetDeviceButtonMapping' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -6965,7 +6965,7 @@ func SetDeviceButtonMapping(c *xgb.Conn, DeviceId byte, MapSize byte, Map []byte
 // SetDeviceButtonMappingUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetDeviceButtonMappingUnchecked(c *xgb.Conn, DeviceId byte, MapSize byte, Map []byte) SetDeviceButtonMappingCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SetDeviceButtonMapping' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -7022,7 +7022,7 @@ func setDeviceButtonMappingRequest(c *xgb.Conn, DeviceId byte, MapSize byte, Map
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 29 // request opcode
@@ -7053,7 +7053,7 @@ type QueryDeviceStateCookie struct {
 // QueryDeviceState sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryDeviceStateCookie.Reply()
 func QueryDeviceState(c *xgb.Conn, DeviceId byte) QueryDeviceStateCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'QueryDeviceState' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -7064,7 +7064,7 @@ func QueryDeviceState(c *xgb.Conn, DeviceId byte) QueryDeviceStateCookie {
 // QueryDeviceStateUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryDeviceStateUnchecked(c *xgb.Conn, DeviceId byte) QueryDeviceStateCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'QueryDeviceState' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -7121,7 +7121,7 @@ func queryDeviceStateRequest(c *xgb.Conn, DeviceId byte) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 30 // request opcode
@@ -7146,7 +7146,7 @@ type SendExtensionEventCookie struct {
 // SendExtensionEvent sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SendExtensionEvent(c *xgb.Conn, Destination xproto.Window, DeviceId byte, Propagate bool, NumClasses uint16, NumEvents byte, Events string, Classes []EventClass) SendExtensionEventCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SendExtensionEvent' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -7157,7 +7157,7 @@ func SendExtensionEvent(c *xgb.Conn, Destination xproto.Window, DeviceId byte, P
 // SendExtensionEventChecked sends a checked request.
 // If an error occurs, it can be retrieved using SendExtensionEventCookie.Check()
 func SendExtensionEventChecked(c *xgb.Conn, Destination xproto.Window, DeviceId byte, Propagate bool, NumClasses uint16, NumEvents byte, Events string, Classes []EventClass) SendExtensionEventCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SendExtensionEvent' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -7178,7 +7178,7 @@ func sendExtensionEventRequest(c *xgb.Conn, Destination xproto.Window, DeviceId
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 31 // request opcode
@@ -7228,7 +7228,7 @@ type DeviceBellCookie struct {
 // DeviceBell sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func DeviceBell(c *xgb.Conn, DeviceId byte, FeedbackId byte, FeedbackClass byte, Percent int8) DeviceBellCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'DeviceBell' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -7239,7 +7239,7 @@ func DeviceBell(c *xgb.Conn, DeviceId byte, FeedbackId byte, FeedbackClass byte,
 // DeviceBellChecked sends a checked request.
 // If an error occurs, it can be retrieved using DeviceBellCookie.Check()
 func DeviceBellChecked(c *xgb.Conn, DeviceId byte, FeedbackId byte, FeedbackClass byte, Percent int8) DeviceBellCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'DeviceBell' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -7260,7 +7260,7 @@ func deviceBellRequest(c *xgb.Conn, DeviceId byte, FeedbackId byte, FeedbackClas
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 32 // request opcode
@@ -7292,7 +7292,7 @@ type SetDeviceValuatorsCookie struct {
 // SetDeviceValuators sends a checked request.
 // If an error occurs, it will be returned with the reply by calling SetDeviceValuatorsCookie.Reply()
 func SetDeviceValuators(c *xgb.Conn, DeviceId byte, FirstValuator byte, NumValuators byte, Valuators []int32) SetDeviceValuatorsCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SetDeviceValuators' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -7303,7 +7303,7 @@ func SetDeviceValuators(c *xgb.Conn, DeviceId byte, FirstValuator byte, NumValua
 // SetDeviceValuatorsUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetDeviceValuatorsUnchecked(c *xgb.Conn, DeviceId byte, FirstValuator byte, NumValuators byte, Valuators []int32) SetDeviceValuatorsCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'SetDeviceValuators' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -7360,7 +7360,7 @@ func setDeviceValuatorsRequest(c *xgb.Conn, DeviceId byte, FirstValuator byte, N
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 33 // request opcode
@@ -7397,7 +7397,7 @@ type GetDeviceControlCookie struct {
 // GetDeviceControl sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetDeviceControlCookie.Reply()
 func GetDeviceControl(c *xgb.Conn, ControlId uint16, DeviceId byte) GetDeviceControlCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceControl' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -7408,7 +7408,7 @@ func GetDeviceControl(c *xgb.Conn, ControlId uint16, DeviceId byte) GetDeviceCon
 // GetDeviceControlUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetDeviceControlUnchecked(c *xgb.Conn, ControlId uint16, DeviceId byte) GetDeviceControlCookie {
-	if _, ok := c.Extensions["XINPUTEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
 		panic("Cannot issue request 'GetDeviceControl' using the uninitialized extension 'XInputExtension'. xinput.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -7465,7 +7465,7 @@ func getDeviceControlRequest(c *xgb.Conn, ControlId uint16, DeviceId byte) []byt
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XINPUTEXTENSION"]
+	buf[b] = c.Extensions["XInputExtension"]
 	b += 1
 
 	buf[b] = 34 // request opcode
@@ -0,0 +1,236 @@
+package xinput2
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// Device class types, as in DeviceClass.Type.
+const (
+	ClassKey      = 0
+	ClassButton   = 1
+	ClassValuator = 2
+	ClassScroll   = 3
+	ClassTouch    = 8
+	ClassGesture  = 9
+)
+
+// Valuator modes, as in ValuatorClass.Mode.
+const (
+	ModeRelative = 0
+	ModeAbsolute = 1
+)
+
+// Scroll types and flags, as in ScrollClass.
+const (
+	ScrollTypeVertical    = 1
+	ScrollTypeHorizontal  = 2
+	ScrollFlagNoEmulation = 1 << 0
+	ScrollFlagPreferred   = 1 << 1
+)
+
+// Touch modes, as in TouchClass.Mode.
+const (
+	TouchModeDirect    = 1
+	TouchModeDependent = 2
+)
+
+// DeviceInfo describes an input device, as returned by QueryDevice.
+type DeviceInfo struct {
+	DeviceId   DeviceId
+	Use        uint16   // one of the DeviceType* constants
+	Attachment DeviceId // the paired master, or the master of a slave
+	Enabled    bool
+	Name       string
+	Classes    []DeviceClass
+}
+
+// DeviceClass describes one capability of an input device. Exactly one of
+// the pointer fields is set, depending on Type. Classes of unknown types only
+// have Type and SourceId set.
+type DeviceClass struct {
+	Type     uint16   // one of the Class* constants
+	SourceId DeviceId // the slave device the class was copied from
+
+	Key      *KeyClass
+	Button   *ButtonClass
+	Valuator *ValuatorClass
+	Scroll   *ScrollClass
+	Touch    *TouchClass
+	Gesture  *GestureClass
+}
+
+// KeyClass lists the keycodes of a device with keys.
+type KeyClass struct {
+	Keys []uint32
+}
+
+// ButtonClass describes the buttons of a device.
+type ButtonClass struct {
+	State  []uint32 // a bit mask of the buttons that are pressed
+	Labels []xproto.Atom
+}
+
+// ValuatorClass describes an axis of a device, e.g., the x coordinate of
+// a pointer.
+type ValuatorClass struct {
+	Number     uint16
+	Label      xproto.Atom
+	Min        Fp3232
+	Max        Fp3232
+	Value      Fp3232
+	Resolution uint32 // in units per meter
+	Mode       byte   // ModeRelative or ModeAbsolute
+}
+
+// ScrollClass marks a valuator (with the same Number) as a scroll axis.
+type ScrollClass struct {
+	Number     uint16
+	ScrollType uint16 // ScrollTypeVertical or ScrollTypeHorizontal
+	Flags      uint32
+	Increment  Fp3232
+}
+
+// TouchClass describes the touch capabilities of a device.
+type TouchClass struct {
+	Mode       byte // TouchModeDirect or TouchModeDependent
+	NumTouches byte // 0 if unlimited
+}
+
+// GestureClass describes the gesture capabilities of a device.
+type GestureClass struct {
+	NumTouches byte // 0 if unlimited
+}
+
+// deviceClassRead reads a DeviceClass from 'buf' and returns the number of
+// bytes read.
+func deviceClassRead(buf []byte, v *DeviceClass) int {
+	v.Type = xgb.Get16(buf)
+	v.SourceId = DeviceId(xgb.Get16(buf[4:]))
+
+	switch v.Type {
+	case ClassKey:
+		n := int(xgb.Get16(buf[6:]))
+		v.Key = &KeyClass{Keys: readMask(buf[8:], n)}
+	case ClassButton:
+		n := int(xgb.Get16(buf[6:]))
+		words := (n + 31) / 32
+		v.Button = &ButtonClass{
+			State:  readMask(buf[8:], words),
+			Labels: make([]xproto.Atom, n),
+		}
+		for i := range v.Button.Labels {
+			v.Button.Labels[i] = xproto.Atom(
+				xgb.Get32(buf[8+words*4+i*4:]))
+		}
+	case ClassValuator:
+		v.Valuator = &ValuatorClass{
+			Number:     xgb.Get16(buf[6:]),
+			Label:      xproto.Atom(xgb.Get32(buf[8:])),
+			Min:        fp3232Read(buf[12:]),
+			Max:        fp3232Read(buf[20:]),
+			Value:      fp3232Read(buf[28:]),
+			Resolution: xgb.Get32(buf[36:]),
+			Mode:       buf[40],
+		}
+	case ClassScroll:
+		v.Scroll = &ScrollClass{
+			Number:     xgb.Get16(buf[6:]),
+			ScrollType: xgb.Get16(buf[8:]),
+			Flags:      xgb.Get32(buf[12:]),
+			Increment:  fp3232Read(buf[16:]),
+		}
+	case ClassTouch:
+		v.Touch = &TouchClass{Mode: buf[6], NumTouches: buf[7]}
+	case ClassGesture:
+		v.Gesture = &GestureClass{NumTouches: buf[6]}
+	}
+	return int(xgb.Get16(buf[2:])) * 4
+}
+
+// deviceInfoRead reads a DeviceInfo from 'buf' and returns the number of
+// bytes read.
+func deviceInfoRead(buf []byte, v *DeviceInfo) int {
+	v.DeviceId = DeviceId(xgb.Get16(buf))
+	v.Use = xgb.Get16(buf[2:])
+	v.Attachment = DeviceId(xgb.Get16(buf[4:]))
+	v.Classes = make([]DeviceClass, xgb.Get16(buf[6:]))
+	nameLen := int(xgb.Get16(buf[8:]))
+	v.Enabled = buf[10] == 1
+	v.Name = string(buf[12 : 12+nameLen])
+
+	b := 12 + xgb.Pad(nameLen)
+	for i := range v.Classes {
+		b += deviceClassRead(buf[b:], &v.Classes[i])
+	}
+	return b
+}
+
+// QueryDeviceCookie is a cookie used only for QueryDevice requests.
+type QueryDeviceCookie struct {
+	*xgb.Cookie
+}
+
+// QueryDevice sends a checked request. 'Device' may be DeviceAll or
+// DeviceAllMaster to query more than one device.
+// If an error occurs, it will be returned with the reply by calling
+// QueryDeviceCookie.Reply()
+func QueryDevice(c *xgb.Conn, Device DeviceId) QueryDeviceCookie {
+	checkInit(c, "QueryDevice")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(queryDeviceRequest(c, Device), cookie)
+	return QueryDeviceCookie{cookie}
+}
+
+// QueryDeviceUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func QueryDeviceUnchecked(c *xgb.Conn, Device DeviceId) QueryDeviceCookie {
+	checkInit(c, "QueryDevice")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(queryDeviceRequest(c, Device), cookie)
+	return QueryDeviceCookie{cookie}
+}
+
+// QueryDeviceReply represents the data returned from a QueryDevice request.
+type QueryDeviceReply struct {
+	Sequence uint16 // sequence number of the request for this reply
+	Length   uint32 // number of bytes in this reply
+	Infos    []DeviceInfo
+}
+
+// Reply blocks and returns the reply data for a QueryDevice request.
+func (cook QueryDeviceCookie) Reply() (*QueryDeviceReply, error) {
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return queryDeviceReply(buf), nil
+}
+
+// queryDeviceReply reads a byte slice into a QueryDeviceReply value.
+func queryDeviceReply(buf []byte) *QueryDeviceReply {
+	v := &QueryDeviceReply{
+		Sequence: xgb.Get16(buf[2:]),
+		Length:   xgb.Get32(buf[4:]),
+		Infos:    make([]DeviceInfo, xgb.Get16(buf[8:])),
+	}
+
+	b := 32
+	for i := range v.Infos {
+		b += deviceInfoRead(buf[b:], &v.Infos[i])
+	}
+	return v
+}
+
+// queryDeviceRequest writes a QueryDevice request to a byte slice.
+func queryDeviceRequest(c *xgb.Conn, Device DeviceId) []byte {
+	buf := make([]byte, 8)
+	requestHeader(c, buf, 48)
+	xgb.Put16(buf[4:], uint16(Device))
+	return buf
+}
@@ -0,0 +1,939 @@
+package xinput2
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// XI2 event types. Pass them to NewEventMask to select the corresponding
+// events.
+const (
+	DeviceChanged      = 1
+	KeyPress           = 2
+	KeyRelease         = 3
+	ButtonPress        = 4
+	ButtonRelease      = 5
+	Motion             = 6
+	Enter              = 7
+	Leave              = 8
+	FocusIn            = 9
+	FocusOut           = 10
+	HierarchyChanged   = 11
+	PropertyChanged    = 12 // XI_PropertyEvent in XI2.h
+	RawKeyPress        = 13
+	RawKeyRelease      = 14
+	RawButtonPress     = 15
+	RawButtonRelease   = 16
+	RawMotion          = 17
+	TouchBegin         = 18
+	TouchUpdate        = 19
+	TouchEnd           = 20
+	TouchOwnership     = 21
+	RawTouchBegin      = 22
+	RawTouchUpdate     = 23
+	RawTouchEnd        = 24
+	BarrierHit         = 25
+	BarrierLeave       = 26
+	GesturePinchBegin  = 27
+	GesturePinchUpdate = 28
+	GesturePinchEnd    = 29
+	GestureSwipeBegin  = 30
+	GestureSwipeUpdate = 31
+	GestureSwipeEnd    = 32
+	lastEvent          = GestureSwipeEnd
+)
+
+// eventNames are used by the String methods of events.
+var eventNames = [lastEvent + 1]string{
+	"", "DeviceChanged", "KeyPress", "KeyRelease", "ButtonPress",
+	"ButtonRelease", "Motion", "Enter", "Leave", "FocusIn", "FocusOut",
+	"HierarchyChanged", "PropertyChanged", "RawKeyPress", "RawKeyRelease",
+	"RawButtonPress", "RawButtonRelease", "RawMotion", "TouchBegin",
+	"TouchUpdate", "TouchEnd", "TouchOwnership", "RawTouchBegin",
+	"RawTouchUpdate", "RawTouchEnd", "BarrierHit", "BarrierLeave",
+	"GesturePinchBegin", "GesturePinchUpdate", "GesturePinchEnd",
+	"GestureSwipeBegin", "GestureSwipeUpdate", "GestureSwipeEnd",
+}
+
+// eventFuncs maps each XI2 event type to the function decoding it. They are
+// registered with the connection by Init.
+var eventFuncs = map[uint16]xgb.GenericEventHandler{
+	DeviceChanged:      DeviceChangedEventNew,
+	KeyPress:           DeviceEventNew,
+	KeyRelease:         DeviceEventNew,
+	ButtonPress:        DeviceEventNew,
+	ButtonRelease:      DeviceEventNew,
+	Motion:             DeviceEventNew,
+	Enter:              EnterEventNew,
+	Leave:              EnterEventNew,
+	FocusIn:            EnterEventNew,
+	FocusOut:           EnterEventNew,
+	HierarchyChanged:   HierarchyEventNew,
+	PropertyChanged:    PropertyEventNew,
+	RawKeyPress:        RawEventNew,
+	RawKeyRelease:      RawEventNew,
+	RawButtonPress:     RawEventNew,
+	RawButtonRelease:   RawEventNew,
+	RawMotion:          RawEventNew,
+	TouchBegin:         DeviceEventNew,
+	TouchUpdate:        DeviceEventNew,
+	TouchEnd:           DeviceEventNew,
+	TouchOwnership:     TouchOwnershipEventNew,
+	RawTouchBegin:      RawEventNew,
+	RawTouchUpdate:     RawEventNew,
+	RawTouchEnd:        RawEventNew,
+	BarrierHit:         BarrierEventNew,
+	BarrierLeave:       BarrierEventNew,
+	GesturePinchBegin:  GesturePinchEventNew,
+	GesturePinchUpdate: GesturePinchEventNew,
+	GesturePinchEnd:    GesturePinchEventNew,
+	GestureSwipeBegin:  GestureSwipeEventNew,
+	GestureSwipeUpdate: GestureSwipeEventNew,
+	GestureSwipeEnd:    GestureSwipeEventNew,
+}
+
+// eventString returns the string representation of an event of type
+// 'evtype' with the given fields.
+func eventString(evtype uint16, fieldVals []string) string {
+	name := "Unknown"
+	if int(evtype) < len(eventNames) && evtype != 0 {
+		name = eventNames[evtype]
+	}
+	return name + " {" + xgb.StringsJoin(fieldVals, ", ") + "}"
+}
+
+// Flags of DeviceEvent, RawEvent and TouchOwnershipEvent.
+const (
+	KeyRepeat             = 1 << 16 // for KeyPress events
+	PointerEmulated       = 1 << 16 // for button and motion events
+	TouchPendingEnd       = 1 << 16 // for touch events
+	TouchEmulatingPointer = 1 << 17 // for touch events
+)
+
+// DeviceEvent is sent for the KeyPress, KeyRelease, ButtonPress,
+// ButtonRelease, Motion, TouchBegin, TouchUpdate and TouchEnd event types.
+type DeviceEvent struct {
+	Sequence  uint16
+	EventType uint16
+	DeviceId  DeviceId
+	Time      xproto.Timestamp
+
+	// Detail is the keycode, the button or the touch id, depending on the
+	// event type.
+	Detail uint32
+
+	Root     xproto.Window
+	Event    xproto.Window
+	Child    xproto.Window
+	RootX    Fp1616
+	RootY    Fp1616
+	EventX   Fp1616
+	EventY   Fp1616
+	SourceId DeviceId
+	Flags    uint32
+	Mods     ModifierInfo
+	Group    GroupInfo
+	Buttons  []uint32 // a bit mask of the buttons that are pressed
+
+	// ValuatorMask has a bit set for each valuator included in the event,
+	// and Valuators holds their values, in order.
+	ValuatorMask []uint32
+	Valuators    []Fp3232
+
+	buf []byte
+}
+
+// DeviceEventNew constructs a DeviceEvent value that implements xgb.Event
+// from a byte slice.
+func DeviceEventNew(buf []byte) xgb.Event {
+	v := DeviceEvent{
+		Sequence:  xgb.Get16(buf[2:]),
+		EventType: xgb.Get16(buf[8:]),
+		DeviceId:  DeviceId(xgb.Get16(buf[10:])),
+		Time:      xproto.Timestamp(xgb.Get32(buf[12:])),
+		Detail:    xgb.Get32(buf[16:]),
+		Root:      xproto.Window(xgb.Get32(buf[20:])),
+		Event:     xproto.Window(xgb.Get32(buf[24:])),
+		Child:     xproto.Window(xgb.Get32(buf[28:])),
+		RootX:     Fp1616(xgb.Get32(buf[32:])),
+		RootY:     Fp1616(xgb.Get32(buf[36:])),
+		EventX:    Fp1616(xgb.Get32(buf[40:])),
+		EventY:    Fp1616(xgb.Get32(buf[44:])),
+		SourceId:  DeviceId(xgb.Get16(buf[52:])),
+		Flags:     xgb.Get32(buf[56:]),
+		Mods:      modifierInfoRead(buf[60:]),
+		Group:     groupInfoRead(buf[76:]),
+		buf:       buf,
+	}
+
+	b := 80
+	buttonsLen := int(xgb.Get16(buf[48:]))
+	v.Buttons = readMask(buf[b:], buttonsLen)
+	b += buttonsLen * 4
+
+	valuatorsLen := int(xgb.Get16(buf[50:]))
+	v.ValuatorMask = readMask(buf[b:], valuatorsLen)
+	b += valuatorsLen * 4
+	v.Valuators, _ = readValuators(buf[b:], v.ValuatorMask)
+	return v
+}
+
+// readValuators reads a value for each bit set in 'mask' from 'buf', and
+// returns them with the number of bytes read.
+func readValuators(buf []byte, mask []uint32) ([]Fp3232, int) {
+	var vals []Fp3232
+	b := 0
+	for i := 0; i < len(mask)*32; i++ {
+		if MaskIsSet(mask, i) {
+			vals = append(vals, fp3232Read(buf[b:]))
+			b += 8
+		}
+	}
+	return vals, b
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v DeviceEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the DeviceEvent event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v DeviceEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of DeviceEvent.
+func (v DeviceEvent) String() string {
+	fieldVals := make([]string, 0, 15)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeviceId: %d", v.DeviceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Time: %d", v.Time))
+	fieldVals = append(fieldVals, xgb.Sprintf("Detail: %d", v.Detail))
+	fieldVals = append(fieldVals, xgb.Sprintf("Root: %d", v.Root))
+	fieldVals = append(fieldVals, xgb.Sprintf("Event: %d", v.Event))
+	fieldVals = append(fieldVals, xgb.Sprintf("Child: %d", v.Child))
+	fieldVals = append(fieldVals, xgb.Sprintf("RootX: %g",
+		v.RootX.Float64()))
+	fieldVals = append(fieldVals, xgb.Sprintf("RootY: %g",
+		v.RootY.Float64()))
+	fieldVals = append(fieldVals, xgb.Sprintf("EventX: %g",
+		v.EventX.Float64()))
+	fieldVals = append(fieldVals, xgb.Sprintf("EventY: %g",
+		v.EventY.Float64()))
+	fieldVals = append(fieldVals, xgb.Sprintf("SourceId: %d", v.SourceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Flags: %d", v.Flags))
+	fieldVals = append(fieldVals, xgb.Sprintf("Mods: %d", v.Mods.Effective))
+	fieldVals = append(fieldVals, xgb.Sprintf("Group: %d",
+		v.Group.Effective))
+	return eventString(v.EventType, fieldVals)
+}
+
+// RawEvent is sent for the RawKeyPress, RawKeyRelease, RawButtonPress,
+// RawButtonRelease, RawMotion, RawTouchBegin, RawTouchUpdate and RawTouchEnd
+// event types. Raw events are only sent to the root window.
+type RawEvent struct {
+	Sequence  uint16
+	EventType uint16
+	DeviceId  DeviceId
+	Time      xproto.Timestamp
+	Detail    uint32
+	SourceId  DeviceId
+	Flags     uint32
+
+	// ValuatorMask has a bit set for each valuator included in the event.
+	// Valuators holds their values (after pointer acceleration), in order,
+	// and RawValuators the values as sent by the device.
+	ValuatorMask []uint32
+	Valuators    []Fp3232
+	RawValuators []Fp3232
+
+	buf []byte
+}
+
+// RawEventNew constructs a RawEvent value that implements xgb.Event from
+// a byte slice.
+func RawEventNew(buf []byte) xgb.Event {
+	v := RawEvent{
+		Sequence:  xgb.Get16(buf[2:]),
+		EventType: xgb.Get16(buf[8:]),
+		DeviceId:  DeviceId(xgb.Get16(buf[10:])),
+		Time:      xproto.Timestamp(xgb.Get32(buf[12:])),
+		Detail:    xgb.Get32(buf[16:]),
+		SourceId:  DeviceId(xgb.Get16(buf[20:])),
+		Flags:     xgb.Get32(buf[24:]),
+		buf:       buf,
+	}
+
+	b := 32
+	valuatorsLen := int(xgb.Get16(buf[22:]))
+	v.ValuatorMask = readMask(buf[b:], valuatorsLen)
+	b += valuatorsLen * 4
+
+	n := 0
+	v.Valuators, n = readValuators(buf[b:], v.ValuatorMask)
+	v.RawValuators, _ = readValuators(buf[b+n:], v.ValuatorMask)
+	return v
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v RawEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the RawEvent event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v RawEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of RawEvent.
+func (v RawEvent) String() string {
+	fieldVals := make([]string, 0, 7)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeviceId: %d", v.DeviceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Time: %d", v.Time))
+	fieldVals = append(fieldVals, xgb.Sprintf("Detail: %d", v.Detail))
+	fieldVals = append(fieldVals, xgb.Sprintf("SourceId: %d", v.SourceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Flags: %d", v.Flags))
+	fieldVals = append(fieldVals, xgb.Sprintf("len(Valuators): %d",
+		len(v.Valuators)))
+	return eventString(v.EventType, fieldVals)
+}
+
+// Notify modes and details of EnterEvent, as in the core protocol.
+const (
+	NotifyNormal        = 0
+	NotifyGrab          = 1
+	NotifyUngrab        = 2
+	NotifyWhileGrabbed  = 3
+	NotifyPassiveGrab   = 4
+	NotifyPassiveUngrab = 5
+
+	NotifyAncestor         = 0
+	NotifyVirtual          = 1
+	NotifyInferior         = 2
+	NotifyNonlinear        = 3
+	NotifyNonlinearVirtual = 4
+	NotifyPointer          = 5
+	NotifyPointerRoot      = 6
+	NotifyDetailNone       = 7
+)
+
+// EnterEvent is sent for the Enter, Leave, FocusIn and FocusOut event types.
+type EnterEvent struct {
+	Sequence   uint16
+	EventType  uint16
+	DeviceId   DeviceId
+	Time       xproto.Timestamp
+	SourceId   DeviceId
+	Mode       byte // one of the Notify* mode constants
+	Detail     byte // one of the Notify* detail constants
+	Root       xproto.Window
+	Event      xproto.Window
+	Child      xproto.Window
+	RootX      Fp1616
+	RootY      Fp1616
+	EventX     Fp1616
+	EventY     Fp1616
+	SameScreen bool
+	Focus      bool
+	Mods       ModifierInfo
+	Group      GroupInfo
+	Buttons    []uint32 // a bit mask of the buttons that are pressed
+
+	buf []byte
+}
+
+// EnterEventNew constructs an EnterEvent value that implements xgb.Event
+// from a byte slice.
+func EnterEventNew(buf []byte) xgb.Event {
+	return EnterEvent{
+		Sequence:   xgb.Get16(buf[2:]),
+		EventType:  xgb.Get16(buf[8:]),
+		DeviceId:   DeviceId(xgb.Get16(buf[10:])),
+		Time:       xproto.Timestamp(xgb.Get32(buf[12:])),
+		SourceId:   DeviceId(xgb.Get16(buf[16:])),
+		Mode:       buf[18],
+		Detail:     buf[19],
+		Root:       xproto.Window(xgb.Get32(buf[20:])),
+		Event:      xproto.Window(xgb.Get32(buf[24:])),
+		Child:      xproto.Window(xgb.Get32(buf[28:])),
+		RootX:      Fp1616(xgb.Get32(buf[32:])),
+		RootY:      Fp1616(xgb.Get32(buf[36:])),
+		EventX:     Fp1616(xgb.Get32(buf[40:])),
+		EventY:     Fp1616(xgb.Get32(buf[44:])),
+		SameScreen: buf[48] == 1,
+		Focus:      buf[49] == 1,
+		Mods:       modifierInfoRead(buf[52:]),
+		Group:      groupInfoRead(buf[68:]),
+		Buttons:    readMask(buf[72:], int(xgb.Get16(buf[50:]))),
+		buf:        buf,
+	}
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v EnterEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the EnterEvent event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v EnterEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of EnterEvent.
+func (v EnterEvent) String() string {
+	fieldVals := make([]string, 0, 13)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeviceId: %d", v.DeviceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Time: %d", v.Time))
+	fieldVals = append(fieldVals, xgb.Sprintf("SourceId: %d", v.SourceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Mode: %d", v.Mode))
+	fieldVals = append(fieldVals, xgb.Sprintf("Detail: %d", v.Detail))
+	fieldVals = append(fieldVals, xgb.Sprintf("Root: %d", v.Root))
+	fieldVals = append(fieldVals, xgb.Sprintf("Event: %d", v.Event))
+	fieldVals = append(fieldVals, xgb.Sprintf("Child: %d", v.Child))
+	fieldVals = append(fieldVals, xgb.Sprintf("EventX: %g",
+		v.EventX.Float64()))
+	fieldVals = append(fieldVals, xgb.Sprintf("EventY: %g",
+		v.EventY.Float64()))
+	fieldVals = append(fieldVals, xgb.Sprintf("SameScreen: %t",
+		v.SameScreen))
+	fieldVals = append(fieldVals, xgb.Sprintf("Focus: %t", v.Focus))
+	return eventString(v.EventType, fieldVals)
+}
+
+// Flags of HierarchyEvent and HierarchyInfo.
+const (
+	HierarchyMasterAdded    = 1 << 0
+	HierarchyMasterRemoved  = 1 << 1
+	HierarchySlaveAdded     = 1 << 2
+	HierarchySlaveRemoved   = 1 << 3
+	HierarchySlaveAttached  = 1 << 4
+	HierarchySlaveDetached  = 1 << 5
+	HierarchyDeviceEnabled  = 1 << 6
+	HierarchyDeviceDisabled = 1 << 7
+)
+
+// HierarchyInfo describes how a device was affected by a change of the
+// device hierarchy.
+type HierarchyInfo struct {
+	DeviceId   DeviceId
+	Attachment DeviceId
+	Use        byte // one of the DeviceType* constants
+	Enabled    bool
+	Flags      uint32 // the Hierarchy* flags that apply to this device
+}
+
+// HierarchyEvent is sent for the HierarchyChanged event type.
+type HierarchyEvent struct {
+	Sequence  uint16
+	EventType uint16
+	DeviceId  DeviceId
+	Time      xproto.Timestamp
+	Flags     uint32 // all the Hierarchy* flags of Infos
+	Infos     []HierarchyInfo
+
+	buf []byte
+}
+
+// HierarchyEventNew constructs a HierarchyEvent value that implements
+// xgb.Event from a byte slice.
+func HierarchyEventNew(buf []byte) xgb.Event {
+	v := HierarchyEvent{
+		Sequence:  xgb.Get16(buf[2:]),
+		EventType: xgb.Get16(buf[8:]),
+		DeviceId:  DeviceId(xgb.Get16(buf[10:])),
+		Time:      xproto.Timestamp(xgb.Get32(buf[12:])),
+		Flags:     xgb.Get32(buf[16:]),
+		Infos:     make([]HierarchyInfo, xgb.Get16(buf[20:])),
+		buf:       buf,
+	}
+	for i := range v.Infos {
+		b := 32 + i*12
+		v.Infos[i] = HierarchyInfo{
+			DeviceId:   DeviceId(xgb.Get16(buf[b:])),
+			Attachment: DeviceId(xgb.Get16(buf[b+2:])),
+			Use:        buf[b+4],
+			Enabled:    buf[b+5] == 1,
+			Flags:      xgb.Get32(buf[b+8:]),
+		}
+	}
+	return v
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v HierarchyEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the HierarchyEvent event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v HierarchyEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of HierarchyEvent.
+func (v HierarchyEvent) String() string {
+	fieldVals := make([]string, 0, 5)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeviceId: %d", v.DeviceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Time: %d", v.Time))
+	fieldVals = append(fieldVals, xgb.Sprintf("Flags: %d", v.Flags))
+	fieldVals = append(fieldVals, xgb.Sprintf("len(Infos): %d",
+		len(v.Infos)))
+	return eventString(v.EventType, fieldVals)
+}
+
+// Device change reasons, as in DeviceChangedEvent.Reason.
+const (
+	SlaveSwitch  = 1
+	DeviceChange = 2
+)
+
+// DeviceChangedEvent is sent for the DeviceChanged event type, when the
+// classes of a device change (e.g., because a master device now sends the
+// events of another slave device).
+type DeviceChangedEvent struct {
+	Sequence  uint16
+	EventType uint16
+	DeviceId  DeviceId
+	Time      xproto.Timestamp
+	SourceId  DeviceId
+	Reason    byte // SlaveSwitch or DeviceChange
+	Classes   []DeviceClass
+
+	buf []byte
+}
+
+// DeviceChangedEventNew constructs a DeviceChangedEvent value that
+// implements xgb.Event from a byte slice.
+func DeviceChangedEventNew(buf []byte) xgb.Event {
+	v := DeviceChangedEvent{
+		Sequence:  xgb.Get16(buf[2:]),
+		EventType: xgb.Get16(buf[8:]),
+		DeviceId:  DeviceId(xgb.Get16(buf[10:])),
+		Time:      xproto.Timestamp(xgb.Get32(buf[12:])),
+		Classes:   make([]DeviceClass, xgb.Get16(buf[16:])),
+		SourceId:  DeviceId(xgb.Get16(buf[18:])),
+		Reason:    buf[20],
+		buf:       buf,
+	}
+
+	b := 32
+	for i := range v.Classes {
+		b += deviceClassRead(buf[b:], &v.Classes[i])
+	}
+	return v
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v DeviceChangedEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the DeviceChangedEvent
+// event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v DeviceChangedEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of DeviceChangedEvent.
+func (v DeviceChangedEvent) String() string {
+	fieldVals := make([]string, 0, 6)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeviceId: %d", v.DeviceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Time: %d", v.Time))
+	fieldVals = append(fieldVals, xgb.Sprintf("SourceId: %d", v.SourceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Reason: %d", v.Reason))
+	fieldVals = append(fieldVals, xgb.Sprintf("len(Classes): %d",
+		len(v.Classes)))
+	return eventString(v.EventType, fieldVals)
+}
+
+// Property changes, as in PropertyEvent.What.
+const (
+	PropertyDeleted  = 0
+	PropertyCreated  = 1
+	PropertyModified = 2
+)
+
+// PropertyEvent is sent for the PropertyChanged event type, when a property
+// of a device changes.
+type PropertyEvent struct {
+	Sequence  uint16
+	EventType uint16
+	DeviceId  DeviceId
+	Time      xproto.Timestamp
+	Property  xproto.Atom
+	What      byte // one of the Property* constants
+
+	buf []byte
+}
+
+// PropertyEventNew constructs a PropertyEvent value that implements
+// xgb.Event from a byte slice.
+func PropertyEventNew(buf []byte) xgb.Event {
+	return PropertyEvent{
+		Sequence:  xgb.Get16(buf[2:]),
+		EventType: xgb.Get16(buf[8:]),
+		DeviceId:  DeviceId(xgb.Get16(buf[10:])),
+		Time:      xproto.Timestamp(xgb.Get32(buf[12:])),
+		Property:  xproto.Atom(xgb.Get32(buf[16:])),
+		What:      buf[20],
+		buf:       buf,
+	}
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v PropertyEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the PropertyEvent event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v PropertyEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of PropertyEvent.
+func (v PropertyEvent) String() string {
+	fieldVals := make([]string, 0, 5)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeviceId: %d", v.DeviceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Time: %d", v.Time))
+	fieldVals = append(fieldVals, xgb.Sprintf("Property: %d", v.Property))
+	fieldVals = append(fieldVals, xgb.Sprintf("What: %d", v.What))
+	return eventString(v.EventType, fieldVals)
+}
+
+// TouchOwnershipEvent is sent for the TouchOwnership event type, when
+// a client that didn't own a touch sequence becomes its owner.
+type TouchOwnershipEvent struct {
+	Sequence  uint16
+	EventType uint16
+	DeviceId  DeviceId
+	Time      xproto.Timestamp
+	TouchId   uint32
+	Root      xproto.Window
+	Event     xproto.Window
+	Child     xproto.Window
+	SourceId  DeviceId
+	Flags     uint32
+
+	buf []byte
+}
+
+// TouchOwnershipEventNew constructs a TouchOwnershipEvent value that
+// implements xgb.Event from a byte slice.
+func TouchOwnershipEventNew(buf []byte) xgb.Event {
+	return TouchOwnershipEvent{
+		Sequence:  xgb.Get16(buf[2:]),
+		EventType: xgb.Get16(buf[8:]),
+		DeviceId:  DeviceId(xgb.Get16(buf[10:])),
+		Time:      xproto.Timestamp(xgb.Get32(buf[12:])),
+		TouchId:   xgb.Get32(buf[16:]),
+		Root:      xproto.Window(xgb.Get32(buf[20:])),
+		Event:     xproto.Window(xgb.Get32(buf[24:])),
+		Child:     xproto.Window(xgb.Get32(buf[28:])),
+		SourceId:  DeviceId(xgb.Get16(buf[32:])),
+		Flags:     xgb.Get32(buf[36:]),
+		buf:       buf,
+	}
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v TouchOwnershipEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the TouchOwnershipEvent
+// event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v TouchOwnershipEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of TouchOwnershipEvent.
+func (v TouchOwnershipEvent) String() string {
+	fieldVals := make([]string, 0, 9)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeviceId: %d", v.DeviceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Time: %d", v.Time))
+	fieldVals = append(fieldVals, xgb.Sprintf("TouchId: %d", v.TouchId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Root: %d", v.Root))
+	fieldVals = append(fieldVals, xgb.Sprintf("Event: %d", v.Event))
+	fieldVals = append(fieldVals, xgb.Sprintf("Child: %d", v.Child))
+	fieldVals = append(fieldVals, xgb.Sprintf("SourceId: %d", v.SourceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Flags: %d", v.Flags))
+	return eventString(v.EventType, fieldVals)
+}
+
+// Flags of BarrierEvent.
+const (
+	BarrierPointerReleased = 1 << 0
+	BarrierDeviceIsGrabbed = 1 << 1
+)
+
+// BarrierEvent is sent for the BarrierHit and BarrierLeave event types, when
+// the pointer is held or released by an XFIXES pointer barrier.
+type BarrierEvent struct {
+	Sequence  uint16
+	EventType uint16
+	DeviceId  DeviceId
+	Time      xproto.Timestamp
+	EventId   uint32
+	Root      xproto.Window
+	Event     xproto.Window
+	Barrier   uint32 // an XFIXES pointer barrier
+	Dtime     uint32 // milliseconds since the last event for the barrier
+	Flags     uint32
+	SourceId  DeviceId
+	RootX     Fp1616
+	RootY     Fp1616
+	Dx        Fp3232
+	Dy        Fp3232
+
+	buf []byte
+}
+
+// BarrierEventNew constructs a BarrierEvent value that implements xgb.Event
+// from a byte slice.
+func BarrierEventNew(buf []byte) xgb.Event {
+	return BarrierEvent{
+		Sequence:  xgb.Get16(buf[2:]),
+		EventType: xgb.Get16(buf[8:]),
+		DeviceId:  DeviceId(xgb.Get16(buf[10:])),
+		Time:      xproto.Timestamp(xgb.Get32(buf[12:])),
+		EventId:   xgb.Get32(buf[16:]),
+		Root:      xproto.Window(xgb.Get32(buf[20:])),
+		Event:     xproto.Window(xgb.Get32(buf[24:])),
+		Barrier:   xgb.Get32(buf[28:]),
+		Dtime:     xgb.Get32(buf[32:]),
+		Flags:     xgb.Get32(buf[36:]),
+		SourceId:  DeviceId(xgb.Get16(buf[40:])),
+		RootX:     Fp1616(xgb.Get32(buf[44:])),
+		RootY:     Fp1616(xgb.Get32(buf[48:])),
+		Dx:        fp3232Read(buf[52:]),
+		Dy:        fp3232Read(buf[60:]),
+		buf:       buf,
+	}
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v BarrierEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the BarrierEvent event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v BarrierEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of BarrierEvent.
+func (v BarrierEvent) String() string {
+	fieldVals := make([]string, 0, 10)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeviceId: %d", v.DeviceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Time: %d", v.Time))
+	fieldVals = append(fieldVals, xgb.Sprintf("EventId: %d", v.EventId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Root: %d", v.Root))
+	fieldVals = append(fieldVals, xgb.Sprintf("Event: %d", v.Event))
+	fieldVals = append(fieldVals, xgb.Sprintf("Barrier: %d", v.Barrier))
+	fieldVals = append(fieldVals, xgb.Sprintf("Flags: %d", v.Flags))
+	fieldVals = append(fieldVals, xgb.Sprintf("Dx: %g", v.Dx.Float64()))
+	fieldVals = append(fieldVals, xgb.Sprintf("Dy: %g", v.Dy.Float64()))
+	return eventString(v.EventType, fieldVals)
+}
+
+// Flags of GesturePinchEvent and GestureSwipeEvent.
+const (
+	GestureCancelled = 1 << 0
+)
+
+// GesturePinchEvent is sent for the GesturePinchBegin, GesturePinchUpdate and
+// GesturePinchEnd event types.
+type GesturePinchEvent struct {
+	Sequence      uint16
+	EventType     uint16
+	DeviceId      DeviceId
+	Time          xproto.Timestamp
+	Detail        uint32 // the number of touches
+	Root          xproto.Window
+	Event         xproto.Window
+	Child         xproto.Window
+	RootX         Fp1616
+	RootY         Fp1616
+	EventX        Fp1616
+	EventY        Fp1616
+	DeltaX        Fp1616
+	DeltaY        Fp1616
+	DeltaUnaccelX Fp1616
+	DeltaUnaccelY Fp1616
+	Scale         Fp1616
+	DeltaAngle    Fp1616
+	SourceId      DeviceId
+	Mods          ModifierInfo
+	Group         GroupInfo
+	Flags         uint32
+
+	buf []byte
+}
+
+// GesturePinchEventNew constructs a GesturePinchEvent value that implements
+// xgb.Event from a byte slice.
+func GesturePinchEventNew(buf []byte) xgb.Event {
+	return GesturePinchEvent{
+		Sequence:      xgb.Get16(buf[2:]),
+		EventType:     xgb.Get16(buf[8:]),
+		DeviceId:      DeviceId(xgb.Get16(buf[10:])),
+		Time:          xproto.Timestamp(xgb.Get32(buf[12:])),
+		Detail:        xgb.Get32(buf[16:]),
+		Root:          xproto.Window(xgb.Get32(buf[20:])),
+		Event:         xproto.Window(xgb.Get32(buf[24:])),
+		Child:         xproto.Window(xgb.Get32(buf[28:])),
+		RootX:         Fp1616(xgb.Get32(buf[32:])),
+		RootY:         Fp1616(xgb.Get32(buf[36:])),
+		EventX:        Fp1616(xgb.Get32(buf[40:])),
+		EventY:        Fp1616(xgb.Get32(buf[44:])),
+		DeltaX:        Fp1616(xgb.Get32(buf[48:])),
+		DeltaY:        Fp1616(xgb.Get32(buf[52:])),
+		DeltaUnaccelX: Fp1616(xgb.Get32(buf[56:])),
+		DeltaUnaccelY: Fp1616(xgb.Get32(buf[60:])),
+		Scale:         Fp1616(xgb.Get32(buf[64:])),
+		DeltaAngle:    Fp1616(xgb.Get32(buf[68:])),
+		SourceId:      DeviceId(xgb.Get16(buf[72:])),
+		Mods:          modifierInfoRead(buf[76:]),
+		Group:         groupInfoRead(buf[92:]),
+		Flags:         xgb.Get32(buf[96:]),
+		buf:           buf,
+	}
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v GesturePinchEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the GesturePinchEvent
+// event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v GesturePinchEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of GesturePinchEvent.
+func (v GesturePinchEvent) String() string {
+	fieldVals := make([]string, 0, 9)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeviceId: %d", v.DeviceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Time: %d", v.Time))
+	fieldVals = append(fieldVals, xgb.Sprintf("Detail: %d", v.Detail))
+	fieldVals = append(fieldVals, xgb.Sprintf("Event: %d", v.Event))
+	fieldVals = append(fieldVals, xgb.Sprintf("Scale: %g",
+		v.Scale.Float64()))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeltaAngle: %g",
+		v.DeltaAngle.Float64()))
+	fieldVals = append(fieldVals, xgb.Sprintf("SourceId: %d", v.SourceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Flags: %d", v.Flags))
+	return eventString(v.EventType, fieldVals)
+}
+
+// GestureSwipeEvent is sent for the GestureSwipeBegin, GestureSwipeUpdate and
+// GestureSwipeEnd event types.
+type GestureSwipeEvent struct {
+	Sequence      uint16
+	EventType     uint16
+	DeviceId      DeviceId
+	Time          xproto.Timestamp
+	Detail        uint32 // the number of touches
+	Root          xproto.Window
+	Event         xproto.Window
+	Child         xproto.Window
+	RootX         Fp1616
+	RootY         Fp1616
+	EventX        Fp1616
+	EventY        Fp1616
+	DeltaX        Fp1616
+	DeltaY        Fp1616
+	DeltaUnaccelX Fp1616
+	DeltaUnaccelY Fp1616
+	SourceId      DeviceId
+	Mods          ModifierInfo
+	Group         GroupInfo
+	Flags         uint32
+
+	buf []byte
+}
+
+// GestureSwipeEventNew constructs a GestureSwipeEvent value that implements
+// xgb.Event from a byte slice.
+func GestureSwipeEventNew(buf []byte) xgb.Event {
+	return GestureSwipeEvent{
+		Sequence:      xgb.Get16(buf[2:]),
+		EventType:     xgb.Get16(buf[8:]),
+		DeviceId:      DeviceId(xgb.Get16(buf[10:])),
+		Time:          xproto.Timestamp(xgb.Get32(buf[12:])),
+		Detail:        xgb.Get32(buf[16:]),
+		Root:          xproto.Window(xgb.Get32(buf[20:])),
+		Event:         xproto.Window(xgb.Get32(buf[24:])),
+		Child:         xproto.Window(xgb.Get32(buf[28:])),
+		RootX:         Fp1616(xgb.Get32(buf[32:])),
+		RootY:         Fp1616(xgb.Get32(buf[36:])),
+		EventX:        Fp1616(xgb.Get32(buf[40:])),
+		EventY:        Fp1616(xgb.Get32(buf[44:])),
+		DeltaX:        Fp1616(xgb.Get32(buf[48:])),
+		DeltaY:        Fp1616(xgb.Get32(buf[52:])),
+		DeltaUnaccelX: Fp1616(xgb.Get32(buf[56:])),
+		DeltaUnaccelY: Fp1616(xgb.Get32(buf[60:])),
+		SourceId:      DeviceId(xgb.Get16(buf[64:])),
+		Mods:          modifierInfoRead(buf[68:]),
+		Group:         groupInfoRead(buf[84:]),
+		Flags:         xgb.Get32(buf[88:]),
+		buf:           buf,
+	}
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v GestureSwipeEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the GestureSwipeEvent
+// event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v GestureSwipeEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of GestureSwipeEvent.
+func (v GestureSwipeEvent) String() string {
+	fieldVals := make([]string, 0, 9)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeviceId: %d", v.DeviceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Time: %d", v.Time))
+	fieldVals = append(fieldVals, xgb.Sprintf("Detail: %d", v.Detail))
+	fieldVals = append(fieldVals, xgb.Sprintf("Event: %d", v.Event))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeltaX: %g",
+		v.DeltaX.Float64()))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeltaY: %g",
+		v.DeltaY.Float64()))
+	fieldVals = append(fieldVals, xgb.Sprintf("SourceId: %d", v.SourceId))
+	fieldVals = append(fieldVals, xgb.Sprintf("Flags: %d", v.Flags))
+	return eventString(v.EventType, fieldVals)
+}
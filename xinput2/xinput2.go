@@ -0,0 +1,1249 @@
+// Package xinput2 is the X client API for version 2 of the X Input extension
+// (XI2), which adds multiple pointers, touch, raw and gesture input to X.
+//
+// XI2 is a part of the XInputExtension extension (see the xinput package),
+// but its events are X Generic Events, which xgbgen doesn't know how to
+// generate. So unlike the other extension packages, this package was written
+// by hand from XI2proto.h. It follows the conventions of the generated
+// packages otherwise.
+//
+// Not every XI2 request is available yet: XIChangeHierarchy and the device
+// property requests (XIListProperties, XIChangeProperty, XIDeleteProperty and
+// XIGetProperty) are missing.
+package xinput2
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xinput"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// MajorVersion and MinorVersion are the version of XI2 implemented by this
+// package. They should be passed to QueryVersion.
+const (
+	MajorVersion = 2
+	MinorVersion = 4
+)
+
+// Init must be called before using XI2. It initializes the xinput package
+// (which provides the X Input errors), and registers the decoders for XI2
+// events with 'c'. Note that the X server only sends XI2 events to clients
+// that have announced support for XI2 with QueryVersion.
+//
+// Since the major opcode of the extension may change, Init must be called
+// again after reconnecting (i.e., in a hook given to xgb.Conn.OnReconnect).
+func Init(c *xgb.Conn) error {
+	if err := xinput.Init(c); err != nil {
+		return err
+	}
+
+	xgb.ExtLock.Lock()
+	major := c.Extensions["XInputExtension"]
+	xgb.ExtLock.Unlock()
+
+	for evtype, fun := range eventFuncs {
+		c.RegisterGenericEventHandler(major, evtype, fun)
+	}
+	return nil
+}
+
+// checkInit panics if Init hasn't been called. 'request' is the name of the
+// request about to be sent.
+func checkInit(c *xgb.Conn, request string) {
+	if _, ok := c.Extensions["XInputExtension"]; !ok {
+		panic("Cannot issue request '" + request + "' using the " +
+			"uninitialized extension 'XInputExtension'. " +
+			"xinput2.Init(connObj) must be called first.")
+	}
+}
+
+// requestHeader writes the header of the XI2 request with the given opcode
+// to the start of 'buf', which must be as long as the request.
+func requestHeader(c *xgb.Conn, buf []byte, opcode byte) {
+	buf[0] = c.Extensions["XInputExtension"]
+	buf[1] = opcode
+	xgb.Put16(buf[2:], uint16(len(buf)/4))
+}
+
+// Fp1616 is a signed 16.16 fixed point number.
+type Fp1616 int32
+
+// Float64 converts a 16.16 fixed point number to a float64.
+func (f Fp1616) Float64() float64 {
+	return float64(f) / (1 << 16)
+}
+
+// Fp3232 is a signed 32.32 fixed point number.
+type Fp3232 struct {
+	Integral int32
+	Frac     uint32
+}
+
+// Float64 converts a 32.32 fixed point number to a float64.
+func (f Fp3232) Float64() float64 {
+	return float64(f.Integral) + float64(f.Frac)/(1<<32)
+}
+
+func fp3232Read(buf []byte) Fp3232 {
+	return Fp3232{int32(xgb.Get32(buf)), xgb.Get32(buf[4:])}
+}
+
+// DeviceId identifies an input device.
+type DeviceId uint16
+
+// Special device ids that can be used to address more than one device.
+const (
+	DeviceAll       DeviceId = 0
+	DeviceAllMaster DeviceId = 1
+)
+
+// Device uses, as in DeviceInfo.Use and HierarchyInfo.Use.
+const (
+	DeviceTypeMasterPointer  = 1
+	DeviceTypeMasterKeyboard = 2
+	DeviceTypeSlavePointer   = 3
+	DeviceTypeSlaveKeyboard  = 4
+	DeviceTypeFloatingSlave  = 5
+)
+
+// ModifierInfo describes the state of the XKB modifiers.
+type ModifierInfo struct {
+	Base      uint32
+	Latched   uint32
+	Locked    uint32
+	Effective uint32
+}
+
+func modifierInfoRead(buf []byte) ModifierInfo {
+	return ModifierInfo{xgb.Get32(buf), xgb.Get32(buf[4:]),
+		xgb.Get32(buf[8:]), xgb.Get32(buf[12:])}
+}
+
+// GroupInfo describes the state of the XKB group.
+type GroupInfo struct {
+	Base      byte
+	Latched   byte
+	Locked    byte
+	Effective byte
+}
+
+func groupInfoRead(buf []byte) GroupInfo {
+	return GroupInfo{buf[0], buf[1], buf[2], buf[3]}
+}
+
+// readMask reads 'n' 32 bit words of a bit mask from 'buf'.
+func readMask(buf []byte, n int) []uint32 {
+	mask := make([]uint32, n)
+	for i := range mask {
+		mask[i] = xgb.Get32(buf[i*4:])
+	}
+	return mask
+}
+
+// putMask writes 'mask' to 'buf' and returns the number of bytes written.
+func putMask(buf []byte, mask []uint32) int {
+	for i, word := range mask {
+		xgb.Put32(buf[i*4:], word)
+	}
+	return len(mask) * 4
+}
+
+// MaskIsSet returns whether bit 'bit' is set in 'mask', where 'mask' is an
+// event mask, a button mask or a valuator mask.
+func MaskIsSet(mask []uint32, bit int) bool {
+	return bit/32 < len(mask) && mask[bit/32]&(1<<uint(bit%32)) != 0
+}
+
+// EventMask is the set of events selected for a device. Bit N of Mask is set
+// if events of type N (e.g., Motion) are selected.
+type EventMask struct {
+	DeviceId DeviceId
+	Mask     []uint32
+}
+
+// NewEventMask returns an event mask selecting events of the given types
+// (e.g., KeyPress, Motion) for device 'id'.
+func NewEventMask(id DeviceId, evtypes ...int) EventMask {
+	mask := EventMask{DeviceId: id}
+	for _, evtype := range evtypes {
+		for len(mask.Mask) <= evtype/32 {
+			mask.Mask = append(mask.Mask, 0)
+		}
+		mask.Mask[evtype/32] |= 1 << uint(evtype%32)
+	}
+	return mask
+}
+
+// QueryVersionCookie is a cookie used only for QueryVersion requests.
+type QueryVersionCookie struct {
+	*xgb.Cookie
+}
+
+// QueryVersion sends a checked request.
+// If an error occurs, it will be returned with the reply by calling
+// QueryVersionCookie.Reply()
+func QueryVersion(c *xgb.Conn, MajorVersion uint16,
+	MinorVersion uint16) QueryVersionCookie {
+
+	checkInit(c, "QueryVersion")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(queryVersionRequest(c, MajorVersion, MinorVersion), cookie)
+	return QueryVersionCookie{cookie}
+}
+
+// QueryVersionUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func QueryVersionUnchecked(c *xgb.Conn, MajorVersion uint16,
+	MinorVersion uint16) QueryVersionCookie {
+
+	checkInit(c, "QueryVersion")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(queryVersionRequest(c, MajorVersion, MinorVersion), cookie)
+	return QueryVersionCookie{cookie}
+}
+
+// QueryVersionReply represents the data returned from a QueryVersion request.
+type QueryVersionReply struct {
+	Sequence     uint16 // sequence number of the request for this reply
+	Length       uint32 // number of bytes in this reply
+	MajorVersion uint16
+	MinorVersion uint16
+}
+
+// Reply blocks and returns the reply data for a QueryVersion request.
+func (cook QueryVersionCookie) Reply() (*QueryVersionReply, error) {
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return queryVersionReply(buf), nil
+}
+
+// queryVersionReply reads a byte slice into a QueryVersionReply value.
+func queryVersionReply(buf []byte) *QueryVersionReply {
+	return &QueryVersionReply{
+		Sequence:     xgb.Get16(buf[2:]),
+		Length:       xgb.Get32(buf[4:]),
+		MajorVersion: xgb.Get16(buf[8:]),
+		MinorVersion: xgb.Get16(buf[10:]),
+	}
+}
+
+// queryVersionRequest writes a QueryVersion request to a byte slice.
+func queryVersionRequest(c *xgb.Conn, MajorVersion uint16,
+	MinorVersion uint16) []byte {
+
+	buf := make([]byte, 8)
+	requestHeader(c, buf, 47)
+	xgb.Put16(buf[4:], MajorVersion)
+	xgb.Put16(buf[6:], MinorVersion)
+	return buf
+}
+
+// SelectEventsCookie is a cookie used only for SelectEvents requests.
+type SelectEventsCookie struct {
+	*xgb.Cookie
+}
+
+// SelectEvents sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func SelectEvents(c *xgb.Conn, Window xproto.Window,
+	Masks []EventMask) SelectEventsCookie {
+
+	checkInit(c, "SelectEvents")
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(selectEventsRequest(c, Window, Masks), cookie)
+	return SelectEventsCookie{cookie}
+}
+
+// SelectEventsChecked sends a checked request.
+// If an error occurs, it can be retrieved using SelectEventsCookie.Check()
+func SelectEventsChecked(c *xgb.Conn, Window xproto.Window,
+	Masks []EventMask) SelectEventsCookie {
+
+	checkInit(c, "SelectEvents")
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(selectEventsRequest(c, Window, Masks), cookie)
+	return SelectEventsCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook SelectEventsCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// selectEventsRequest writes a SelectEvents request to a byte slice.
+func selectEventsRequest(c *xgb.Conn, Window xproto.Window,
+	Masks []EventMask) []byte {
+
+	size := 12
+	for _, mask := range Masks {
+		size += 4 + len(mask.Mask)*4
+	}
+	buf := make([]byte, size)
+	requestHeader(c, buf, 46)
+	xgb.Put32(buf[4:], uint32(Window))
+	xgb.Put16(buf[8:], uint16(len(Masks)))
+
+	b := 12
+	for _, mask := range Masks {
+		xgb.Put16(buf[b:], uint16(mask.DeviceId))
+		xgb.Put16(buf[b+2:], uint16(len(mask.Mask)))
+		b += 4
+		b += putMask(buf[b:], mask.Mask)
+	}
+	return buf
+}
+
+// GetSelectedEventsCookie is a cookie used only for GetSelectedEvents
+// requests.
+type GetSelectedEventsCookie struct {
+	*xgb.Cookie
+}
+
+// GetSelectedEvents sends a checked request.
+// If an error occurs, it will be returned with the reply by calling
+// GetSelectedEventsCookie.Reply()
+func GetSelectedEvents(c *xgb.Conn,
+	Window xproto.Window) GetSelectedEventsCookie {
+
+	checkInit(c, "GetSelectedEvents")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(getSelectedEventsRequest(c, Window), cookie)
+	return GetSelectedEventsCookie{cookie}
+}
+
+// GetSelectedEventsUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func GetSelectedEventsUnchecked(c *xgb.Conn,
+	Window xproto.Window) GetSelectedEventsCookie {
+
+	checkInit(c, "GetSelectedEvents")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(getSelectedEventsRequest(c, Window), cookie)
+	return GetSelectedEventsCookie{cookie}
+}
+
+// GetSelectedEventsReply represents the data returned from
+// a GetSelectedEvents request.
+type GetSelectedEventsReply struct {
+	Sequence uint16 // sequence number of the request for this reply
+	Length   uint32 // number of bytes in this reply
+	Masks    []EventMask
+}
+
+// Reply blocks and returns the reply data for a GetSelectedEvents request.
+func (cook GetSelectedEventsCookie) Reply() (*GetSelectedEventsReply, error) {
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return getSelectedEventsReply(buf), nil
+}
+
+// getSelectedEventsReply reads a byte slice into a GetSelectedEventsReply
+// value.
+func getSelectedEventsReply(buf []byte) *GetSelectedEventsReply {
+	v := &GetSelectedEventsReply{
+		Sequence: xgb.Get16(buf[2:]),
+		Length:   xgb.Get32(buf[4:]),
+		Masks:    make([]EventMask, xgb.Get16(buf[8:])),
+	}
+
+	b := 32
+	for i := range v.Masks {
+		n := int(xgb.Get16(buf[b+2:]))
+		v.Masks[i].DeviceId = DeviceId(xgb.Get16(buf[b:]))
+		v.Masks[i].Mask = readMask(buf[b+4:], n)
+		b += 4 + n*4
+	}
+	return v
+}
+
+// getSelectedEventsRequest writes a GetSelectedEvents request to a byte
+// slice.
+func getSelectedEventsRequest(c *xgb.Conn, Window xproto.Window) []byte {
+	buf := make([]byte, 8)
+	requestHeader(c, buf, 60)
+	xgb.Put32(buf[4:], uint32(Window))
+	return buf
+}
+
+// QueryPointerCookie is a cookie used only for QueryPointer requests.
+type QueryPointerCookie struct {
+	*xgb.Cookie
+}
+
+// QueryPointer sends a checked request.
+// If an error occurs, it will be returned with the reply by calling
+// QueryPointerCookie.Reply()
+func QueryPointer(c *xgb.Conn, Window xproto.Window,
+	Device DeviceId) QueryPointerCookie {
+
+	checkInit(c, "QueryPointer")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(queryPointerRequest(c, Window, Device), cookie)
+	return QueryPointerCookie{cookie}
+}
+
+// QueryPointerUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func QueryPointerUnchecked(c *xgb.Conn, Window xproto.Window,
+	Device DeviceId) QueryPointerCookie {
+
+	checkInit(c, "QueryPointer")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(queryPointerRequest(c, Window, Device), cookie)
+	return QueryPointerCookie{cookie}
+}
+
+// QueryPointerReply represents the data returned from a QueryPointer request.
+type QueryPointerReply struct {
+	Sequence   uint16 // sequence number of the request for this reply
+	Length     uint32 // number of bytes in this reply
+	Root       xproto.Window
+	Child      xproto.Window
+	RootX      Fp1616
+	RootY      Fp1616
+	WinX       Fp1616
+	WinY       Fp1616
+	SameScreen bool
+	Mods       ModifierInfo
+	Group      GroupInfo
+	Buttons    []uint32 // a bit mask of the buttons that are pressed
+}
+
+// Reply blocks and returns the reply data for a QueryPointer request.
+func (cook QueryPointerCookie) Reply() (*QueryPointerReply, error) {
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return queryPointerReply(buf), nil
+}
+
+// queryPointerReply reads a byte slice into a QueryPointerReply value.
+func queryPointerReply(buf []byte) *QueryPointerReply {
+	return &QueryPointerReply{
+		Sequence:   xgb.Get16(buf[2:]),
+		Length:     xgb.Get32(buf[4:]),
+		Root:       xproto.Window(xgb.Get32(buf[8:])),
+		Child:      xproto.Window(xgb.Get32(buf[12:])),
+		RootX:      Fp1616(xgb.Get32(buf[16:])),
+		RootY:      Fp1616(xgb.Get32(buf[20:])),
+		WinX:       Fp1616(xgb.Get32(buf[24:])),
+		WinY:       Fp1616(xgb.Get32(buf[28:])),
+		SameScreen: buf[32] == 1,
+		Mods:       modifierInfoRead(buf[36:]),
+		Group:      groupInfoRead(buf[52:]),
+		Buttons:    readMask(buf[56:], int(xgb.Get16(buf[34:]))),
+	}
+}
+
+// queryPointerRequest writes a QueryPointer request to a byte slice.
+func queryPointerRequest(c *xgb.Conn, Window xproto.Window,
+	Device DeviceId) []byte {
+
+	buf := make([]byte, 12)
+	requestHeader(c, buf, 40)
+	xgb.Put32(buf[4:], uint32(Window))
+	xgb.Put16(buf[8:], uint16(Device))
+	return buf
+}
+
+// WarpPointerCookie is a cookie used only for WarpPointer requests.
+type WarpPointerCookie struct {
+	*xgb.Cookie
+}
+
+// WarpPointer sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func WarpPointer(c *xgb.Conn, SrcWin xproto.Window, DstWin xproto.Window,
+	SrcX Fp1616, SrcY Fp1616, SrcWidth uint16, SrcHeight uint16,
+	DstX Fp1616, DstY Fp1616, Device DeviceId) WarpPointerCookie {
+
+	checkInit(c, "WarpPointer")
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(warpPointerRequest(c, SrcWin, DstWin, SrcX, SrcY,
+		SrcWidth, SrcHeight, DstX, DstY, Device), cookie)
+	return WarpPointerCookie{cookie}
+}
+
+// WarpPointerChecked sends a checked request.
+// If an error occurs, it can be retrieved using WarpPointerCookie.Check()
+func WarpPointerChecked(c *xgb.Conn, SrcWin xproto.Window,
+	DstWin xproto.Window, SrcX Fp1616, SrcY Fp1616, SrcWidth uint16,
+	SrcHeight uint16, DstX Fp1616, DstY Fp1616,
+	Device DeviceId) WarpPointerCookie {
+
+	checkInit(c, "WarpPointer")
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(warpPointerRequest(c, SrcWin, DstWin, SrcX, SrcY,
+		SrcWidth, SrcHeight, DstX, DstY, Device), cookie)
+	return WarpPointerCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook WarpPointerCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// warpPointerRequest writes a WarpPointer request to a byte slice.
+func warpPointerRequest(c *xgb.Conn, SrcWin xproto.Window,
+	DstWin xproto.Window, SrcX Fp1616, SrcY Fp1616, SrcWidth uint16,
+	SrcHeight uint16, DstX Fp1616, DstY Fp1616, Device DeviceId) []byte {
+
+	buf := make([]byte, 36)
+	requestHeader(c, buf, 41)
+	xgb.Put32(buf[4:], uint32(SrcWin))
+	xgb.Put32(buf[8:], uint32(DstWin))
+	xgb.Put32(buf[12:], uint32(SrcX))
+	xgb.Put32(buf[16:], uint32(SrcY))
+	xgb.Put16(buf[20:], SrcWidth)
+	xgb.Put16(buf[22:], SrcHeight)
+	xgb.Put32(buf[24:], uint32(DstX))
+	xgb.Put32(buf[28:], uint32(DstY))
+	xgb.Put16(buf[32:], uint16(Device))
+	return buf
+}
+
+// ChangeCursorCookie is a cookie used only for ChangeCursor requests.
+type ChangeCursorCookie struct {
+	*xgb.Cookie
+}
+
+// ChangeCursor sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func ChangeCursor(c *xgb.Conn, Window xproto.Window, Cursor xproto.Cursor,
+	Device DeviceId) ChangeCursorCookie {
+
+	checkInit(c, "ChangeCursor")
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(changeCursorRequest(c, Window, Cursor, Device), cookie)
+	return ChangeCursorCookie{cookie}
+}
+
+// ChangeCursorChecked sends a checked request.
+// If an error occurs, it can be retrieved using ChangeCursorCookie.Check()
+func ChangeCursorChecked(c *xgb.Conn, Window xproto.Window,
+	Cursor xproto.Cursor, Device DeviceId) ChangeCursorCookie {
+
+	checkInit(c, "ChangeCursor")
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(changeCursorRequest(c, Window, Cursor, Device), cookie)
+	return ChangeCursorCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook ChangeCursorCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// changeCursorRequest writes a ChangeCursor request to a byte slice.
+func changeCursorRequest(c *xgb.Conn, Window xproto.Window,
+	Cursor xproto.Cursor, Device DeviceId) []byte {
+
+	buf := make([]byte, 16)
+	requestHeader(c, buf, 42)
+	xgb.Put32(buf[4:], uint32(Window))
+	xgb.Put32(buf[8:], uint32(Cursor))
+	xgb.Put16(buf[12:], uint16(Device))
+	return buf
+}
+
+// SetClientPointerCookie is a cookie used only for SetClientPointer requests.
+type SetClientPointerCookie struct {
+	*xgb.Cookie
+}
+
+// SetClientPointer sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func SetClientPointer(c *xgb.Conn, Window xproto.Window,
+	Device DeviceId) SetClientPointerCookie {
+
+	checkInit(c, "SetClientPointer")
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(setClientPointerRequest(c, Window, Device), cookie)
+	return SetClientPointerCookie{cookie}
+}
+
+// SetClientPointerChecked sends a checked request.
+// If an error occurs, it can be retrieved using
+// SetClientPointerCookie.Check()
+func SetClientPointerChecked(c *xgb.Conn, Window xproto.Window,
+	Device DeviceId) SetClientPointerCookie {
+
+	checkInit(c, "SetClientPointer")
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(setClientPointerRequest(c, Window, Device), cookie)
+	return SetClientPointerCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook SetClientPointerCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// setClientPointerRequest writes a SetClientPointer request to a byte slice.
+func setClientPointerRequest(c *xgb.Conn, Window xproto.Window,
+	Device DeviceId) []byte {
+
+	buf := make([]byte, 12)
+	requestHeader(c, buf, 44)
+	xgb.Put32(buf[4:], uint32(Window))
+	xgb.Put16(buf[8:], uint16(Device))
+	return buf
+}
+
+// GetClientPointerCookie is a cookie used only for GetClientPointer requests.
+type GetClientPointerCookie struct {
+	*xgb.Cookie
+}
+
+// GetClientPointer sends a checked request.
+// If an error occurs, it will be returned with the reply by calling
+// GetClientPointerCookie.Reply()
+func GetClientPointer(c *xgb.Conn,
+	Window xproto.Window) GetClientPointerCookie {
+
+	checkInit(c, "GetClientPointer")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(getClientPointerRequest(c, Window), cookie)
+	return GetClientPointerCookie{cookie}
+}
+
+// GetClientPointerUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func GetClientPointerUnchecked(c *xgb.Conn,
+	Window xproto.Window) GetClientPointerCookie {
+
+	checkInit(c, "GetClientPointer")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(getClientPointerRequest(c, Window), cookie)
+	return GetClientPointerCookie{cookie}
+}
+
+// GetClientPointerReply represents the data returned from a GetClientPointer
+// request.
+type GetClientPointerReply struct {
+	Sequence uint16 // sequence number of the request for this reply
+	Length   uint32 // number of bytes in this reply
+	Set      bool
+	Device   DeviceId
+}
+
+// Reply blocks and returns the reply data for a GetClientPointer request.
+func (cook GetClientPointerCookie) Reply() (*GetClientPointerReply, error) {
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return getClientPointerReply(buf), nil
+}
+
+// getClientPointerReply reads a byte slice into a GetClientPointerReply
+// value.
+func getClientPointerReply(buf []byte) *GetClientPointerReply {
+	return &GetClientPointerReply{
+		Sequence: xgb.Get16(buf[2:]),
+		Length:   xgb.Get32(buf[4:]),
+		Set:      buf[8] == 1,
+		Device:   DeviceId(xgb.Get16(buf[10:])),
+	}
+}
+
+// getClientPointerRequest writes a GetClientPointer request to a byte slice.
+func getClientPointerRequest(c *xgb.Conn, Window xproto.Window) []byte {
+	buf := make([]byte, 8)
+	requestHeader(c, buf, 45)
+	xgb.Put32(buf[4:], uint32(Window))
+	return buf
+}
+
+// SetFocusCookie is a cookie used only for SetFocus requests.
+type SetFocusCookie struct {
+	*xgb.Cookie
+}
+
+// SetFocus sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func SetFocus(c *xgb.Conn, Window xproto.Window, Time xproto.Timestamp,
+	Device DeviceId) SetFocusCookie {
+
+	checkInit(c, "SetFocus")
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(setFocusRequest(c, Window, Time, Device), cookie)
+	return SetFocusCookie{cookie}
+}
+
+// SetFocusChecked sends a checked request.
+// If an error occurs, it can be retrieved using SetFocusCookie.Check()
+func SetFocusChecked(c *xgb.Conn, Window xproto.Window,
+	Time xproto.Timestamp, Device DeviceId) SetFocusCookie {
+
+	checkInit(c, "SetFocus")
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(setFocusRequest(c, Window, Time, Device), cookie)
+	return SetFocusCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook SetFocusCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// setFocusRequest writes a SetFocus request to a byte slice.
+func setFocusRequest(c *xgb.Conn, Window xproto.Window,
+	Time xproto.Timestamp, Device DeviceId) []byte {
+
+	buf := make([]byte, 16)
+	requestHeader(c, buf, 49)
+	xgb.Put32(buf[4:], uint32(Window))
+	xgb.Put32(buf[8:], uint32(Time))
+	xgb.Put16(buf[12:], uint16(Device))
+	return buf
+}
+
+// GetFocusCookie is a cookie used only for GetFocus requests.
+type GetFocusCookie struct {
+	*xgb.Cookie
+}
+
+// GetFocus sends a checked request.
+// If an error occurs, it will be returned with the reply by calling
+// GetFocusCookie.Reply()
+func GetFocus(c *xgb.Conn, Device DeviceId) GetFocusCookie {
+	checkInit(c, "GetFocus")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(getFocusRequest(c, Device), cookie)
+	return GetFocusCookie{cookie}
+}
+
+// GetFocusUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func GetFocusUnchecked(c *xgb.Conn, Device DeviceId) GetFocusCookie {
+	checkInit(c, "GetFocus")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(getFocusRequest(c, Device), cookie)
+	return GetFocusCookie{cookie}
+}
+
+// GetFocusReply represents the data returned from a GetFocus request.
+type GetFocusReply struct {
+	Sequence uint16 // sequence number of the request for this reply
+	Length   uint32 // number of bytes in this reply
+	Focus    xproto.Window
+}
+
+// Reply blocks and returns the reply data for a GetFocus request.
+func (cook GetFocusCookie) Reply() (*GetFocusReply, error) {
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return getFocusReply(buf), nil
+}
+
+// getFocusReply reads a byte slice into a GetFocusReply value.
+func getFocusReply(buf []byte) *GetFocusReply {
+	return &GetFocusReply{
+		Sequence: xgb.Get16(buf[2:]),
+		Length:   xgb.Get32(buf[4:]),
+		Focus:    xproto.Window(xgb.Get32(buf[8:])),
+	}
+}
+
+// getFocusRequest writes a GetFocus request to a byte slice.
+func getFocusRequest(c *xgb.Conn, Device DeviceId) []byte {
+	buf := make([]byte, 8)
+	requestHeader(c, buf, 50)
+	xgb.Put16(buf[4:], uint16(Device))
+	return buf
+}
+
+// Grab modes, as used by GrabDevice and PassiveGrabDevice.
+const (
+	GrabModeSync  = 0
+	GrabModeAsync = 1
+	GrabModeTouch = 2 // only for touch begin passive grabs
+)
+
+// GrabDeviceCookie is a cookie used only for GrabDevice requests.
+type GrabDeviceCookie struct {
+	*xgb.Cookie
+}
+
+// GrabDevice sends a checked request.
+// If an error occurs, it will be returned with the reply by calling
+// GrabDeviceCookie.Reply()
+func GrabDevice(c *xgb.Conn, Window xproto.Window, Time xproto.Timestamp,
+	Cursor xproto.Cursor, Device DeviceId, Mode byte, PairedDeviceMode byte,
+	OwnerEvents bool, Mask []uint32) GrabDeviceCookie {
+
+	checkInit(c, "GrabDevice")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(grabDeviceRequest(c, Window, Time, Cursor, Device, Mode,
+		PairedDeviceMode, OwnerEvents, Mask), cookie)
+	return GrabDeviceCookie{cookie}
+}
+
+// GrabDeviceUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func GrabDeviceUnchecked(c *xgb.Conn, Window xproto.Window,
+	Time xproto.Timestamp, Cursor xproto.Cursor, Device DeviceId, Mode byte,
+	PairedDeviceMode byte, OwnerEvents bool,
+	Mask []uint32) GrabDeviceCookie {
+
+	checkInit(c, "GrabDevice")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(grabDeviceRequest(c, Window, Time, Cursor, Device, Mode,
+		PairedDeviceMode, OwnerEvents, Mask), cookie)
+	return GrabDeviceCookie{cookie}
+}
+
+// GrabDeviceReply represents the data returned from a GrabDevice request.
+type GrabDeviceReply struct {
+	Sequence uint16 // sequence number of the request for this reply
+	Length   uint32 // number of bytes in this reply
+	Status   byte   // one of the xproto.GrabStatus* constants
+}
+
+// Reply blocks and returns the reply data for a GrabDevice request.
+func (cook GrabDeviceCookie) Reply() (*GrabDeviceReply, error) {
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return grabDeviceReply(buf), nil
+}
+
+// grabDeviceReply reads a byte slice into a GrabDeviceReply value.
+func grabDeviceReply(buf []byte) *GrabDeviceReply {
+	return &GrabDeviceReply{
+		Sequence: xgb.Get16(buf[2:]),
+		Length:   xgb.Get32(buf[4:]),
+		Status:   buf[8],
+	}
+}
+
+// grabDeviceRequest writes a GrabDevice request to a byte slice.
+func grabDeviceRequest(c *xgb.Conn, Window xproto.Window,
+	Time xproto.Timestamp, Cursor xproto.Cursor, Device DeviceId, Mode byte,
+	PairedDeviceMode byte, OwnerEvents bool, Mask []uint32) []byte {
+
+	buf := make([]byte, 24+len(Mask)*4)
+	requestHeader(c, buf, 51)
+	xgb.Put32(buf[4:], uint32(Window))
+	xgb.Put32(buf[8:], uint32(Time))
+	xgb.Put32(buf[12:], uint32(Cursor))
+	xgb.Put16(buf[16:], uint16(Device))
+	buf[18] = Mode
+	buf[19] = PairedDeviceMode
+	if OwnerEvents {
+		buf[20] = 1
+	}
+	xgb.Put16(buf[22:], uint16(len(Mask)))
+	putMask(buf[24:], Mask)
+	return buf
+}
+
+// UngrabDeviceCookie is a cookie used only for UngrabDevice requests.
+type UngrabDeviceCookie struct {
+	*xgb.Cookie
+}
+
+// UngrabDevice sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func UngrabDevice(c *xgb.Conn, Time xproto.Timestamp,
+	Device DeviceId) UngrabDeviceCookie {
+
+	checkInit(c, "UngrabDevice")
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(ungrabDeviceRequest(c, Time, Device), cookie)
+	return UngrabDeviceCookie{cookie}
+}
+
+// UngrabDeviceChecked sends a checked request.
+// If an error occurs, it can be retrieved using UngrabDeviceCookie.Check()
+func UngrabDeviceChecked(c *xgb.Conn, Time xproto.Timestamp,
+	Device DeviceId) UngrabDeviceCookie {
+
+	checkInit(c, "UngrabDevice")
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(ungrabDeviceRequest(c, Time, Device), cookie)
+	return UngrabDeviceCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook UngrabDeviceCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// ungrabDeviceRequest writes an UngrabDevice request to a byte slice.
+func ungrabDeviceRequest(c *xgb.Conn, Time xproto.Timestamp,
+	Device DeviceId) []byte {
+
+	buf := make([]byte, 12)
+	requestHeader(c, buf, 52)
+	xgb.Put32(buf[4:], uint32(Time))
+	xgb.Put16(buf[8:], uint16(Device))
+	return buf
+}
+
+// Event modes, as used by AllowEvents.
+const (
+	EventModeAsyncDevice       = 0
+	EventModeSyncDevice        = 1
+	EventModeReplayDevice      = 2
+	EventModeAsyncPairedDevice = 3
+	EventModeAsyncPair         = 4
+	EventModeSyncPair          = 5
+	EventModeAcceptTouch       = 6
+	EventModeRejectTouch       = 7
+)
+
+// AllowEventsCookie is a cookie used only for AllowEvents requests.
+type AllowEventsCookie struct {
+	*xgb.Cookie
+}
+
+// AllowEvents sends an unchecked request. 'Touch' and 'GrabWindow' are only
+// used with EventModeAcceptTouch and EventModeRejectTouch.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func AllowEvents(c *xgb.Conn, Time xproto.Timestamp, Device DeviceId,
+	Mode byte, Touch uint32, GrabWindow xproto.Window) AllowEventsCookie {
+
+	checkInit(c, "AllowEvents")
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(allowEventsRequest(c, Time, Device, Mode, Touch,
+		GrabWindow), cookie)
+	return AllowEventsCookie{cookie}
+}
+
+// AllowEventsChecked sends a checked request.
+// If an error occurs, it can be retrieved using AllowEventsCookie.Check()
+func AllowEventsChecked(c *xgb.Conn, Time xproto.Timestamp,
+	Device DeviceId, Mode byte, Touch uint32,
+	GrabWindow xproto.Window) AllowEventsCookie {
+
+	checkInit(c, "AllowEvents")
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(allowEventsRequest(c, Time, Device, Mode, Touch,
+		GrabWindow), cookie)
+	return AllowEventsCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook AllowEventsCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// allowEventsRequest writes an AllowEvents request (in its XI 2.2 form) to
+// a byte slice.
+func allowEventsRequest(c *xgb.Conn, Time xproto.Timestamp, Device DeviceId,
+	Mode byte, Touch uint32, GrabWindow xproto.Window) []byte {
+
+	buf := make([]byte, 20)
+	requestHeader(c, buf, 53)
+	xgb.Put32(buf[4:], uint32(Time))
+	xgb.Put16(buf[8:], uint16(Device))
+	buf[10] = Mode
+	xgb.Put32(buf[12:], Touch)
+	xgb.Put32(buf[16:], uint32(GrabWindow))
+	return buf
+}
+
+// Grab types, as used by PassiveGrabDevice and PassiveUngrabDevice.
+const (
+	GrabTypeButton          = 0
+	GrabTypeKeycode         = 1
+	GrabTypeEnter           = 2
+	GrabTypeFocusIn         = 3
+	GrabTypeTouchBegin      = 4
+	GrabTypeGesturePinch    = 5
+	GrabTypeGestureSwipe    = 6
+	GrabAnyModifier         = 1 << 31
+	GrabAnyButtonOrKeycode  = 0
+	GrabOwnerEventsPossible = 1
+)
+
+// GrabModifierInfo is the result of a passive grab for one set of modifiers.
+type GrabModifierInfo struct {
+	Modifiers uint32
+	Status    byte // one of the xproto.GrabStatus* constants
+}
+
+// PassiveGrabDeviceCookie is a cookie used only for PassiveGrabDevice
+// requests.
+type PassiveGrabDeviceCookie struct {
+	*xgb.Cookie
+}
+
+// PassiveGrabDevice sends a checked request.
+// If an error occurs, it will be returned with the reply by calling
+// PassiveGrabDeviceCookie.Reply()
+func PassiveGrabDevice(c *xgb.Conn, Time xproto.Timestamp,
+	Window xproto.Window, Cursor xproto.Cursor, Detail uint32,
+	Device DeviceId, GrabType byte, GrabMode byte, PairedDeviceMode byte,
+	OwnerEvents bool, Mask []uint32,
+	Modifiers []uint32) PassiveGrabDeviceCookie {
+
+	checkInit(c, "PassiveGrabDevice")
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(passiveGrabDeviceRequest(c, Time, Window, Cursor, Detail,
+		Device, GrabType, GrabMode, PairedDeviceMode, OwnerEvents, Mask,
+		Modifiers), cookie)
+	return PassiveGrabDeviceCookie{cookie}
+}
+
+// PassiveGrabDeviceUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func PassiveGrabDeviceUnchecked(c *xgb.Conn, Time xproto.Timestamp,
+	Window xproto.Window, Cursor xproto.Cursor, Detail uint32,
+	Device DeviceId, GrabType byte, GrabMode byte, PairedDeviceMode byte,
+	OwnerEvents bool, Mask []uint32,
+	Modifiers []uint32) PassiveGrabDeviceCookie {
+
+	checkInit(c, "PassiveGrabDevice")
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(passiveGrabDeviceRequest(c, Time, Window, Cursor, Detail,
+		Device, GrabType, GrabMode, PairedDeviceMode, OwnerEvents, Mask,
+		Modifiers), cookie)
+	return PassiveGrabDeviceCookie{cookie}
+}
+
+// PassiveGrabDeviceReply represents the data returned from
+// a PassiveGrabDevice request.
+type PassiveGrabDeviceReply struct {
+	Sequence uint16 // sequence number of the request for this reply
+	Length   uint32 // number of bytes in this reply
+
+	// Modifiers lists the modifiers that could not be grabbed.
+	Modifiers []GrabModifierInfo
+}
+
+// Reply blocks and returns the reply data for a PassiveGrabDevice request.
+func (cook PassiveGrabDeviceCookie) Reply() (*PassiveGrabDeviceReply,
+	error) {
+
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return passiveGrabDeviceReply(buf), nil
+}
+
+// passiveGrabDeviceReply reads a byte slice into a PassiveGrabDeviceReply
+// value.
+func passiveGrabDeviceReply(buf []byte) *PassiveGrabDeviceReply {
+	v := &PassiveGrabDeviceReply{
+		Sequence:  xgb.Get16(buf[2:]),
+		Length:    xgb.Get32(buf[4:]),
+		Modifiers: make([]GrabModifierInfo, xgb.Get16(buf[8:])),
+	}
+	for i := range v.Modifiers {
+		b := 32 + i*8
+		v.Modifiers[i].Modifiers = xgb.Get32(buf[b:])
+		v.Modifiers[i].Status = buf[b+4]
+	}
+	return v
+}
+
+// passiveGrabDeviceRequest writes a PassiveGrabDevice request to a byte
+// slice.
+func passiveGrabDeviceRequest(c *xgb.Conn, Time xproto.Timestamp,
+	Window xproto.Window, Cursor xproto.Cursor, Detail uint32,
+	Device DeviceId, GrabType byte, GrabMode byte, PairedDeviceMode byte,
+	OwnerEvents bool, Mask []uint32, Modifiers []uint32) []byte {
+
+	buf := make([]byte, 32+len(Mask)*4+len(Modifiers)*4)
+	requestHeader(c, buf, 54)
+	xgb.Put32(buf[4:], uint32(Time))
+	xgb.Put32(buf[8:], uint32(Window))
+	xgb.Put32(buf[12:], uint32(Cursor))
+	xgb.Put32(buf[16:], Detail)
+	xgb.Put16(buf[20:], uint16(Device))
+	xgb.Put16(buf[22:], uint16(len(Modifiers)))
+	xgb.Put16(buf[24:], uint16(len(Mask)))
+	buf[26] = GrabType
+	buf[27] = GrabMode
+	buf[28] = PairedDeviceMode
+	if OwnerEvents {
+		buf[29] = 1
+	}
+
+	b := 32
+	b += putMask(buf[b:], Mask)
+	putMask(buf[b:], Modifiers)
+	return buf
+}
+
+// PassiveUngrabDeviceCookie is a cookie used only for PassiveUngrabDevice
+// requests.
+type PassiveUngrabDeviceCookie struct {
+	*xgb.Cookie
+}
+
+// PassiveUngrabDevice sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func PassiveUngrabDevice(c *xgb.Conn, Window xproto.Window, Detail uint32,
+	Device DeviceId, GrabType byte,
+	Modifiers []uint32) PassiveUngrabDeviceCookie {
+
+	checkInit(c, "PassiveUngrabDevice")
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(passiveUngrabDeviceRequest(c, Window, Detail, Device,
+		GrabType, Modifiers), cookie)
+	return PassiveUngrabDeviceCookie{cookie}
+}
+
+// PassiveUngrabDeviceChecked sends a checked request.
+// If an error occurs, it can be retrieved using
+// PassiveUngrabDeviceCookie.Check()
+func PassiveUngrabDeviceChecked(c *xgb.Conn, Window xproto.Window,
+	Detail uint32, Device DeviceId, GrabType byte,
+	Modifiers []uint32) PassiveUngrabDeviceCookie {
+
+	checkInit(c, "PassiveUngrabDevice")
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(passiveUngrabDeviceRequest(c, Window, Detail, Device,
+		GrabType, Modifiers), cookie)
+	return PassiveUngrabDeviceCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook PassiveUngrabDeviceCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// passiveUngrabDeviceRequest writes a PassiveUngrabDevice request to a byte
+// slice.
+func passiveUngrabDeviceRequest(c *xgb.Conn, Window xproto.Window,
+	Detail uint32, Device DeviceId, GrabType byte,
+	Modifiers []uint32) []byte {
+
+	buf := make([]byte, 20+len(Modifiers)*4)
+	requestHeader(c, buf, 55)
+	xgb.Put32(buf[4:], uint32(Window))
+	xgb.Put32(buf[8:], Detail)
+	xgb.Put16(buf[12:], uint16(Device))
+	xgb.Put16(buf[14:], uint16(len(Modifiers)))
+	buf[16] = GrabType
+	putMask(buf[20:], Modifiers)
+	return buf
+}
+
+// BarrierReleasePointerInfo identifies a pointer to release from an XFIXES
+// pointer barrier it is held by.
+type BarrierReleasePointerInfo struct {
+	Device  DeviceId
+	Barrier uint32 // an XFIXES pointer barrier
+	EventId uint32 // as in BarrierEvent.EventId
+}
+
+// BarrierReleasePointerCookie is a cookie used only for BarrierReleasePointer
+// requests.
+type BarrierReleasePointerCookie struct {
+	*xgb.Cookie
+}
+
+// BarrierReleasePointer sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or
+// xgb.PollForEvent.
+func BarrierReleasePointer(c *xgb.Conn,
+	Barriers []BarrierReleasePointerInfo) BarrierReleasePointerCookie {
+
+	checkInit(c, "BarrierReleasePointer")
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(barrierReleasePointerRequest(c, Barriers), cookie)
+	return BarrierReleasePointerCookie{cookie}
+}
+
+// BarrierReleasePointerChecked sends a checked request.
+// If an error occurs, it can be retrieved using
+// BarrierReleasePointerCookie.Check()
+func BarrierReleasePointerChecked(c *xgb.Conn,
+	Barriers []BarrierReleasePointerInfo) BarrierReleasePointerCookie {
+
+	checkInit(c, "BarrierReleasePointer")
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(barrierReleasePointerRequest(c, Barriers), cookie)
+	return BarrierReleasePointerCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not
+// expecting a reply. This cannot be called for requests expecting a reply,
+// nor for unchecked requests.
+func (cook BarrierReleasePointerCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// barrierReleasePointerRequest writes a BarrierReleasePointer request to
+// a byte slice.
+func barrierReleasePointerRequest(c *xgb.Conn,
+	Barriers []BarrierReleasePointerInfo) []byte {
+
+	buf := make([]byte, 8+len(Barriers)*12)
+	requestHeader(c, buf, 61)
+	xgb.Put32(buf[4:], uint32(len(Barriers)))
+	for i, info := range Barriers {
+		b := 8 + i*12
+		xgb.Put16(buf[b:], uint16(info.Device))
+		xgb.Put32(buf[b+4:], info.Barrier)
+		xgb.Put32(buf[b+8:], info.EventId)
+	}
+	return buf
+}
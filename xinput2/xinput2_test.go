@@ -0,0 +1,141 @@
+package xinput2
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestDeviceEvent decodes a ButtonPress event with two valuators.
+func TestDeviceEvent(t *testing.T) {
+	buf := make([]byte, 80+4+4+16)
+	buf[0] = xgb.GenericEventCode
+	xgb.Put16(buf[2:], 7)
+	xgb.Put32(buf[4:], uint32(len(buf)-32)/4)
+	xgb.Put16(buf[8:], ButtonPress)
+	xgb.Put16(buf[10:], 2)
+	xgb.Put32(buf[16:], 3)                  // button 3
+	xgb.Put32(buf[32:], 0x00018000)         // RootX 1.5
+	xgb.Put16(buf[48:], 1)                  // buttons_len
+	xgb.Put16(buf[50:], 1)                  // valuators_len
+	xgb.Put32(buf[80:], 1<<3)               // buttons
+	xgb.Put32(buf[84:], 1<<0|1<<2)          // valuator mask
+	xgb.Put32(buf[88:], 10)                 // valuator 0
+	xgb.Put32(buf[96:], uint32(0xffffffff)) // valuator 2: -1 + 0.5
+	xgb.Put32(buf[100:], 1<<31)
+
+	ev, ok := DeviceEventNew(buf).(DeviceEvent)
+	if !ok {
+		t.Fatalf("DeviceEventNew didn't return a DeviceEvent.")
+	}
+	if ev.Sequence != 7 || ev.EventType != ButtonPress ||
+		ev.DeviceId != 2 || ev.Detail != 3 {
+
+		t.Fatalf("Wrong header: %s", ev)
+	}
+	if ev.RootX.Float64() != 1.5 {
+		t.Fatalf("Expected RootX 1.5, but got %g.", ev.RootX.Float64())
+	}
+	if !MaskIsSet(ev.Buttons, 3) || MaskIsSet(ev.Buttons, 1) {
+		t.Fatalf("Wrong button mask: %v", ev.Buttons)
+	}
+	if len(ev.Valuators) != 2 || ev.Valuators[0].Float64() != 10 ||
+		ev.Valuators[1].Float64() != -0.5 {
+
+		t.Fatalf("Wrong valuators: %v", ev.Valuators)
+	}
+}
+
+// TestRawEvent decodes a RawMotion event, which has both accelerated and
+// raw valuator values.
+func TestRawEvent(t *testing.T) {
+	buf := make([]byte, 32+4+8+8)
+	buf[0] = xgb.GenericEventCode
+	xgb.Put32(buf[4:], uint32(len(buf)-32)/4)
+	xgb.Put16(buf[8:], RawMotion)
+	xgb.Put16(buf[20:], 5) // sourceid
+	xgb.Put16(buf[22:], 1) // valuators_len
+	xgb.Put32(buf[32:], 1<<1)
+	xgb.Put32(buf[36:], 4)
+	xgb.Put32(buf[44:], 2)
+
+	ev := RawEventNew(buf).(RawEvent)
+	if ev.SourceId != 5 {
+		t.Fatalf("Expected SourceId 5, but got %d.", ev.SourceId)
+	}
+	if len(ev.Valuators) != 1 || ev.Valuators[0].Float64() != 4 ||
+		len(ev.RawValuators) != 1 || ev.RawValuators[0].Float64() != 2 {
+
+		t.Fatalf("Wrong valuators: %v (raw %v)",
+			ev.Valuators, ev.RawValuators)
+	}
+}
+
+// TestSelectEventsRequest checks the encoding of a SelectEvents request.
+func TestSelectEventsRequest(t *testing.T) {
+	c := &xgb.Conn{Extensions: map[string]byte{"XInputExtension": 131}}
+	mask := NewEventMask(DeviceAllMaster, Motion, GestureSwipeEnd)
+	buf := selectEventsRequest(c, 0x123, []EventMask{mask})
+
+	want := []byte{
+		131, 46, 6, 0,
+		0x23, 0x01, 0, 0,
+		1, 0, 0, 0,
+		1, 0, 2, 0,
+		1 << Motion, 0, 0, 0,
+		1, 0, 0, 0,
+	}
+	if !reflect.DeepEqual(buf, want) {
+		t.Fatalf("Expected\n% x\nbut got\n% x", want, buf)
+	}
+}
+
+// TestSelectEvents selects XI2 events on the root window of a running
+// X server, and reads them back.
+func TestSelectEvents(t *testing.T) {
+	if len(os.Getenv("DISPLAY")) == 0 {
+		t.Skip("DISPLAY is not set")
+	}
+	X, err := xgb.NewConn()
+	if err != nil {
+		t.Fatalf("NewConn: %s", err)
+	}
+	defer X.Close()
+	if err := Init(X); err != nil {
+		t.Skipf("XInputExtension is not available: %s", err)
+	}
+
+	version, err := QueryVersion(X, 2, 2).Reply()
+	if err != nil {
+		t.Fatalf("QueryVersion: %s", err)
+	}
+	if version.MajorVersion < 2 {
+		t.Skipf("XI2 is not supported (version %d.%d)",
+			version.MajorVersion, version.MinorVersion)
+	}
+
+	root := xproto.Setup(X).DefaultScreen(X).Root
+	mask := NewEventMask(DeviceAllMaster, Motion, ButtonPress)
+	err = SelectEventsChecked(X, root, []EventMask{mask}).Check()
+	if err != nil {
+		t.Fatalf("SelectEvents: %s", err)
+	}
+
+	selected, err := GetSelectedEvents(X, root).Reply()
+	if err != nil {
+		t.Fatalf("GetSelectedEvents: %s", err)
+	}
+	for _, m := range selected.Masks {
+		if m.DeviceId == DeviceAllMaster && MaskIsSet(m.Mask, Motion) &&
+			MaskIsSet(m.Mask, ButtonPress) {
+
+			return
+		}
+	}
+	t.Fatalf("Selected events %v, but got back %v",
+		mask, selected.Masks)
+}
@@ -0,0 +1,1803 @@
+// Package xkb is the X client API for the XKEYBOARD extension.
+package xkb
+
+/*
+	This file was generated by xkb.xml on Oct 14 2026 5:34:08pm UTC.
+	This file is automatically generated. Edit at your peril!
+*/
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// Init must be called before using the XKEYBOARD extension.
+func Init(c *xgb.Conn) error {
+	reply, err := xproto.QueryExtension(c, 9, "XKEYBOARD").Reply()
+	switch {
+	case err != nil:
+		return err
+	case !reply.Present:
+		return xproto.ExtNotAvailableError{Name: "XKEYBOARD", Reply: reply}
+	}
+
+	xgb.ExtLock.Lock()
+	c.Extensions["XKEYBOARD"] = reply.MajorOpcode
+	for evNum, fun := range xgb.NewExtEventFuncs["XKEYBOARD"] {
+		xgb.NewEventFuncs[int(reply.FirstEvent)+evNum] = fun
+	}
+	for errNum, fun := range xgb.NewExtErrorFuncs["XKEYBOARD"] {
+		xgb.NewErrorFuncs[int(reply.FirstError)+errNum] = fun
+	}
+	xgb.ExtLock.Unlock()
+
+	return nil
+}
+
+func init() {
+	xgb.NewExtEventFuncs["XKEYBOARD"] = make(map[int]xgb.NewEventFun)
+	xgb.NewExtErrorFuncs["XKEYBOARD"] = make(map[int]xgb.NewErrorFun)
+}
+
+// Skipping definition for base type 'Bool'
+
+// Skipping definition for base type 'Byte'
+
+// Skipping definition for base type 'Card16'
+
+// Skipping definition for base type 'Card32'
+
+// Skipping definition for base type 'Card8'
+
+// Skipping definition for base type 'Int16'
+
+// Skipping definition for base type 'Int32'
+
+// Skipping definition for base type 'Int8'
+
+// Skipping definition for base type 'Char'
+
+// Skipping definition for base type 'Double'
+
+// Skipping definition for base type 'Float'
+
+// Skipping definition for base type 'Void'
+
+const (
+	EventTypeNewKeyboardNotify     = 1
+	EventTypeMapNotify             = 2
+	EventTypeStateNotify           = 4
+	EventTypeControlsNotify        = 8
+	EventTypeIndicatorStateNotify  = 16
+	EventTypeIndicatorMapNotify    = 32
+	EventTypeNamesNotify           = 64
+	EventTypeCompatMapNotify       = 128
+	EventTypeBellNotify            = 256
+	EventTypeActionMessage         = 512
+	EventTypeAccessXNotify         = 1024
+	EventTypeExtensionDeviceNotify = 2048
+)
+
+const (
+	MapPartKeyTypes           = 1
+	MapPartKeySyms            = 2
+	MapPartModifierMap        = 4
+	MapPartExplicitComponents = 8
+	MapPartKeyActions         = 16
+	MapPartKeyBehaviors       = 32
+	MapPartVirtualMods        = 64
+	MapPartVirtualModMap      = 128
+)
+
+const (
+	StatePartModifierState    = 1
+	StatePartModifierBase     = 2
+	StatePartModifierLatch    = 4
+	StatePartModifierLock     = 8
+	StatePartGroupState       = 16
+	StatePartGroupBase        = 32
+	StatePartGroupLatch       = 64
+	StatePartGroupLock        = 128
+	StatePartCompatState      = 256
+	StatePartGrabMods         = 512
+	StatePartCompatGrabMods   = 1024
+	StatePartLookupMods       = 2048
+	StatePartCompatLookupMods = 4096
+	StatePartPointerButtons   = 8192
+)
+
+const (
+	IdUseCoreKbd  = 256
+	IdUseCorePtr  = 512
+	IdDfltXIClass = 768
+	IdDfltXIId    = 1024
+	IdAllXIClass  = 1280
+	IdAllXIId     = 1536
+	IdXINone      = 65280
+)
+
+const (
+	Group1 = 0
+	Group2 = 1
+	Group3 = 2
+	Group4 = 3
+)
+
+type DeviceSpec uint16
+
+type ModDef struct {
+	Mask     byte
+	RealMods byte
+	Vmods    uint16
+}
+
+// ModDefRead reads a byte slice into a ModDef value.
+func ModDefRead(buf []byte, v *ModDef) int {
+	b := 0
+
+	v.Mask = buf[b]
+	b += 1
+
+	v.RealMods = buf[b]
+	b += 1
+
+	v.Vmods = xgb.Get16(buf[b:])
+	b += 2
+
+	return b
+}
+
+// ModDefReadList reads a byte slice into a list of ModDef values.
+func ModDefReadList(buf []byte, dest []ModDef) int {
+	b := 0
+	for i := 0; i < len(dest); i++ {
+		dest[i] = ModDef{}
+		b += ModDefRead(buf[b:], &dest[i])
+	}
+	return xgb.Pad(b)
+}
+
+// Bytes writes a ModDef value to a byte slice.
+func (v ModDef) Bytes() []byte {
+	buf := make([]byte, 4)
+	b := 0
+
+	buf[b] = v.Mask
+	b += 1
+
+	buf[b] = v.RealMods
+	b += 1
+
+	xgb.Put16(buf[b:], v.Vmods)
+	b += 2
+
+	return buf
+}
+
+// ModDefListBytes writes a list of ModDef values to a byte slice.
+func ModDefListBytes(buf []byte, list []ModDef) int {
+	b := 0
+	var structBytes []byte
+	for _, item := range list {
+		structBytes = item.Bytes()
+		copy(buf[b:], structBytes)
+		b += xgb.Pad(len(structBytes))
+	}
+	return b
+}
+
+type KTMapEntry struct {
+	Active    bool
+	ModsMask  byte
+	Level     byte
+	ModsMods  byte
+	ModsVmods uint16
+	// padding: 2 bytes
+}
+
+// KTMapEntryRead reads a byte slice into a KTMapEntry value.
+func KTMapEntryRead(buf []byte, v *KTMapEntry) int {
+	b := 0
+
+	if buf[b] == 1 {
+		v.Active = true
+	} else {
+		v.Active = false
+	}
+	b += 1
+
+	v.ModsMask = buf[b]
+	b += 1
+
+	v.Level = buf[b]
+	b += 1
+
+	v.ModsMods = buf[b]
+	b += 1
+
+	v.ModsVmods = xgb.Get16(buf[b:])
+	b += 2
+
+	b += 2 // padding
+
+	return b
+}
+
+// KTMapEntryReadList reads a byte slice into a list of KTMapEntry values.
+func KTMapEntryReadList(buf []byte, dest []KTMapEntry) int {
+	b := 0
+	for i := 0; i < len(dest); i++ {
+		dest[i] = KTMapEntry{}
+		b += KTMapEntryRead(buf[b:], &dest[i])
+	}
+	return xgb.Pad(b)
+}
+
+// Bytes writes a KTMapEntry value to a byte slice.
+func (v KTMapEntry) Bytes() []byte {
+	buf := make([]byte, 8)
+	b := 0
+
+	if v.Active {
+		buf[b] = 1
+	} else {
+		buf[b] = 0
+	}
+	b += 1
+
+	buf[b] = v.ModsMask
+	b += 1
+
+	buf[b] = v.Level
+	b += 1
+
+	buf[b] = v.ModsMods
+	b += 1
+
+	xgb.Put16(buf[b:], v.ModsVmods)
+	b += 2
+
+	b += 2 // padding
+
+	return buf
+}
+
+// KTMapEntryListBytes writes a list of KTMapEntry values to a byte slice.
+func KTMapEntryListBytes(buf []byte, list []KTMapEntry) int {
+	b := 0
+	var structBytes []byte
+	for _, item := range list {
+		structBytes = item.Bytes()
+		copy(buf[b:], structBytes)
+		b += xgb.Pad(len(structBytes))
+	}
+	return b
+}
+
+type KeyType struct {
+	ModsMask    byte
+	ModsMods    byte
+	ModsVmods   uint16
+	NumLevels   byte
+	NMapEntries byte
+	HasPreserve byte
+	// padding: 1 bytes
+	Map      []KTMapEntry // size: xgb.Pad((int(NMapEntries) * 8))
+	Preserve []ModDef     // size: xgb.Pad(((int(HasPreserve) * int(NMapEntries)) * 4))
+}
+
+// KeyTypeRead reads a byte slice into a KeyType value.
+func KeyTypeRead(buf []byte, v *KeyType) int {
+	b := 0
+
+	v.ModsMask = buf[b]
+	b += 1
+
+	v.ModsMods = buf[b]
+	b += 1
+
+	v.ModsVmods = xgb.Get16(buf[b:])
+	b += 2
+
+	v.NumLevels = buf[b]
+	b += 1
+
+	v.NMapEntries = buf[b]
+	b += 1
+
+	v.HasPreserve = buf[b]
+	b += 1
+
+	b += 1 // padding
+
+	v.Map = make([]KTMapEntry, v.NMapEntries)
+	b += KTMapEntryReadList(buf[b:], v.Map)
+
+	v.Preserve = make([]ModDef, (int(v.HasPreserve) * int(v.NMapEntries)))
+	b += ModDefReadList(buf[b:], v.Preserve)
+
+	return b
+}
+
+// KeyTypeReadList reads a byte slice into a list of KeyType values.
+func KeyTypeReadList(buf []byte, dest []KeyType) int {
+	b := 0
+	for i := 0; i < len(dest); i++ {
+		dest[i] = KeyType{}
+		b += KeyTypeRead(buf[b:], &dest[i])
+	}
+	return xgb.Pad(b)
+}
+
+// Bytes writes a KeyType value to a byte slice.
+func (v KeyType) Bytes() []byte {
+	buf := make([]byte, ((8 + xgb.Pad((int(v.NMapEntries) * 8))) + xgb.Pad(((int(v.HasPreserve) * int(v.NMapEntries)) * 4))))
+	b := 0
+
+	buf[b] = v.ModsMask
+	b += 1
+
+	buf[b] = v.ModsMods
+	b += 1
+
+	xgb.Put16(buf[b:], v.ModsVmods)
+	b += 2
+
+	buf[b] = v.NumLevels
+	b += 1
+
+	buf[b] = v.NMapEntries
+	b += 1
+
+	buf[b] = v.HasPreserve
+	b += 1
+
+	b += 1 // padding
+
+	b += KTMapEntryListBytes(buf[b:], v.Map)
+
+	b += ModDefListBytes(buf[b:], v.Preserve)
+
+	return buf
+}
+
+// KeyTypeListBytes writes a list of KeyType values to a byte slice.
+func KeyTypeListBytes(buf []byte, list []KeyType) int {
+	b := 0
+	var structBytes []byte
+	for _, item := range list {
+		structBytes = item.Bytes()
+		copy(buf[b:], structBytes)
+		b += xgb.Pad(len(structBytes))
+	}
+	return b
+}
+
+// KeyTypeListSize computes the size (bytes) of a list of KeyType values.
+func KeyTypeListSize(list []KeyType) int {
+	size := 0
+	for _, item := range list {
+		size += ((8 + xgb.Pad((int(item.NMapEntries) * 8))) + xgb.Pad(((int(item.HasPreserve) * int(item.NMapEntries)) * 4)))
+	}
+	return size
+}
+
+type KeySymMap struct {
+	KtIndex   []byte // size: 4
+	GroupInfo byte
+	Width     byte
+	NSyms     uint16
+	Syms      []xproto.Keysym // size: xgb.Pad((int(NSyms) * 4))
+}
+
+// KeySymMapRead reads a byte slice into a KeySymMap value.
+func KeySymMapRead(buf []byte, v *KeySymMap) int {
+	b := 0
+
+	v.KtIndex = make([]byte, 4)
+	copy(v.KtIndex[:4], buf[b:])
+	b += xgb.Pad(int(4))
+
+	v.GroupInfo = buf[b]
+	b += 1
+
+	v.Width = buf[b]
+	b += 1
+
+	v.NSyms = xgb.Get16(buf[b:])
+	b += 2
+
+	v.Syms = make([]xproto.Keysym, v.NSyms)
+	for i := 0; i < int(v.NSyms); i++ {
+		v.Syms[i] = xproto.Keysym(xgb.Get32(buf[b:]))
+		b += 4
+	}
+	b = xgb.Pad(b)
+
+	return b
+}
+
+// KeySymMapReadList reads a byte slice into a list of KeySymMap values.
+func KeySymMapReadList(buf []byte, dest []KeySymMap) int {
+	b := 0
+	for i := 0; i < len(dest); i++ {
+		dest[i] = KeySymMap{}
+		b += KeySymMapRead(buf[b:], &dest[i])
+	}
+	return xgb.Pad(b)
+}
+
+// Bytes writes a KeySymMap value to a byte slice.
+func (v KeySymMap) Bytes() []byte {
+	buf := make([]byte, (8 + xgb.Pad((int(v.NSyms) * 4))))
+	b := 0
+
+	copy(buf[b:], v.KtIndex[:4])
+	b += xgb.Pad(int(4))
+
+	buf[b] = v.GroupInfo
+	b += 1
+
+	buf[b] = v.Width
+	b += 1
+
+	xgb.Put16(buf[b:], v.NSyms)
+	b += 2
+
+	for i := 0; i < int(v.NSyms); i++ {
+		xgb.Put32(buf[b:], uint32(v.Syms[i]))
+		b += 4
+	}
+	b = xgb.Pad(b)
+
+	return buf
+}
+
+// KeySymMapListBytes writes a list of KeySymMap values to a byte slice.
+func KeySymMapListBytes(buf []byte, list []KeySymMap) int {
+	b := 0
+	var structBytes []byte
+	for _, item := range list {
+		structBytes = item.Bytes()
+		copy(buf[b:], structBytes)
+		b += xgb.Pad(len(structBytes))
+	}
+	return b
+}
+
+// KeySymMapListSize computes the size (bytes) of a list of KeySymMap values.
+func KeySymMapListSize(list []KeySymMap) int {
+	size := 0
+	for _, item := range list {
+		size += (8 + xgb.Pad((int(item.NSyms) * 4)))
+	}
+	return size
+}
+
+type Action struct {
+	Type byte
+	Data []byte // size: 8
+}
+
+// ActionRead reads a byte slice into a Action value.
+func ActionRead(buf []byte, v *Action) int {
+	b := 0
+
+	v.Type = buf[b]
+	b += 1
+
+	v.Data = make([]byte, 7)
+	copy(v.Data[:7], buf[b:])
+	b += xgb.Pad(int(7))
+
+	return b
+}
+
+// ActionReadList reads a byte slice into a list of Action values.
+func ActionReadList(buf []byte, dest []Action) int {
+	b := 0
+	for i := 0; i < len(dest); i++ {
+		dest[i] = Action{}
+		b += ActionRead(buf[b:], &dest[i])
+	}
+	return xgb.Pad(b)
+}
+
+// Bytes writes a Action value to a byte slice.
+func (v Action) Bytes() []byte {
+	buf := make([]byte, 9)
+	b := 0
+
+	buf[b] = v.Type
+	b += 1
+
+	copy(buf[b:], v.Data[:7])
+	b += xgb.Pad(int(7))
+
+	return buf
+}
+
+// ActionListBytes writes a list of Action values to a byte slice.
+func ActionListBytes(buf []byte, list []Action) int {
+	b := 0
+	var structBytes []byte
+	for _, item := range list {
+		structBytes = item.Bytes()
+		copy(buf[b:], structBytes)
+		b += xgb.Pad(len(structBytes))
+	}
+	return b
+}
+
+// ActionListSize computes the size (bytes) of a list of Action values.
+func ActionListSize(list []Action) int {
+	size := 0
+	for _ = range list {
+		size += 9
+	}
+	return size
+}
+
+type Behavior struct {
+	Type byte
+	Data byte
+}
+
+// BehaviorRead reads a byte slice into a Behavior value.
+func BehaviorRead(buf []byte, v *Behavior) int {
+	b := 0
+
+	v.Type = buf[b]
+	b += 1
+
+	v.Data = buf[b]
+	b += 1
+
+	return b
+}
+
+// BehaviorReadList reads a byte slice into a list of Behavior values.
+func BehaviorReadList(buf []byte, dest []Behavior) int {
+	b := 0
+	for i := 0; i < len(dest); i++ {
+		dest[i] = Behavior{}
+		b += BehaviorRead(buf[b:], &dest[i])
+	}
+	return xgb.Pad(b)
+}
+
+// Bytes writes a Behavior value to a byte slice.
+func (v Behavior) Bytes() []byte {
+	buf := make([]byte, 2)
+	b := 0
+
+	buf[b] = v.Type
+	b += 1
+
+	buf[b] = v.Data
+	b += 1
+
+	return buf
+}
+
+// BehaviorListBytes writes a list of Behavior values to a byte slice.
+func BehaviorListBytes(buf []byte, list []Behavior) int {
+	b := 0
+	var structBytes []byte
+	for _, item := range list {
+		structBytes = item.Bytes()
+		copy(buf[b:], structBytes)
+		b += xgb.Pad(len(structBytes))
+	}
+	return b
+}
+
+type SetBehavior struct {
+	Keycode  xproto.Keycode
+	Behavior Behavior
+	// padding: 1 bytes
+}
+
+// SetBehaviorRead reads a byte slice into a SetBehavior value.
+func SetBehaviorRead(buf []byte, v *SetBehavior) int {
+	b := 0
+
+	v.Keycode = xproto.Keycode(buf[b])
+	b += 1
+
+	v.Behavior = Behavior{}
+	b += BehaviorRead(buf[b:], &v.Behavior)
+
+	b += 1 // padding
+
+	return b
+}
+
+// SetBehaviorReadList reads a byte slice into a list of SetBehavior values.
+func SetBehaviorReadList(buf []byte, dest []SetBehavior) int {
+	b := 0
+	for i := 0; i < len(dest); i++ {
+		dest[i] = SetBehavior{}
+		b += SetBehaviorRead(buf[b:], &dest[i])
+	}
+	return xgb.Pad(b)
+}
+
+// Bytes writes a SetBehavior value to a byte slice.
+func (v SetBehavior) Bytes() []byte {
+	buf := make([]byte, 4)
+	b := 0
+
+	buf[b] = byte(v.Keycode)
+	b += 1
+
+	{
+		structBytes := v.Behavior.Bytes()
+		copy(buf[b:], structBytes)
+		b += xgb.Pad(len(structBytes))
+	}
+
+	b += 1 // padding
+
+	return buf
+}
+
+// SetBehaviorListBytes writes a list of SetBehavior values to a byte slice.
+func SetBehaviorListBytes(buf []byte, list []SetBehavior) int {
+	b := 0
+	var structBytes []byte
+	for _, item := range list {
+		structBytes = item.Bytes()
+		copy(buf[b:], structBytes)
+		b += xgb.Pad(len(structBytes))
+	}
+	return b
+}
+
+type SetExplicit struct {
+	Keycode  xproto.Keycode
+	Explicit byte
+}
+
+// SetExplicitRead reads a byte slice into a SetExplicit value.
+func SetExplicitRead(buf []byte, v *SetExplicit) int {
+	b := 0
+
+	v.Keycode = xproto.Keycode(buf[b])
+	b += 1
+
+	v.Explicit = buf[b]
+	b += 1
+
+	return b
+}
+
+// SetExplicitReadList reads a byte slice into a list of SetExplicit values.
+func SetExplicitReadList(buf []byte, dest []SetExplicit) int {
+	b := 0
+	for i := 0; i < len(dest); i++ {
+		dest[i] = SetExplicit{}
+		b += SetExplicitRead(buf[b:], &dest[i])
+	}
+	return xgb.Pad(b)
+}
+
+// Bytes writes a SetExplicit value to a byte slice.
+func (v SetExplicit) Bytes() []byte {
+	buf := make([]byte, 2)
+	b := 0
+
+	buf[b] = byte(v.Keycode)
+	b += 1
+
+	buf[b] = v.Explicit
+	b += 1
+
+	return buf
+}
+
+// SetExplicitListBytes writes a list of SetExplicit values to a byte slice.
+func SetExplicitListBytes(buf []byte, list []SetExplicit) int {
+	b := 0
+	var structBytes []byte
+	for _, item := range list {
+		structBytes = item.Bytes()
+		copy(buf[b:], structBytes)
+		b += xgb.Pad(len(structBytes))
+	}
+	return b
+}
+
+type KeyModMap struct {
+	Keycode xproto.Keycode
+	Mods    byte
+}
+
+// KeyModMapRead reads a byte slice into a KeyModMap value.
+func KeyModMapRead(buf []byte, v *KeyModMap) int {
+	b := 0
+
+	v.Keycode = xproto.Keycode(buf[b])
+	b += 1
+
+	v.Mods = buf[b]
+	b += 1
+
+	return b
+}
+
+// KeyModMapReadList reads a byte slice into a list of KeyModMap values.
+func KeyModMapReadList(buf []byte, dest []KeyModMap) int {
+	b := 0
+	for i := 0; i < len(dest); i++ {
+		dest[i] = KeyModMap{}
+		b += KeyModMapRead(buf[b:], &dest[i])
+	}
+	return xgb.Pad(b)
+}
+
+// Bytes writes a KeyModMap value to a byte slice.
+func (v KeyModMap) Bytes() []byte {
+	buf := make([]byte, 2)
+	b := 0
+
+	buf[b] = byte(v.Keycode)
+	b += 1
+
+	buf[b] = v.Mods
+	b += 1
+
+	return buf
+}
+
+// KeyModMapListBytes writes a list of KeyModMap values to a byte slice.
+func KeyModMapListBytes(buf []byte, list []KeyModMap) int {
+	b := 0
+	var structBytes []byte
+	for _, item := range list {
+		structBytes = item.Bytes()
+		copy(buf[b:], structBytes)
+		b += xgb.Pad(len(structBytes))
+	}
+	return b
+}
+
+type KeyVModMap struct {
+	Keycode xproto.Keycode
+	// padding: 1 bytes
+	Vmods uint16
+}
+
+// KeyVModMapRead reads a byte slice into a KeyVModMap value.
+func KeyVModMapRead(buf []byte, v *KeyVModMap) int {
+	b := 0
+
+	v.Keycode = xproto.Keycode(buf[b])
+	b += 1
+
+	b += 1 // padding
+
+	v.Vmods = xgb.Get16(buf[b:])
+	b += 2
+
+	return b
+}
+
+// KeyVModMapReadList reads a byte slice into a list of KeyVModMap values.
+func KeyVModMapReadList(buf []byte, dest []KeyVModMap) int {
+	b := 0
+	for i := 0; i < len(dest); i++ {
+		dest[i] = KeyVModMap{}
+		b += KeyVModMapRead(buf[b:], &dest[i])
+	}
+	return xgb.Pad(b)
+}
+
+// Bytes writes a KeyVModMap value to a byte slice.
+func (v KeyVModMap) Bytes() []byte {
+	buf := make([]byte, 4)
+	b := 0
+
+	buf[b] = byte(v.Keycode)
+	b += 1
+
+	b += 1 // padding
+
+	xgb.Put16(buf[b:], v.Vmods)
+	b += 2
+
+	return buf
+}
+
+// KeyVModMapListBytes writes a list of KeyVModMap values to a byte slice.
+func KeyVModMapListBytes(buf []byte, list []KeyVModMap) int {
+	b := 0
+	var structBytes []byte
+	for _, item := range list {
+		structBytes = item.Bytes()
+		copy(buf[b:], structBytes)
+		b += xgb.Pad(len(structBytes))
+	}
+	return b
+}
+
+// StateNotify is the event number for a StateNotifyEvent.
+const StateNotify = 2
+
+type StateNotifyEvent struct {
+	Sequence         uint16
+	XkbType          byte
+	Time             xproto.Timestamp
+	DeviceID         byte
+	Mods             byte
+	BaseMods         byte
+	LatchedMods      byte
+	LockedMods       byte
+	Group            byte
+	BaseGroup        int16
+	LatchedGroup     int16
+	LockedGroup      byte
+	CompatState      byte
+	GrabMods         byte
+	CompatGrabMods   byte
+	LookupMods       byte
+	CompatLookupMods byte
+	PtrBtnState      uint16
+	Changed          uint16
+	Keycode          xproto.Keycode
+	EventType        byte
+	RequestMajor     byte
+	RequestMinor     byte
+}
+
+// StateNotifyEventNew constructs a StateNotifyEvent value that implements xgb.Event from a byte slice.
+func StateNotifyEventNew(buf []byte) xgb.Event {
+	v := StateNotifyEvent{}
+	b := 1 // don't read event number
+
+	v.XkbType = buf[b]
+	b += 1
+
+	v.Sequence = xgb.Get16(buf[b:])
+	b += 2
+
+	v.Time = xproto.Timestamp(xgb.Get32(buf[b:]))
+	b += 4
+
+	v.DeviceID = buf[b]
+	b += 1
+
+	v.Mods = buf[b]
+	b += 1
+
+	v.BaseMods = buf[b]
+	b += 1
+
+	v.LatchedMods = buf[b]
+	b += 1
+
+	v.LockedMods = buf[b]
+	b += 1
+
+	v.Group = buf[b]
+	b += 1
+
+	v.BaseGroup = int16(xgb.Get16(buf[b:]))
+	b += 2
+
+	v.LatchedGroup = int16(xgb.Get16(buf[b:]))
+	b += 2
+
+	v.LockedGroup = buf[b]
+	b += 1
+
+	v.CompatState = buf[b]
+	b += 1
+
+	v.GrabMods = buf[b]
+	b += 1
+
+	v.CompatGrabMods = buf[b]
+	b += 1
+
+	v.LookupMods = buf[b]
+	b += 1
+
+	v.CompatLookupMods = buf[b]
+	b += 1
+
+	v.PtrBtnState = xgb.Get16(buf[b:])
+	b += 2
+
+	v.Changed = xgb.Get16(buf[b:])
+	b += 2
+
+	v.Keycode = xproto.Keycode(buf[b])
+	b += 1
+
+	v.EventType = buf[b]
+	b += 1
+
+	v.RequestMajor = buf[b]
+	b += 1
+
+	v.RequestMinor = buf[b]
+	b += 1
+
+	return v
+}
+
+// Bytes writes a StateNotifyEvent value to a byte slice.
+func (v StateNotifyEvent) Bytes() []byte {
+	buf := make([]byte, 32)
+	b := 0
+
+	// write event number
+	buf[b] = 2
+	b += 1
+
+	buf[b] = v.XkbType
+	b += 1
+
+	b += 2 // skip sequence number
+
+	xgb.Put32(buf[b:], uint32(v.Time))
+	b += 4
+
+	buf[b] = v.DeviceID
+	b += 1
+
+	buf[b] = v.Mods
+	b += 1
+
+	buf[b] = v.BaseMods
+	b += 1
+
+	buf[b] = v.LatchedMods
+	b += 1
+
+	buf[b] = v.LockedMods
+	b += 1
+
+	buf[b] = v.Group
+	b += 1
+
+	xgb.Put16(buf[b:], uint16(v.BaseGroup))
+	b += 2
+
+	xgb.Put16(buf[b:], uint16(v.LatchedGroup))
+	b += 2
+
+	buf[b] = v.LockedGroup
+	b += 1
+
+	buf[b] = v.CompatState
+	b += 1
+
+	buf[b] = v.GrabMods
+	b += 1
+
+	buf[b] = v.CompatGrabMods
+	b += 1
+
+	buf[b] = v.LookupMods
+	b += 1
+
+	buf[b] = v.CompatLookupMods
+	b += 1
+
+	xgb.Put16(buf[b:], v.PtrBtnState)
+	b += 2
+
+	xgb.Put16(buf[b:], v.Changed)
+	b += 2
+
+	buf[b] = byte(v.Keycode)
+	b += 1
+
+	buf[b] = v.EventType
+	b += 1
+
+	buf[b] = v.RequestMajor
+	b += 1
+
+	buf[b] = v.RequestMinor
+	b += 1
+
+	return buf
+}
+
+// SequenceId returns the sequence id attached to the StateNotify event.
+// Events without a sequence number (KeymapNotify) return 0.
+// This is mostly used internally.
+func (v StateNotifyEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of StateNotifyEvent.
+func (v StateNotifyEvent) String() string {
+	fieldVals := make([]string, 0, 22)
+	fieldVals = append(fieldVals, xgb.Sprintf("Sequence: %d", v.Sequence))
+	fieldVals = append(fieldVals, xgb.Sprintf("XkbType: %d", v.XkbType))
+	fieldVals = append(fieldVals, xgb.Sprintf("Time: %d", v.Time))
+	fieldVals = append(fieldVals, xgb.Sprintf("DeviceID: %d", v.DeviceID))
+	fieldVals = append(fieldVals, xgb.Sprintf("Mods: %d", v.Mods))
+	fieldVals = append(fieldVals, xgb.Sprintf("BaseMods: %d", v.BaseMods))
+	fieldVals = append(fieldVals, xgb.Sprintf("LatchedMods: %d", v.LatchedMods))
+	fieldVals = append(fieldVals, xgb.Sprintf("LockedMods: %d", v.LockedMods))
+	fieldVals = append(fieldVals, xgb.Sprintf("Group: %d", v.Group))
+	fieldVals = append(fieldVals, xgb.Sprintf("BaseGroup: %d", v.BaseGroup))
+	fieldVals = append(fieldVals, xgb.Sprintf("LatchedGroup: %d", v.LatchedGroup))
+	fieldVals = append(fieldVals, xgb.Sprintf("LockedGroup: %d", v.LockedGroup))
+	fieldVals = append(fieldVals, xgb.Sprintf("CompatState: %d", v.CompatState))
+	fieldVals = append(fieldVals, xgb.Sprintf("GrabMods: %d", v.GrabMods))
+	fieldVals = append(fieldVals, xgb.Sprintf("CompatGrabMods: %d", v.CompatGrabMods))
+	fieldVals = append(fieldVals, xgb.Sprintf("LookupMods: %d", v.LookupMods))
+	fieldVals = append(fieldVals, xgb.Sprintf("CompatLookupMods: %d", v.CompatLookupMods))
+	fieldVals = append(fieldVals, xgb.Sprintf("PtrBtnState: %d", v.PtrBtnState))
+	fieldVals = append(fieldVals, xgb.Sprintf("Changed: %d", v.Changed))
+	fieldVals = append(fieldVals, xgb.Sprintf("Keycode: %d", v.Keycode))
+	fieldVals = append(fieldVals, xgb.Sprintf("EventType: %d", v.EventType))
+	fieldVals = append(fieldVals, xgb.Sprintf("RequestMajor: %d", v.RequestMajor))
+	fieldVals = append(fieldVals, xgb.Sprintf("RequestMinor: %d", v.RequestMinor))
+	return "StateNotify {" + xgb.StringsJoin(fieldVals, ", ") + "}"
+}
+
+func init() {
+	xgb.NewExtEventFuncs["XKEYBOARD"][2] = StateNotifyEventNew
+}
+
+// UseExtensionCookie is a cookie used only for UseExtension requests.
+type UseExtensionCookie struct {
+	*xgb.Cookie
+}
+
+// UseExtension sends a checked request.
+// If an error occurs, it will be returned with the reply by calling UseExtensionCookie.Reply()
+func UseExtension(c *xgb.Conn, WantedMajor uint16, WantedMinor uint16) UseExtensionCookie {
+	if _, ok := c.Extensions["XKEYBOARD"]; !ok {
+		panic("Cannot issue request 'UseExtension' using the uninitialized extension 'XKEYBOARD'. xkb.Init(connObj) must be called first.")
+	}
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(useExtensionRequest(c, WantedMajor, WantedMinor), cookie)
+	return UseExtensionCookie{cookie}
+}
+
+// UseExtensionUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
+func UseExtensionUnchecked(c *xgb.Conn, WantedMajor uint16, WantedMinor uint16) UseExtensionCookie {
+	if _, ok := c.Extensions["XKEYBOARD"]; !ok {
+		panic("Cannot issue request 'UseExtension' using the uninitialized extension 'XKEYBOARD'. xkb.Init(connObj) must be called first.")
+	}
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(useExtensionRequest(c, WantedMajor, WantedMinor), cookie)
+	return UseExtensionCookie{cookie}
+}
+
+// UseExtensionReply represents the data returned from a UseExtension request.
+type UseExtensionReply struct {
+	Sequence    uint16 // sequence number of the request for this reply
+	Length      uint32 // number of bytes in this reply
+	Supported   bool
+	ServerMajor uint16
+	ServerMinor uint16
+	// padding: 20 bytes
+}
+
+// Reply blocks and returns the reply data for a UseExtension request.
+func (cook UseExtensionCookie) Reply() (*UseExtensionReply, error) {
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return useExtensionReply(buf), nil
+}
+
+// useExtensionReply reads a byte slice into a UseExtensionReply value.
+func useExtensionReply(buf []byte) *UseExtensionReply {
+	v := new(UseExtensionReply)
+	b := 1 // skip reply determinant
+
+	if buf[b] == 1 {
+		v.Supported = true
+	} else {
+		v.Supported = false
+	}
+	b += 1
+
+	v.Sequence = xgb.Get16(buf[b:])
+	b += 2
+
+	v.Length = xgb.Get32(buf[b:]) // 4-byte units
+	b += 4
+
+	v.ServerMajor = xgb.Get16(buf[b:])
+	b += 2
+
+	v.ServerMinor = xgb.Get16(buf[b:])
+	b += 2
+
+	b += 20 // padding
+
+	return v
+}
+
+// Write request to wire for UseExtension
+// useExtensionRequest writes a UseExtension request to a byte slice.
+func useExtensionRequest(c *xgb.Conn, WantedMajor uint16, WantedMinor uint16) []byte {
+	size := 8
+	b := 0
+	buf := make([]byte, size)
+
+	buf[b] = c.Extensions["XKEYBOARD"]
+	b += 1
+
+	buf[b] = 0 // request opcode
+	b += 1
+
+	xgb.Put16(buf[b:], uint16(size/4)) // write request size in 4-byte units
+	b += 2
+
+	xgb.Put16(buf[b:], WantedMajor)
+	b += 2
+
+	xgb.Put16(buf[b:], WantedMinor)
+	b += 2
+
+	return buf
+}
+
+// SelectEventsCookie is a cookie used only for SelectEvents requests.
+type SelectEventsCookie struct {
+	*xgb.Cookie
+}
+
+// SelectEvents sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
+func SelectEvents(c *xgb.Conn, DeviceSpec DeviceSpec, AffectWhich uint16, Clear uint16, SelectAll uint16, AffectMap uint16, Map uint16, AffectNewKeyboard uint16, NewKeyboardDetails uint16, AffectState uint16, StateDetails uint16, AffectCtrls uint32, CtrlDetails uint32, AffectIndicatorState uint32, IndicatorStateDetails uint32, AffectIndicatorMap uint32, IndicatorMapDetails uint32, AffectNames uint16, NamesDetails uint16, AffectCompat byte, CompatDetails byte, AffectBell byte, BellDetails byte, AffectMsgDetails byte, MsgDetails byte, AffectAccessX uint16, AccessXDetails uint16, AffectExtDev uint16, ExtdevDetails uint16) SelectEventsCookie {
+	if _, ok := c.Extensions["XKEYBOARD"]; !ok {
+		panic("Cannot issue request 'SelectEvents' using the uninitialized extension 'XKEYBOARD'. xkb.Init(connObj) must be called first.")
+	}
+	cookie := c.NewCookie(false, false)
+	c.NewRequest(selectEventsRequest(c, DeviceSpec, AffectWhich, Clear, SelectAll, AffectMap, Map, AffectNewKeyboard, NewKeyboardDetails, AffectState, StateDetails, AffectCtrls, CtrlDetails, AffectIndicatorState, IndicatorStateDetails, AffectIndicatorMap, IndicatorMapDetails, AffectNames, NamesDetails, AffectCompat, CompatDetails, AffectBell, BellDetails, AffectMsgDetails, MsgDetails, AffectAccessX, AccessXDetails, AffectExtDev, ExtdevDetails), cookie)
+	return SelectEventsCookie{cookie}
+}
+
+// SelectEventsChecked sends a checked request.
+// If an error occurs, it can be retrieved using SelectEventsCookie.Check()
+func SelectEventsChecked(c *xgb.Conn, DeviceSpec DeviceSpec, AffectWhich uint16, Clear uint16, SelectAll uint16, AffectMap uint16, Map uint16, AffectNewKeyboard uint16, NewKeyboardDetails uint16, AffectState uint16, StateDetails uint16, AffectCtrls uint32, CtrlDetails uint32, AffectIndicatorState uint32, IndicatorStateDetails uint32, AffectIndicatorMap uint32, IndicatorMapDetails uint32, AffectNames uint16, NamesDetails uint16, AffectCompat byte, CompatDetails byte, AffectBell byte, BellDetails byte, AffectMsgDetails byte, MsgDetails byte, AffectAccessX uint16, AccessXDetails uint16, AffectExtDev uint16, ExtdevDetails uint16) SelectEventsCookie {
+	if _, ok := c.Extensions["XKEYBOARD"]; !ok {
+		panic("Cannot issue request 'SelectEvents' using the uninitialized extension 'XKEYBOARD'. xkb.Init(connObj) must be called first.")
+	}
+	cookie := c.NewCookie(true, false)
+	c.NewRequest(selectEventsRequest(c, DeviceSpec, AffectWhich, Clear, SelectAll, AffectMap, Map, AffectNewKeyboard, NewKeyboardDetails, AffectState, StateDetails, AffectCtrls, CtrlDetails, AffectIndicatorState, IndicatorStateDetails, AffectIndicatorMap, IndicatorMapDetails, AffectNames, NamesDetails, AffectCompat, CompatDetails, AffectBell, BellDetails, AffectMsgDetails, MsgDetails, AffectAccessX, AccessXDetails, AffectExtDev, ExtdevDetails), cookie)
+	return SelectEventsCookie{cookie}
+}
+
+// Check returns an error if one occurred for checked requests that are not expecting a reply.
+// This cannot be called for requests expecting a reply, nor for unchecked requests.
+func (cook SelectEventsCookie) Check() error {
+	return cook.Cookie.Check()
+}
+
+// Write request to wire for SelectEvents
+// selectEventsRequest writes a SelectEvents request to a byte slice.
+func selectEventsRequest(c *xgb.Conn, DeviceSpec DeviceSpec, AffectWhich uint16, Clear uint16, SelectAll uint16, AffectMap uint16, Map uint16, AffectNewKeyboard uint16, NewKeyboardDetails uint16, AffectState uint16, StateDetails uint16, AffectCtrls uint32, CtrlDetails uint32, AffectIndicatorState uint32, IndicatorStateDetails uint32, AffectIndicatorMap uint32, IndicatorMapDetails uint32, AffectNames uint16, NamesDetails uint16, AffectCompat byte, CompatDetails byte, AffectBell byte, BellDetails byte, AffectMsgDetails byte, MsgDetails byte, AffectAccessX uint16, AccessXDetails uint16, AffectExtDev uint16, ExtdevDetails uint16) []byte {
+	size := xgb.Pad((16 + (((((((((((0 + func() int {
+		if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeNewKeyboardNotify) != 0 {
+			return 4
+		}
+		return 0
+	}()) + func() int {
+		if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeStateNotify) != 0 {
+			return 4
+		}
+		return 0
+	}()) + func() int {
+		if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeControlsNotify) != 0 {
+			return 8
+		}
+		return 0
+	}()) + func() int {
+		if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeIndicatorStateNotify) != 0 {
+			return 8
+		}
+		return 0
+	}()) + func() int {
+		if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeIndicatorMapNotify) != 0 {
+			return 8
+		}
+		return 0
+	}()) + func() int {
+		if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeNamesNotify) != 0 {
+			return 4
+		}
+		return 0
+	}()) + func() int {
+		if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeCompatMapNotify) != 0 {
+			return 2
+		}
+		return 0
+	}()) + func() int {
+		if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeBellNotify) != 0 {
+			return 2
+		}
+		return 0
+	}()) + func() int {
+		if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeActionMessage) != 0 {
+			return 2
+		}
+		return 0
+	}()) + func() int {
+		if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeAccessXNotify) != 0 {
+			return 4
+		}
+		return 0
+	}()) + func() int {
+		if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeExtensionDeviceNotify) != 0 {
+			return 4
+		}
+		return 0
+	}())))
+	b := 0
+	buf := make([]byte, size)
+
+	buf[b] = c.Extensions["XKEYBOARD"]
+	b += 1
+
+	buf[b] = 1 // request opcode
+	b += 1
+
+	xgb.Put16(buf[b:], uint16(size/4)) // write request size in 4-byte units
+	b += 2
+
+	xgb.Put16(buf[b:], uint16(DeviceSpec))
+	b += 2
+
+	xgb.Put16(buf[b:], AffectWhich)
+	b += 2
+
+	xgb.Put16(buf[b:], Clear)
+	b += 2
+
+	xgb.Put16(buf[b:], SelectAll)
+	b += 2
+
+	xgb.Put16(buf[b:], AffectMap)
+	b += 2
+
+	xgb.Put16(buf[b:], Map)
+	b += 2
+
+	if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeNewKeyboardNotify) != 0 {
+		xgb.Put16(buf[b:], AffectNewKeyboard)
+		b += 2
+
+		xgb.Put16(buf[b:], NewKeyboardDetails)
+		b += 2
+	}
+	if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeStateNotify) != 0 {
+		xgb.Put16(buf[b:], AffectState)
+		b += 2
+
+		xgb.Put16(buf[b:], StateDetails)
+		b += 2
+	}
+	if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeControlsNotify) != 0 {
+		xgb.Put32(buf[b:], AffectCtrls)
+		b += 4
+
+		xgb.Put32(buf[b:], CtrlDetails)
+		b += 4
+	}
+	if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeIndicatorStateNotify) != 0 {
+		xgb.Put32(buf[b:], AffectIndicatorState)
+		b += 4
+
+		xgb.Put32(buf[b:], IndicatorStateDetails)
+		b += 4
+	}
+	if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeIndicatorMapNotify) != 0 {
+		xgb.Put32(buf[b:], AffectIndicatorMap)
+		b += 4
+
+		xgb.Put32(buf[b:], IndicatorMapDetails)
+		b += 4
+	}
+	if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeNamesNotify) != 0 {
+		xgb.Put16(buf[b:], AffectNames)
+		b += 2
+
+		xgb.Put16(buf[b:], NamesDetails)
+		b += 2
+	}
+	if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeCompatMapNotify) != 0 {
+		buf[b] = AffectCompat
+		b += 1
+
+		buf[b] = CompatDetails
+		b += 1
+	}
+	if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeBellNotify) != 0 {
+		buf[b] = AffectBell
+		b += 1
+
+		buf[b] = BellDetails
+		b += 1
+	}
+	if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeActionMessage) != 0 {
+		buf[b] = AffectMsgDetails
+		b += 1
+
+		buf[b] = MsgDetails
+		b += 1
+	}
+	if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeAccessXNotify) != 0 {
+		xgb.Put16(buf[b:], AffectAccessX)
+		b += 2
+
+		xgb.Put16(buf[b:], AccessXDetails)
+		b += 2
+	}
+	if ((int(AffectWhich) & ((^int(Clear)) & (^int(SelectAll)))) & EventTypeExtensionDeviceNotify) != 0 {
+		xgb.Put16(buf[b:], AffectExtDev)
+		b += 2
+
+		xgb.Put16(buf[b:], ExtdevDetails)
+		b += 2
+	}
+
+	return buf
+}
+
+// GetStateCookie is a cookie used only for GetState requests.
+type GetStateCookie struct {
+	*xgb.Cookie
+}
+
+// GetState sends a checked request.
+// If an error occurs, it will be returned with the reply by calling GetStateCookie.Reply()
+func GetState(c *xgb.Conn, DeviceSpec DeviceSpec) GetStateCookie {
+	if _, ok := c.Extensions["XKEYBOARD"]; !ok {
+		panic("Cannot issue request 'GetState' using the uninitialized extension 'XKEYBOARD'. xkb.Init(connObj) must be called first.")
+	}
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(getStateRequest(c, DeviceSpec), cookie)
+	return GetStateCookie{cookie}
+}
+
+// GetStateUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
+func GetStateUnchecked(c *xgb.Conn, DeviceSpec DeviceSpec) GetStateCookie {
+	if _, ok := c.Extensions["XKEYBOARD"]; !ok {
+		panic("Cannot issue request 'GetState' using the uninitialized extension 'XKEYBOARD'. xkb.Init(connObj) must be called first.")
+	}
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(getStateRequest(c, DeviceSpec), cookie)
+	return GetStateCookie{cookie}
+}
+
+// GetStateReply represents the data returned from a GetState request.
+type GetStateReply struct {
+	Sequence         uint16 // sequence number of the request for this reply
+	Length           uint32 // number of bytes in this reply
+	DeviceID         byte
+	Mods             byte
+	BaseMods         byte
+	LatchedMods      byte
+	LockedMods       byte
+	Group            byte
+	LockedGroup      byte
+	BaseGroup        int16
+	LatchedGroup     int16
+	CompatState      byte
+	GrabMods         byte
+	CompatGrabMods   byte
+	LookupMods       byte
+	CompatLookupMods byte
+	// padding: 1 bytes
+	PtrBtnState uint16
+	// padding: 6 bytes
+}
+
+// Reply blocks and returns the reply data for a GetState request.
+func (cook GetStateCookie) Reply() (*GetStateReply, error) {
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return getStateReply(buf), nil
+}
+
+// getStateReply reads a byte slice into a GetStateReply value.
+func getStateReply(buf []byte) *GetStateReply {
+	v := new(GetStateReply)
+	b := 1 // skip reply determinant
+
+	v.DeviceID = buf[b]
+	b += 1
+
+	v.Sequence = xgb.Get16(buf[b:])
+	b += 2
+
+	v.Length = xgb.Get32(buf[b:]) // 4-byte units
+	b += 4
+
+	v.Mods = buf[b]
+	b += 1
+
+	v.BaseMods = buf[b]
+	b += 1
+
+	v.LatchedMods = buf[b]
+	b += 1
+
+	v.LockedMods = buf[b]
+	b += 1
+
+	v.Group = buf[b]
+	b += 1
+
+	v.LockedGroup = buf[b]
+	b += 1
+
+	v.BaseGroup = int16(xgb.Get16(buf[b:]))
+	b += 2
+
+	v.LatchedGroup = int16(xgb.Get16(buf[b:]))
+	b += 2
+
+	v.CompatState = buf[b]
+	b += 1
+
+	v.GrabMods = buf[b]
+	b += 1
+
+	v.CompatGrabMods = buf[b]
+	b += 1
+
+	v.LookupMods = buf[b]
+	b += 1
+
+	v.CompatLookupMods = buf[b]
+	b += 1
+
+	b += 1 // padding
+
+	v.PtrBtnState = xgb.Get16(buf[b:])
+	b += 2
+
+	b += 6 // padding
+
+	return v
+}
+
+// Write request to wire for GetState
+// getStateRequest writes a GetState request to a byte slice.
+func getStateRequest(c *xgb.Conn, DeviceSpec DeviceSpec) []byte {
+	size := 8
+	b := 0
+	buf := make([]byte, size)
+
+	buf[b] = c.Extensions["XKEYBOARD"]
+	b += 1
+
+	buf[b] = 4 // request opcode
+	b += 1
+
+	xgb.Put16(buf[b:], uint16(size/4)) // write request size in 4-byte units
+	b += 2
+
+	xgb.Put16(buf[b:], uint16(DeviceSpec))
+	b += 2
+
+	b += 2 // padding
+
+	return buf
+}
+
+// GetMapCookie is a cookie used only for GetMap requests.
+type GetMapCookie struct {
+	*xgb.Cookie
+}
+
+// GetMap sends a checked request.
+// If an error occurs, it will be returned with the reply by calling GetMapCookie.Reply()
+func GetMap(c *xgb.Conn, DeviceSpec DeviceSpec, Full uint16, Partial uint16, FirstType byte, NTypes byte, FirstKeySym xproto.Keycode, NKeySyms byte, FirstKeyAction xproto.Keycode, NKeyActions byte, FirstKeyBehavior xproto.Keycode, NKeyBehaviors byte, VirtualMods uint16, FirstKeyExplicit xproto.Keycode, NKeyExplicit byte, FirstModMapKey xproto.Keycode, NModMapKeys byte, FirstVModMapKey xproto.Keycode, NVModMapKeys byte) GetMapCookie {
+	if _, ok := c.Extensions["XKEYBOARD"]; !ok {
+		panic("Cannot issue request 'GetMap' using the uninitialized extension 'XKEYBOARD'. xkb.Init(connObj) must be called first.")
+	}
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(getMapRequest(c, DeviceSpec, Full, Partial, FirstType, NTypes, FirstKeySym, NKeySyms, FirstKeyAction, NKeyActions, FirstKeyBehavior, NKeyBehaviors, VirtualMods, FirstKeyExplicit, NKeyExplicit, FirstModMapKey, NModMapKeys, FirstVModMapKey, NVModMapKeys), cookie)
+	return GetMapCookie{cookie}
+}
+
+// GetMapUnchecked sends an unchecked request.
+// If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
+func GetMapUnchecked(c *xgb.Conn, DeviceSpec DeviceSpec, Full uint16, Partial uint16, FirstType byte, NTypes byte, FirstKeySym xproto.Keycode, NKeySyms byte, FirstKeyAction xproto.Keycode, NKeyActions byte, FirstKeyBehavior xproto.Keycode, NKeyBehaviors byte, VirtualMods uint16, FirstKeyExplicit xproto.Keycode, NKeyExplicit byte, FirstModMapKey xproto.Keycode, NModMapKeys byte, FirstVModMapKey xproto.Keycode, NVModMapKeys byte) GetMapCookie {
+	if _, ok := c.Extensions["XKEYBOARD"]; !ok {
+		panic("Cannot issue request 'GetMap' using the uninitialized extension 'XKEYBOARD'. xkb.Init(connObj) must be called first.")
+	}
+	cookie := c.NewCookie(false, true)
+	c.NewRequest(getMapRequest(c, DeviceSpec, Full, Partial, FirstType, NTypes, FirstKeySym, NKeySyms, FirstKeyAction, NKeyActions, FirstKeyBehavior, NKeyBehaviors, VirtualMods, FirstKeyExplicit, NKeyExplicit, FirstModMapKey, NModMapKeys, FirstVModMapKey, NVModMapKeys), cookie)
+	return GetMapCookie{cookie}
+}
+
+// GetMapReply represents the data returned from a GetMap request.
+type GetMapReply struct {
+	Sequence uint16 // sequence number of the request for this reply
+	Length   uint32 // number of bytes in this reply
+	DeviceID byte
+	// padding: 2 bytes
+	MinKeyCode        xproto.Keycode
+	MaxKeyCode        xproto.Keycode
+	Present           uint16
+	FirstType         byte
+	NTypes            byte
+	TotalTypes        byte
+	FirstKeySym       xproto.Keycode
+	TotalSyms         uint16
+	NKeySyms          byte
+	FirstKeyAction    xproto.Keycode
+	TotalActions      uint16
+	NKeyActions       byte
+	FirstKeyBehavior  xproto.Keycode
+	NKeyBehaviors     byte
+	TotalKeyBehaviors byte
+	FirstKeyExplicit  xproto.Keycode
+	NKeyExplicit      byte
+	TotalKeyExplicit  byte
+	FirstModMapKey    xproto.Keycode
+	NModMapKeys       byte
+	TotalModMapKeys   byte
+	FirstVModMapKey   xproto.Keycode
+	NVModMapKeys      byte
+	TotalVModMapKeys  byte
+	// padding: 1 bytes
+	VirtualMods uint16
+	// present if ((int(Present) & MapPartKeyTypes) != 0)
+	TypesRtrn []KeyType // size: KeyTypeListSize(TypesRtrn)
+	// present if ((int(Present) & MapPartKeySyms) != 0)
+	SymsRtrn []KeySymMap // size: KeySymMapListSize(SymsRtrn)
+	// present if ((int(Present) & MapPartKeyActions) != 0)
+	ActsRtrnCount []byte   // size: xgb.Pad((int(NKeyActions) * 1))
+	ActsRtrnActs  []Action // size: ActionListSize(ActsRtrnActs)
+	// present if ((int(Present) & MapPartKeyBehaviors) != 0)
+	BehaviorsRtrn []SetBehavior // size: xgb.Pad((int(TotalKeyBehaviors) * 4))
+	// present if ((int(Present) & MapPartVirtualMods) != 0)
+	VmodsRtrn []byte // size: xgb.Pad((xgb.PopCount(int(VirtualMods)) * 1))
+	// present if ((int(Present) & MapPartExplicitComponents) != 0)
+	ExplicitRtrn []SetExplicit // size: xgb.Pad((int(TotalKeyExplicit) * 2))
+	// present if ((int(Present) & MapPartModifierMap) != 0)
+	ModmapRtrn []KeyModMap // size: xgb.Pad((int(TotalModMapKeys) * 2))
+	// present if ((int(Present) & MapPartVirtualModMap) != 0)
+	VmodmapRtrn []KeyVModMap // size: xgb.Pad((int(TotalVModMapKeys) * 4))
+}
+
+// Reply blocks and returns the reply data for a GetMap request.
+func (cook GetMapCookie) Reply() (*GetMapReply, error) {
+	buf, err := cook.Cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	return getMapReply(buf), nil
+}
+
+// getMapReply reads a byte slice into a GetMapReply value.
+func getMapReply(buf []byte) *GetMapReply {
+	v := new(GetMapReply)
+	b := 1 // skip reply determinant
+
+	v.DeviceID = buf[b]
+	b += 1
+
+	v.Sequence = xgb.Get16(buf[b:])
+	b += 2
+
+	v.Length = xgb.Get32(buf[b:]) // 4-byte units
+	b += 4
+
+	b += 2 // padding
+
+	v.MinKeyCode = xproto.Keycode(buf[b])
+	b += 1
+
+	v.MaxKeyCode = xproto.Keycode(buf[b])
+	b += 1
+
+	v.Present = xgb.Get16(buf[b:])
+	b += 2
+
+	v.FirstType = buf[b]
+	b += 1
+
+	v.NTypes = buf[b]
+	b += 1
+
+	v.TotalTypes = buf[b]
+	b += 1
+
+	v.FirstKeySym = xproto.Keycode(buf[b])
+	b += 1
+
+	v.TotalSyms = xgb.Get16(buf[b:])
+	b += 2
+
+	v.NKeySyms = buf[b]
+	b += 1
+
+	v.FirstKeyAction = xproto.Keycode(buf[b])
+	b += 1
+
+	v.TotalActions = xgb.Get16(buf[b:])
+	b += 2
+
+	v.NKeyActions = buf[b]
+	b += 1
+
+	v.FirstKeyBehavior = xproto.Keycode(buf[b])
+	b += 1
+
+	v.NKeyBehaviors = buf[b]
+	b += 1
+
+	v.TotalKeyBehaviors = buf[b]
+	b += 1
+
+	v.FirstKeyExplicit = xproto.Keycode(buf[b])
+	b += 1
+
+	v.NKeyExplicit = buf[b]
+	b += 1
+
+	v.TotalKeyExplicit = buf[b]
+	b += 1
+
+	v.FirstModMapKey = xproto.Keycode(buf[b])
+	b += 1
+
+	v.NModMapKeys = buf[b]
+	b += 1
+
+	v.TotalModMapKeys = buf[b]
+	b += 1
+
+	v.FirstVModMapKey = xproto.Keycode(buf[b])
+	b += 1
+
+	v.NVModMapKeys = buf[b]
+	b += 1
+
+	v.TotalVModMapKeys = buf[b]
+	b += 1
+
+	b += 1 // padding
+
+	v.VirtualMods = xgb.Get16(buf[b:])
+	b += 2
+
+	if (int(v.Present) & MapPartKeyTypes) != 0 {
+		v.TypesRtrn = make([]KeyType, v.NTypes)
+		b += KeyTypeReadList(buf[b:], v.TypesRtrn)
+	}
+	if (int(v.Present) & MapPartKeySyms) != 0 {
+		v.SymsRtrn = make([]KeySymMap, v.NKeySyms)
+		b += KeySymMapReadList(buf[b:], v.SymsRtrn)
+	}
+	if (int(v.Present) & MapPartKeyActions) != 0 {
+		v.ActsRtrnCount = make([]byte, v.NKeyActions)
+		copy(v.ActsRtrnCount[:v.NKeyActions], buf[b:])
+		b += xgb.Pad(int(v.NKeyActions))
+
+		v.ActsRtrnActs = make([]Action, v.TotalActions)
+		b += ActionReadList(buf[b:], v.ActsRtrnActs)
+	}
+	if (int(v.Present) & MapPartKeyBehaviors) != 0 {
+		v.BehaviorsRtrn = make([]SetBehavior, v.TotalKeyBehaviors)
+		b += SetBehaviorReadList(buf[b:], v.BehaviorsRtrn)
+	}
+	if (int(v.Present) & MapPartVirtualMods) != 0 {
+		v.VmodsRtrn = make([]byte, xgb.PopCount(int(v.VirtualMods)))
+		copy(v.VmodsRtrn[:xgb.PopCount(int(v.VirtualMods))], buf[b:])
+		b += xgb.Pad(int(xgb.PopCount(int(v.VirtualMods))))
+	}
+	if (int(v.Present) & MapPartExplicitComponents) != 0 {
+		v.ExplicitRtrn = make([]SetExplicit, v.TotalKeyExplicit)
+		b += SetExplicitReadList(buf[b:], v.ExplicitRtrn)
+	}
+	if (int(v.Present) & MapPartModifierMap) != 0 {
+		v.ModmapRtrn = make([]KeyModMap, v.TotalModMapKeys)
+		b += KeyModMapReadList(buf[b:], v.ModmapRtrn)
+	}
+	if (int(v.Present) & MapPartVirtualModMap) != 0 {
+		v.VmodmapRtrn = make([]KeyVModMap, v.TotalVModMapKeys)
+		b += KeyVModMapReadList(buf[b:], v.VmodmapRtrn)
+	}
+
+	return v
+}
+
+// Write request to wire for GetMap
+// getMapRequest writes a GetMap request to a byte slice.
+func getMapRequest(c *xgb.Conn, DeviceSpec DeviceSpec, Full uint16, Partial uint16, FirstType byte, NTypes byte, FirstKeySym xproto.Keycode, NKeySyms byte, FirstKeyAction xproto.Keycode, NKeyActions byte, FirstKeyBehavior xproto.Keycode, NKeyBehaviors byte, VirtualMods uint16, FirstKeyExplicit xproto.Keycode, NKeyExplicit byte, FirstModMapKey xproto.Keycode, NModMapKeys byte, FirstVModMapKey xproto.Keycode, NVModMapKeys byte) []byte {
+	size := 28
+	b := 0
+	buf := make([]byte, size)
+
+	buf[b] = c.Extensions["XKEYBOARD"]
+	b += 1
+
+	buf[b] = 8 // request opcode
+	b += 1
+
+	xgb.Put16(buf[b:], uint16(size/4)) // write request size in 4-byte units
+	b += 2
+
+	xgb.Put16(buf[b:], uint16(DeviceSpec))
+	b += 2
+
+	xgb.Put16(buf[b:], Full)
+	b += 2
+
+	xgb.Put16(buf[b:], Partial)
+	b += 2
+
+	buf[b] = FirstType
+	b += 1
+
+	buf[b] = NTypes
+	b += 1
+
+	buf[b] = byte(FirstKeySym)
+	b += 1
+
+	buf[b] = NKeySyms
+	b += 1
+
+	buf[b] = byte(FirstKeyAction)
+	b += 1
+
+	buf[b] = NKeyActions
+	b += 1
+
+	buf[b] = byte(FirstKeyBehavior)
+	b += 1
+
+	buf[b] = NKeyBehaviors
+	b += 1
+
+	xgb.Put16(buf[b:], VirtualMods)
+	b += 2
+
+	buf[b] = byte(FirstKeyExplicit)
+	b += 1
+
+	buf[b] = NKeyExplicit
+	b += 1
+
+	buf[b] = byte(FirstModMapKey)
+	b += 1
+
+	buf[b] = NModMapKeys
+	b += 1
+
+	buf[b] = byte(FirstVModMapKey)
+	b += 1
+
+	buf[b] = NVModMapKeys
+	b += 1
+
+	b += 2 // padding
+
+	return buf
+}
@@ -0,0 +1,160 @@
+package xkb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// firstEvent is the first event code of XKEYBOARD on the mock server, and
+// majorOpcode its major opcode.
+const (
+	firstEvent  = 85
+	majorOpcode = 135
+)
+
+// rawEvent is an event sent by the mock server as it is on the wire.
+type rawEvent []byte
+
+func (ev rawEvent) Bytes() []byte      { return append([]byte(nil), ev...) }
+func (ev rawEvent) SequenceId() uint16 { return 0 }
+func (ev rawEvent) String() string     { return "raw event" }
+
+// connect returns a connection to a mock server on which XKEYBOARD has been
+// initialized.
+func connect(t *testing.T) (*xgb.Conn, *xgbtest.MockServer) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+
+	ext := make([]byte, 32)
+	ext[8] = 1           // present
+	ext[9] = majorOpcode // major opcode
+	ext[10] = firstEvent // first event
+	server.Expect(xgbtest.Request{Opcode: 98}).WithData(ext)
+	if err := Init(X); err != nil {
+		X.Close()
+		t.Fatalf("Init: %s", err)
+	}
+	return X, server
+}
+
+// TestEvents makes sure XKB events, which all have the first event code of
+// the extension, are read according to their xkbType.
+func TestEvents(t *testing.T) {
+	X, server := connect(t)
+	defer X.Close()
+
+	sent := StateNotifyEvent{
+		XkbType:  StateNotify,
+		Time:     1234,
+		DeviceID: 3,
+		Mods:     byte(xproto.ModMaskShift),
+		Group:    Group2,
+		Changed:  StatePartModifierState | StatePartGroupState,
+		Keycode:  50,
+	}
+	buf := sent.Bytes()
+	buf[0] = firstEvent
+	if err := server.SendEvent(rawEvent(buf)); err != nil {
+		t.Fatalf("SendEvent: %s", err)
+	}
+
+	// A MapNotify, which this package doesn't read yet.
+	buf = make([]byte, 32)
+	buf[0], buf[1] = firstEvent, 1
+	xgb.Put32(buf[4:], 5678)
+	buf[8] = 3
+	if err := server.SendEvent(rawEvent(buf)); err != nil {
+		t.Fatalf("SendEvent: %s", err)
+	}
+
+	ev, err := X.WaitForEventTimeout(5 * time.Second)
+	if err != nil {
+		t.Fatalf("WaitForEventTimeout: %s", err)
+	}
+	state, ok := ev.(StateNotifyEvent)
+	if !ok {
+		t.Fatalf("Expected a StateNotifyEvent, but got %v.", ev)
+	}
+	if state.Time != sent.Time || state.Mods != sent.Mods ||
+		state.Group != sent.Group || state.Changed != sent.Changed ||
+		state.Keycode != sent.Keycode {
+
+		t.Fatalf("Expected %v, but got %v.", sent, state)
+	}
+
+	ev, err = X.WaitForEventTimeout(5 * time.Second)
+	if err != nil {
+		t.Fatalf("WaitForEventTimeout: %s", err)
+	}
+	any, ok := ev.(AnyEvent)
+	if !ok {
+		t.Fatalf("Expected an AnyEvent, but got %v.", ev)
+	}
+	if any.XkbType != 1 || any.Time != 5678 || any.DeviceID != 3 {
+		t.Fatalf("Expected a MapNotify of device 3 at time 5678, but "+
+			"got %v.", any)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
+
+// TestGetMap reads a made up keyboard map with only the parts asked for,
+// which are in a switch on the Present field of the reply.
+func TestGetMap(t *testing.T) {
+	X, server := connect(t)
+	defer X.Close()
+
+	present := uint16(MapPartKeySyms | MapPartModifierMap)
+	reply := make([]byte, 40+16+4)
+	reply[10] = 8   // minKeyCode
+	reply[11] = 255 // maxKeyCode
+	xgb.Put16(reply[12:], present)
+	reply[17] = 38           // firstKeySym
+	xgb.Put16(reply[18:], 2) // totalSyms
+	reply[20] = 1            // nKeySyms
+	reply[31] = 50           // firstModMapKey
+	reply[32] = 1            // nModMapKeys
+	reply[33] = 1            // totalModMapKeys
+
+	// The KeySymMap of keycode 38, with one group of two levels.
+	reply[44] = 1               // groupInfo
+	reply[45] = 2               // width
+	xgb.Put16(reply[46:], 2)    // nSyms
+	xgb.Put32(reply[48:], 0x61) // a
+	xgb.Put32(reply[52:], 0x41) // A
+	reply[56] = 50              // keycode
+	reply[57] = byte(xproto.ModMaskShift)
+	server.Expect(xgbtest.Request{Opcode: majorOpcode, Data: 8}).
+		WithData(reply)
+
+	m, err := GetMap(X, IdUseCoreKbd, present, 0, 0, 0, 38, 1, 0, 0, 0,
+		0, 0, 0, 0, 50, 1, 0, 0).Reply()
+	if err != nil {
+		t.Fatalf("GetMap: %s", err)
+	}
+	if m.MinKeyCode != 8 || m.MaxKeyCode != 255 {
+		t.Errorf("Expected the keycodes 8 to 255, but got %d to %d.",
+			m.MinKeyCode, m.MaxKeyCode)
+	}
+	if len(m.TypesRtrn) != 0 {
+		t.Errorf("Expected no key types, but got %v.", m.TypesRtrn)
+	}
+	if len(m.SymsRtrn) != 1 || len(m.SymsRtrn[0].Syms) != 2 ||
+		m.SymsRtrn[0].Syms[1] != 0x41 {
+
+		t.Errorf("Expected the keysyms a and A, but got %v.",
+			m.SymsRtrn)
+	}
+	want := KeyModMap{Keycode: 50, Mods: byte(xproto.ModMaskShift)}
+	if len(m.ModmapRtrn) != 1 || m.ModmapRtrn[0] != want {
+		t.Errorf("Expected the modifier map %v, but got %v.", want,
+			m.ModmapRtrn)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
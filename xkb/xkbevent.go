@@ -0,0 +1,79 @@
+package xkb
+
+/*
+	Reading XKB events, which all have the same event code (the first
+	event of the extension) and are told apart by their xkbType, the byte
+	after it.
+	Unlike the rest of this package, this file is not generated.
+
+	xkb.go registers each event as if its xkbType was an event code of its
+	own, so the init function below, which replaces those registrations,
+	has to run after the ones of xkb.go. Go initializes the files of a
+	package in the order of their names, which is why this file isn't
+	called event.go.
+*/
+
+import (
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// eventFuncs maps the xkbType of the XKB events this package can read to
+// the functions that construct them.
+var eventFuncs = make(map[int]xgb.NewEventFun)
+
+func init() {
+	funcs := xgb.NewExtEventFuncs["XKEYBOARD"]
+	for xkbType, fun := range funcs {
+		eventFuncs[xkbType] = fun
+		delete(funcs, xkbType)
+	}
+	funcs[0] = eventNew
+}
+
+// eventNew constructs the XKB event in 'buf' according to its xkbType.
+func eventNew(buf []byte) xgb.Event {
+	if fun, ok := eventFuncs[int(buf[1])]; ok {
+		return fun(buf)
+	}
+	return AnyEventNew(buf)
+}
+
+// AnyEvent is an XKB event of a type this package can't read yet, i.e., of
+// any type but StateNotify. It has the fields every XKB event starts with.
+type AnyEvent struct {
+	XkbType  byte
+	Sequence uint16
+	Time     xproto.Timestamp
+	DeviceID byte
+
+	buf []byte
+}
+
+// AnyEventNew constructs an AnyEvent value that implements xgb.Event from a
+// byte slice.
+func AnyEventNew(buf []byte) xgb.Event {
+	return AnyEvent{
+		XkbType:  buf[1],
+		Sequence: xgb.Get16(buf[2:]),
+		Time:     xproto.Timestamp(xgb.Get32(buf[4:])),
+		DeviceID: buf[8],
+		buf:      buf,
+	}
+}
+
+// Bytes returns the raw bytes of the event, as read from the wire.
+func (v AnyEvent) Bytes() []byte {
+	return v.buf
+}
+
+// SequenceId returns the sequence id attached to the event.
+func (v AnyEvent) SequenceId() uint16 {
+	return v.Sequence
+}
+
+// String is a rudimentary string representation of AnyEvent.
+func (v AnyEvent) String() string {
+	return xgb.Sprintf("AnyEvent {XkbType: %d, Sequence: %d, Time: %d, "+
+		"DeviceID: %d}", v.XkbType, v.Sequence, v.Time, v.DeviceID)
+}
@@ -0,0 +1,58 @@
+package xprint
+
+/*
+	Listing printers and creating print contexts with Go strings rather
+	than the String8 lists (and their lengths) the requests take.
+	Unlike the rest of this package, this file is not generated.
+*/
+
+import (
+	"github.com/BurntSushi/xgb"
+)
+
+// ToString8 converts 's' to the String8 list X Print requests take.
+func ToString8(s string) []String8 {
+	list := make([]String8, len(s))
+	for i := 0; i < len(s); i++ {
+		list[i] = String8(s[i])
+	}
+	return list
+}
+
+// FromString8 converts a String8 list, like the name of a Printer, to a
+// string.
+func FromString8(list []String8) string {
+	buf := make([]byte, len(list))
+	for i, c := range list {
+		buf[i] = byte(c)
+	}
+	return string(buf)
+}
+
+// GetPrinters returns the printers of the print server, with their
+// descriptions in 'locale' (the default locale of the server if empty).
+func GetPrinters(c *xgb.Conn, locale string) ([]Printer, error) {
+	reply, err := PrintGetPrinterList(c, 0, uint32(len(locale)), nil,
+		ToString8(locale)).Reply()
+	if err != nil {
+		return nil, err
+	}
+	return reply.Printers, nil
+}
+
+// NewContext creates a print context for the printer named 'printer', as
+// returned by GetPrinters, in 'locale'. It has to be made current with
+// PrintSetContext before starting a print job.
+func NewContext(c *xgb.Conn, printer, locale string) (Pcontext, error) {
+	ctx, err := NewPcontextId(c)
+	if err != nil {
+		return 0, err
+	}
+	err = CreateContextChecked(c, uint32(ctx), uint32(len(printer)),
+		uint32(len(locale)), ToString8(printer),
+		ToString8(locale)).Check()
+	if err != nil {
+		return 0, err
+	}
+	return ctx, nil
+}
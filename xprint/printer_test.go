@@ -0,0 +1,66 @@
+package xprint
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// majorOpcode is the major opcode of XpExtension on the mock server.
+const majorOpcode = 140
+
+// TestGetPrinters lists the printers of the mock server and creates a print
+// context for one of them, which makes sure requests can be sent once the
+// extension has been initialized.
+func TestGetPrinters(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	ext := make([]byte, 32)
+	ext[8] = 1           // present
+	ext[9] = majorOpcode // major opcode
+	server.Expect(xgbtest.Request{Opcode: 98}).WithData(ext)
+	if err := Init(X); err != nil {
+		t.Fatalf("Init: %s", err)
+	}
+
+	list := []Printer{
+		{NameLen: 3, Name: ToString8("lp0"), DescLen: 5,
+			Description: ToString8("Laser")},
+		{NameLen: 5, Name: ToString8("plot1")},
+	}
+	reply := make([]byte, 32+PrinterListSize(list))
+	xgb.Put32(reply[8:], uint32(len(list)))
+	PrinterListBytes(reply[32:], list)
+	body := make([]byte, 12) // no printer name, and the locale "C"
+	xgb.Put32(body[4:], 1)
+	body[8] = 'C'
+	server.Expect(xgbtest.Request{Opcode: majorOpcode, Data: 1,
+		Body: body}).WithData(reply)
+
+	printers, err := GetPrinters(X, "C")
+	if err != nil {
+		t.Fatalf("GetPrinters: %s", err)
+	}
+	if len(printers) != 2 {
+		t.Fatalf("Expected 2 printers, but got %d.", len(printers))
+	}
+	want := []string{"lp0", "Laser", "plot1", ""}
+	for i, p := range printers {
+		name, desc := FromString8(p.Name), FromString8(p.Description)
+		if name != want[2*i] || desc != want[2*i+1] {
+			t.Errorf("Expected printer %q (%q), but got %q (%q).",
+				want[2*i], want[2*i+1], name, desc)
+		}
+	}
+
+	server.Expect(xgbtest.Request{Opcode: majorOpcode, Data: 2})
+	if _, err := NewContext(X, "lp0", ""); err != nil {
+		t.Fatalf("NewContext: %s", err)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
@@ -19,7 +19,7 @@ func Init(c *xgb.Conn) error {
 	case err != nil:
 		return err
 	case !reply.Present:
-		return xgb.Errorf("No extension named XpExtension could be found on on the server.")
+		return xproto.ExtNotAvailableError{Name: "XpExtension", Reply: reply}
 	}
 
 	xgb.ExtLock.Lock()
@@ -445,7 +445,7 @@ type PrintQueryVersionCookie struct {
 // PrintQueryVersion sends a checked request.
 // If an error occurs, it will be returned with the reply by calling PrintQueryVersionCookie.Reply()
 func PrintQueryVersion(c *xgb.Conn) PrintQueryVersionCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintQueryVersion' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -456,7 +456,7 @@ func PrintQueryVersion(c *xgb.Conn) PrintQueryVersionCookie {
 // PrintQueryVersionUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintQueryVersionUnchecked(c *xgb.Conn) PrintQueryVersionCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintQueryVersion' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -514,7 +514,7 @@ func printQueryVersionRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 0 // request opcode
@@ -534,7 +534,7 @@ type PrintGetPrinterListCookie struct {
 // PrintGetPrinterList sends a checked request.
 // If an error occurs, it will be returned with the reply by calling PrintGetPrinterListCookie.Reply()
 func PrintGetPrinterList(c *xgb.Conn, PrinterNameLen uint32, LocaleLen uint32, PrinterName []String8, Locale []String8) PrintGetPrinterListCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetPrinterList' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -545,7 +545,7 @@ func PrintGetPrinterList(c *xgb.Conn, PrinterNameLen uint32, LocaleLen uint32, P
 // PrintGetPrinterListUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintGetPrinterListUnchecked(c *xgb.Conn, PrinterNameLen uint32, LocaleLen uint32, PrinterName []String8, Locale []String8) PrintGetPrinterListCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetPrinterList' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -606,7 +606,7 @@ func printGetPrinterListRequest(c *xgb.Conn, PrinterNameLen uint32, LocaleLen ui
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 1 // request opcode
@@ -644,7 +644,7 @@ type PrintRehashPrinterListCookie struct {
 // PrintRehashPrinterList sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintRehashPrinterList(c *xgb.Conn) PrintRehashPrinterListCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintRehashPrinterList' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -655,7 +655,7 @@ func PrintRehashPrinterList(c *xgb.Conn) PrintRehashPrinterListCookie {
 // PrintRehashPrinterListChecked sends a checked request.
 // If an error occurs, it can be retrieved using PrintRehashPrinterListCookie.Check()
 func PrintRehashPrinterListChecked(c *xgb.Conn) PrintRehashPrinterListCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintRehashPrinterList' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -676,7 +676,7 @@ func printRehashPrinterListRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 20 // request opcode
@@ -696,7 +696,7 @@ type CreateContextCookie struct {
 // CreateContext sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func CreateContext(c *xgb.Conn, ContextId uint32, PrinterNameLen uint32, LocaleLen uint32, PrinterName []String8, Locale []String8) CreateContextCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'CreateContext' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -707,7 +707,7 @@ func CreateContext(c *xgb.Conn, ContextId uint32, PrinterNameLen uint32, LocaleL
 // CreateContextChecked sends a checked request.
 // If an error occurs, it can be retrieved using CreateContextCookie.Check()
 func CreateContextChecked(c *xgb.Conn, ContextId uint32, PrinterNameLen uint32, LocaleLen uint32, PrinterName []String8, Locale []String8) CreateContextCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'CreateContext' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -728,7 +728,7 @@ func createContextRequest(c *xgb.Conn, ContextId uint32, PrinterNameLen uint32,
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 2 // request opcode
@@ -769,7 +769,7 @@ type PrintSetContextCookie struct {
 // PrintSetContext sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintSetContext(c *xgb.Conn, Context uint32) PrintSetContextCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintSetContext' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -780,7 +780,7 @@ func PrintSetContext(c *xgb.Conn, Context uint32) PrintSetContextCookie {
 // PrintSetContextChecked sends a checked request.
 // If an error occurs, it can be retrieved using PrintSetContextCookie.Check()
 func PrintSetContextChecked(c *xgb.Conn, Context uint32) PrintSetContextCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintSetContext' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -801,7 +801,7 @@ func printSetContextRequest(c *xgb.Conn, Context uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 3 // request opcode
@@ -824,7 +824,7 @@ type PrintGetContextCookie struct {
 // PrintGetContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling PrintGetContextCookie.Reply()
 func PrintGetContext(c *xgb.Conn) PrintGetContextCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetContext' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -835,7 +835,7 @@ func PrintGetContext(c *xgb.Conn) PrintGetContextCookie {
 // PrintGetContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintGetContextUnchecked(c *xgb.Conn) PrintGetContextCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetContext' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -889,7 +889,7 @@ func printGetContextRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 4 // request opcode
@@ -909,7 +909,7 @@ type PrintDestroyContextCookie struct {
 // PrintDestroyContext sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintDestroyContext(c *xgb.Conn, Context uint32) PrintDestroyContextCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintDestroyContext' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -920,7 +920,7 @@ func PrintDestroyContext(c *xgb.Conn, Context uint32) PrintDestroyContextCookie
 // PrintDestroyContextChecked sends a checked request.
 // If an error occurs, it can be retrieved using PrintDestroyContextCookie.Check()
 func PrintDestroyContextChecked(c *xgb.Conn, Context uint32) PrintDestroyContextCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintDestroyContext' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -941,7 +941,7 @@ func printDestroyContextRequest(c *xgb.Conn, Context uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 5 // request opcode
@@ -964,7 +964,7 @@ type PrintGetScreenOfContextCookie struct {
 // PrintGetScreenOfContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling PrintGetScreenOfContextCookie.Reply()
 func PrintGetScreenOfContext(c *xgb.Conn) PrintGetScreenOfContextCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetScreenOfContext' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -975,7 +975,7 @@ func PrintGetScreenOfContext(c *xgb.Conn) PrintGetScreenOfContextCookie {
 // PrintGetScreenOfContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintGetScreenOfContextUnchecked(c *xgb.Conn) PrintGetScreenOfContextCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetScreenOfContext' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1029,7 +1029,7 @@ func printGetScreenOfContextRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 6 // request opcode
@@ -1049,7 +1049,7 @@ type PrintStartJobCookie struct {
 // PrintStartJob sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintStartJob(c *xgb.Conn, OutputMode byte) PrintStartJobCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintStartJob' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1060,7 +1060,7 @@ func PrintStartJob(c *xgb.Conn, OutputMode byte) PrintStartJobCookie {
 // PrintStartJobChecked sends a checked request.
 // If an error occurs, it can be retrieved using PrintStartJobCookie.Check()
 func PrintStartJobChecked(c *xgb.Conn, OutputMode byte) PrintStartJobCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintStartJob' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1081,7 +1081,7 @@ func printStartJobRequest(c *xgb.Conn, OutputMode byte) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 7 // request opcode
@@ -1104,7 +1104,7 @@ type PrintEndJobCookie struct {
 // PrintEndJob sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintEndJob(c *xgb.Conn, Cancel bool) PrintEndJobCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintEndJob' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1115,7 +1115,7 @@ func PrintEndJob(c *xgb.Conn, Cancel bool) PrintEndJobCookie {
 // PrintEndJobChecked sends a checked request.
 // If an error occurs, it can be retrieved using PrintEndJobCookie.Check()
 func PrintEndJobChecked(c *xgb.Conn, Cancel bool) PrintEndJobCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintEndJob' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1136,7 +1136,7 @@ func printEndJobRequest(c *xgb.Conn, Cancel bool) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 8 // request opcode
@@ -1163,7 +1163,7 @@ type PrintStartDocCookie struct {
 // PrintStartDoc sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintStartDoc(c *xgb.Conn, DriverMode byte) PrintStartDocCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintStartDoc' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1174,7 +1174,7 @@ func PrintStartDoc(c *xgb.Conn, DriverMode byte) PrintStartDocCookie {
 // PrintStartDocChecked sends a checked request.
 // If an error occurs, it can be retrieved using PrintStartDocCookie.Check()
 func PrintStartDocChecked(c *xgb.Conn, DriverMode byte) PrintStartDocCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintStartDoc' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1195,7 +1195,7 @@ func printStartDocRequest(c *xgb.Conn, DriverMode byte) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 9 // request opcode
@@ -1218,7 +1218,7 @@ type PrintEndDocCookie struct {
 // PrintEndDoc sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintEndDoc(c *xgb.Conn, Cancel bool) PrintEndDocCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintEndDoc' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1229,7 +1229,7 @@ func PrintEndDoc(c *xgb.Conn, Cancel bool) PrintEndDocCookie {
 // PrintEndDocChecked sends a checked request.
 // If an error occurs, it can be retrieved using PrintEndDocCookie.Check()
 func PrintEndDocChecked(c *xgb.Conn, Cancel bool) PrintEndDocCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintEndDoc' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1250,7 +1250,7 @@ func printEndDocRequest(c *xgb.Conn, Cancel bool) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 10 // request opcode
@@ -1277,7 +1277,7 @@ type PrintPutDocumentDataCookie struct {
 // PrintPutDocumentData sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintPutDocumentData(c *xgb.Conn, Drawable xproto.Drawable, LenData uint32, LenFmt uint16, LenOptions uint16, Data []byte, DocFormat []String8, Options []String8) PrintPutDocumentDataCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintPutDocumentData' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1288,7 +1288,7 @@ func PrintPutDocumentData(c *xgb.Conn, Drawable xproto.Drawable, LenData uint32,
 // PrintPutDocumentDataChecked sends a checked request.
 // If an error occurs, it can be retrieved using PrintPutDocumentDataCookie.Check()
 func PrintPutDocumentDataChecked(c *xgb.Conn, Drawable xproto.Drawable, LenData uint32, LenFmt uint16, LenOptions uint16, Data []byte, DocFormat []String8, Options []String8) PrintPutDocumentDataCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintPutDocumentData' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1309,7 +1309,7 @@ func printPutDocumentDataRequest(c *xgb.Conn, Drawable xproto.Drawable, LenData
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 11 // request opcode
@@ -1356,7 +1356,7 @@ type PrintGetDocumentDataCookie struct {
 // PrintGetDocumentData sends a checked request.
 // If an error occurs, it will be returned with the reply by calling PrintGetDocumentDataCookie.Reply()
 func PrintGetDocumentData(c *xgb.Conn, Context Pcontext, MaxBytes uint32) PrintGetDocumentDataCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetDocumentData' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1367,7 +1367,7 @@ func PrintGetDocumentData(c *xgb.Conn, Context Pcontext, MaxBytes uint32) PrintG
 // PrintGetDocumentDataUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintGetDocumentDataUnchecked(c *xgb.Conn, Context Pcontext, MaxBytes uint32) PrintGetDocumentDataCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetDocumentData' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1437,7 +1437,7 @@ func printGetDocumentDataRequest(c *xgb.Conn, Context Pcontext, MaxBytes uint32)
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 12 // request opcode
@@ -1463,7 +1463,7 @@ type PrintStartPageCookie struct {
 // PrintStartPage sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintStartPage(c *xgb.Conn, Window xproto.Window) PrintStartPageCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintStartPage' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1474,7 +1474,7 @@ func PrintStartPage(c *xgb.Conn, Window xproto.Window) PrintStartPageCookie {
 // PrintStartPageChecked sends a checked request.
 // If an error occurs, it can be retrieved using PrintStartPageCookie.Check()
 func PrintStartPageChecked(c *xgb.Conn, Window xproto.Window) PrintStartPageCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintStartPage' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1495,7 +1495,7 @@ func printStartPageRequest(c *xgb.Conn, Window xproto.Window) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 13 // request opcode
@@ -1518,7 +1518,7 @@ type PrintEndPageCookie struct {
 // PrintEndPage sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintEndPage(c *xgb.Conn, Cancel bool) PrintEndPageCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintEndPage' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1529,7 +1529,7 @@ func PrintEndPage(c *xgb.Conn, Cancel bool) PrintEndPageCookie {
 // PrintEndPageChecked sends a checked request.
 // If an error occurs, it can be retrieved using PrintEndPageCookie.Check()
 func PrintEndPageChecked(c *xgb.Conn, Cancel bool) PrintEndPageCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintEndPage' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1550,7 +1550,7 @@ func printEndPageRequest(c *xgb.Conn, Cancel bool) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 14 // request opcode
@@ -1579,7 +1579,7 @@ type PrintSelectInputCookie struct {
 // PrintSelectInput sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintSelectInput(c *xgb.Conn, Context Pcontext, EventMask uint32, EventList []uint32) PrintSelectInputCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintSelectInput' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1590,7 +1590,7 @@ func PrintSelectInput(c *xgb.Conn, Context Pcontext, EventMask uint32, EventList
 // PrintSelectInputChecked sends a checked request.
 // If an error occurs, it can be retrieved using PrintSelectInputCookie.Check()
 func PrintSelectInputChecked(c *xgb.Conn, Context Pcontext, EventMask uint32, EventList []uint32) PrintSelectInputCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintSelectInput' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1611,7 +1611,7 @@ func printSelectInputRequest(c *xgb.Conn, Context Pcontext, EventMask uint32, Ev
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 15 // request opcode
@@ -1642,7 +1642,7 @@ type PrintInputSelectedCookie struct {
 // PrintInputSelected sends a checked request.
 // If an error occurs, it will be returned with the reply by calling PrintInputSelectedCookie.Reply()
 func PrintInputSelected(c *xgb.Conn, Context Pcontext) PrintInputSelectedCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintInputSelected' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1653,7 +1653,7 @@ func PrintInputSelected(c *xgb.Conn, Context Pcontext) PrintInputSelectedCookie
 // PrintInputSelectedUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintInputSelectedUnchecked(c *xgb.Conn, Context Pcontext) PrintInputSelectedCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintInputSelected' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1727,7 +1727,7 @@ func printInputSelectedRequest(c *xgb.Conn, Context Pcontext) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 16 // request opcode
@@ -1750,7 +1750,7 @@ type PrintGetAttributesCookie struct {
 // PrintGetAttributes sends a checked request.
 // If an error occurs, it will be returned with the reply by calling PrintGetAttributesCookie.Reply()
 func PrintGetAttributes(c *xgb.Conn, Context Pcontext, Pool byte) PrintGetAttributesCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetAttributes' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1761,7 +1761,7 @@ func PrintGetAttributes(c *xgb.Conn, Context Pcontext, Pool byte) PrintGetAttrib
 // PrintGetAttributesUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintGetAttributesUnchecked(c *xgb.Conn, Context Pcontext, Pool byte) PrintGetAttributesCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetAttributes' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1822,7 +1822,7 @@ func printGetAttributesRequest(c *xgb.Conn, Context Pcontext, Pool byte) []byte
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 17 // request opcode
@@ -1850,7 +1850,7 @@ type PrintGetOneAttributesCookie struct {
 // PrintGetOneAttributes sends a checked request.
 // If an error occurs, it will be returned with the reply by calling PrintGetOneAttributesCookie.Reply()
 func PrintGetOneAttributes(c *xgb.Conn, Context Pcontext, NameLen uint32, Pool byte, Name []String8) PrintGetOneAttributesCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetOneAttributes' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1861,7 +1861,7 @@ func PrintGetOneAttributes(c *xgb.Conn, Context Pcontext, NameLen uint32, Pool b
 // PrintGetOneAttributesUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintGetOneAttributesUnchecked(c *xgb.Conn, Context Pcontext, NameLen uint32, Pool byte, Name []String8) PrintGetOneAttributesCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetOneAttributes' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1926,7 +1926,7 @@ func printGetOneAttributesRequest(c *xgb.Conn, Context Pcontext, NameLen uint32,
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 19 // request opcode
@@ -1963,7 +1963,7 @@ type PrintSetAttributesCookie struct {
 // PrintSetAttributes sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintSetAttributes(c *xgb.Conn, Context Pcontext, StringLen uint32, Pool byte, Rule byte, Attributes []String8) PrintSetAttributesCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintSetAttributes' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1974,7 +1974,7 @@ func PrintSetAttributes(c *xgb.Conn, Context Pcontext, StringLen uint32, Pool by
 // PrintSetAttributesChecked sends a checked request.
 // If an error occurs, it can be retrieved using PrintSetAttributesCookie.Check()
 func PrintSetAttributesChecked(c *xgb.Conn, Context Pcontext, StringLen uint32, Pool byte, Rule byte, Attributes []String8) PrintSetAttributesCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintSetAttributes' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1995,7 +1995,7 @@ func printSetAttributesRequest(c *xgb.Conn, Context Pcontext, StringLen uint32,
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 18 // request opcode
@@ -2035,7 +2035,7 @@ type PrintGetPageDimensionsCookie struct {
 // PrintGetPageDimensions sends a checked request.
 // If an error occurs, it will be returned with the reply by calling PrintGetPageDimensionsCookie.Reply()
 func PrintGetPageDimensions(c *xgb.Conn, Context Pcontext) PrintGetPageDimensionsCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetPageDimensions' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2046,7 +2046,7 @@ func PrintGetPageDimensions(c *xgb.Conn, Context Pcontext) PrintGetPageDimension
 // PrintGetPageDimensionsUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintGetPageDimensionsUnchecked(c *xgb.Conn, Context Pcontext) PrintGetPageDimensionsCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetPageDimensions' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2120,7 +2120,7 @@ func printGetPageDimensionsRequest(c *xgb.Conn, Context Pcontext) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 21 // request opcode
@@ -2143,7 +2143,7 @@ type PrintQueryScreensCookie struct {
 // PrintQueryScreens sends a checked request.
 // If an error occurs, it will be returned with the reply by calling PrintQueryScreensCookie.Reply()
 func PrintQueryScreens(c *xgb.Conn) PrintQueryScreensCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintQueryScreens' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2154,7 +2154,7 @@ func PrintQueryScreens(c *xgb.Conn) PrintQueryScreensCookie {
 // PrintQueryScreensUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintQueryScreensUnchecked(c *xgb.Conn) PrintQueryScreensCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintQueryScreens' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2219,7 +2219,7 @@ func printQueryScreensRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 22 // request opcode
@@ -2239,7 +2239,7 @@ type PrintSetImageResolutionCookie struct {
 // PrintSetImageResolution sends a checked request.
 // If an error occurs, it will be returned with the reply by calling PrintSetImageResolutionCookie.Reply()
 func PrintSetImageResolution(c *xgb.Conn, Context Pcontext, ImageResolution uint16) PrintSetImageResolutionCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintSetImageResolution' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2250,7 +2250,7 @@ func PrintSetImageResolution(c *xgb.Conn, Context Pcontext, ImageResolution uint
 // PrintSetImageResolutionUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintSetImageResolutionUnchecked(c *xgb.Conn, Context Pcontext, ImageResolution uint16) PrintSetImageResolutionCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintSetImageResolution' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2309,7 +2309,7 @@ func printSetImageResolutionRequest(c *xgb.Conn, Context Pcontext, ImageResoluti
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 23 // request opcode
@@ -2335,7 +2335,7 @@ type PrintGetImageResolutionCookie struct {
 // PrintGetImageResolution sends a checked request.
 // If an error occurs, it will be returned with the reply by calling PrintGetImageResolutionCookie.Reply()
 func PrintGetImageResolution(c *xgb.Conn, Context Pcontext) PrintGetImageResolutionCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetImageResolution' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2346,7 +2346,7 @@ func PrintGetImageResolution(c *xgb.Conn, Context Pcontext) PrintGetImageResolut
 // PrintGetImageResolutionUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PrintGetImageResolutionUnchecked(c *xgb.Conn, Context Pcontext) PrintGetImageResolutionCookie {
-	if _, ok := c.Extensions["XPEXTENSION"]; !ok {
+	if _, ok := c.Extensions["XpExtension"]; !ok {
 		panic("Cannot issue request 'PrintGetImageResolution' using the uninitialized extension 'XpExtension'. xprint.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2400,7 +2400,7 @@ func printGetImageResolutionRequest(c *xgb.Conn, Context Pcontext) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XPEXTENSION"]
+	buf[b] = c.Extensions["XpExtension"]
 	b += 1
 
 	buf[b] = 24 // request opcode
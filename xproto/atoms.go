@@ -0,0 +1,179 @@
+package xproto
+
+/*
+	InternAtomCached and InternAtoms, which only ask the X server for an
+	atom the first time it is needed, and GetAtomNames. Unlike the rest of
+	this package, this file is not generated.
+*/
+
+import (
+	"github.com/BurntSushi/xgb"
+)
+
+// AtomLastPredefined is the last of the atoms predefined by the core
+// protocol. Every atom from AtomPrimary to AtomLastPredefined exists on every
+// X server, with the same value.
+const AtomLastPredefined = AtomWmTransientFor
+
+// atomCacheKey is the key of the cache of InternAtomCached (see
+// xgb.Conn.Cache), which maps the names of atoms to them.
+type atomCacheKey struct{}
+
+// predefinedAtoms maps the names of the predefined atoms to them. They make
+// up the part of the atom cache that doesn't need to be filled in by the X
+// server.
+var predefinedAtoms = map[string]Atom{
+	"PRIMARY":             AtomPrimary,
+	"SECONDARY":           AtomSecondary,
+	"ARC":                 AtomArc,
+	"ATOM":                AtomAtom,
+	"BITMAP":              AtomBitmap,
+	"CARDINAL":            AtomCardinal,
+	"COLORMAP":            AtomColormap,
+	"CURSOR":              AtomCursor,
+	"CUT_BUFFER0":         AtomCutBuffer0,
+	"CUT_BUFFER1":         AtomCutBuffer1,
+	"CUT_BUFFER2":         AtomCutBuffer2,
+	"CUT_BUFFER3":         AtomCutBuffer3,
+	"CUT_BUFFER4":         AtomCutBuffer4,
+	"CUT_BUFFER5":         AtomCutBuffer5,
+	"CUT_BUFFER6":         AtomCutBuffer6,
+	"CUT_BUFFER7":         AtomCutBuffer7,
+	"DRAWABLE":            AtomDrawable,
+	"FONT":                AtomFont,
+	"INTEGER":             AtomInteger,
+	"PIXMAP":              AtomPixmap,
+	"POINT":               AtomPoint,
+	"RECTANGLE":           AtomRectangle,
+	"RESOURCE_MANAGER":    AtomResourceManager,
+	"RGB_COLOR_MAP":       AtomRgbColorMap,
+	"RGB_BEST_MAP":        AtomRgbBestMap,
+	"RGB_BLUE_MAP":        AtomRgbBlueMap,
+	"RGB_DEFAULT_MAP":     AtomRgbDefaultMap,
+	"RGB_GRAY_MAP":        AtomRgbGrayMap,
+	"RGB_GREEN_MAP":       AtomRgbGreenMap,
+	"RGB_RED_MAP":         AtomRgbRedMap,
+	"STRING":              AtomString,
+	"VISUALID":            AtomVisualid,
+	"WINDOW":              AtomWindow,
+	"WM_COMMAND":          AtomWmCommand,
+	"WM_HINTS":            AtomWmHints,
+	"WM_CLIENT_MACHINE":   AtomWmClientMachine,
+	"WM_ICON_NAME":        AtomWmIconName,
+	"WM_ICON_SIZE":        AtomWmIconSize,
+	"WM_NAME":             AtomWmName,
+	"WM_NORMAL_HINTS":     AtomWmNormalHints,
+	"WM_SIZE_HINTS":       AtomWmSizeHints,
+	"WM_ZOOM_HINTS":       AtomWmZoomHints,
+	"MIN_SPACE":           AtomMinSpace,
+	"NORM_SPACE":          AtomNormSpace,
+	"MAX_SPACE":           AtomMaxSpace,
+	"END_SPACE":           AtomEndSpace,
+	"SUPERSCRIPT_X":       AtomSuperscriptX,
+	"SUPERSCRIPT_Y":       AtomSuperscriptY,
+	"SUBSCRIPT_X":         AtomSubscriptX,
+	"SUBSCRIPT_Y":         AtomSubscriptY,
+	"UNDERLINE_POSITION":  AtomUnderlinePosition,
+	"UNDERLINE_THICKNESS": AtomUnderlineThickness,
+	"STRIKEOUT_ASCENT":    AtomStrikeoutAscent,
+	"STRIKEOUT_DESCENT":   AtomStrikeoutDescent,
+	"ITALIC_ANGLE":        AtomItalicAngle,
+	"X_HEIGHT":            AtomXHeight,
+	"QUAD_WIDTH":          AtomQuadWidth,
+	"WEIGHT":              AtomWeight,
+	"POINT_SIZE":          AtomPointSize,
+	"RESOLUTION":          AtomResolution,
+	"COPYRIGHT":           AtomCopyright,
+	"NOTICE":              AtomNotice,
+	"FONT_NAME":           AtomFontName,
+	"FAMILY_NAME":         AtomFamilyName,
+	"FULL_NAME":           AtomFullName,
+	"CAP_HEIGHT":          AtomCapHeight,
+	"WM_CLASS":            AtomWmClass,
+	"WM_TRANSIENT_FOR":    AtomWmTransientFor,
+}
+
+// InternAtomCached returns the atom named 'name', like InternAtom(c, false,
+// ...) does, creating it if it doesn't exist yet. It only makes a round trip
+// to the X server the first time it is called with a name on a connection;
+// after that, the atom comes from a cache that is safe for use by any number
+// of goroutines. The predefined atoms (AtomPrimary to AtomLastPredefined)
+// never need a round trip.
+//
+// Since atoms don't survive a restart of the X server, the cache is emptied by
+// xgb.Conn.Reconnect.
+func InternAtomCached(c *xgb.Conn, name string) (Atom, error) {
+	if atom, ok := predefinedAtoms[name]; ok {
+		return atom, nil
+	}
+	if atom, ok := c.Cache(atomCacheKey{}).Load(name); ok {
+		return atom.(Atom), nil
+	}
+
+	reply, err := InternAtom(c, false, uint16(len(name)), name).Reply()
+	if err != nil {
+		return 0, err
+	}
+	c.Cache(atomCacheKey{}).Store(name, reply.Atom)
+	return reply.Atom, nil
+}
+
+// InternAtoms is like InternAtomCached for each of 'names', except that the
+// atoms not in the cache yet are asked for all at once, which takes a single
+// round trip instead of one per atom. It returns a map from each of 'names' to
+// its atom, or the first error the X server returned.
+func InternAtoms(c *xgb.Conn, names []string) (map[string]Atom, error) {
+	atoms := make(map[string]Atom, len(names))
+	var asked []string // in the order of 'names', like 'cookies'
+	var cookies []InternAtomCookie
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if atom, ok := predefinedAtoms[name]; ok {
+			atoms[name] = atom
+		} else if atom, ok := c.Cache(atomCacheKey{}).Load(name); ok {
+			atoms[name] = atom.(Atom)
+		} else if !seen[name] {
+			seen[name] = true
+			asked = append(asked, name)
+			cookies = append(cookies, InternAtom(c, false,
+				uint16(len(name)), name))
+		}
+	}
+
+	// Every reply is read, even after an error, so that the successful
+	// ones still make it to the cache.
+	var firstErr error
+	for i, cookie := range cookies {
+		reply, err := cookie.Reply()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		c.Cache(atomCacheKey{}).Store(asked[i], reply.Atom)
+		atoms[asked[i]] = reply.Atom
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return atoms, nil
+}
+
+// GetAtomNames returns the names of 'atoms'. It asks for all of them before
+// waiting for any, which takes a single round trip instead of one per atom.
+func GetAtomNames(c *xgb.Conn, atoms []Atom) ([]string, error) {
+	cookies := make([]GetAtomNameCookie, len(atoms))
+	for i, atom := range atoms {
+		cookies[i] = GetAtomName(c, atom)
+	}
+	names := make([]string, len(atoms))
+	for i, cookie := range cookies {
+		reply, err := cookie.Reply()
+		if err != nil {
+			return nil, err
+		}
+		names[i] = reply.Name
+	}
+	return names, nil
+}
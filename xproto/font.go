@@ -0,0 +1,29 @@
+package xproto
+
+/*
+	ListFontsWithInfoAll, which gets every reply to a ListFontsWithInfo
+	request. The generated ListFontsWithInfo only handles the first one,
+	since xgbgen doesn't know that the X server sends a reply per font,
+	and then one with an empty name.
+	Unlike the rest of this package, this file is not generated.
+*/
+
+import (
+	"github.com/BurntSushi/xgb"
+)
+
+// ListFontsWithInfoAll sends a checked ListFontsWithInfo request for the
+// fonts matching 'Pattern'. Each call to Reply on the cookie returns the
+// next reply: one for each font (at most 'MaxNames'), and then a last one
+// whose NameLen is 0. Every reply has to be read, since the connection
+// doesn't read past the replies to a request until they are taken.
+func ListFontsWithInfoAll(c *xgb.Conn, MaxNames uint16,
+	Pattern string) ListFontsWithInfoCookie {
+
+	cookie := c.NewCookieMulti(true, func(buf []byte) bool {
+		return buf[1] == 0 // NameLen
+	})
+	c.NewRequest(listFontsWithInfoRequest(c, MaxNames,
+		uint16(len(Pattern)), Pattern), cookie)
+	return ListFontsWithInfoCookie{cookie}
+}
@@ -0,0 +1,201 @@
+package xproto
+
+/*
+	Typed helpers around GetProperty and ChangeProperty, which check the
+	type and format of a property and decode its value, like xprop does.
+	Unlike the rest of this package, this file is not generated.
+*/
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/BurntSushi/xgb"
+)
+
+// ErrPropertyNotSet is returned by the GetProperty helpers (like
+// GetPropertyString) when the window doesn't have the property.
+var ErrPropertyNotSet = errors.New("xproto: the property is not set")
+
+// PropertyTypeError is returned by the GetProperty helpers when the property
+// doesn't have the type and format they expect.
+type PropertyTypeError struct {
+	Property Atom
+	Type     Atom // the type the property has
+	Format   byte // the format the property has
+}
+
+func (err PropertyTypeError) Error() string {
+	return fmt.Sprintf("xproto: property %d has type %d and format %d, "+
+		"which is not the one expected", err.Property, err.Type,
+		err.Format)
+}
+
+// getPropertyValue returns the whole value of the property 'prop' of 'win',
+// which must have format 'format' and one of the types in 'types'.
+func getPropertyValue(c *xgb.Conn, win Window, prop Atom, format byte,
+	types ...Atom) (*GetPropertyReply, error) {
+
+	reply, err := GetProperty(c, false, win, prop, GetPropertyTypeAny,
+		0, (1<<32)-1).Reply()
+	if err != nil {
+		return nil, err
+	}
+	if reply.Type == AtomNone {
+		return nil, ErrPropertyNotSet
+	}
+	typeErr := PropertyTypeError{prop, reply.Type, reply.Format}
+	if reply.Format != format {
+		return nil, typeErr
+	}
+	for _, typ := range types {
+		if typ == GetPropertyTypeAny || typ == reply.Type {
+			return reply, nil
+		}
+	}
+	return nil, typeErr
+}
+
+// GetPropertyString returns the value of the property 'prop' of 'win', which
+// must be a STRING or UTF8_STRING. A STRING is Latin-1, and isn't converted
+// to UTF-8.
+func GetPropertyString(c *xgb.Conn, win Window, prop Atom) (string, error) {
+	utf8String, err := InternAtomCached(c, "UTF8_STRING")
+	if err != nil {
+		return "", err
+	}
+	reply, err := getPropertyValue(c, win, prop, 8, AtomString, utf8String)
+	if err != nil {
+		return "", err
+	}
+	return string(reply.Value[:reply.ValueLen]), nil
+}
+
+// GetPropertyStrings returns the value of the property 'prop' of 'win' like
+// GetPropertyString, split into the strings in it, each terminated by a null
+// byte (as in WM_CLASS).
+func GetPropertyStrings(c *xgb.Conn, win Window, prop Atom) ([]string,
+	error) {
+
+	s, err := GetPropertyString(c, win, prop)
+	if err != nil {
+		return nil, err
+	}
+	return splitStrings(s), nil
+}
+
+// splitStrings splits 's' into the strings in it, each terminated by a null
+// byte. The terminator of the last one may be missing.
+func splitStrings(s string) []string {
+	var strs []string
+	for len(s) > 0 {
+		i := 0
+		for i < len(s) && s[i] != 0 {
+			i++
+		}
+		strs = append(strs, s[:i])
+		if i == len(s) {
+			break
+		}
+		s = s[i+1:]
+	}
+	return strs
+}
+
+// GetPropertyUint32List returns the value of the property 'prop' of 'win',
+// which must be a list of 32 bit values of type 'typ' (or of any type, if
+// 'typ' is GetPropertyTypeAny).
+func GetPropertyUint32List(c *xgb.Conn, win Window, prop,
+	typ Atom) ([]uint32, error) {
+
+	reply, err := getPropertyValue(c, win, prop, 32, typ)
+	if err != nil {
+		return nil, err
+	}
+	vals := make([]uint32, reply.ValueLen)
+	for i := range vals {
+		vals[i] = xgb.Get32(reply.Value[i*4:])
+	}
+	return vals, nil
+}
+
+// GetPropertyAtomList returns the value of the property 'prop' of 'win',
+// which must be a list of ATOMs.
+func GetPropertyAtomList(c *xgb.Conn, win Window, prop Atom) ([]Atom, error) {
+	vals, err := GetPropertyUint32List(c, win, prop, AtomAtom)
+	if err != nil {
+		return nil, err
+	}
+	atoms := make([]Atom, len(vals))
+	for i, val := range vals {
+		atoms[i] = Atom(val)
+	}
+	return atoms, nil
+}
+
+// GetPropertyWindow returns the value of the property 'prop' of 'win', which
+// must be a WINDOW. If it is a list of them, the first one is returned.
+func GetPropertyWindow(c *xgb.Conn, win Window, prop Atom) (Window, error) {
+	val, err := getPropertyFirst(c, win, prop, AtomWindow)
+	return Window(val), err
+}
+
+// GetPropertyCardinal returns the value of the property 'prop' of 'win',
+// which must be a CARDINAL. If it is a list of them, the first one is
+// returned.
+func GetPropertyCardinal(c *xgb.Conn, win Window, prop Atom) (uint32, error) {
+	return getPropertyFirst(c, win, prop, AtomCardinal)
+}
+
+// getPropertyFirst returns the first value of the property 'prop' of 'win',
+// which must be a list of 32 bit values of type 'typ'. An empty list is
+// treated as a property of the wrong format.
+func getPropertyFirst(c *xgb.Conn, win Window, prop, typ Atom) (uint32,
+	error) {
+
+	vals, err := GetPropertyUint32List(c, win, prop, typ)
+	if err != nil {
+		return 0, err
+	}
+	if len(vals) == 0 {
+		return 0, PropertyTypeError{prop, typ, 32}
+	}
+	return vals[0], nil
+}
+
+// ChangePropertyString replaces the property 'prop' of 'win' with 's', as
+// a value of type 'typ' (e.g., AtomString) with format 8, and waits for the X
+// server to say whether it worked.
+func ChangePropertyString(c *xgb.Conn, win Window, prop, typ Atom,
+	s string) error {
+
+	return ChangePropertyChecked(c, PropModeReplace, win, prop, typ, 8,
+		uint32(len(s)), []byte(s)).Check()
+}
+
+// ChangePropertyStrings is like ChangePropertyString, but sets the property
+// to the strings 'strs', each terminated by a null byte.
+func ChangePropertyStrings(c *xgb.Conn, win Window, prop, typ Atom,
+	strs []string) error {
+
+	var s []byte
+	for _, str := range strs {
+		s = append(s, str...)
+		s = append(s, 0)
+	}
+	return ChangePropertyString(c, win, prop, typ, string(s))
+}
+
+// ChangePropertyUint32List replaces the property 'prop' of 'win' with
+// 'vals', as a list of type 'typ' (e.g., AtomCardinal) with format 32, and
+// waits for the X server to say whether it worked.
+func ChangePropertyUint32List(c *xgb.Conn, win Window, prop, typ Atom,
+	vals []uint32) error {
+
+	data := make([]byte, len(vals)*4)
+	for i, val := range vals {
+		xgb.Put32(data[i*4:], val)
+	}
+	return ChangePropertyChecked(c, PropModeReplace, win, prop, typ, 32,
+		uint32(len(vals)), data).Check()
+}
@@ -0,0 +1,28 @@
+package xproto
+
+/*
+	Synchronous versions of the requests that change a window, for callers
+	that want the error right away rather than a cookie.
+	Unlike the rest of this package, this file is not generated.
+*/
+
+import (
+	"math/bits"
+
+	"github.com/BurntSushi/xgb"
+)
+
+// ChangeWindowAttributesSync sends a ChangeWindowAttributes request and
+// waits for it to be processed. It returns the X error the request caused
+// (e.g., a WindowError if 'win' doesn't exist), or nil. 'values' must have
+// a value for each bit set in 'valueMask', in the order of the bits, and
+// an error is returned without sending anything if it doesn't.
+func ChangeWindowAttributesSync(c *xgb.Conn, win Window, valueMask uint32,
+	values []uint32) error {
+
+	if n := bits.OnesCount32(valueMask); n != len(values) {
+		return xgb.Errorf("xproto: the value mask %#x has %d values, "+
+			"but %d were given", valueMask, n, len(values))
+	}
+	return ChangeWindowAttributesChecked(c, win, valueMask, values).Check()
+}
@@ -23,6 +23,17 @@ func (s *SetupInfo) DefaultScreen(c *xgb.Conn) *ScreenInfo {
 	return &s.Roots[c.DefaultScreen]
 }
 
+// ExtNotAvailableError is returned by the Init function of an extension
+// package when the X server does not support that extension.
+type ExtNotAvailableError struct {
+	Name  string // the X name of the extension, e.g., "RANDR"
+	Reply *QueryExtensionReply
+}
+
+func (err ExtNotAvailableError) Error() string {
+	return "No extension named " + err.Name + " could be found on the server."
+}
+
 // Skipping definition for base type 'Byte'
 
 // Skipping definition for base type 'Int8'
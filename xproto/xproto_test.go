@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"reflect"
 	"testing"
 	"time"
 
@@ -75,6 +76,21 @@ func TestAsynchronousError(t *testing.T) {
 	verifyMapWindowError(t, evOrErr.err)
 }
 
+// TestChangeWindowAttributesSync changes the attributes of window 0, which
+// doesn't exist, and makes sure the BadWindow error is returned.
+func TestChangeWindowAttributesSync(t *testing.T) {
+	err := ChangeWindowAttributesSync(X, 0, CwEventMask,
+		[]uint32{EventMaskExposure})
+	if _, ok := err.(WindowError); !ok {
+		t.Fatalf("Expected a WindowError but got %v instead.", err)
+	}
+	err = ChangeWindowAttributesSync(X, 0, CwEventMask, nil)
+	if _, ok := err.(xgb.Error); err == nil || ok {
+		t.Fatalf("Expected an error about the value list, but got %v.",
+			err)
+	}
+}
+
 // TestCookieBuffer issues (2^16) + n requets *without* replies to guarantee
 // that the sequence number wraps and that the cookie buffer will have to
 // flush itself (since there are no replies coming in to flush it).
@@ -117,6 +133,125 @@ func TestProperty(t *testing.T) {
 	}
 }
 
+// TestInternAtomCached makes sure InternAtomCached returns the same atoms as
+// InternAtom, both for a predefined atom and one that has to be created.
+func TestInternAtomCached(t *testing.T) {
+	for _, name := range []string{"WM_NAME", randString(20)} {
+		reply, err := InternAtom(X, false, uint16(len(name)),
+			name).Reply()
+		if err != nil {
+			t.Fatalf("InternAtom: %s", err)
+		}
+		for i := 0; i < 2; i++ {
+			atom, err := InternAtomCached(X, name)
+			if err != nil {
+				t.Fatalf("InternAtomCached: %s", err)
+			}
+			if atom != reply.Atom {
+				t.Fatalf("Expected the atom %d for '%s', "+
+					"but got %d.", reply.Atom, name, atom)
+			}
+		}
+	}
+}
+
+// TestInternAtoms interns a predefined atom and two new ones at once, and
+// makes sure they are the same as those InternAtomCached returns.
+func TestInternAtoms(t *testing.T) {
+	names := []string{"WM_CLASS", randString(20), randString(20)}
+	atoms, err := InternAtoms(X, names)
+	if err != nil {
+		t.Fatalf("InternAtoms: %s", err)
+	}
+	for _, name := range names {
+		atom, err := InternAtomCached(X, name)
+		if err != nil {
+			t.Fatalf("InternAtomCached: %s", err)
+		}
+		if atoms[name] != atom {
+			t.Fatalf("Expected the atom %d for '%s', but got %d.",
+				atom, name, atoms[name])
+		}
+	}
+}
+
+// TestTypedProperty writes a string and a list of cardinals to properties of
+// the root window, and reads them back with the typed GetProperty helpers.
+func TestTypedProperty(t *testing.T) {
+	root := Setup(X).DefaultScreen(X).Root
+	names := []string{randString(20), randString(20)}
+	atoms, err := InternAtoms(X, names)
+	if err != nil {
+		t.Fatalf("InternAtoms: %s", err)
+	}
+	strProp, listProp := atoms[names[0]], atoms[names[1]]
+
+	val := randString(20)
+	if err := ChangePropertyString(X, root, strProp, AtomString,
+		val); err != nil {
+		t.Fatalf("ChangePropertyString: %s", err)
+	}
+	s, err := GetPropertyString(X, root, strProp)
+	if err != nil {
+		t.Fatalf("GetPropertyString: %s", err)
+	}
+	if s != val {
+		t.Fatalf("Expected '%s', but got '%s'.", val, s)
+	}
+
+	vals := []uint32{1, 2, 0xffffffff}
+	if err := ChangePropertyUint32List(X, root, listProp, AtomCardinal,
+		vals); err != nil {
+		t.Fatalf("ChangePropertyUint32List: %s", err)
+	}
+	got, err := GetPropertyUint32List(X, root, listProp, AtomCardinal)
+	if err != nil {
+		t.Fatalf("GetPropertyUint32List: %s", err)
+	}
+	if !reflect.DeepEqual(got, vals) {
+		t.Fatalf("Expected %v, but got %v.", vals, got)
+	}
+	if n, err := GetPropertyCardinal(X, root, listProp); n != 1 {
+		t.Fatalf("Expected 1, but got %d (%v).", n, err)
+	}
+
+	// The list isn't a string, nor a list of windows.
+	if _, err := GetPropertyString(X, root, listProp); err == nil {
+		t.Fatalf("GetPropertyString didn't fail on a CARDINAL.")
+	}
+	if _, err := GetPropertyWindow(X, root, listProp); err == nil {
+		t.Fatalf("GetPropertyWindow didn't fail on a CARDINAL.")
+	}
+
+	DeleteProperty(X, root, strProp)
+	DeleteProperty(X, root, listProp)
+	if _, err := GetPropertyString(X, root,
+		strProp); err != ErrPropertyNotSet {
+		t.Fatalf("Expected ErrPropertyNotSet, but got %v.", err)
+	}
+}
+
+// TestSplitStrings splits lists of null terminated strings.
+func TestSplitStrings(t *testing.T) {
+	tests := []struct {
+		s    string
+		strs []string
+	}{
+		{"", nil},
+		{"one\x00", []string{"one"}},
+		{"one\x00two\x00", []string{"one", "two"}},
+		{"one\x00two", []string{"one", "two"}},
+		{"\x00two\x00", []string{"", "two"}},
+	}
+	for _, test := range tests {
+		strs := splitStrings(test.s)
+		if !reflect.DeepEqual(strs, test.strs) {
+			t.Errorf("splitStrings(%q): expected %q, but got %q",
+				test.s, test.strs, strs)
+		}
+	}
+}
+
 // TestWindowEvents creates a window, maps it, listens for configure notify
 // events, issues a configure request, and checks for the appropriate
 // configure notify event.
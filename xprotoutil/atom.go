@@ -0,0 +1,109 @@
+package xprotoutil
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// atomNameCacheKey is the key of the cache of GetAtomNameCached (see
+// xgb.Conn.Cache), which maps atoms to their names.
+type atomNameCacheKey struct{}
+
+// predefinedAtomNames are the names of the atoms predefined by the core
+// protocol, by atom. They never need a round trip.
+var predefinedAtomNames = [xproto.AtomLastPredefined + 1]string{
+	xproto.AtomPrimary:            "PRIMARY",
+	xproto.AtomSecondary:          "SECONDARY",
+	xproto.AtomArc:                "ARC",
+	xproto.AtomAtom:               "ATOM",
+	xproto.AtomBitmap:             "BITMAP",
+	xproto.AtomCardinal:           "CARDINAL",
+	xproto.AtomColormap:           "COLORMAP",
+	xproto.AtomCursor:             "CURSOR",
+	xproto.AtomCutBuffer0:         "CUT_BUFFER0",
+	xproto.AtomCutBuffer1:         "CUT_BUFFER1",
+	xproto.AtomCutBuffer2:         "CUT_BUFFER2",
+	xproto.AtomCutBuffer3:         "CUT_BUFFER3",
+	xproto.AtomCutBuffer4:         "CUT_BUFFER4",
+	xproto.AtomCutBuffer5:         "CUT_BUFFER5",
+	xproto.AtomCutBuffer6:         "CUT_BUFFER6",
+	xproto.AtomCutBuffer7:         "CUT_BUFFER7",
+	xproto.AtomDrawable:           "DRAWABLE",
+	xproto.AtomFont:               "FONT",
+	xproto.AtomInteger:            "INTEGER",
+	xproto.AtomPixmap:             "PIXMAP",
+	xproto.AtomPoint:              "POINT",
+	xproto.AtomRectangle:          "RECTANGLE",
+	xproto.AtomResourceManager:    "RESOURCE_MANAGER",
+	xproto.AtomRgbColorMap:        "RGB_COLOR_MAP",
+	xproto.AtomRgbBestMap:         "RGB_BEST_MAP",
+	xproto.AtomRgbBlueMap:         "RGB_BLUE_MAP",
+	xproto.AtomRgbDefaultMap:      "RGB_DEFAULT_MAP",
+	xproto.AtomRgbGrayMap:         "RGB_GRAY_MAP",
+	xproto.AtomRgbGreenMap:        "RGB_GREEN_MAP",
+	xproto.AtomRgbRedMap:          "RGB_RED_MAP",
+	xproto.AtomString:             "STRING",
+	xproto.AtomVisualid:           "VISUALID",
+	xproto.AtomWindow:             "WINDOW",
+	xproto.AtomWmCommand:          "WM_COMMAND",
+	xproto.AtomWmHints:            "WM_HINTS",
+	xproto.AtomWmClientMachine:    "WM_CLIENT_MACHINE",
+	xproto.AtomWmIconName:         "WM_ICON_NAME",
+	xproto.AtomWmIconSize:         "WM_ICON_SIZE",
+	xproto.AtomWmName:             "WM_NAME",
+	xproto.AtomWmNormalHints:      "WM_NORMAL_HINTS",
+	xproto.AtomWmSizeHints:        "WM_SIZE_HINTS",
+	xproto.AtomWmZoomHints:        "WM_ZOOM_HINTS",
+	xproto.AtomMinSpace:           "MIN_SPACE",
+	xproto.AtomNormSpace:          "NORM_SPACE",
+	xproto.AtomMaxSpace:           "MAX_SPACE",
+	xproto.AtomEndSpace:           "END_SPACE",
+	xproto.AtomSuperscriptX:       "SUPERSCRIPT_X",
+	xproto.AtomSuperscriptY:       "SUPERSCRIPT_Y",
+	xproto.AtomSubscriptX:         "SUBSCRIPT_X",
+	xproto.AtomSubscriptY:         "SUBSCRIPT_Y",
+	xproto.AtomUnderlinePosition:  "UNDERLINE_POSITION",
+	xproto.AtomUnderlineThickness: "UNDERLINE_THICKNESS",
+	xproto.AtomStrikeoutAscent:    "STRIKEOUT_ASCENT",
+	xproto.AtomStrikeoutDescent:   "STRIKEOUT_DESCENT",
+	xproto.AtomItalicAngle:        "ITALIC_ANGLE",
+	xproto.AtomXHeight:            "X_HEIGHT",
+	xproto.AtomQuadWidth:          "QUAD_WIDTH",
+	xproto.AtomWeight:             "WEIGHT",
+	xproto.AtomPointSize:          "POINT_SIZE",
+	xproto.AtomResolution:         "RESOLUTION",
+	xproto.AtomCopyright:          "COPYRIGHT",
+	xproto.AtomNotice:             "NOTICE",
+	xproto.AtomFontName:           "FONT_NAME",
+	xproto.AtomFamilyName:         "FAMILY_NAME",
+	xproto.AtomFullName:           "FULL_NAME",
+	xproto.AtomCapHeight:          "CAP_HEIGHT",
+	xproto.AtomWmClass:            "WM_CLASS",
+	xproto.AtomWmTransientFor:     "WM_TRANSIENT_FOR",
+}
+
+// GetAtomNameCached returns the name of 'atom', like GetAtomName does, but
+// only makes a round trip to the X server the first time it is called with
+// an atom on a connection, which helps when printing the atoms of property
+// events and the like. The predefined atoms (xproto.AtomPrimary to
+// xproto.AtomLastPredefined) never need one. Errors (e.g., for atoms that
+// don't exist) aren't cached.
+//
+// Like the atoms cached by xproto.InternAtomCached, the names are forgotten
+// by xgb.Conn.Reconnect.
+func GetAtomNameCached(c *xgb.Conn, atom xproto.Atom) (string, error) {
+	if atom >= xproto.AtomPrimary && atom <= xproto.AtomLastPredefined {
+		return predefinedAtomNames[atom], nil
+	}
+	if name, ok := c.Cache(atomNameCacheKey{}).Load(atom); ok {
+		return name.(string), nil
+	}
+
+	reply, err := xproto.GetAtomName(c, atom).Reply()
+	if err != nil {
+		return "", err
+	}
+	c.Cache(atomNameCacheKey{}).Store(atom, reply.Name)
+	return reply.Name, nil
+}
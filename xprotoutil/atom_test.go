@@ -0,0 +1,60 @@
+package xprotoutil
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestGetAtomNameCached makes sure the name of an atom is only asked for
+// once, that errors aren't cached, and that the predefined atoms don't need
+// a round trip.
+func TestGetAtomNameCached(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	atom := xproto.Atom(300)
+	body := make([]byte, 4)
+	xgb.Put32(body, uint32(atom))
+	server.Expect(xgbtest.Request{Opcode: 17, Body: body}).
+		WithError(xproto.BadAtom, uint32(atom))
+	name := "_NET_WM_NAME"
+	reply := make([]byte, 32+len(name))
+	xgb.Put16(reply[8:], uint16(len(name)))
+	copy(reply[32:], name)
+	server.Expect(xgbtest.Request{Opcode: 17, Body: body}).WithData(reply)
+
+	if _, err := GetAtomNameCached(X, atom); err == nil {
+		t.Fatalf("GetAtomNameCached succeeded for a bad atom.")
+	}
+	for i := 0; i < 2; i++ {
+		got, err := GetAtomNameCached(X, atom)
+		if err != nil {
+			t.Fatalf("GetAtomNameCached: %s", err)
+		}
+		if got != name {
+			t.Fatalf("Expected %q, but got %q.", name, got)
+		}
+	}
+
+	// The names of the predefined atoms agree with InternAtomCached,
+	// which doesn't need a round trip for them either.
+	for atom := xproto.Atom(xproto.AtomPrimary); atom <=
+		xproto.AtomLastPredefined; atom++ {
+
+		name, err := GetAtomNameCached(X, atom)
+		if err != nil {
+			t.Fatalf("GetAtomNameCached(%d): %s", atom, err)
+		}
+		if got, err := xproto.InternAtomCached(X, name); err != nil ||
+			got != atom {
+			t.Fatalf("Expected %q to be the atom %d, but got %d.",
+				name, atom, got)
+		}
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
@@ -0,0 +1,64 @@
+package xprotoutil
+
+import (
+	"errors"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// ErrNoColormap is returned by AllocColor and AllocNamedColor when they are
+// given 0 (None) as the colormap, which the X server would reject.
+var ErrNoColormap = errors.New("xprotoutil: no colormap given")
+
+// ErrBadColorName is returned by AllocNamedColor when the name of the color
+// is empty or too long to be sent.
+var ErrBadColorName = errors.New("xprotoutil: invalid color name")
+
+// AllocColor allocates a read-only cell of 'cmap' for the closest color to
+// (r, g, b) the hardware can show, with 0xffff for full intensity, and
+// returns its pixel. The pixel is the one the X server gave, and is the
+// value to use as is (e.g., with WindowAttributeBuilder.BackPixel) for any
+// drawable whose colormap is 'cmap': it already fits the depth of the
+// visual of 'cmap', so it shouldn't be masked or shifted.
+//
+// The cell should be freed with xproto.FreeColors when it isn't needed.
+func AllocColor(c *xgb.Conn, cmap xproto.Colormap,
+	r, g, b uint16) (pixel uint32, err error) {
+
+	if cmap == 0 {
+		return 0, ErrNoColormap
+	}
+	reply, err := xproto.AllocColor(c, cmap, r, g, b).Reply()
+	if err != nil {
+		return 0, err
+	}
+	return reply.Pixel, nil
+}
+
+// AllocNamedColor is like AllocColor, but for a color the X server knows by
+// name (e.g., "slate gray" or "#708090"). It also returns the exact values
+// of the color in the database of the X server. An xproto.NameError is
+// returned if it doesn't know the color.
+func AllocNamedColor(c *xgb.Conn, cmap xproto.Colormap,
+	name string) (pixel uint32, exact xproto.Rgb, err error) {
+
+	if cmap == 0 {
+		return 0, xproto.Rgb{}, ErrNoColormap
+	}
+	if len(name) == 0 || len(name) > 0xffff {
+		return 0, xproto.Rgb{}, ErrBadColorName
+	}
+	reply, err := xproto.AllocNamedColor(c, cmap, uint16(len(name)),
+		name).Reply()
+	if err != nil {
+		return 0, xproto.Rgb{}, err
+	}
+	exact = xproto.Rgb{
+		Red:   reply.ExactRed,
+		Green: reply.ExactGreen,
+		Blue:  reply.ExactBlue,
+	}
+	return reply.Pixel, exact, nil
+}
@@ -0,0 +1,60 @@
+package xprotoutil
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestAllocColor allocates colors by value and by name on a mock server.
+func TestAllocColor(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	const cmap = 0x20
+	reply := make([]byte, 32)
+	xgb.Put32(reply[16:], 0xff708090)
+	server.Expect(xgbtest.Request{Opcode: 84}).WithData(reply)
+	pixel, err := AllocColor(X, cmap, 0x7070, 0x8080, 0x9090)
+	if err != nil {
+		t.Fatalf("AllocColor: %s", err)
+	}
+	if pixel != 0xff708090 {
+		t.Fatalf("Expected pixel 0xff708090, but got %#x.", pixel)
+	}
+
+	reply = make([]byte, 32)
+	xgb.Put32(reply[8:], 42)
+	xgb.Put16(reply[12:], 0x7070)
+	xgb.Put16(reply[14:], 0x8080)
+	xgb.Put16(reply[16:], 0x9090)
+	server.Expect(xgbtest.Request{Opcode: 85}).WithData(reply)
+	pixel, exact, err := AllocNamedColor(X, cmap, "slate gray")
+	if err != nil {
+		t.Fatalf("AllocNamedColor: %s", err)
+	}
+	want := xproto.Rgb{Red: 0x7070, Green: 0x8080, Blue: 0x9090}
+	if pixel != 42 || exact != want {
+		t.Fatalf("Expected pixel 42 and %+v, but got %d and %+v.",
+			want, pixel, exact)
+	}
+
+	server.Expect(xgbtest.Request{Opcode: 85}).WithError(xproto.BadName, 0)
+	if _, _, err := AllocNamedColor(X, cmap, "no such color"); err == nil {
+		t.Fatalf("Expected an error for an unknown color.")
+	} else if _, ok := err.(xproto.NameError); !ok {
+		t.Fatalf("Expected a NameError, but got %v.", err)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+
+	if _, err := AllocColor(X, 0, 0, 0, 0); err != ErrNoColormap {
+		t.Fatalf("Expected ErrNoColormap, but got %v.", err)
+	}
+	if _, _, err := AllocNamedColor(X, cmap, ""); err != ErrBadColorName {
+		t.Fatalf("Expected ErrBadColorName, but got %v.", err)
+	}
+}
@@ -0,0 +1,84 @@
+package xprotoutil
+
+import (
+	"errors"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// ErrEventTooLong is returned by SendTypedEvent for events that don't fit
+// in the 32 bytes SendEvent carries, like the generic events of XInput 2.
+var ErrEventTooLong = errors.New("xprotoutil: the event is longer than " +
+	"32 bytes")
+
+// SendTypedEvent sends 'event' to 'destination' with SendEvent, as a
+// checked request, e.g.:
+//
+//	err := xprotoutil.SendTypedEvent(X, false, win,
+//		xproto.EventMaskNoEvent, xproto.ClientMessageEvent{...})
+//
+// The event is written with its Bytes method and padded to 32 bytes. The
+// X server sets the bit marking it as sent with SendEvent itself. 'mask'
+// is made of xproto.EventMask* values, and 'destination' may also be
+// xproto.SendEventDestPointerWindow or xproto.SendEventDestItemFocus.
+func SendTypedEvent(c *xgb.Conn, propagate bool, destination xproto.Window,
+	mask uint32, event xgb.Event) error {
+
+	buf := event.Bytes()
+	if len(buf) > 32 {
+		return ErrEventTooLong
+	}
+	padded := make([]byte, 32)
+	copy(padded, buf)
+	return xproto.SendEventChecked(c, propagate, destination, mask,
+		string(padded)).Check()
+}
+
+// allEventsMask is every event mask a client can select on any window,
+// whatever the other clients do. See SelectAllEvents.
+const allEventsMask = xproto.EventMaskKeyPress |
+	xproto.EventMaskKeyRelease |
+	xproto.EventMaskButtonRelease |
+	xproto.EventMaskEnterWindow |
+	xproto.EventMaskLeaveWindow |
+	xproto.EventMaskPointerMotion |
+	xproto.EventMaskButton1Motion |
+	xproto.EventMaskButton2Motion |
+	xproto.EventMaskButton3Motion |
+	xproto.EventMaskButton4Motion |
+	xproto.EventMaskButton5Motion |
+	xproto.EventMaskButtonMotion |
+	xproto.EventMaskKeymapState |
+	xproto.EventMaskExposure |
+	xproto.EventMaskVisibilityChange |
+	xproto.EventMaskStructureNotify |
+	xproto.EventMaskSubstructureNotify |
+	xproto.EventMaskFocusChange |
+	xproto.EventMaskPropertyChange |
+	xproto.EventMaskColorMapChange
+
+// SelectAllEvents selects every event on 'win' that can be selected for
+// watching it, e.g., in an event logger. Like any ChangeWindowAttributes
+// with a CwEventMask, it replaces the events the client had selected on
+// the window. The masks left out are:
+//
+//   - ButtonPress, SubstructureRedirect and ResizeRedirect, which only one
+//     client at a time may select on a window, since they make the X server
+//     grab the pointer for it (ButtonPress) or hand it the requests of
+//     others (the redirects, which window managers select on the root).
+//     Selecting them fails with an Access error if another client has.
+//   - PointerMotionHint, which cuts MotionNotify events down to one until
+//     the pointer is queried, and OwnerGrabButton, which only changes how
+//     the events are reported during grabs.
+//
+// None of the masks requires 'win' to be the root window, but some are
+// mostly useful there: SubstructureNotify reports the top-level windows
+// being created, mapped and destroyed, and PropertyChange the properties
+// of the root (e.g., _NET_ACTIVE_WINDOW). The root only gets the key and
+// pointer events that no window below it takes.
+func SelectAllEvents(c *xgb.Conn, win xproto.Window) error {
+	return xproto.ChangeWindowAttributesChecked(c, win,
+		xproto.CwEventMask, []uint32{allEventsMask}).Check()
+}
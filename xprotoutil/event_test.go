@@ -0,0 +1,71 @@
+package xprotoutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestSendTypedEvent sends a client message to a window of a mock server.
+func TestSendTypedEvent(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	ev := xproto.ClientMessageEvent{
+		Format: 32,
+		Window: 0x200001,
+		Type:   300,
+		Data: xproto.ClientMessageDataUnionData32New(
+			[]uint32{301, 0, 0, 0, 0}),
+	}
+	reply := server.Expect(xgbtest.Request{Opcode: 25})
+	err := SendTypedEvent(X, false, ev.Window, xproto.EventMaskNoEvent, ev)
+	if err != nil {
+		t.Fatalf("SendTypedEvent: %s", err)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+
+	// The event follows the destination and the event mask.
+	req := reply.Received()
+	if len(req) != 44 || !bytes.Equal(req[12:], ev.Bytes()) {
+		t.Fatalf("Expected the event after 12 bytes, but got %v.", req)
+	}
+}
+
+// TestSelectAllEvents makes sure SelectAllEvents leaves out the masks only
+// one client may select.
+func TestSelectAllEvents(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	reply := server.Expect(xgbtest.Request{Opcode: 2})
+	if err := SelectAllEvents(X, 0x200001); err != nil {
+		t.Fatalf("SelectAllEvents: %s", err)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+
+	req := reply.Received()
+	if len(req) != 16 || xgb.Get32(req[4:]) != 0x200001 ||
+		xgb.Get32(req[8:]) != xproto.CwEventMask {
+		t.Fatalf("Expected an event mask for the window, but got %v.",
+			req)
+	}
+	mask := xgb.Get32(req[12:])
+	exclusive := uint32(xproto.EventMaskButtonPress |
+		xproto.EventMaskSubstructureRedirect |
+		xproto.EventMaskResizeRedirect)
+	if mask&exclusive != 0 {
+		t.Fatalf("The mask %#x has bits only one client may select.",
+			mask)
+	}
+	if mask&xproto.EventMaskPropertyChange == 0 {
+		t.Fatalf("The mask %#x doesn't select PropertyNotify.", mask)
+	}
+}
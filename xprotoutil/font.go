@@ -0,0 +1,50 @@
+package xprotoutil
+
+import (
+	"errors"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// ErrNoFont is returned by LoadFont when the X server has no font matching
+// any of the patterns.
+var ErrNoFont = errors.New("xprotoutil: no font matches the patterns")
+
+// LoadFont opens the first font out of 'patterns' (X Logical Font
+// Descriptions, which may have wildcards, or aliases like "fixed") the X
+// server has, e.g.:
+//
+//	font, err := xprotoutil.LoadFont(X,
+//		"-*-dejavu sans mono-medium-r-normal--14-*-*-*-*-*-iso10646-1",
+//		"-misc-fixed-medium-r-normal--13-*-*-*-*-*-iso10646-1",
+//		"fixed")
+//
+// A pattern no font matches is skipped, but any other error is returned
+// right away.
+func LoadFont(c *xgb.Conn, patterns ...string) (xproto.Font, error) {
+	font, err := xproto.NewFontId(c)
+	if err != nil {
+		return 0, err
+	}
+	for _, pattern := range patterns {
+		err := xproto.OpenFontChecked(c, font, uint16(len(pattern)),
+			pattern).Check()
+		if err == nil {
+			return font, nil
+		}
+		// The id is still free if the font couldn't be opened, so it
+		// can be tried with the next pattern, or given back. Unless the
+		// X server says the id itself is bad, that is.
+		if _, ok := err.(xproto.NameError); ok {
+			continue
+		}
+		if _, ok := err.(xproto.IDChoiceError); !ok {
+			c.FreeId(uint32(font))
+		}
+		return 0, err
+	}
+	c.FreeId(uint32(font))
+	return 0, ErrNoFont
+}
@@ -0,0 +1,41 @@
+package xprotoutil
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestLoadFont opens a font on a mock server that doesn't have the one
+// asked for first.
+func TestLoadFont(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	server.Expect(xgbtest.Request{Opcode: 45}).WithError(xproto.BadName, 0)
+	server.Expect(xgbtest.Request{Opcode: 45})
+	if _, err := LoadFont(X, "-*-nonexistent-*", "fixed"); err != nil {
+		t.Fatalf("LoadFont: %s", err)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+
+	openFont := server.Expect(xgbtest.Request{Opcode: 45}).
+		WithError(xproto.BadName, 0)
+	if _, err := LoadFont(X, "-*-nonexistent-*"); err != ErrNoFont {
+		t.Fatalf("Expected ErrNoFont, but got %v.", err)
+	}
+	// The id of the font that couldn't be opened is free again.
+	font := xgb.Get32(openFont.Received()[4:])
+	if id, err := X.NewId(); err != nil || id != font {
+		t.Fatalf("Expected the font id %#x to be given out again, "+
+			"but got (%#x, %v).", font, id, err)
+	}
+	if _, err := LoadFont(X); err != ErrNoFont {
+		t.Fatalf("Expected ErrNoFont without patterns, but got %v.",
+			err)
+	}
+}
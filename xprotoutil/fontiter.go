@@ -0,0 +1,58 @@
+//go:build go1.23
+
+package xprotoutil
+
+import (
+	"iter"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// FontInfoIter returns an iterator over the fonts matching 'pattern' (at
+// most 'maxNames' of them), with their information, as
+// xproto.ListFontsWithInfo returns them:
+//
+//	for info, err := range xprotoutil.FontInfoIter(X, "*-iso10646-1", 50) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(info.Name)
+//	}
+//
+// The request is sent when the iteration starts. The last reply, which
+// only marks the end of the list, isn't yielded. An error ends the
+// iteration. If the loop is left early, the rest of the replies are still
+// waited for, since the connection can't read past them otherwise.
+func FontInfoIter(c *xgb.Conn, pattern string,
+	maxNames uint16) iter.Seq2[xproto.ListFontsWithInfoReply, error] {
+
+	return func(yield func(xproto.ListFontsWithInfoReply, error) bool) {
+		cookie := xproto.ListFontsWithInfoAll(c, maxNames, pattern)
+		for {
+			reply, err := cookie.Reply()
+			if err != nil {
+				yield(xproto.ListFontsWithInfoReply{}, err)
+				return
+			}
+			if reply.NameLen == 0 {
+				return
+			}
+			if !yield(*reply, nil) {
+				drainFontInfo(cookie)
+				return
+			}
+		}
+	}
+}
+
+// drainFontInfo reads the replies to 'cookie' up to the last one.
+func drainFontInfo(cookie xproto.ListFontsWithInfoCookie) {
+	for {
+		reply, err := cookie.Reply()
+		if err != nil || reply.NameLen == 0 {
+			return
+		}
+	}
+}
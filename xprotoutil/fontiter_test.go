@@ -0,0 +1,56 @@
+//go:build go1.23
+
+package xprotoutil
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestFontInfoIter lists the fonts of a mock server twice, leaving the loop
+// early the second time.
+func TestFontInfoIter(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	fontInfo := func(name string) []byte {
+		reply := make([]byte, 60+len(name))
+		reply[1] = byte(len(name))
+		copy(reply[60:], name)
+		return reply
+	}
+	replies := [][]byte{fontInfo("fixed"), fontInfo("cursor"),
+		make([]byte, 60)}
+	server.Expect(xgbtest.Request{Opcode: 50}).WithReplies(replies...)
+	server.Expect(xgbtest.Request{Opcode: 50}).WithReplies(replies...)
+
+	var names []string
+	for info, err := range FontInfoIter(X, "*", 10) {
+		if err != nil {
+			t.Fatalf("FontInfoIter: %s", err)
+		}
+		names = append(names, info.Name)
+	}
+	if len(names) != 2 || names[0] != "fixed" || names[1] != "cursor" {
+		t.Fatalf("Expected fixed and cursor, but got %q.", names)
+	}
+
+	for info, err := range FontInfoIter(X, "*", 10) {
+		if err != nil || info.Name != "fixed" {
+			t.Fatalf("Expected fixed, but got %q (%v).",
+				info.Name, err)
+		}
+		break
+	}
+
+	// The connection still gets the responses to later requests.
+	server.Expect(xgbtest.Request{Opcode: 45})
+	if _, err := LoadFont(X, "fixed"); err != nil {
+		t.Fatalf("LoadFont: %s", err)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
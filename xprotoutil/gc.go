@@ -0,0 +1,171 @@
+package xprotoutil
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// GCBuilder collects the values of a graphics context, so that they don't
+// have to be put in the order of their bits in the value mask by hand. Its
+// zero value has no values set, and its methods return the builder so that
+// calls can be chained:
+//
+//	gc, err := new(xprotoutil.GCBuilder).
+//		Foreground(screen.BlackPixel).
+//		LineWidth(2).
+//		Create(X, xproto.Drawable(win))
+type GCBuilder struct {
+	values valueList
+}
+
+// set sets the value with the bit 'bit' (one of xproto.Gc*) to 'v'.
+func (b *GCBuilder) set(bit uint32, v uint32) *GCBuilder {
+	b.values.set(bit, v)
+	return b
+}
+
+// Function sets the logical operation, e.g., xproto.GxCopy.
+func (b *GCBuilder) Function(f uint32) *GCBuilder {
+	return b.set(xproto.GcFunction, f)
+}
+
+// PlaneMask sets the planes drawing operations affect.
+func (b *GCBuilder) PlaneMask(mask uint32) *GCBuilder {
+	return b.set(xproto.GcPlaneMask, mask)
+}
+
+// Foreground sets the foreground pixel.
+func (b *GCBuilder) Foreground(pixel uint32) *GCBuilder {
+	return b.set(xproto.GcForeground, pixel)
+}
+
+// Background sets the background pixel.
+func (b *GCBuilder) Background(pixel uint32) *GCBuilder {
+	return b.set(xproto.GcBackground, pixel)
+}
+
+// LineWidth sets the width of lines in pixels, 0 being the fastest.
+func (b *GCBuilder) LineWidth(width uint16) *GCBuilder {
+	return b.set(xproto.GcLineWidth, uint32(width))
+}
+
+// LineStyle sets the style of lines, e.g., xproto.LineStyleOnOffDash.
+func (b *GCBuilder) LineStyle(style uint32) *GCBuilder {
+	return b.set(xproto.GcLineStyle, style)
+}
+
+// CapStyle sets how the ends of lines are drawn, e.g., xproto.CapStyleRound.
+func (b *GCBuilder) CapStyle(style uint32) *GCBuilder {
+	return b.set(xproto.GcCapStyle, style)
+}
+
+// JoinStyle sets how lines are joined, e.g., xproto.JoinStyleMiter.
+func (b *GCBuilder) JoinStyle(style uint32) *GCBuilder {
+	return b.set(xproto.GcJoinStyle, style)
+}
+
+// FillStyle sets how shapes are filled, e.g., xproto.FillStyleTiled.
+func (b *GCBuilder) FillStyle(style uint32) *GCBuilder {
+	return b.set(xproto.GcFillStyle, style)
+}
+
+// FillRule sets the rule of FillPoly, e.g., xproto.FillRuleWinding.
+func (b *GCBuilder) FillRule(rule uint32) *GCBuilder {
+	return b.set(xproto.GcFillRule, rule)
+}
+
+// Tile sets the pixmap tiled shapes are filled with.
+func (b *GCBuilder) Tile(pixmap xproto.Pixmap) *GCBuilder {
+	return b.set(xproto.GcTile, uint32(pixmap))
+}
+
+// Stipple sets the bitmap stippled shapes are filled with.
+func (b *GCBuilder) Stipple(pixmap xproto.Pixmap) *GCBuilder {
+	return b.set(xproto.GcStipple, uint32(pixmap))
+}
+
+// TileStippleOrigin sets the origin of the tile or stipple.
+func (b *GCBuilder) TileStippleOrigin(x, y int16) *GCBuilder {
+	b.set(xproto.GcTileStippleOriginX, uint32(int32(x)))
+	return b.set(xproto.GcTileStippleOriginY, uint32(int32(y)))
+}
+
+// Font sets the font text is drawn with.
+func (b *GCBuilder) Font(font xproto.Font) *GCBuilder {
+	return b.set(xproto.GcFont, uint32(font))
+}
+
+// SubwindowMode sets whether drawing is clipped by the children of a
+// window, e.g., xproto.SubwindowModeIncludeInferiors.
+func (b *GCBuilder) SubwindowMode(mode uint32) *GCBuilder {
+	return b.set(xproto.GcSubwindowMode, mode)
+}
+
+// GraphicsExposures sets whether CopyArea and CopyPlane generate
+// GraphicsExposure events.
+func (b *GCBuilder) GraphicsExposures(on bool) *GCBuilder {
+	return b.set(xproto.GcGraphicsExposures, boolValue(on))
+}
+
+// ClipOrigin sets the origin of the clip mask.
+func (b *GCBuilder) ClipOrigin(x, y int16) *GCBuilder {
+	b.set(xproto.GcClipOriginX, uint32(int32(x)))
+	return b.set(xproto.GcClipOriginY, uint32(int32(y)))
+}
+
+// ClipMask sets the bitmap drawing is clipped to, or xproto.PixmapNone not
+// to clip drawing.
+func (b *GCBuilder) ClipMask(pixmap xproto.Pixmap) *GCBuilder {
+	return b.set(xproto.GcClipMask, uint32(pixmap))
+}
+
+// DashOffset sets the pixel of the dash pattern lines start with.
+func (b *GCBuilder) DashOffset(offset uint16) *GCBuilder {
+	return b.set(xproto.GcDashOffset, uint32(offset))
+}
+
+// Dashes sets the length of both the dashes and the gaps of dashed lines.
+// Patterns of different lengths have to be set with xproto.SetDashes.
+func (b *GCBuilder) Dashes(length byte) *GCBuilder {
+	return b.set(xproto.GcDashList, uint32(length))
+}
+
+// ArcMode sets how PolyFillArc fills arcs, e.g., xproto.ArcModePieSlice.
+func (b *GCBuilder) ArcMode(mode uint32) *GCBuilder {
+	return b.set(xproto.GcArcMode, mode)
+}
+
+// Values returns the value mask and the value list of the values set, as
+// CreateGC and ChangeGC take them.
+func (b *GCBuilder) Values() (uint32, []uint32) {
+	return b.values.list()
+}
+
+// Create creates a graphics context with the values set for drawables of
+// the same root and depth as 'drawable'.
+func (b *GCBuilder) Create(c *xgb.Conn,
+	drawable xproto.Drawable) (xproto.Gcontext, error) {
+
+	gc, err := xproto.NewGcontextId(c)
+	if err != nil {
+		return 0, err
+	}
+	mask, list := b.Values()
+	err = xproto.CreateGCChecked(c, gc, drawable, mask, list).Check()
+	if err != nil {
+		// The id is still free, unless it is what the X server
+		// says is bad.
+		if _, ok := err.(xproto.IDChoiceError); !ok {
+			c.FreeId(uint32(gc))
+		}
+		return 0, err
+	}
+	return gc, nil
+}
+
+// Change sets the values set in the builder on the graphics context 'gc'.
+func (b *GCBuilder) Change(c *xgb.Conn, gc xproto.Gcontext) error {
+	mask, list := b.Values()
+	return xproto.ChangeGCChecked(c, gc, mask, list).Check()
+}
@@ -0,0 +1,54 @@
+package xprotoutil
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestGCBuilder sets values out of the order of their bits, and makes sure
+// they end up in that order anyway.
+func TestGCBuilder(t *testing.T) {
+	b := new(GCBuilder).
+		Font(0x400001).
+		LineWidth(3).
+		TileStippleOrigin(-1, 2).
+		Foreground(0xff0000).
+		GraphicsExposures(false).
+		Function(xproto.GxXor)
+
+	wantMask := uint32(xproto.GcFunction | xproto.GcForeground |
+		xproto.GcLineWidth | xproto.GcTileStippleOriginX |
+		xproto.GcTileStippleOriginY | xproto.GcFont |
+		xproto.GcGraphicsExposures)
+	want := []uint32{xproto.GxXor, 0xff0000, 3, 0xffffffff, 2, 0x400001, 0}
+	mask, list := b.Values()
+	if mask != wantMask {
+		t.Errorf("Expected the mask %#x, but got %#x.", wantMask, mask)
+	}
+	if len(list) != len(want) {
+		t.Fatalf("Expected %v, but got %v.", want, list)
+	}
+	for i := range want {
+		if list[i] != want[i] {
+			t.Fatalf("Expected %v, but got %v.", want, list)
+		}
+	}
+
+	// Setting a value again replaces it.
+	b.LineWidth(5)
+	if _, list := b.Values(); list[2] != 5 {
+		t.Errorf("Expected the line width 5, but got %d.", list[2])
+	}
+
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+	server.Expect(xgbtest.Request{Opcode: 55})
+	if _, err := b.Create(X, 0x200001); err != nil {
+		t.Fatalf("Create: %s", err)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
@@ -0,0 +1,51 @@
+package xprotoutil
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// Geometry is the geometry of a window, with its position relative to the
+// root window rather than to its parent. See GetAbsoluteGeometry.
+type Geometry struct {
+	Root xproto.Window
+
+	// X and Y are the position of the outer top-left corner of the
+	// border of the window, like in xproto.GetGeometryReply.
+	X, Y int16
+
+	// Width and Height leave out the border.
+	Width, Height uint16
+
+	BorderWidth uint16
+	Depth       byte
+}
+
+// GetAbsoluteGeometry returns the geometry of 'win', with its position
+// translated to the coordinates of its root window by TranslateCoordinates.
+// It takes two round trips to the X server, since the root window is only
+// known once GetGeometry is answered.
+func GetAbsoluteGeometry(c *xgb.Conn, win xproto.Window) (Geometry, error) {
+	geom, err := xproto.GetGeometry(c, xproto.Drawable(win)).Reply()
+	if err != nil {
+		return Geometry{}, err
+	}
+	// (0, 0) is the inner top-left corner of the window, inside its
+	// border.
+	pos, err := xproto.TranslateCoordinates(c, win, geom.Root,
+		0, 0).Reply()
+	if err != nil {
+		return Geometry{}, err
+	}
+	border := int16(geom.BorderWidth)
+	return Geometry{
+		Root:        geom.Root,
+		X:           pos.DstX - border,
+		Y:           pos.DstY - border,
+		Width:       geom.Width,
+		Height:      geom.Height,
+		BorderWidth: geom.BorderWidth,
+		Depth:       geom.Depth,
+	}, nil
+}
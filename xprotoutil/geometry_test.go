@@ -0,0 +1,44 @@
+package xprotoutil
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestGetAbsoluteGeometry gets the geometry of a window with a border from
+// a mock server.
+func TestGetAbsoluteGeometry(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	geom := make([]byte, 32)
+	geom[1] = 24
+	xgb.Put32(geom[8:], 0x100)
+	xgb.Put16(geom[12:], 10)
+	xgb.Put16(geom[14:], 20)
+	xgb.Put16(geom[16:], 300)
+	xgb.Put16(geom[18:], 200)
+	xgb.Put16(geom[20:], 2)
+	server.Expect(xgbtest.Request{Opcode: 14}).WithData(geom)
+	pos := make([]byte, 32)
+	pos[1] = 1
+	xgb.Put16(pos[12:], 112)
+	xgb.Put16(pos[14:], 222)
+	server.Expect(xgbtest.Request{Opcode: 40}).WithData(pos)
+
+	got, err := GetAbsoluteGeometry(X, 0x200001)
+	if err != nil {
+		t.Fatalf("GetAbsoluteGeometry: %s", err)
+	}
+	want := Geometry{Root: 0x100, X: 110, Y: 220, Width: 300, Height: 200,
+		BorderWidth: 2, Depth: 24}
+	if got != want {
+		t.Fatalf("Expected %+v, but got %+v.", want, got)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
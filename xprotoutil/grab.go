@@ -0,0 +1,27 @@
+package xprotoutil
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// WithServerGrab grabs the X server, calls 'fn' and ungrabs the server,
+// even if 'fn' panics. While the server is grabbed, it handles the requests
+// of no other client, so 'fn' should be short, and mustn't wait for other
+// clients.
+//
+// The error of 'fn' is returned, or the one of UngrabServer if 'fn'
+// returned none. 'fn' isn't called if the server couldn't be grabbed.
+func WithServerGrab(c *xgb.Conn, fn func() error) (err error) {
+	if err := xproto.GrabServerChecked(c).Check(); err != nil {
+		return err
+	}
+	defer func() {
+		ungrabErr := xproto.UngrabServerChecked(c).Check()
+		if err == nil {
+			err = ungrabErr
+		}
+	}()
+	return fn()
+}
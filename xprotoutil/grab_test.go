@@ -0,0 +1,51 @@
+package xprotoutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestWithServerGrab grabs a mock server around functions that succeed,
+// fail and panic.
+func TestWithServerGrab(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	expectGrab := func() {
+		server.Expect(xgbtest.Request{Opcode: 36})
+		server.Expect(xgbtest.Request{Opcode: 37})
+	}
+
+	expectGrab()
+	called := false
+	err := WithServerGrab(X, func() error {
+		called = true
+		return nil
+	})
+	if err != nil || !called {
+		t.Fatalf("Expected fn to be called, but got %v.", err)
+	}
+
+	expectGrab()
+	fnErr := errors.New("fn failed")
+	err = WithServerGrab(X, func() error { return fnErr })
+	if err != fnErr {
+		t.Fatalf("Expected the error of fn, but got %v.", err)
+	}
+
+	expectGrab()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("Expected the panic of fn.")
+			}
+		}()
+		WithServerGrab(X, func() error { panic("fn panicked") })
+	}()
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
@@ -0,0 +1,424 @@
+// Package xprotoutil has helpers for core protocol requests that take more
+// work to use than a single call. They cover:
+//
+//   - images: uploading a Go image with PutGoImage, and reading one back
+//     with GetGoImage;
+//   - value lists: GCBuilder and WindowAttributeBuilder build those of
+//     CreateGC and CreateWindow (and ChangeGC and ChangeWindowAttributes);
+//   - resources: loading the first font that matches (LoadFont), listing
+//     fonts (FontInfoIter), and allocating colors;
+//   - windows: absolute geometry, the window tree, visuals, and waiting for
+//     the window manager to ask for a window to be closed (WMClient);
+//   - requests and events: sending several checked requests at once
+//     (Pipeline), grabbing the server, sending typed events, and running an
+//     event loop (RunLoop).
+package xprotoutil
+
+import (
+	"errors"
+	"image"
+	"math"
+	"math/bits"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// ErrUnsupportedFormat is returned by PutGoImage and GetGoImage when the X
+// server stores pixels of the depth of the drawable in a way they can't
+// convert images to or from, e.g., with less than 8 bits per pixel (except
+// for bitmaps, in GetGoImage) or as colormap indices.
+var ErrUnsupportedFormat = errors.New("xprotoutil: unsupported pixmap format")
+
+// ErrShortImage is returned by GetGoImage when the X server sends less data
+// than an image of the size asked for needs.
+var ErrShortImage = errors.New("xprotoutil: the image data is too short")
+
+// putImageHeader is the length of a PutImage request without its data.
+const putImageHeader = 24
+
+// PutGoImage draws 'img' on 'drawable' with 'gc', with the top left corner
+// of its bounds at (x, y). The image is converted to the pixel format of the
+// depth of the drawable (in ZPixmap format), and sent in as many PutImage
+// requests as needed for each to fit in the maximum request length. Only the
+// part of the image that ends up at coordinates from 0 to 32767 (the largest
+// a request can give) is sent, since the rest can't be drawn anyway.
+//
+// The masks of the red, green and blue channels are those of the first
+// TrueColor visual of that depth on any screen, or 0xff0000, 0xff00 and 0xff
+// if there is none. For a depth of 32, the remaining bits hold the alpha
+// channel; otherwise the colors are premultiplied and alpha is dropped, as
+// if the image was drawn over black. DirectColor and PseudoColor visuals
+// aren't taken into account.
+//
+// PutGoImage waits for every request to be processed, and returns the first
+// error the X server sent for any of them.
+func PutGoImage(c *xgb.Conn, drawable xproto.Drawable, gc xproto.Gcontext,
+	img image.Image, x, y int16) error {
+
+	geom, err := xproto.GetGeometry(c, drawable).Reply()
+	if err != nil {
+		return err
+	}
+	setup := xproto.Setup(c)
+	format, ok := pixmapFormat(setup, geom.Depth)
+	if !ok || format.BitsPerPixel < 8 || format.BitsPerPixel%8 != 0 {
+		return ErrUnsupportedFormat
+	}
+	var visual *xproto.VisualInfo
+	if v, ok := trueColorVisual(setup, geom.Depth); ok {
+		visual = &v
+	}
+	zp := newZPixmap(setup, format, visual)
+
+	// 'origin' is the point of the image that goes at (0, 0). Cropping
+	// the image also keeps the width and height of the chunks within the
+	// range of a request.
+	origin := img.Bounds().Min.Sub(image.Pt(int(x), int(y)))
+	bounds := img.Bounds().Intersect(image.Rect(0, 0,
+		math.MaxInt16+1, math.MaxInt16+1).Add(origin))
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil
+	}
+
+	// Cut the image in chunks of full rows if they fit in a request, or of
+	// as much of a row as fits otherwise.
+	avail := int(c.MaxRequestSizeBytes()) - putImageHeader
+	chunkWidth := width
+	if limit := avail / zp.bytesPerPixel; limit < chunkWidth {
+		chunkWidth = limit
+	}
+	for chunkWidth > 0 && zp.stride(chunkWidth) > avail {
+		chunkWidth--
+	}
+	if chunkWidth <= 0 {
+		return xgb.ErrRequestTooLong
+	}
+
+	var cookies []xproto.PutImageCookie
+	for cy := 0; cy < height; {
+		rows := avail / zp.stride(chunkWidth)
+		if rows > height-cy {
+			rows = height - cy
+		}
+		for cx := 0; cx < width; cx += chunkWidth {
+			w := chunkWidth
+			if w > width-cx {
+				w = width - cx
+			}
+			rect := image.Rect(cx, cy, cx+w, cy+rows).
+				Add(bounds.Min)
+			data := zp.encode(img, rect)
+			at := rect.Min.Sub(origin)
+			cookie := xproto.PutImageChecked(c,
+				xproto.ImageFormatZPixmap, drawable, gc,
+				uint16(w), uint16(rows), int16(at.X),
+				int16(at.Y), 0, geom.Depth, data)
+			cookies = append(cookies, cookie)
+		}
+		cy += rows
+	}
+	for _, cookie := range cookies {
+		if err := cookie.Check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetGoImage returns the contents of the rectangle of 'drawable' at (x, y)
+// of size 'w' by 'h', with the planes not in 'planeMask' cleared, as read
+// with GetImage in ZPixmap format. Its bounds start at (0, 0).
+//
+// Bitmaps (of depth 1) and drawables with a StaticGray or GrayScale visual
+// give an *image.Gray, with the pixel values spread over the range of gray
+// levels (so a 1 of depth 1 is white). Those with a TrueColor or DirectColor
+// visual give an *image.NRGBA, whose channels are expanded to 8 bits (e.g.,
+// 0x1f of 5 bits becomes 0xff). Colormaps aren't taken into account, so
+// images of PseudoColor and StaticColor visuals give ErrUnsupportedFormat.
+// Pixmaps have no visual; those of depth 1 are bitmaps, and the others are
+// read with the channel masks PutGoImage would use for them.
+func GetGoImage(c *xgb.Conn, drawable xproto.Drawable, x, y int16,
+	w, h uint16, planeMask uint32) (image.Image, error) {
+
+	reply, err := xproto.GetImage(c, xproto.ImageFormatZPixmap, drawable,
+		x, y, w, h, planeMask).Reply()
+	if err != nil {
+		return nil, err
+	}
+	setup := xproto.Setup(c)
+	format, ok := pixmapFormat(setup, reply.Depth)
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	class := byte(xproto.VisualClassStaticGray)
+	var visual *xproto.VisualInfo
+	if reply.Depth != 1 {
+		class = xproto.VisualClassTrueColor
+		if v, ok := findVisual(setup, reply.Visual); ok {
+			class, visual = v.Class, &v
+		} else if v, ok := trueColorVisual(setup, reply.Depth); ok {
+			visual = &v
+		}
+	}
+	bpp := format.BitsPerPixel
+	if bpp != 1 && (bpp < 8 || bpp%8 != 0) {
+		return nil, ErrUnsupportedFormat
+	}
+
+	zp := newZPixmap(setup, format, visual)
+	rect := image.Rect(0, 0, int(w), int(h))
+	if len(reply.Data) < zp.stride(rect.Dx())*rect.Dy() {
+		return nil, ErrShortImage
+	}
+	switch class {
+	case xproto.VisualClassStaticGray, xproto.VisualClassGrayScale:
+		return zp.decodeGray(reply.Data, rect), nil
+	case xproto.VisualClassTrueColor, xproto.VisualClassDirectColor:
+		return zp.decodeNRGBA(reply.Data, rect), nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// pixmapFormat returns the format of ZPixmap images of depth 'depth'.
+func pixmapFormat(setup *xproto.SetupInfo, depth byte) (xproto.Format,
+	bool) {
+
+	for _, format := range setup.PixmapFormats {
+		if format.Depth == depth {
+			return format, true
+		}
+	}
+	return xproto.Format{}, false
+}
+
+// channel is where a color channel is in a pixel value.
+type channel struct {
+	shift, bits uint
+}
+
+// newChannel returns the channel of the bits set in 'mask'.
+func newChannel(mask uint32) channel {
+	ch := channel{
+		shift: uint(bits.TrailingZeros32(mask)),
+		bits:  uint(bits.OnesCount32(mask)),
+	}
+	if ch.bits > 16 {
+		ch.bits = 16
+	}
+	return ch
+}
+
+// get returns the value of the channel in 'pixel', expanded to 8 bits.
+func (ch channel) get(pixel uint32) uint8 {
+	if ch.bits == 0 {
+		return 0
+	}
+	max := uint32(1)<<ch.bits - 1
+	return uint8((pixel >> ch.shift & max) * 0xff / max)
+}
+
+// value returns the pixel bits of the 16 bit color value 'v'.
+func (ch channel) value(v uint32) uint32 {
+	if ch.bits == 0 {
+		return 0
+	}
+	return v >> (16 - ch.bits) << ch.shift
+}
+
+// zpixmap converts images to and from the pixels of a ZPixmap format.
+type zpixmap struct {
+	red, green, blue, alpha channel
+
+	depth         uint
+	bitsPerPixel  int
+	bytesPerPixel int
+	scanlinePad   int // in bytes
+	msbFirst      bool
+}
+
+// newZPixmap returns a zpixmap for images in 'format', with the channel
+// masks of 'visual', or 0xff0000, 0xff00 and 0xff if it is nil.
+func newZPixmap(setup *xproto.SetupInfo, format xproto.Format,
+	visual *xproto.VisualInfo) *zpixmap {
+
+	red, green, blue := uint32(0xff0000), uint32(0xff00), uint32(0xff)
+	if visual != nil {
+		red, green = visual.RedMask, visual.GreenMask
+		blue = visual.BlueMask
+	}
+	zp := &zpixmap{
+		red:           newChannel(red),
+		green:         newChannel(green),
+		blue:          newChannel(blue),
+		depth:         uint(format.Depth),
+		bitsPerPixel:  int(format.BitsPerPixel),
+		bytesPerPixel: int(format.BitsPerPixel) / 8,
+		scanlinePad:   int(format.ScanlinePad) / 8,
+	}
+	zp.msbFirst = setup.ImageByteOrder == xproto.ImageOrderMSBFirst
+	if format.BitsPerPixel == 1 {
+		zp.msbFirst = setup.BitmapFormatBitOrder ==
+			xproto.ImageOrderMSBFirst
+	}
+	if format.Depth == 32 {
+		zp.alpha = newChannel(^(red | green | blue))
+	}
+	if zp.scanlinePad == 0 {
+		zp.scanlinePad = 1
+	}
+	return zp
+}
+
+// trueColorVisual returns the first TrueColor visual of depth 'depth'.
+func trueColorVisual(setup *xproto.SetupInfo, depth byte) (
+	xproto.VisualInfo, bool) {
+
+	for _, screen := range setup.Roots {
+		for _, d := range screen.AllowedDepths {
+			if d.Depth != depth {
+				continue
+			}
+			for _, visual := range d.Visuals {
+				if visual.Class == xproto.VisualClassTrueColor {
+					return visual, true
+				}
+			}
+		}
+	}
+	return xproto.VisualInfo{}, false
+}
+
+// stride returns the length in bytes of a row of 'width' pixels, with its
+// padding.
+func (zp *zpixmap) stride(width int) int {
+	n := (width*zp.bitsPerPixel + 7) / 8
+	return (n + zp.scanlinePad - 1) / zp.scanlinePad * zp.scanlinePad
+}
+
+// encode returns the pixels of 'rect' in 'img', as PutImage takes them.
+func (zp *zpixmap) encode(img image.Image, rect image.Rectangle) []byte {
+	stride := zp.stride(rect.Dx())
+	data := make([]byte, stride*rect.Dy())
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		row := data[(y-rect.Min.Y)*stride:]
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			pixel := zp.red.value(r) | zp.green.value(g) |
+				zp.blue.value(b) | zp.alpha.value(a)
+
+			n := zp.bytesPerPixel
+			buf := row[(x-rect.Min.X)*n:]
+			for i := 0; i < n; i++ {
+				shift := uint(8 * i)
+				if zp.msbFirst {
+					shift = uint(8 * (n - 1 - i))
+				}
+				buf[i] = byte(pixel >> shift)
+			}
+		}
+	}
+	return data
+}
+
+// pixel returns the value of pixel 'x' of the row of pixels 'row'.
+func (zp *zpixmap) pixel(row []byte, x int) uint32 {
+	if zp.bitsPerPixel == 1 {
+		bit := uint(x % 8)
+		if zp.msbFirst {
+			bit = 7 - bit
+		}
+		return uint32(row[x/8]>>bit) & 1
+	}
+
+	n := zp.bytesPerPixel
+	buf := row[x*n:]
+	var pixel uint32
+	for i := 0; i < n; i++ {
+		shift := uint(8 * i)
+		if zp.msbFirst {
+			shift = uint(8 * (n - 1 - i))
+		}
+		pixel |= uint32(buf[i]) << shift
+	}
+	return pixel
+}
+
+// decodeGray returns the gray levels of the pixels in 'data', an image of
+// the size of 'rect'.
+func (zp *zpixmap) decodeGray(data []byte, rect image.Rectangle) *image.Gray {
+	img := image.NewGray(rect)
+	gray := channel{bits: zp.depth}
+	if gray.bits > 16 {
+		gray.bits = 16
+	}
+	stride := zp.stride(rect.Dx())
+	for y := 0; y < rect.Dy(); y++ {
+		row := data[y*stride:]
+		for x := 0; x < rect.Dx(); x++ {
+			img.Pix[y*img.Stride+x] = gray.get(zp.pixel(row, x))
+		}
+	}
+	return img
+}
+
+// decodeNRGBA returns the colors of the pixels in 'data', an image of the
+// size of 'rect'. Pixels with an alpha channel are premultiplied, as in
+// images with the ARGB visual of depth 32, and are converted back.
+func (zp *zpixmap) decodeNRGBA(data []byte,
+	rect image.Rectangle) *image.NRGBA {
+
+	img := image.NewNRGBA(rect)
+	stride := zp.stride(rect.Dx())
+	for y := 0; y < rect.Dy(); y++ {
+		row := data[y*stride:]
+		for x := 0; x < rect.Dx(); x++ {
+			pixel := zp.pixel(row, x)
+			r, g, b := zp.red.get(pixel), zp.green.get(pixel),
+				zp.blue.get(pixel)
+			a := uint8(0xff)
+			if zp.alpha.bits != 0 {
+				a = zp.alpha.get(pixel)
+				r = unpremultiply(r, a)
+				g = unpremultiply(g, a)
+				b = unpremultiply(b, a)
+			}
+			i := y*img.Stride + 4*x
+			img.Pix[i], img.Pix[i+1] = r, g
+			img.Pix[i+2], img.Pix[i+3] = b, a
+		}
+	}
+	return img
+}
+
+// unpremultiply returns the color value 'v', premultiplied by 'alpha', as it
+// was before.
+func unpremultiply(v, alpha uint8) uint8 {
+	if alpha == 0 || alpha == 0xff {
+		return v
+	}
+	n := (uint32(v)*0xff + uint32(alpha)/2) / uint32(alpha)
+	if n > 0xff {
+		n = 0xff
+	}
+	return uint8(n)
+}
+
+// findVisual returns the visual with id 'id'.
+func findVisual(setup *xproto.SetupInfo, id xproto.Visualid) (
+	xproto.VisualInfo, bool) {
+
+	for _, screen := range setup.Roots {
+		for _, d := range screen.AllowedDepths {
+			for _, visual := range d.Visuals {
+				if visual.VisualId == id {
+					return visual, true
+				}
+			}
+		}
+	}
+	return xproto.VisualInfo{}, false
+}
@@ -0,0 +1,223 @@
+package xprotoutil
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestPutGoImage uploads a 5x2 image to a drawable of depth 24 on a mock
+// server whose requests can only hold 4 pixels, so the image has to be cut
+// in two chunks per row.
+func TestPutGoImage(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{
+		MaximumRequestLength: (putImageHeader + 16) / 4,
+		PixmapFormats: []xproto.Format{
+			{Depth: 1, BitsPerPixel: 1, ScanlinePad: 32},
+			{Depth: 24, BitsPerPixel: 32, ScanlinePad: 32},
+		},
+	})
+	defer X.Close()
+
+	img := image.NewRGBA(image.Rect(10, 20, 15, 22))
+	for x := 10; x < 15; x++ {
+		img.Set(x, 20, color.RGBA{0x11, 0x22, byte(x), 0xff})
+		img.Set(x, 21, color.RGBA{0x80, 0, 0, 0x80})
+	}
+
+	geom := make([]byte, 32)
+	geom[1] = 24 // depth
+	server.Expect(xgbtest.Request{Opcode: 14}).WithData(geom)
+	// BIG-REQUESTS isn't available.
+	server.Expect(xgbtest.Request{Opcode: 98}).WithData(make([]byte, 32))
+
+	chunks := []struct {
+		x, y, width int16
+		pixels      []uint32
+	}{
+		{100, 50, 4, []uint32{0x11220a, 0x11220b, 0x11220c, 0x11220d}},
+		{104, 50, 1, []uint32{0x11220e}},
+		{100, 51, 4, []uint32{0x800000, 0x800000, 0x800000, 0x800000}},
+		{104, 51, 1, []uint32{0x800000}},
+	}
+	for _, chunk := range chunks {
+		body := make([]byte, 20+4*len(chunk.pixels))
+		xgb.Put32(body, 0x200001)     // drawable
+		xgb.Put32(body[4:], 0x200002) // gc
+		xgb.Put16(body[8:], uint16(chunk.width))
+		xgb.Put16(body[10:], 1) // height
+		xgb.Put16(body[12:], uint16(chunk.x))
+		xgb.Put16(body[14:], uint16(chunk.y))
+		body[17] = 24 // depth
+		for i, pixel := range chunk.pixels {
+			xgb.Put32(body[20+4*i:], pixel)
+		}
+		server.Expect(xgbtest.Request{Opcode: 72,
+			Data: xproto.ImageFormatZPixmap, Body: body})
+	}
+
+	err := PutGoImage(X, 0x200001, 0x200002, img, 100, 50)
+	if err != nil {
+		t.Fatalf("PutGoImage: %s", err)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
+
+// TestPutGoImageCrop uploads an image wider than a PutImage request can say,
+// partly left of the drawable, and makes sure only what can be drawn is
+// sent.
+func TestPutGoImageCrop(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{
+		MaximumRequestLength: 0xffff,
+		PixmapFormats: []xproto.Format{
+			{Depth: 8, BitsPerPixel: 8, ScanlinePad: 32},
+		},
+	})
+	defer X.Close()
+
+	geom := make([]byte, 32)
+	geom[1] = 8 // depth
+	server.Expect(xgbtest.Request{Opcode: 14}).WithData(geom)
+	// BIG-REQUESTS lets a request hold all 70000 pixels.
+	ext := make([]byte, 32)
+	ext[8], ext[9] = 1, 130 // present, major opcode
+	server.Expect(xgbtest.Request{Opcode: 98}).WithData(ext)
+	enable := make([]byte, 32)
+	xgb.Put32(enable[8:], 1<<20)
+	server.Expect(xgbtest.Request{Opcode: 130}).WithData(enable)
+	put := server.Expect(xgbtest.Request{Opcode: 72,
+		Data: xproto.ImageFormatZPixmap})
+
+	img := image.NewGray(image.Rect(0, 0, 70000, 1))
+	if err := PutGoImage(X, 0x200001, 0x200002, img, -100, 0); err != nil {
+		t.Fatalf("PutGoImage: %s", err)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+	req := put.Received()
+	width, x := xgb.Get16(req[12:]), int16(xgb.Get16(req[16:]))
+	if width != 32768 || x != 0 {
+		t.Fatalf("Expected 32768 pixels at x = 0, but got %d at %d.",
+			width, x)
+	}
+}
+
+// TestEncode converts pixels to the formats of depths 16 and 32, in both
+// byte orders.
+func TestEncode(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, color.RGBA{0xff, 0x80, 0x08, 0xff})
+
+	setup := &xproto.SetupInfo{
+		Roots: []xproto.ScreenInfo{{
+			AllowedDepths: []xproto.DepthInfo{{
+				Depth: 16,
+				Visuals: []xproto.VisualInfo{{
+					Class:     xproto.VisualClassTrueColor,
+					RedMask:   0xf800,
+					GreenMask: 0x07e0,
+					BlueMask:  0x001f,
+				}},
+			}},
+		}},
+	}
+	tests := []struct {
+		depth, bpp byte
+		byteOrder  byte
+		want       []byte
+	}{
+		{16, 16, xproto.ImageOrderLSBFirst, []byte{0x01, 0xfc, 0, 0}},
+		{16, 16, xproto.ImageOrderMSBFirst, []byte{0xfc, 0x01, 0, 0}},
+		{32, 32, xproto.ImageOrderLSBFirst,
+			[]byte{0x08, 0x80, 0xff, 0xff}},
+		{32, 32, xproto.ImageOrderMSBFirst,
+			[]byte{0xff, 0xff, 0x80, 0x08}},
+	}
+	for _, test := range tests {
+		setup.ImageByteOrder = test.byteOrder
+		format := xproto.Format{Depth: test.depth,
+			BitsPerPixel: test.bpp, ScanlinePad: 32}
+		visual := &setup.Roots[0].AllowedDepths[0].Visuals[0]
+		if test.depth != 16 {
+			visual = nil
+		}
+		enc := newZPixmap(setup, format, visual)
+		data := enc.encode(img, img.Bounds())
+		if !bytes.Equal(data, test.want) {
+			t.Errorf("Depth %d, byte order %d: expected %x, "+
+				"but got %x.", test.depth, test.byteOrder,
+				test.want, data)
+		}
+	}
+}
+
+// TestGetGoImage reads images of depths 16, 1 and 32 from a mock server.
+func TestGetGoImage(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{
+		PixmapFormats: []xproto.Format{
+			{Depth: 1, BitsPerPixel: 1, ScanlinePad: 32},
+			{Depth: 16, BitsPerPixel: 16, ScanlinePad: 32},
+			{Depth: 32, BitsPerPixel: 32, ScanlinePad: 32},
+		},
+		Roots: []xproto.ScreenInfo{{
+			AllowedDepths: []xproto.DepthInfo{{
+				Depth: 16,
+				Visuals: []xproto.VisualInfo{{
+					VisualId:  0x21,
+					Class:     xproto.VisualClassTrueColor,
+					RedMask:   0xf800,
+					GreenMask: 0x07e0,
+					BlueMask:  0x001f,
+				}},
+			}},
+		}},
+	})
+	defer X.Close()
+
+	expect := func(depth byte, visual uint32, data ...byte) {
+		reply := make([]byte, 32+len(data))
+		reply[1] = depth
+		xgb.Put32(reply[8:], visual)
+		copy(reply[32:], data)
+		server.Expect(xgbtest.Request{Opcode: 73,
+			Data: xproto.ImageFormatZPixmap}).WithData(reply)
+	}
+	expect(16, 0x21, 0x00, 0xf8, 0x1f, 0x00) // red, and blue
+	expect(1, 0, 0x05, 0, 0, 0)              // on, off, on
+	expect(32, 0, 0x40, 0x00, 0x40, 0x80)    // half transparent purple
+
+	tests := []struct {
+		width  uint16
+		colors []color.Color
+	}{
+		{2, []color.Color{color.NRGBA{0xff, 0, 0, 0xff},
+			color.NRGBA{0, 0, 0xff, 0xff}}},
+		{3, []color.Color{color.Gray{0xff}, color.Gray{0},
+			color.Gray{0xff}}},
+		{1, []color.Color{color.NRGBA{0x80, 0, 0x80, 0x80}}},
+	}
+	for i, test := range tests {
+		img, err := GetGoImage(X, 0x200001, 0, 0, test.width, 1,
+			0xffffffff)
+		if err != nil {
+			t.Fatalf("GetGoImage %d: %s", i, err)
+		}
+		for x, want := range test.colors {
+			if got := img.At(x, 0); got != want {
+				t.Errorf("Image %d, pixel %d: expected %v, "+
+					"but got %v.", i, x, want, got)
+			}
+		}
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
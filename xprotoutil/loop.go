@@ -0,0 +1,56 @@
+package xprotoutil
+
+import (
+	"errors"
+	"time"
+
+	"github.com/BurntSushi/xgb"
+)
+
+// ErrBadFrameRate is returned by RunLoop when the frame rate isn't
+// positive.
+var ErrBadFrameRate = errors.New("xprotoutil: the frame rate must be " +
+	"positive")
+
+// RunLoop runs a render loop at 'fps' frames per second: on every frame,
+// it calls 'onEvent' for each event waiting in the queue of the
+// connection, then 'onFrame' to draw the frame. Events aren't waited for,
+// so a frame isn't held up when there are none. A frame that takes too
+// long only delays the next one; frames aren't made up for.
+//
+// RunLoop returns the first X error from an unchecked request (as an
+// xgb.Error), right away and without drawing the frame, so that it can be
+// dealt with before RunLoop is called again; the events behind it are left
+// in the queue. It returns nil once the connection is closed with Close, or
+// the xgb.ConnectionClosedError if it is lost.
+func RunLoop(c *xgb.Conn, fps int, onEvent func(xgb.Event),
+	onFrame func()) error {
+
+	if fps <= 0 {
+		return ErrBadFrameRate
+	}
+	ticker := time.NewTicker(time.Second / time.Duration(fps))
+	defer ticker.Stop()
+
+	for {
+		<-ticker.C
+		for {
+			ev, err, ok := c.PollForEvent()
+			if !ok {
+				break
+			}
+			if err != nil {
+				if _, isX := err.(xgb.Error); isX {
+					return err
+				}
+				var lost xgb.ConnectionClosedError
+				if errors.As(err, &lost) {
+					return err
+				}
+				return nil
+			}
+			onEvent(ev)
+		}
+		onFrame()
+	}
+}
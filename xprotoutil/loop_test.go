@@ -0,0 +1,64 @@
+package xprotoutil
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestRunLoop runs a render loop on a mock server that sends an event in
+// between frames, until the connection is closed.
+func TestRunLoop(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+
+	var events []xgb.Event
+	frames := 0
+	onEvent := func(ev xgb.Event) { events = append(events, ev) }
+	onFrame := func() {
+		frames++
+		switch frames {
+		case 1:
+			server.SendEvent(xproto.MapNotifyEvent{Window: 1})
+			// Have the event arrive before the next frame.
+			X.Sync()
+		case 3:
+			X.Close()
+		}
+	}
+	if err := RunLoop(X, 1000, onEvent, onFrame); err != nil {
+		t.Fatalf("RunLoop: %s", err)
+	}
+	if frames != 3 {
+		t.Fatalf("Expected 3 frames, but got %d.", frames)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected the MapNotify event, but got %v.", events)
+	}
+
+	if err := RunLoop(X, 0, onEvent, onFrame); err != ErrBadFrameRate {
+		t.Fatalf("Expected ErrBadFrameRate, but got %v.", err)
+	}
+}
+
+// TestRunLoopError makes sure RunLoop returns the X error of an unchecked
+// request instead of dropping it.
+func TestRunLoopError(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	server.Expect(xgbtest.Request{Opcode: 8}).
+		WithError(xproto.BadWindow, 0x201)
+	xproto.MapWindow(X, 0x201)
+	X.Sync()
+
+	frames := 0
+	err := RunLoop(X, 1000, func(xgb.Event) {}, func() { frames++ })
+	if _, ok := err.(xproto.WindowError); !ok {
+		t.Fatalf("Expected a WindowError, but got %v.", err)
+	}
+	if frames != 0 {
+		t.Fatalf("Expected no frame, but got %d.", frames)
+	}
+}
@@ -0,0 +1,36 @@
+package xprotoutil
+
+import (
+	"github.com/BurntSushi/xgb"
+)
+
+// CheckedRequest is the cookie of a checked request, like the one
+// xproto.MapWindowChecked returns.
+type CheckedRequest interface {
+	Check() error
+}
+
+// Pipeline waits for the checked requests 'reqs', made on the connection
+// 'c', to be processed, and returns the error each of them caused (or nil),
+// in the order of 'reqs'. Since a request is sent when its cookie is made,
+// passing the cookies straight to Pipeline sends every request before
+// waiting for any:
+//
+//	errs := xprotoutil.Pipeline(X,
+//		xproto.MapWindowChecked(X, win),
+//		xproto.ConfigureWindowChecked(X, win, mask, values),
+//	)
+//
+// Waiting takes a single round trip to the X server, however many requests
+// there are, rather than one per request.
+func Pipeline(c *xgb.Conn, reqs ...CheckedRequest) []error {
+	// Once the X server has answered a request sent after them, every
+	// cookie has had its response, so none of the Checks waits.
+	c.Sync()
+
+	errs := make([]error, len(reqs))
+	for i, req := range reqs {
+		errs[i] = req.Check()
+	}
+	return errs
+}
@@ -0,0 +1,42 @@
+package xprotoutil
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestPipeline maps three windows on a mock server, the second of which
+// doesn't exist.
+func TestPipeline(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	server.Expect(xgbtest.Request{Opcode: 8})
+	server.Expect(xgbtest.Request{Opcode: 8}).WithError(xproto.BadWindow, 2)
+	server.Expect(xgbtest.Request{Opcode: 8})
+
+	errs := Pipeline(X,
+		xproto.MapWindowChecked(X, 1),
+		xproto.MapWindowChecked(X, 2),
+		xproto.MapWindowChecked(X, 3),
+	)
+	if len(errs) != 3 || errs[0] != nil || errs[2] != nil {
+		t.Fatalf("Expected only the second request to fail, "+
+			"but got %v.", errs)
+	}
+	if err, ok := errs[1].(xproto.WindowError); !ok || err.BadValue != 2 {
+		t.Errorf("Expected a WindowError about window 2, but got %v.",
+			errs[1])
+	}
+
+	server.Expect(xgbtest.Request{Opcode: 8})
+	errs = Pipeline(X, xproto.MapWindowChecked(X, 1))
+	if len(errs) != 1 || errs[0] != nil {
+		t.Errorf("Expected a single nil error, but got %v.", errs)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+}
@@ -0,0 +1,89 @@
+package xprotoutil
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// WindowTree is a window and the windows below it, as QueryTreeRecursive
+// found them.
+type WindowTree struct {
+	Window xproto.Window
+
+	// Parent is the tree of the parent of the window, or nil for the
+	// window the walk started from.
+	Parent *WindowTree
+
+	// Children are the trees of the children of the window, in stacking
+	// order from the bottom up, like in xproto.QueryTreeReply. They are
+	// nil if the walk didn't go that far down.
+	Children []*WindowTree
+}
+
+// QueryTreeRecursive returns the tree of windows below 'root', going down
+// 'depth' levels: 0 for 'root' alone, 1 to add its children, and so on. A
+// negative depth walks the whole tree.
+//
+// The QueryTree requests of a level are all sent before waiting for any of
+// their replies, so the walk takes one round trip to the X server per
+// level. The windows destroyed during the walk are left out, but an error
+// for 'root' itself is returned.
+func QueryTreeRecursive(c *xgb.Conn, root xproto.Window,
+	depth int) (*WindowTree, error) {
+
+	tree := &WindowTree{Window: root}
+	level := []*WindowTree{tree}
+	for ; depth != 0 && len(level) > 0; depth-- {
+		cookies := make([]xproto.QueryTreeCookie, len(level))
+		for i, node := range level {
+			cookies[i] = xproto.QueryTree(c, node.Window)
+		}
+
+		var next []*WindowTree
+		for i, node := range level {
+			reply, err := cookies[i].Reply()
+			if err != nil {
+				_, destroyed := err.(xproto.WindowError)
+				if !destroyed || node == tree {
+					return nil, err
+				}
+				node.Parent.remove(node)
+				continue
+			}
+			node.Children = make([]*WindowTree, len(reply.Children))
+			for j, child := range reply.Children {
+				node.Children[j] = &WindowTree{
+					Window: child,
+					Parent: node,
+				}
+			}
+			next = append(next, node.Children...)
+		}
+		level = next
+	}
+	return tree, nil
+}
+
+// remove removes the subtree 'child' from the children of 't'.
+func (t *WindowTree) remove(child *WindowTree) {
+	for i, c := range t.Children {
+		if c == child {
+			t.Children = append(t.Children[:i], t.Children[i+1:]...)
+			return
+		}
+	}
+}
+
+// Walk calls 'fn' for every window of the tree, parents before their
+// children, with the depth of the window below the root of the tree.
+func (t *WindowTree) Walk(fn func(tree *WindowTree, depth int)) {
+	t.walk(fn, 0)
+}
+
+func (t *WindowTree) walk(fn func(tree *WindowTree, depth int), depth int) {
+	fn(t, depth)
+	for _, child := range t.Children {
+		child.walk(fn, depth+1)
+	}
+}
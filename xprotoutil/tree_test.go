@@ -0,0 +1,62 @@
+package xprotoutil
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestQueryTreeRecursive walks the windows of a mock server, one of which
+// is destroyed during the walk.
+func TestQueryTreeRecursive(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	children := func(wins ...uint32) []byte {
+		reply := make([]byte, 32+4*len(wins))
+		xgb.Put16(reply[16:], uint16(len(wins)))
+		for i, win := range wins {
+			xgb.Put32(reply[32+4*i:], win)
+		}
+		return reply
+	}
+	server.Expect(xgbtest.Request{Opcode: 15}).WithData(children(2, 3))
+	server.Expect(xgbtest.Request{Opcode: 15}).WithData(children(4))
+	server.Expect(xgbtest.Request{Opcode: 15}).
+		WithError(xproto.BadWindow, 3)
+	server.Expect(xgbtest.Request{Opcode: 15}).WithData(children())
+	tree, err := QueryTreeRecursive(X, 1, -1)
+	if err != nil {
+		t.Fatalf("QueryTreeRecursive: %s", err)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+
+	var wins []xproto.Window
+	tree.Walk(func(node *WindowTree, depth int) {
+		if depth > 0 && node.Parent.Children == nil {
+			t.Errorf("Window %d isn't a child of its parent.",
+				node.Window)
+		}
+		wins = append(wins, node.Window)
+	})
+	if len(wins) != 3 || wins[0] != 1 || wins[1] != 2 || wins[2] != 4 {
+		t.Fatalf("Expected windows 1, 2 and 4, but got %v.", wins)
+	}
+
+	// The root alone takes no request.
+	tree, err = QueryTreeRecursive(X, 1, 0)
+	if err != nil || tree.Window != 1 || tree.Children != nil {
+		t.Fatalf("Expected the root alone, but got %+v (%v).",
+			tree, err)
+	}
+
+	server.Expect(xgbtest.Request{Opcode: 15}).
+		WithError(xproto.BadWindow, 1)
+	if _, err := QueryTreeRecursive(X, 1, 1); err == nil {
+		t.Fatalf("Expected an error for a root that doesn't exist.")
+	}
+}
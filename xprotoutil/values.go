@@ -0,0 +1,39 @@
+package xprotoutil
+
+import (
+	"math/bits"
+)
+
+// valueList is the value mask and the value list of requests like CreateGC
+// and CreateWindow, which take the values in the order of their bits in the
+// mask.
+type valueList struct {
+	mask   uint32
+	values [32]uint32 // indexed by the bit of each value in the mask
+}
+
+// set sets the value with the bit 'bit' to 'v'.
+func (l *valueList) set(bit uint32, v uint32) {
+	l.mask |= bit
+	l.values[bits.TrailingZeros32(bit)] = v
+}
+
+// list returns the value mask and the values set, in the order they are
+// sent in.
+func (l *valueList) list() (uint32, []uint32) {
+	list := make([]uint32, 0, bits.OnesCount32(l.mask))
+	for i, v := range l.values {
+		if l.mask&(1<<uint(i)) != 0 {
+			list = append(list, v)
+		}
+	}
+	return l.mask, list
+}
+
+// boolValue returns the value of a boolean in a value list.
+func boolValue(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,69 @@
+package xprotoutil
+
+import (
+	"errors"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// ErrNoSuchVisual is returned by FindVisualInfo when the screen has no
+// visual with the id asked for, and by WindowAttributeBuilder.Create when
+// no screen has it.
+var ErrNoSuchVisual = errors.New("xprotoutil: no such visual on the screen")
+
+// visualCacheKey is the key of the cache of lookupVisual (see
+// xgb.Conn.Cache), which maps visualKeys to cachedVisuals.
+type visualCacheKey struct{}
+
+// visualKey is the key of a visual in the cache of lookupVisual.
+type visualKey struct {
+	screen int
+	id     xproto.Visualid
+}
+
+// cachedVisual is a visual in the cache of lookupVisual, with its depth.
+type cachedVisual struct {
+	visual xproto.VisualInfo
+	depth  byte
+}
+
+// FindVisualInfo returns the visual with id 'vid' of the screen numbered
+// 'screen'. The result is cached, so only the first call for a visual goes
+// through the setup information.
+func FindVisualInfo(c *xgb.Conn, screen int,
+	vid xproto.Visualid) (xproto.VisualInfo, error) {
+
+	cached, err := lookupVisual(c, screen, vid)
+	return cached.visual, err
+}
+
+// lookupVisual is FindVisualInfo, but also returns the depth the visual is
+// listed under, which is what CreateWindow needs along with the visual id.
+func lookupVisual(c *xgb.Conn, screen int,
+	vid xproto.Visualid) (cachedVisual, error) {
+
+	cache := c.Cache(visualCacheKey{})
+	key := visualKey{screen, vid}
+	if v, ok := cache.Load(key); ok {
+		return v.(cachedVisual), nil
+	}
+
+	setup := xproto.Setup(c)
+	if screen < 0 || screen >= len(setup.Roots) {
+		return cachedVisual{},
+			xgb.Errorf("xprotoutil: there is no screen %d", screen)
+	}
+	for _, d := range setup.Roots[screen].AllowedDepths {
+		for _, visual := range d.Visuals {
+			if visual.VisualId != vid {
+				continue
+			}
+			cached := cachedVisual{visual, d.Depth}
+			cache.Store(key, cached)
+			return cached, nil
+		}
+	}
+	return cachedVisual{}, ErrNoSuchVisual
+}
@@ -0,0 +1,50 @@
+package xprotoutil
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestFindVisualInfo looks up visuals of the second screen of a mock
+// server, and makes sure the ones found are cached.
+func TestFindVisualInfo(t *testing.T) {
+	X, _ := xgbtest.NewMockConn(xproto.SetupInfo{
+		Roots: []xproto.ScreenInfo{{}, {
+			AllowedDepths: []xproto.DepthInfo{
+				{Depth: 24, Visuals: []xproto.VisualInfo{
+					{VisualId: 0x21},
+				}},
+				{Depth: 32, Visuals: []xproto.VisualInfo{
+					{VisualId: 0x42},
+				}},
+			},
+		}},
+	})
+	defer X.Close()
+
+	visual, err := FindVisualInfo(X, 1, 0x42)
+	if err != nil {
+		t.Fatalf("FindVisualInfo: %s", err)
+	}
+	if visual.VisualId != 0x42 {
+		t.Errorf("Expected visual 0x42, but got %#x.", visual.VisualId)
+	}
+	cached, ok := X.Cache(visualCacheKey{}).Load(visualKey{1, 0x42})
+	if !ok {
+		t.Fatalf("Visual 0x42 wasn't cached.")
+	}
+	if depth := cached.(cachedVisual).depth; depth != 32 {
+		t.Errorf("Expected visual 0x42 to have depth 32, but got %d.",
+			depth)
+	}
+
+	if _, err := FindVisualInfo(X, 0, 0x42); err != ErrNoSuchVisual {
+		t.Errorf("Expected ErrNoSuchVisual on screen 0, but got %v.",
+			err)
+	}
+	if _, err := FindVisualInfo(X, 2, 0x42); err == nil {
+		t.Errorf("Expected an error for screen 2.")
+	}
+}
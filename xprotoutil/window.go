@@ -0,0 +1,202 @@
+package xprotoutil
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// WindowAttributeBuilder collects the attributes of a window, so that they
+// don't have to be put in the order of their bits in the value mask by
+// hand. Like GCBuilder, its zero value has no attributes set, and its
+// methods return the builder so that calls can be chained:
+//
+//	win, err := new(xprotoutil.WindowAttributeBuilder).
+//		BackPixel(screen.WhitePixel).
+//		EventMask(xproto.EventMaskExposure).
+//		Create(X, screen.Root, xproto.WindowClassInputOutput,
+//			screen.RootVisual, 0, 0, 500, 500, 0)
+type WindowAttributeBuilder struct {
+	values valueList
+}
+
+// set sets the attribute with the bit 'bit' (one of xproto.Cw*) to 'v'.
+func (b *WindowAttributeBuilder) set(bit uint32,
+	v uint32) *WindowAttributeBuilder {
+
+	b.values.set(bit, v)
+	return b
+}
+
+// BackPixmap sets the pixmap the background is tiled with, or one of
+// xproto.BackPixmapNone and xproto.BackPixmapParentRelative.
+func (b *WindowAttributeBuilder) BackPixmap(
+	pixmap xproto.Pixmap) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwBackPixmap, uint32(pixmap))
+}
+
+// BackPixel sets the pixel the background is filled with.
+func (b *WindowAttributeBuilder) BackPixel(
+	pixel uint32) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwBackPixel, pixel)
+}
+
+// BorderPixmap sets the pixmap the border is tiled with.
+func (b *WindowAttributeBuilder) BorderPixmap(
+	pixmap xproto.Pixmap) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwBorderPixmap, uint32(pixmap))
+}
+
+// BorderPixel sets the pixel the border is filled with.
+func (b *WindowAttributeBuilder) BorderPixel(
+	pixel uint32) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwBorderPixel, pixel)
+}
+
+// BitGravity sets where the contents of the window are moved to when it is
+// resized, e.g., xproto.GravityNorthWest.
+func (b *WindowAttributeBuilder) BitGravity(
+	gravity uint32) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwBitGravity, gravity)
+}
+
+// WinGravity sets where the window is moved to when its parent is resized,
+// e.g., xproto.GravityCenter.
+func (b *WindowAttributeBuilder) WinGravity(
+	gravity uint32) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwWinGravity, gravity)
+}
+
+// BackingStore sets when the X server should keep the contents of the
+// window, e.g., xproto.BackingStoreWhenMapped.
+func (b *WindowAttributeBuilder) BackingStore(
+	when uint32) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwBackingStore, when)
+}
+
+// BackingPlanes sets the planes the backing store has to keep.
+func (b *WindowAttributeBuilder) BackingPlanes(
+	planes uint32) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwBackingPlanes, planes)
+}
+
+// BackingPixel sets the pixel planes that aren't kept are restored with.
+func (b *WindowAttributeBuilder) BackingPixel(
+	pixel uint32) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwBackingPixel, pixel)
+}
+
+// OverrideRedirect sets whether the window manager should leave the window
+// alone, as for menus and tooltips.
+func (b *WindowAttributeBuilder) OverrideRedirect(
+	on bool) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwOverrideRedirect, boolValue(on))
+}
+
+// SaveUnder sets whether the X server should keep the contents of the
+// windows below the window while it is mapped.
+func (b *WindowAttributeBuilder) SaveUnder(on bool) *WindowAttributeBuilder {
+	return b.set(xproto.CwSaveUnder, boolValue(on))
+}
+
+// EventMask sets the events (xproto.EventMask* ORed together) sent to the
+// client.
+func (b *WindowAttributeBuilder) EventMask(
+	mask uint32) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwEventMask, mask)
+}
+
+// DontPropagate sets the device events that shouldn't be propagated to the
+// ancestors of the window.
+func (b *WindowAttributeBuilder) DontPropagate(
+	mask uint32) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwDontPropagate, mask)
+}
+
+// Colormap sets the colormap of the window. It has to be set when the
+// visual of the window isn't the one of its parent.
+func (b *WindowAttributeBuilder) Colormap(
+	cmap xproto.Colormap) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwColormap, uint32(cmap))
+}
+
+// Cursor sets the cursor shown in the window, or xproto.CursorNone to show
+// the one of its parent.
+func (b *WindowAttributeBuilder) Cursor(
+	cursor xproto.Cursor) *WindowAttributeBuilder {
+
+	return b.set(xproto.CwCursor, uint32(cursor))
+}
+
+// Values returns the value mask and the value list of the attributes set,
+// as CreateWindow and ChangeWindowAttributes take them.
+func (b *WindowAttributeBuilder) Values() (uint32, []uint32) {
+	return b.values.list()
+}
+
+// Create creates a window with the attributes set as a child of 'parent'.
+// 'class' is one of xproto.WindowClass*, and 'visual' is 0 to use the
+// visual of the parent. Otherwise, the depth of the window is the one the
+// visual is listed under (see FindVisualInfo), or ErrNoSuchVisual is
+// returned if no screen has that visual.
+func (b *WindowAttributeBuilder) Create(c *xgb.Conn, parent xproto.Window,
+	class uint16, visual xproto.Visualid, x, y int16,
+	width, height, borderWidth uint16) (xproto.Window, error) {
+
+	depth := byte(0) // CopyFromParent
+	if visual != 0 && class != xproto.WindowClassInputOnly {
+		var err error
+		if depth, err = visualDepth(c, visual); err != nil {
+			return 0, err
+		}
+	}
+
+	win, err := xproto.NewWindowId(c)
+	if err != nil {
+		return 0, err
+	}
+	mask, list := b.Values()
+	err = xproto.CreateWindowChecked(c, depth, win, parent, x, y,
+		width, height, borderWidth, class, visual, mask, list).Check()
+	if err != nil {
+		// The id is still free, unless it is what the X server
+		// says is bad.
+		if _, ok := err.(xproto.IDChoiceError); !ok {
+			c.FreeId(uint32(win))
+		}
+		return 0, err
+	}
+	return win, nil
+}
+
+// Change sets the attributes set in the builder on the window 'win'.
+func (b *WindowAttributeBuilder) Change(c *xgb.Conn,
+	win xproto.Window) error {
+
+	mask, list := b.Values()
+	return xproto.ChangeWindowAttributesChecked(c, win, mask, list).Check()
+}
+
+// visualDepth returns the depth of the visual 'vid' on whichever screen has
+// it.
+func visualDepth(c *xgb.Conn, vid xproto.Visualid) (byte, error) {
+	for i := range xproto.Setup(c).Roots {
+		if cached, err := lookupVisual(c, i, vid); err == nil {
+			return cached.depth, nil
+		}
+	}
+	return 0, ErrNoSuchVisual
+}
@@ -0,0 +1,63 @@
+package xprotoutil
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestWindowAttributeBuilder creates a window with a visual of depth 32,
+// and makes sure the depth and the attributes are sent in the right order.
+func TestWindowAttributeBuilder(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{
+		Roots: []xproto.ScreenInfo{{
+			AllowedDepths: []xproto.DepthInfo{
+				{Depth: 32, Visuals: []xproto.VisualInfo{
+					{VisualId: 0x42},
+				}},
+			},
+		}},
+	})
+	defer X.Close()
+
+	b := new(WindowAttributeBuilder).
+		Colormap(0x400001).
+		OverrideRedirect(true).
+		BorderPixel(0).
+		EventMask(xproto.EventMaskExposure)
+	wantMask := uint32(xproto.CwBorderPixel | xproto.CwOverrideRedirect |
+		xproto.CwEventMask | xproto.CwColormap)
+	want := []uint32{0, 1, xproto.EventMaskExposure, 0x400001}
+	mask, list := b.Values()
+	if mask != wantMask {
+		t.Errorf("Expected the mask %#x, but got %#x.", wantMask, mask)
+	}
+	if len(list) != len(want) {
+		t.Fatalf("Expected %v, but got %v.", want, list)
+	}
+	for i := range want {
+		if list[i] != want[i] {
+			t.Fatalf("Expected %v, but got %v.", want, list)
+		}
+	}
+
+	server.Expect(xgbtest.Request{Opcode: 1, Data: 32})
+	win, err := b.Create(X, 0x100, xproto.WindowClassInputOutput, 0x42,
+		0, 0, 10, 10, 0)
+	if err != nil {
+		t.Fatalf("Create: %s", err)
+	}
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+	if win == 0 {
+		t.Errorf("Expected a window id, but got 0.")
+	}
+
+	_, err = b.Create(X, 0x100, xproto.WindowClassInputOutput, 0x21,
+		0, 0, 10, 10, 0)
+	if err != ErrNoSuchVisual {
+		t.Errorf("Expected ErrNoSuchVisual, but got %v.", err)
+	}
+}
@@ -0,0 +1,99 @@
+package xprotoutil
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/icccm"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// ErrConnClosed is returned by WMClient.WaitForClose when the connection is
+// closed (or the WMClient is) before the window manager asks for the window
+// to be closed.
+var ErrConnClosed = errors.New("xprotoutil: the connection was closed")
+
+// WMClient takes part in the WM_DELETE_WINDOW protocol of the ICCCM for a
+// top-level window, so that the window manager asks the client to close the
+// window rather than killing the connection. See NewWMClient.
+type WMClient struct {
+	c            *xgb.Conn
+	win          xproto.Window
+	protocols    xproto.Atom // WM_PROTOCOLS
+	deleteWindow xproto.Atom // WM_DELETE_WINDOW
+	events       <-chan xgb.Event
+}
+
+// NewWMClient adds WM_DELETE_WINDOW to the WM_PROTOCOLS property of 'win'.
+// It subscribes to the ClientMessage events of the connection (see
+// xgb.Conn.Subscribe), so they are no longer returned by WaitForEvent and
+// the like until Close is called.
+func NewWMClient(c *xgb.Conn, win xproto.Window) (*WMClient, error) {
+	atoms, err := xproto.InternAtoms(c,
+		[]string{"WM_PROTOCOLS", "WM_DELETE_WINDOW"})
+	if err != nil {
+		return nil, err
+	}
+	protocols, err := icccm.GetWMProtocols(c, win)
+	if err != nil && err != icccm.ErrPropertyNotSet {
+		return nil, err
+	}
+
+	mask := xgb.NewEventMask(xproto.ClientMessage)
+	wm := &WMClient{
+		c:            c,
+		win:          win,
+		protocols:    atoms["WM_PROTOCOLS"],
+		deleteWindow: atoms["WM_DELETE_WINDOW"],
+		events:       c.Subscribe(mask),
+	}
+	for _, name := range protocols {
+		if name == "WM_DELETE_WINDOW" {
+			return wm, nil
+		}
+	}
+	protocols = append(protocols, "WM_DELETE_WINDOW")
+	if err := icccm.SetWMProtocols(c, win, protocols); err != nil {
+		wm.Close()
+		return nil, err
+	}
+	return wm, nil
+}
+
+// WaitForClose blocks until the window manager sends the WM_DELETE_WINDOW
+// client message for the window, which means the user wants it closed, and
+// returns nil. It returns the error of 'ctx' if it is done first, and
+// ErrConnClosed if the connection is closed first. Other ClientMessage
+// events are dropped.
+func (wm *WMClient) WaitForClose(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-wm.events:
+			if !ok {
+				return ErrConnClosed
+			}
+			if wm.isDelete(ev) {
+				return nil
+			}
+		}
+	}
+}
+
+// isDelete returns whether 'ev' is the WM_DELETE_WINDOW client message for
+// the window.
+func (wm *WMClient) isDelete(ev xgb.Event) bool {
+	msg, ok := ev.(xproto.ClientMessageEvent)
+	return ok && msg.Window == wm.win && msg.Type == wm.protocols &&
+		msg.Format == 32 &&
+		xproto.Atom(msg.Data.Data32[0]) == wm.deleteWindow
+}
+
+// Close ends the subscription to ClientMessage events. WM_PROTOCOLS is left
+// as it is.
+func (wm *WMClient) Close() {
+	wm.c.Unsubscribe(wm.events)
+}
@@ -0,0 +1,58 @@
+package xprotoutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xgbtest"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestWMClient registers WM_DELETE_WINDOW for a window on a mock server,
+// which then sends a message for another protocol before the one asking
+// for the window to be closed.
+func TestWMClient(t *testing.T) {
+	X, server := xgbtest.NewMockConn(xproto.SetupInfo{})
+	defer X.Close()
+
+	const win, protocols, deleteWindow, takeFocus = 0x200001, 300, 301, 302
+	for _, atom := range []uint32{protocols, deleteWindow} {
+		reply := make([]byte, 32)
+		xgb.Put32(reply[8:], atom)
+		server.Expect(xgbtest.Request{Opcode: 16}).WithData(reply)
+	}
+
+	// WM_PROTOCOLS isn't set yet.
+	server.Expect(xgbtest.Request{Opcode: 20}).WithData(make([]byte, 32))
+	server.Expect(xgbtest.Request{Opcode: 18})
+	wm, err := NewWMClient(X, win)
+	if err != nil {
+		t.Fatalf("NewWMClient: %s", err)
+	}
+	defer wm.Close()
+	if err := server.Verify(); err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+
+	send := func(protocol uint32) {
+		server.SendEvent(xproto.ClientMessageEvent{
+			Format: 32,
+			Window: win,
+			Type:   protocols,
+			Data: xproto.ClientMessageDataUnionData32New(
+				[]uint32{protocol, 0, 0, 0, 0}),
+		})
+	}
+	send(takeFocus)
+	send(deleteWindow)
+	if err := wm.WaitForClose(context.Background()); err != nil {
+		t.Fatalf("WaitForClose: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := wm.WaitForClose(ctx); err != context.Canceled {
+		t.Fatalf("Expected context.Canceled, but got %v.", err)
+	}
+}
@@ -19,7 +19,7 @@ func Init(c *xgb.Conn) error {
 	case err != nil:
 		return err
 	case !reply.Present:
-		return xgb.Errorf("No extension named SELinux could be found on on the server.")
+		return xproto.ExtNotAvailableError{Name: "SELinux", Reply: reply}
 	}
 
 	xgb.ExtLock.Lock()
@@ -164,7 +164,7 @@ type QueryVersionCookie struct {
 // QueryVersion sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryVersionCookie.Reply()
 func QueryVersion(c *xgb.Conn, ClientMajor byte, ClientMinor byte) QueryVersionCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'QueryVersion' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -175,7 +175,7 @@ func QueryVersion(c *xgb.Conn, ClientMajor byte, ClientMinor byte) QueryVersionC
 // QueryVersionUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryVersionUnchecked(c *xgb.Conn, ClientMajor byte, ClientMinor byte) QueryVersionCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'QueryVersion' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -233,7 +233,7 @@ func queryVersionRequest(c *xgb.Conn, ClientMajor byte, ClientMinor byte) []byte
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 0 // request opcode
@@ -259,7 +259,7 @@ type SetDeviceCreateContextCookie struct {
 // SetDeviceCreateContext sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetDeviceCreateContext(c *xgb.Conn, ContextLen uint32, Context string) SetDeviceCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetDeviceCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -270,7 +270,7 @@ func SetDeviceCreateContext(c *xgb.Conn, ContextLen uint32, Context string) SetD
 // SetDeviceCreateContextChecked sends a checked request.
 // If an error occurs, it can be retrieved using SetDeviceCreateContextCookie.Check()
 func SetDeviceCreateContextChecked(c *xgb.Conn, ContextLen uint32, Context string) SetDeviceCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetDeviceCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -291,7 +291,7 @@ func setDeviceCreateContextRequest(c *xgb.Conn, ContextLen uint32, Context strin
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 1 // request opcode
@@ -317,7 +317,7 @@ type GetDeviceCreateContextCookie struct {
 // GetDeviceCreateContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetDeviceCreateContextCookie.Reply()
 func GetDeviceCreateContext(c *xgb.Conn) GetDeviceCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetDeviceCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -328,7 +328,7 @@ func GetDeviceCreateContext(c *xgb.Conn) GetDeviceCreateContextCookie {
 // GetDeviceCreateContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetDeviceCreateContextUnchecked(c *xgb.Conn) GetDeviceCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetDeviceCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -393,7 +393,7 @@ func getDeviceCreateContextRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 2 // request opcode
@@ -413,7 +413,7 @@ type SetDeviceContextCookie struct {
 // SetDeviceContext sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetDeviceContext(c *xgb.Conn, Device uint32, ContextLen uint32, Context string) SetDeviceContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetDeviceContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -424,7 +424,7 @@ func SetDeviceContext(c *xgb.Conn, Device uint32, ContextLen uint32, Context str
 // SetDeviceContextChecked sends a checked request.
 // If an error occurs, it can be retrieved using SetDeviceContextCookie.Check()
 func SetDeviceContextChecked(c *xgb.Conn, Device uint32, ContextLen uint32, Context string) SetDeviceContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetDeviceContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -445,7 +445,7 @@ func setDeviceContextRequest(c *xgb.Conn, Device uint32, ContextLen uint32, Cont
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 3 // request opcode
@@ -474,7 +474,7 @@ type GetDeviceContextCookie struct {
 // GetDeviceContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetDeviceContextCookie.Reply()
 func GetDeviceContext(c *xgb.Conn, Device uint32) GetDeviceContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetDeviceContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -485,7 +485,7 @@ func GetDeviceContext(c *xgb.Conn, Device uint32) GetDeviceContextCookie {
 // GetDeviceContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetDeviceContextUnchecked(c *xgb.Conn, Device uint32) GetDeviceContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetDeviceContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -550,7 +550,7 @@ func getDeviceContextRequest(c *xgb.Conn, Device uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 4 // request opcode
@@ -573,7 +573,7 @@ type SetWindowCreateContextCookie struct {
 // SetWindowCreateContext sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetWindowCreateContext(c *xgb.Conn, ContextLen uint32, Context string) SetWindowCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetWindowCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -584,7 +584,7 @@ func SetWindowCreateContext(c *xgb.Conn, ContextLen uint32, Context string) SetW
 // SetWindowCreateContextChecked sends a checked request.
 // If an error occurs, it can be retrieved using SetWindowCreateContextCookie.Check()
 func SetWindowCreateContextChecked(c *xgb.Conn, ContextLen uint32, Context string) SetWindowCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetWindowCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -605,7 +605,7 @@ func setWindowCreateContextRequest(c *xgb.Conn, ContextLen uint32, Context strin
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 5 // request opcode
@@ -631,7 +631,7 @@ type GetWindowCreateContextCookie struct {
 // GetWindowCreateContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetWindowCreateContextCookie.Reply()
 func GetWindowCreateContext(c *xgb.Conn) GetWindowCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetWindowCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -642,7 +642,7 @@ func GetWindowCreateContext(c *xgb.Conn) GetWindowCreateContextCookie {
 // GetWindowCreateContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetWindowCreateContextUnchecked(c *xgb.Conn) GetWindowCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetWindowCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -707,7 +707,7 @@ func getWindowCreateContextRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 6 // request opcode
@@ -727,7 +727,7 @@ type GetWindowContextCookie struct {
 // GetWindowContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetWindowContextCookie.Reply()
 func GetWindowContext(c *xgb.Conn, Window xproto.Window) GetWindowContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetWindowContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -738,7 +738,7 @@ func GetWindowContext(c *xgb.Conn, Window xproto.Window) GetWindowContextCookie
 // GetWindowContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetWindowContextUnchecked(c *xgb.Conn, Window xproto.Window) GetWindowContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetWindowContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -803,7 +803,7 @@ func getWindowContextRequest(c *xgb.Conn, Window xproto.Window) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 7 // request opcode
@@ -826,7 +826,7 @@ type SetPropertyCreateContextCookie struct {
 // SetPropertyCreateContext sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetPropertyCreateContext(c *xgb.Conn, ContextLen uint32, Context string) SetPropertyCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetPropertyCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -837,7 +837,7 @@ func SetPropertyCreateContext(c *xgb.Conn, ContextLen uint32, Context string) Se
 // SetPropertyCreateContextChecked sends a checked request.
 // If an error occurs, it can be retrieved using SetPropertyCreateContextCookie.Check()
 func SetPropertyCreateContextChecked(c *xgb.Conn, ContextLen uint32, Context string) SetPropertyCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetPropertyCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -858,7 +858,7 @@ func setPropertyCreateContextRequest(c *xgb.Conn, ContextLen uint32, Context str
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 8 // request opcode
@@ -884,7 +884,7 @@ type GetPropertyCreateContextCookie struct {
 // GetPropertyCreateContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetPropertyCreateContextCookie.Reply()
 func GetPropertyCreateContext(c *xgb.Conn) GetPropertyCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetPropertyCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -895,7 +895,7 @@ func GetPropertyCreateContext(c *xgb.Conn) GetPropertyCreateContextCookie {
 // GetPropertyCreateContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetPropertyCreateContextUnchecked(c *xgb.Conn) GetPropertyCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetPropertyCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -960,7 +960,7 @@ func getPropertyCreateContextRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 9 // request opcode
@@ -980,7 +980,7 @@ type SetPropertyUseContextCookie struct {
 // SetPropertyUseContext sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetPropertyUseContext(c *xgb.Conn, ContextLen uint32, Context string) SetPropertyUseContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetPropertyUseContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -991,7 +991,7 @@ func SetPropertyUseContext(c *xgb.Conn, ContextLen uint32, Context string) SetPr
 // SetPropertyUseContextChecked sends a checked request.
 // If an error occurs, it can be retrieved using SetPropertyUseContextCookie.Check()
 func SetPropertyUseContextChecked(c *xgb.Conn, ContextLen uint32, Context string) SetPropertyUseContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetPropertyUseContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1012,7 +1012,7 @@ func setPropertyUseContextRequest(c *xgb.Conn, ContextLen uint32, Context string
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 10 // request opcode
@@ -1038,7 +1038,7 @@ type GetPropertyUseContextCookie struct {
 // GetPropertyUseContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetPropertyUseContextCookie.Reply()
 func GetPropertyUseContext(c *xgb.Conn) GetPropertyUseContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetPropertyUseContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1049,7 +1049,7 @@ func GetPropertyUseContext(c *xgb.Conn) GetPropertyUseContextCookie {
 // GetPropertyUseContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetPropertyUseContextUnchecked(c *xgb.Conn) GetPropertyUseContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetPropertyUseContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1114,7 +1114,7 @@ func getPropertyUseContextRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 11 // request opcode
@@ -1134,7 +1134,7 @@ type GetPropertyContextCookie struct {
 // GetPropertyContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetPropertyContextCookie.Reply()
 func GetPropertyContext(c *xgb.Conn, Window xproto.Window, Property xproto.Atom) GetPropertyContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetPropertyContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1145,7 +1145,7 @@ func GetPropertyContext(c *xgb.Conn, Window xproto.Window, Property xproto.Atom)
 // GetPropertyContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetPropertyContextUnchecked(c *xgb.Conn, Window xproto.Window, Property xproto.Atom) GetPropertyContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetPropertyContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1210,7 +1210,7 @@ func getPropertyContextRequest(c *xgb.Conn, Window xproto.Window, Property xprot
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 12 // request opcode
@@ -1236,7 +1236,7 @@ type GetPropertyDataContextCookie struct {
 // GetPropertyDataContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetPropertyDataContextCookie.Reply()
 func GetPropertyDataContext(c *xgb.Conn, Window xproto.Window, Property xproto.Atom) GetPropertyDataContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetPropertyDataContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1247,7 +1247,7 @@ func GetPropertyDataContext(c *xgb.Conn, Window xproto.Window, Property xproto.A
 // GetPropertyDataContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetPropertyDataContextUnchecked(c *xgb.Conn, Window xproto.Window, Property xproto.Atom) GetPropertyDataContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetPropertyDataContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1312,7 +1312,7 @@ func getPropertyDataContextRequest(c *xgb.Conn, Window xproto.Window, Property x
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 13 // request opcode
@@ -1338,7 +1338,7 @@ type ListPropertiesCookie struct {
 // ListProperties sends a checked request.
 // If an error occurs, it will be returned with the reply by calling ListPropertiesCookie.Reply()
 func ListProperties(c *xgb.Conn, Window xproto.Window) ListPropertiesCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'ListProperties' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1349,7 +1349,7 @@ func ListProperties(c *xgb.Conn, Window xproto.Window) ListPropertiesCookie {
 // ListPropertiesUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ListPropertiesUnchecked(c *xgb.Conn, Window xproto.Window) ListPropertiesCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'ListProperties' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1410,7 +1410,7 @@ func listPropertiesRequest(c *xgb.Conn, Window xproto.Window) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 14 // request opcode
@@ -1433,7 +1433,7 @@ type SetSelectionCreateContextCookie struct {
 // SetSelectionCreateContext sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetSelectionCreateContext(c *xgb.Conn, ContextLen uint32, Context string) SetSelectionCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetSelectionCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1444,7 +1444,7 @@ func SetSelectionCreateContext(c *xgb.Conn, ContextLen uint32, Context string) S
 // SetSelectionCreateContextChecked sends a checked request.
 // If an error occurs, it can be retrieved using SetSelectionCreateContextCookie.Check()
 func SetSelectionCreateContextChecked(c *xgb.Conn, ContextLen uint32, Context string) SetSelectionCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetSelectionCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1465,7 +1465,7 @@ func setSelectionCreateContextRequest(c *xgb.Conn, ContextLen uint32, Context st
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 15 // request opcode
@@ -1491,7 +1491,7 @@ type GetSelectionCreateContextCookie struct {
 // GetSelectionCreateContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetSelectionCreateContextCookie.Reply()
 func GetSelectionCreateContext(c *xgb.Conn) GetSelectionCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetSelectionCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1502,7 +1502,7 @@ func GetSelectionCreateContext(c *xgb.Conn) GetSelectionCreateContextCookie {
 // GetSelectionCreateContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetSelectionCreateContextUnchecked(c *xgb.Conn) GetSelectionCreateContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetSelectionCreateContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1567,7 +1567,7 @@ func getSelectionCreateContextRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 16 // request opcode
@@ -1587,7 +1587,7 @@ type SetSelectionUseContextCookie struct {
 // SetSelectionUseContext sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetSelectionUseContext(c *xgb.Conn, ContextLen uint32, Context string) SetSelectionUseContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetSelectionUseContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1598,7 +1598,7 @@ func SetSelectionUseContext(c *xgb.Conn, ContextLen uint32, Context string) SetS
 // SetSelectionUseContextChecked sends a checked request.
 // If an error occurs, it can be retrieved using SetSelectionUseContextCookie.Check()
 func SetSelectionUseContextChecked(c *xgb.Conn, ContextLen uint32, Context string) SetSelectionUseContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'SetSelectionUseContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1619,7 +1619,7 @@ func setSelectionUseContextRequest(c *xgb.Conn, ContextLen uint32, Context strin
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 17 // request opcode
@@ -1645,7 +1645,7 @@ type GetSelectionUseContextCookie struct {
 // GetSelectionUseContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetSelectionUseContextCookie.Reply()
 func GetSelectionUseContext(c *xgb.Conn) GetSelectionUseContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetSelectionUseContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1656,7 +1656,7 @@ func GetSelectionUseContext(c *xgb.Conn) GetSelectionUseContextCookie {
 // GetSelectionUseContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetSelectionUseContextUnchecked(c *xgb.Conn) GetSelectionUseContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetSelectionUseContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1721,7 +1721,7 @@ func getSelectionUseContextRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 18 // request opcode
@@ -1741,7 +1741,7 @@ type GetSelectionContextCookie struct {
 // GetSelectionContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetSelectionContextCookie.Reply()
 func GetSelectionContext(c *xgb.Conn, Selection xproto.Atom) GetSelectionContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetSelectionContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1752,7 +1752,7 @@ func GetSelectionContext(c *xgb.Conn, Selection xproto.Atom) GetSelectionContext
 // GetSelectionContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetSelectionContextUnchecked(c *xgb.Conn, Selection xproto.Atom) GetSelectionContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetSelectionContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1817,7 +1817,7 @@ func getSelectionContextRequest(c *xgb.Conn, Selection xproto.Atom) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 19 // request opcode
@@ -1840,7 +1840,7 @@ type GetSelectionDataContextCookie struct {
 // GetSelectionDataContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetSelectionDataContextCookie.Reply()
 func GetSelectionDataContext(c *xgb.Conn, Selection xproto.Atom) GetSelectionDataContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetSelectionDataContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1851,7 +1851,7 @@ func GetSelectionDataContext(c *xgb.Conn, Selection xproto.Atom) GetSelectionDat
 // GetSelectionDataContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetSelectionDataContextUnchecked(c *xgb.Conn, Selection xproto.Atom) GetSelectionDataContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetSelectionDataContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1916,7 +1916,7 @@ func getSelectionDataContextRequest(c *xgb.Conn, Selection xproto.Atom) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 20 // request opcode
@@ -1939,7 +1939,7 @@ type ListSelectionsCookie struct {
 // ListSelections sends a checked request.
 // If an error occurs, it will be returned with the reply by calling ListSelectionsCookie.Reply()
 func ListSelections(c *xgb.Conn) ListSelectionsCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'ListSelections' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1950,7 +1950,7 @@ func ListSelections(c *xgb.Conn) ListSelectionsCookie {
 // ListSelectionsUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ListSelectionsUnchecked(c *xgb.Conn) ListSelectionsCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'ListSelections' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2011,7 +2011,7 @@ func listSelectionsRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 21 // request opcode
@@ -2031,7 +2031,7 @@ type GetClientContextCookie struct {
 // GetClientContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetClientContextCookie.Reply()
 func GetClientContext(c *xgb.Conn, Resource uint32) GetClientContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetClientContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2042,7 +2042,7 @@ func GetClientContext(c *xgb.Conn, Resource uint32) GetClientContextCookie {
 // GetClientContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetClientContextUnchecked(c *xgb.Conn, Resource uint32) GetClientContextCookie {
-	if _, ok := c.Extensions["SELINUX"]; !ok {
+	if _, ok := c.Extensions["SELinux"]; !ok {
 		panic("Cannot issue request 'GetClientContext' using the uninitialized extension 'SELinux'. xselinux.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2107,7 +2107,7 @@ func getClientContextRequest(c *xgb.Conn, Resource uint32) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["SELINUX"]
+	buf[b] = c.Extensions["SELinux"]
 	b += 1
 
 	buf[b] = 22 // request opcode
@@ -0,0 +1,57 @@
+package xselinux
+
+import (
+	"os"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// TestGetWindowContextReply decodes a GetWindowContext reply. The context
+// follows the fixed 32 bytes of the reply.
+func TestGetWindowContextReply(t *testing.T) {
+	context := "system_u:object_r:x_window_t:s0"
+	buf := make([]byte, 32+xgb.Pad(len(context)))
+	buf[0] = 1
+	xgb.Put16(buf[2:], 7)
+	xgb.Put32(buf[4:], uint32(xgb.Pad(len(context))/4))
+	xgb.Put32(buf[8:], uint32(len(context)))
+	copy(buf[32:], context)
+
+	reply := getWindowContextReply(buf)
+	if reply.Sequence != 7 || reply.Context != context {
+		t.Fatalf("Expected sequence 7 and context %q, but got "+
+			"%d and %q", context, reply.Sequence, reply.Context)
+	}
+}
+
+// TestGetWindowContext asks the X server in DISPLAY for the security
+// context of the root window, and is skipped when DISPLAY isn't set or the
+// server doesn't run with SELinux.
+func TestGetWindowContext(t *testing.T) {
+	if len(os.Getenv("DISPLAY")) == 0 {
+		t.Skip("DISPLAY is not set")
+	}
+	X, err := xgb.NewConn()
+	if err != nil {
+		t.Fatalf("NewConn: %s", err)
+	}
+	defer X.Close()
+	if err := Init(X); err != nil {
+		t.Skipf("SELinux is not available: %s", err)
+	}
+	if _, err := QueryVersion(X, 1, 0).Reply(); err != nil {
+		t.Fatalf("QueryVersion: %s", err)
+	}
+
+	root := xproto.Setup(X).DefaultScreen(X).Root
+	reply, err := GetWindowContext(X, root).Reply()
+	if err != nil {
+		t.Fatalf("GetWindowContext: %s", err)
+	}
+	if len(reply.Context) == 0 {
+		t.Fatalf("The root window has no security context.")
+	}
+}
@@ -0,0 +1,56 @@
+package xtest
+
+/*
+	Typed wrappers around FakeInput, which takes the core event type of the
+	event to fake and interprets its other arguments differently for each.
+	Unlike the rest of this package, this file is not generated.
+*/
+
+import (
+	"github.com/BurntSushi/xgb"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// FakeKeyEvent fakes pressing (if 'press' is true) or releasing the key with
+// keycode 'key' after 'delay' milliseconds.
+func FakeKeyEvent(c *xgb.Conn, key xproto.Keycode, press bool,
+	delay uint32) FakeInputCookie {
+
+	typ := byte(xproto.KeyRelease)
+	if press {
+		typ = xproto.KeyPress
+	}
+	return FakeInput(c, typ, byte(key), delay, xproto.WindowNone, 0, 0, 0)
+}
+
+// FakeButtonEvent fakes pressing (if 'press' is true) or releasing pointer
+// button 'button' after 'delay' milliseconds.
+func FakeButtonEvent(c *xgb.Conn, button xproto.Button, press bool,
+	delay uint32) FakeInputCookie {
+
+	typ := byte(xproto.ButtonRelease)
+	if press {
+		typ = xproto.ButtonPress
+	}
+	return FakeInput(c, typ, byte(button), delay, xproto.WindowNone,
+		0, 0, 0)
+}
+
+// FakeMotionEvent fakes moving the pointer to (x, y) on the screen of root
+// window 'root' after 'delay' milliseconds. If 'root' is xproto.WindowNone,
+// the pointer stays on its current screen.
+func FakeMotionEvent(c *xgb.Conn, root xproto.Window, x, y int16,
+	delay uint32) FakeInputCookie {
+
+	return FakeInput(c, xproto.MotionNotify, 0, delay, root, x, y, 0)
+}
+
+// FakeRelativeMotionEvent fakes moving the pointer by (dx, dy) after 'delay'
+// milliseconds.
+func FakeRelativeMotionEvent(c *xgb.Conn, dx, dy int16,
+	delay uint32) FakeInputCookie {
+
+	return FakeInput(c, xproto.MotionNotify, 1, delay, xproto.WindowNone,
+		dx, dy, 0)
+}
@@ -0,0 +1,80 @@
+package xv
+
+/*
+	Finding and grabbing a port that can show images of a given format, the
+	way video players using XvPutImage do.
+	Unlike the rest of this package, this file is not generated.
+*/
+
+import (
+	"errors"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// ErrNoPort is returned by GrabImagePort when no port that shows images of
+// the format asked for is available.
+var ErrNoPort = errors.New("xv: no free port for the image format")
+
+// FourCC returns the image format id named by the four characters of
+// 'code', as in FourCC("YV12"). The id of an image format is usually its
+// FOURCC code, with the first character in the least significant byte.
+func FourCC(code string) uint32 {
+	var id uint32
+	for i := 0; i < 4 && i < len(code); i++ {
+		id |= uint32(code[i]) << (8 * uint(i))
+	}
+	return id
+}
+
+// HasImageFormat reports whether 'formats' (from ListImageFormats) has the
+// image format 'id'.
+func HasImageFormat(formats []ImageFormatInfo, id uint32) bool {
+	for _, format := range formats {
+		if format.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// GrabImagePort looks for an adaptor on the screen of 'window' that can put
+// images of format 'id' (a FOURCC code, see FourCC), and grabs one of its
+// ports. It returns ErrNoPort if there is no such adaptor, or all of their
+// ports are grabbed by other clients. The port should be released with
+// UngrabPort when it's no longer needed.
+func GrabImagePort(c *xgb.Conn, window xproto.Window,
+	id uint32) (Port, error) {
+
+	adaptors, err := QueryAdaptors(c, window).Reply()
+	if err != nil {
+		return 0, err
+	}
+	for _, adaptor := range adaptors.Info {
+		want := byte(TypeInputMask | TypeImageMask)
+		if adaptor.Type&want != want || adaptor.NumPorts == 0 {
+			continue
+		}
+		formats, err := ListImageFormats(c, adaptor.BaseId).Reply()
+		if err != nil {
+			return 0, err
+		}
+		if !HasImageFormat(formats.Format, id) {
+			continue
+		}
+
+		for i := 0; i < int(adaptor.NumPorts); i++ {
+			port := adaptor.BaseId + Port(i)
+			reply, err := GrabPort(c, port,
+				xproto.TimeCurrentTime).Reply()
+			if err != nil {
+				return 0, err
+			}
+			if reply.Result == GrabPortStatusSuccess {
+				return port, nil
+			}
+		}
+	}
+	return 0, ErrNoPort
+}
@@ -0,0 +1,33 @@
+package xv
+
+import (
+	"testing"
+)
+
+// TestFourCC makes sure FourCC gives the ids X servers use for common YUV
+// formats.
+func TestFourCC(t *testing.T) {
+	tests := []struct {
+		code string
+		id   uint32
+	}{
+		{"YV12", 0x32315659},
+		{"I420", 0x30323449},
+		{"YUY2", 0x32595559},
+		{"UYVY", 0x59565955},
+	}
+	for _, test := range tests {
+		if id := FourCC(test.code); id != test.id {
+			t.Errorf("FourCC(%q): expected %#x, but got %#x.",
+				test.code, test.id, id)
+		}
+	}
+
+	formats := []ImageFormatInfo{{Id: 0x32315659}, {Id: 0x32595559}}
+	if !HasImageFormat(formats, FourCC("YUY2")) {
+		t.Errorf("YUY2 isn't in %v.", formats)
+	}
+	if HasImageFormat(formats, FourCC("I420")) {
+		t.Errorf("I420 is in %v.", formats)
+	}
+}
@@ -20,7 +20,7 @@ func Init(c *xgb.Conn) error {
 	case err != nil:
 		return err
 	case !reply.Present:
-		return xgb.Errorf("No extension named XVideo could be found on on the server.")
+		return xproto.ExtNotAvailableError{Name: "XVideo", Reply: reply}
 	}
 
 	xgb.ExtLock.Lock()
@@ -1205,7 +1205,7 @@ type QueryExtensionCookie struct {
 // QueryExtension sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryExtensionCookie.Reply()
 func QueryExtension(c *xgb.Conn) QueryExtensionCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'QueryExtension' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1216,7 +1216,7 @@ func QueryExtension(c *xgb.Conn) QueryExtensionCookie {
 // QueryExtensionUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryExtensionUnchecked(c *xgb.Conn) QueryExtensionCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'QueryExtension' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1274,7 +1274,7 @@ func queryExtensionRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 0 // request opcode
@@ -1294,7 +1294,7 @@ type QueryAdaptorsCookie struct {
 // QueryAdaptors sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryAdaptorsCookie.Reply()
 func QueryAdaptors(c *xgb.Conn, Window xproto.Window) QueryAdaptorsCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'QueryAdaptors' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1305,7 +1305,7 @@ func QueryAdaptors(c *xgb.Conn, Window xproto.Window) QueryAdaptorsCookie {
 // QueryAdaptorsUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryAdaptorsUnchecked(c *xgb.Conn, Window xproto.Window) QueryAdaptorsCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'QueryAdaptors' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1366,7 +1366,7 @@ func queryAdaptorsRequest(c *xgb.Conn, Window xproto.Window) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 1 // request opcode
@@ -1389,7 +1389,7 @@ type QueryEncodingsCookie struct {
 // QueryEncodings sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryEncodingsCookie.Reply()
 func QueryEncodings(c *xgb.Conn, Port Port) QueryEncodingsCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'QueryEncodings' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1400,7 +1400,7 @@ func QueryEncodings(c *xgb.Conn, Port Port) QueryEncodingsCookie {
 // QueryEncodingsUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryEncodingsUnchecked(c *xgb.Conn, Port Port) QueryEncodingsCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'QueryEncodings' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1461,7 +1461,7 @@ func queryEncodingsRequest(c *xgb.Conn, Port Port) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 2 // request opcode
@@ -1484,7 +1484,7 @@ type GrabPortCookie struct {
 // GrabPort sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GrabPortCookie.Reply()
 func GrabPort(c *xgb.Conn, Port Port, Time xproto.Timestamp) GrabPortCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'GrabPort' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -1495,7 +1495,7 @@ func GrabPort(c *xgb.Conn, Port Port, Time xproto.Timestamp) GrabPortCookie {
 // GrabPortUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GrabPortUnchecked(c *xgb.Conn, Port Port, Time xproto.Timestamp) GrabPortCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'GrabPort' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -1546,7 +1546,7 @@ func grabPortRequest(c *xgb.Conn, Port Port, Time xproto.Timestamp) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 3 // request opcode
@@ -1572,7 +1572,7 @@ type UngrabPortCookie struct {
 // UngrabPort sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func UngrabPort(c *xgb.Conn, Port Port, Time xproto.Timestamp) UngrabPortCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'UngrabPort' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1583,7 +1583,7 @@ func UngrabPort(c *xgb.Conn, Port Port, Time xproto.Timestamp) UngrabPortCookie
 // UngrabPortChecked sends a checked request.
 // If an error occurs, it can be retrieved using UngrabPortCookie.Check()
 func UngrabPortChecked(c *xgb.Conn, Port Port, Time xproto.Timestamp) UngrabPortCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'UngrabPort' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1604,7 +1604,7 @@ func ungrabPortRequest(c *xgb.Conn, Port Port, Time xproto.Timestamp) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 4 // request opcode
@@ -1630,7 +1630,7 @@ type PutVideoCookie struct {
 // PutVideo sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PutVideo(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gcontext, VidX int16, VidY int16, VidW uint16, VidH uint16, DrwX int16, DrwY int16, DrwW uint16, DrwH uint16) PutVideoCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'PutVideo' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1641,7 +1641,7 @@ func PutVideo(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gconte
 // PutVideoChecked sends a checked request.
 // If an error occurs, it can be retrieved using PutVideoCookie.Check()
 func PutVideoChecked(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gcontext, VidX int16, VidY int16, VidW uint16, VidH uint16, DrwX int16, DrwY int16, DrwW uint16, DrwH uint16) PutVideoCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'PutVideo' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1662,7 +1662,7 @@ func putVideoRequest(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 5 // request opcode
@@ -1715,7 +1715,7 @@ type PutStillCookie struct {
 // PutStill sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PutStill(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gcontext, VidX int16, VidY int16, VidW uint16, VidH uint16, DrwX int16, DrwY int16, DrwW uint16, DrwH uint16) PutStillCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'PutStill' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1726,7 +1726,7 @@ func PutStill(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gconte
 // PutStillChecked sends a checked request.
 // If an error occurs, it can be retrieved using PutStillCookie.Check()
 func PutStillChecked(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gcontext, VidX int16, VidY int16, VidW uint16, VidH uint16, DrwX int16, DrwY int16, DrwW uint16, DrwH uint16) PutStillCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'PutStill' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1747,7 +1747,7 @@ func putStillRequest(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 6 // request opcode
@@ -1800,7 +1800,7 @@ type GetVideoCookie struct {
 // GetVideo sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetVideo(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gcontext, VidX int16, VidY int16, VidW uint16, VidH uint16, DrwX int16, DrwY int16, DrwW uint16, DrwH uint16) GetVideoCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'GetVideo' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1811,7 +1811,7 @@ func GetVideo(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gconte
 // GetVideoChecked sends a checked request.
 // If an error occurs, it can be retrieved using GetVideoCookie.Check()
 func GetVideoChecked(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gcontext, VidX int16, VidY int16, VidW uint16, VidH uint16, DrwX int16, DrwY int16, DrwW uint16, DrwH uint16) GetVideoCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'GetVideo' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1832,7 +1832,7 @@ func getVideoRequest(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 7 // request opcode
@@ -1885,7 +1885,7 @@ type GetStillCookie struct {
 // GetStill sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetStill(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gcontext, VidX int16, VidY int16, VidW uint16, VidH uint16, DrwX int16, DrwY int16, DrwW uint16, DrwH uint16) GetStillCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'GetStill' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1896,7 +1896,7 @@ func GetStill(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gconte
 // GetStillChecked sends a checked request.
 // If an error occurs, it can be retrieved using GetStillCookie.Check()
 func GetStillChecked(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gcontext, VidX int16, VidY int16, VidW uint16, VidH uint16, DrwX int16, DrwY int16, DrwW uint16, DrwH uint16) GetStillCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'GetStill' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -1917,7 +1917,7 @@ func getStillRequest(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 8 // request opcode
@@ -1970,7 +1970,7 @@ type StopVideoCookie struct {
 // StopVideo sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func StopVideo(c *xgb.Conn, Port Port, Drawable xproto.Drawable) StopVideoCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'StopVideo' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -1981,7 +1981,7 @@ func StopVideo(c *xgb.Conn, Port Port, Drawable xproto.Drawable) StopVideoCookie
 // StopVideoChecked sends a checked request.
 // If an error occurs, it can be retrieved using StopVideoCookie.Check()
 func StopVideoChecked(c *xgb.Conn, Port Port, Drawable xproto.Drawable) StopVideoCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'StopVideo' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -2002,7 +2002,7 @@ func stopVideoRequest(c *xgb.Conn, Port Port, Drawable xproto.Drawable) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 9 // request opcode
@@ -2028,7 +2028,7 @@ type SelectVideoNotifyCookie struct {
 // SelectVideoNotify sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SelectVideoNotify(c *xgb.Conn, Drawable xproto.Drawable, Onoff bool) SelectVideoNotifyCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'SelectVideoNotify' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -2039,7 +2039,7 @@ func SelectVideoNotify(c *xgb.Conn, Drawable xproto.Drawable, Onoff bool) Select
 // SelectVideoNotifyChecked sends a checked request.
 // If an error occurs, it can be retrieved using SelectVideoNotifyCookie.Check()
 func SelectVideoNotifyChecked(c *xgb.Conn, Drawable xproto.Drawable, Onoff bool) SelectVideoNotifyCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'SelectVideoNotify' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -2060,7 +2060,7 @@ func selectVideoNotifyRequest(c *xgb.Conn, Drawable xproto.Drawable, Onoff bool)
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 10 // request opcode
@@ -2092,7 +2092,7 @@ type SelectPortNotifyCookie struct {
 // SelectPortNotify sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SelectPortNotify(c *xgb.Conn, Port Port, Onoff bool) SelectPortNotifyCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'SelectPortNotify' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -2103,7 +2103,7 @@ func SelectPortNotify(c *xgb.Conn, Port Port, Onoff bool) SelectPortNotifyCookie
 // SelectPortNotifyChecked sends a checked request.
 // If an error occurs, it can be retrieved using SelectPortNotifyCookie.Check()
 func SelectPortNotifyChecked(c *xgb.Conn, Port Port, Onoff bool) SelectPortNotifyCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'SelectPortNotify' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -2124,7 +2124,7 @@ func selectPortNotifyRequest(c *xgb.Conn, Port Port, Onoff bool) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 11 // request opcode
@@ -2156,7 +2156,7 @@ type QueryBestSizeCookie struct {
 // QueryBestSize sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryBestSizeCookie.Reply()
 func QueryBestSize(c *xgb.Conn, Port Port, VidW uint16, VidH uint16, DrwW uint16, DrwH uint16, Motion bool) QueryBestSizeCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'QueryBestSize' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2167,7 +2167,7 @@ func QueryBestSize(c *xgb.Conn, Port Port, VidW uint16, VidH uint16, DrwW uint16
 // QueryBestSizeUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryBestSizeUnchecked(c *xgb.Conn, Port Port, VidW uint16, VidH uint16, DrwW uint16, DrwH uint16, Motion bool) QueryBestSizeCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'QueryBestSize' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2225,7 +2225,7 @@ func queryBestSizeRequest(c *xgb.Conn, Port Port, VidW uint16, VidH uint16, DrwW
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 12 // request opcode
@@ -2269,7 +2269,7 @@ type SetPortAttributeCookie struct {
 // SetPortAttribute sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func SetPortAttribute(c *xgb.Conn, Port Port, Attribute xproto.Atom, Value int32) SetPortAttributeCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'SetPortAttribute' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -2280,7 +2280,7 @@ func SetPortAttribute(c *xgb.Conn, Port Port, Attribute xproto.Atom, Value int32
 // SetPortAttributeChecked sends a checked request.
 // If an error occurs, it can be retrieved using SetPortAttributeCookie.Check()
 func SetPortAttributeChecked(c *xgb.Conn, Port Port, Attribute xproto.Atom, Value int32) SetPortAttributeCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'SetPortAttribute' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -2301,7 +2301,7 @@ func setPortAttributeRequest(c *xgb.Conn, Port Port, Attribute xproto.Atom, Valu
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 13 // request opcode
@@ -2330,7 +2330,7 @@ type GetPortAttributeCookie struct {
 // GetPortAttribute sends a checked request.
 // If an error occurs, it will be returned with the reply by calling GetPortAttributeCookie.Reply()
 func GetPortAttribute(c *xgb.Conn, Port Port, Attribute xproto.Atom) GetPortAttributeCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'GetPortAttribute' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2341,7 +2341,7 @@ func GetPortAttribute(c *xgb.Conn, Port Port, Attribute xproto.Atom) GetPortAttr
 // GetPortAttributeUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func GetPortAttributeUnchecked(c *xgb.Conn, Port Port, Attribute xproto.Atom) GetPortAttributeCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'GetPortAttribute' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2395,7 +2395,7 @@ func getPortAttributeRequest(c *xgb.Conn, Port Port, Attribute xproto.Atom) []by
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 14 // request opcode
@@ -2421,7 +2421,7 @@ type QueryPortAttributesCookie struct {
 // QueryPortAttributes sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryPortAttributesCookie.Reply()
 func QueryPortAttributes(c *xgb.Conn, Port Port) QueryPortAttributesCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'QueryPortAttributes' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2432,7 +2432,7 @@ func QueryPortAttributes(c *xgb.Conn, Port Port) QueryPortAttributesCookie {
 // QueryPortAttributesUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryPortAttributesUnchecked(c *xgb.Conn, Port Port) QueryPortAttributesCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'QueryPortAttributes' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2497,7 +2497,7 @@ func queryPortAttributesRequest(c *xgb.Conn, Port Port) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 15 // request opcode
@@ -2520,7 +2520,7 @@ type ListImageFormatsCookie struct {
 // ListImageFormats sends a checked request.
 // If an error occurs, it will be returned with the reply by calling ListImageFormatsCookie.Reply()
 func ListImageFormats(c *xgb.Conn, Port Port) ListImageFormatsCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'ListImageFormats' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2531,7 +2531,7 @@ func ListImageFormats(c *xgb.Conn, Port Port) ListImageFormatsCookie {
 // ListImageFormatsUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ListImageFormatsUnchecked(c *xgb.Conn, Port Port) ListImageFormatsCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'ListImageFormats' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2592,7 +2592,7 @@ func listImageFormatsRequest(c *xgb.Conn, Port Port) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 16 // request opcode
@@ -2615,7 +2615,7 @@ type QueryImageAttributesCookie struct {
 // QueryImageAttributes sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryImageAttributesCookie.Reply()
 func QueryImageAttributes(c *xgb.Conn, Port Port, Id uint32, Width uint16, Height uint16) QueryImageAttributesCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'QueryImageAttributes' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -2626,7 +2626,7 @@ func QueryImageAttributes(c *xgb.Conn, Port Port, Id uint32, Width uint16, Heigh
 // QueryImageAttributesUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryImageAttributesUnchecked(c *xgb.Conn, Port Port, Id uint32, Width uint16, Height uint16) QueryImageAttributesCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'QueryImageAttributes' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -2711,7 +2711,7 @@ func queryImageAttributesRequest(c *xgb.Conn, Port Port, Id uint32, Width uint16
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 17 // request opcode
@@ -2743,7 +2743,7 @@ type PutImageCookie struct {
 // PutImage sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func PutImage(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gcontext, Id uint32, SrcX int16, SrcY int16, SrcW uint16, SrcH uint16, DrwX int16, DrwY int16, DrwW uint16, DrwH uint16, Width uint16, Height uint16, Data []byte) PutImageCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'PutImage' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -2754,7 +2754,7 @@ func PutImage(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gconte
 // PutImageChecked sends a checked request.
 // If an error occurs, it can be retrieved using PutImageCookie.Check()
 func PutImageChecked(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gcontext, Id uint32, SrcX int16, SrcY int16, SrcW uint16, SrcH uint16, DrwX int16, DrwY int16, DrwW uint16, DrwH uint16, Width uint16, Height uint16, Data []byte) PutImageCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'PutImage' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -2775,7 +2775,7 @@ func putImageRequest(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 18 // request opcode
@@ -2840,7 +2840,7 @@ type ShmPutImageCookie struct {
 // ShmPutImage sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ShmPutImage(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gcontext, Shmseg shm.Seg, Id uint32, Offset uint32, SrcX int16, SrcY int16, SrcW uint16, SrcH uint16, DrwX int16, DrwY int16, DrwW uint16, DrwH uint16, Width uint16, Height uint16, SendEvent byte) ShmPutImageCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'ShmPutImage' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -2851,7 +2851,7 @@ func ShmPutImage(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gco
 // ShmPutImageChecked sends a checked request.
 // If an error occurs, it can be retrieved using ShmPutImageCookie.Check()
 func ShmPutImageChecked(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xproto.Gcontext, Shmseg shm.Seg, Id uint32, Offset uint32, SrcX int16, SrcY int16, SrcW uint16, SrcH uint16, DrwX int16, DrwY int16, DrwW uint16, DrwH uint16, Width uint16, Height uint16, SendEvent byte) ShmPutImageCookie {
-	if _, ok := c.Extensions["XVIDEO"]; !ok {
+	if _, ok := c.Extensions["XVideo"]; !ok {
 		panic("Cannot issue request 'ShmPutImage' using the uninitialized extension 'XVideo'. xv.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -2872,7 +2872,7 @@ func shmPutImageRequest(c *xgb.Conn, Port Port, Drawable xproto.Drawable, Gc xpr
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO"]
+	buf[b] = c.Extensions["XVideo"]
 	b += 1
 
 	buf[b] = 19 // request opcode
@@ -20,7 +20,7 @@ func Init(c *xgb.Conn) error {
 	case err != nil:
 		return err
 	case !reply.Present:
-		return xgb.Errorf("No extension named XVideo-MotionCompensation could be found on on the server.")
+		return xproto.ExtNotAvailableError{Name: "XVideo-MotionCompensation", Reply: reply}
 	}
 
 	xgb.ExtLock.Lock()
@@ -206,7 +206,7 @@ type QueryVersionCookie struct {
 // QueryVersion sends a checked request.
 // If an error occurs, it will be returned with the reply by calling QueryVersionCookie.Reply()
 func QueryVersion(c *xgb.Conn) QueryVersionCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'QueryVersion' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -217,7 +217,7 @@ func QueryVersion(c *xgb.Conn) QueryVersionCookie {
 // QueryVersionUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func QueryVersionUnchecked(c *xgb.Conn) QueryVersionCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'QueryVersion' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -275,7 +275,7 @@ func queryVersionRequest(c *xgb.Conn) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO-MOTIONCOMPENSATION"]
+	buf[b] = c.Extensions["XVideo-MotionCompensation"]
 	b += 1
 
 	buf[b] = 0 // request opcode
@@ -295,7 +295,7 @@ type ListSurfaceTypesCookie struct {
 // ListSurfaceTypes sends a checked request.
 // If an error occurs, it will be returned with the reply by calling ListSurfaceTypesCookie.Reply()
 func ListSurfaceTypes(c *xgb.Conn, PortId xv.Port) ListSurfaceTypesCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'ListSurfaceTypes' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -306,7 +306,7 @@ func ListSurfaceTypes(c *xgb.Conn, PortId xv.Port) ListSurfaceTypesCookie {
 // ListSurfaceTypesUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ListSurfaceTypesUnchecked(c *xgb.Conn, PortId xv.Port) ListSurfaceTypesCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'ListSurfaceTypes' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -367,7 +367,7 @@ func listSurfaceTypesRequest(c *xgb.Conn, PortId xv.Port) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO-MOTIONCOMPENSATION"]
+	buf[b] = c.Extensions["XVideo-MotionCompensation"]
 	b += 1
 
 	buf[b] = 1 // request opcode
@@ -390,7 +390,7 @@ type CreateContextCookie struct {
 // CreateContext sends a checked request.
 // If an error occurs, it will be returned with the reply by calling CreateContextCookie.Reply()
 func CreateContext(c *xgb.Conn, ContextId Context, PortId xv.Port, SurfaceId Surface, Width uint16, Height uint16, Flags uint32) CreateContextCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'CreateContext' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -401,7 +401,7 @@ func CreateContext(c *xgb.Conn, ContextId Context, PortId xv.Port, SurfaceId Sur
 // CreateContextUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func CreateContextUnchecked(c *xgb.Conn, ContextId Context, PortId xv.Port, SurfaceId Surface, Width uint16, Height uint16, Flags uint32) CreateContextCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'CreateContext' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -417,7 +417,7 @@ type CreateContextReply struct {
 	WidthActual  uint16
 	HeightActual uint16
 	FlagsReturn  uint32
-	// padding: 20 bytes
+	// padding: 16 bytes
 	PrivData []uint32 // size: xgb.Pad((int(Length) * 4))
 }
 
@@ -455,7 +455,7 @@ func createContextReply(buf []byte) *CreateContextReply {
 	v.FlagsReturn = xgb.Get32(buf[b:])
 	b += 4
 
-	b += 20 // padding
+	b += 16 // padding
 
 	v.PrivData = make([]uint32, v.Length)
 	for i := 0; i < int(v.Length); i++ {
@@ -474,7 +474,7 @@ func createContextRequest(c *xgb.Conn, ContextId Context, PortId xv.Port, Surfac
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO-MOTIONCOMPENSATION"]
+	buf[b] = c.Extensions["XVideo-MotionCompensation"]
 	b += 1
 
 	buf[b] = 2 // request opcode
@@ -512,7 +512,7 @@ type DestroyContextCookie struct {
 // DestroyContext sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func DestroyContext(c *xgb.Conn, ContextId Context) DestroyContextCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'DestroyContext' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -523,7 +523,7 @@ func DestroyContext(c *xgb.Conn, ContextId Context) DestroyContextCookie {
 // DestroyContextChecked sends a checked request.
 // If an error occurs, it can be retrieved using DestroyContextCookie.Check()
 func DestroyContextChecked(c *xgb.Conn, ContextId Context) DestroyContextCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'DestroyContext' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -544,7 +544,7 @@ func destroyContextRequest(c *xgb.Conn, ContextId Context) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO-MOTIONCOMPENSATION"]
+	buf[b] = c.Extensions["XVideo-MotionCompensation"]
 	b += 1
 
 	buf[b] = 3 // request opcode
@@ -567,7 +567,7 @@ type CreateSurfaceCookie struct {
 // CreateSurface sends a checked request.
 // If an error occurs, it will be returned with the reply by calling CreateSurfaceCookie.Reply()
 func CreateSurface(c *xgb.Conn, SurfaceId Surface, ContextId Context) CreateSurfaceCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'CreateSurface' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -578,7 +578,7 @@ func CreateSurface(c *xgb.Conn, SurfaceId Surface, ContextId Context) CreateSurf
 // CreateSurfaceUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func CreateSurfaceUnchecked(c *xgb.Conn, SurfaceId Surface, ContextId Context) CreateSurfaceCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'CreateSurface' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -639,7 +639,7 @@ func createSurfaceRequest(c *xgb.Conn, SurfaceId Surface, ContextId Context) []b
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO-MOTIONCOMPENSATION"]
+	buf[b] = c.Extensions["XVideo-MotionCompensation"]
 	b += 1
 
 	buf[b] = 4 // request opcode
@@ -665,7 +665,7 @@ type DestroySurfaceCookie struct {
 // DestroySurface sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func DestroySurface(c *xgb.Conn, SurfaceId Surface) DestroySurfaceCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'DestroySurface' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -676,7 +676,7 @@ func DestroySurface(c *xgb.Conn, SurfaceId Surface) DestroySurfaceCookie {
 // DestroySurfaceChecked sends a checked request.
 // If an error occurs, it can be retrieved using DestroySurfaceCookie.Check()
 func DestroySurfaceChecked(c *xgb.Conn, SurfaceId Surface) DestroySurfaceCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'DestroySurface' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -697,7 +697,7 @@ func destroySurfaceRequest(c *xgb.Conn, SurfaceId Surface) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO-MOTIONCOMPENSATION"]
+	buf[b] = c.Extensions["XVideo-MotionCompensation"]
 	b += 1
 
 	buf[b] = 5 // request opcode
@@ -720,7 +720,7 @@ type CreateSubpictureCookie struct {
 // CreateSubpicture sends a checked request.
 // If an error occurs, it will be returned with the reply by calling CreateSubpictureCookie.Reply()
 func CreateSubpicture(c *xgb.Conn, SubpictureId Subpicture, Context Context, XvimageId uint32, Width uint16, Height uint16) CreateSubpictureCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'CreateSubpicture' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -731,7 +731,7 @@ func CreateSubpicture(c *xgb.Conn, SubpictureId Subpicture, Context Context, Xvi
 // CreateSubpictureUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func CreateSubpictureUnchecked(c *xgb.Conn, SubpictureId Subpicture, Context Context, XvimageId uint32, Width uint16, Height uint16) CreateSubpictureCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'CreateSubpicture' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -813,7 +813,7 @@ func createSubpictureRequest(c *xgb.Conn, SubpictureId Subpicture, Context Conte
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO-MOTIONCOMPENSATION"]
+	buf[b] = c.Extensions["XVideo-MotionCompensation"]
 	b += 1
 
 	buf[b] = 6 // request opcode
@@ -848,7 +848,7 @@ type DestroySubpictureCookie struct {
 // DestroySubpicture sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func DestroySubpicture(c *xgb.Conn, SubpictureId Subpicture) DestroySubpictureCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'DestroySubpicture' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, false)
@@ -859,7 +859,7 @@ func DestroySubpicture(c *xgb.Conn, SubpictureId Subpicture) DestroySubpictureCo
 // DestroySubpictureChecked sends a checked request.
 // If an error occurs, it can be retrieved using DestroySubpictureCookie.Check()
 func DestroySubpictureChecked(c *xgb.Conn, SubpictureId Subpicture) DestroySubpictureCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'DestroySubpicture' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, false)
@@ -880,7 +880,7 @@ func destroySubpictureRequest(c *xgb.Conn, SubpictureId Subpicture) []byte {
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO-MOTIONCOMPENSATION"]
+	buf[b] = c.Extensions["XVideo-MotionCompensation"]
 	b += 1
 
 	buf[b] = 7 // request opcode
@@ -903,7 +903,7 @@ type ListSubpictureTypesCookie struct {
 // ListSubpictureTypes sends a checked request.
 // If an error occurs, it will be returned with the reply by calling ListSubpictureTypesCookie.Reply()
 func ListSubpictureTypes(c *xgb.Conn, PortId xv.Port, SurfaceId Surface) ListSubpictureTypesCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'ListSubpictureTypes' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(true, true)
@@ -914,7 +914,7 @@ func ListSubpictureTypes(c *xgb.Conn, PortId xv.Port, SurfaceId Surface) ListSub
 // ListSubpictureTypesUnchecked sends an unchecked request.
 // If an error occurs, it can only be retrieved using xgb.WaitForEvent or xgb.PollForEvent.
 func ListSubpictureTypesUnchecked(c *xgb.Conn, PortId xv.Port, SurfaceId Surface) ListSubpictureTypesCookie {
-	if _, ok := c.Extensions["XVIDEO-MOTIONCOMPENSATION"]; !ok {
+	if _, ok := c.Extensions["XVideo-MotionCompensation"]; !ok {
 		panic("Cannot issue request 'ListSubpictureTypes' using the uninitialized extension 'XVideo-MotionCompensation'. xvmc.Init(connObj) must be called first.")
 	}
 	cookie := c.NewCookie(false, true)
@@ -975,7 +975,7 @@ func listSubpictureTypesRequest(c *xgb.Conn, PortId xv.Port, SurfaceId Surface)
 	b := 0
 	buf := make([]byte, size)
 
-	buf[b] = c.Extensions["XVIDEO-MOTIONCOMPENSATION"]
+	buf[b] = c.Extensions["XVideo-MotionCompensation"]
 	b += 1
 
 	buf[b] = 8 // request opcode
@@ -0,0 +1,67 @@
+package xvmc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/xgb"
+)
+
+// TestListSurfaceTypesReply decodes a ListSurfaceTypes reply with one
+// surface type, laid out as in XvMCproto.h.
+func TestListSurfaceTypesReply(t *testing.T) {
+	buf := make([]byte, 32+24)
+	buf[0] = 1
+	xgb.Put16(buf[2:], 3)
+	xgb.Put32(buf[4:], 6)
+	xgb.Put32(buf[8:], 1)
+	xgb.Put32(buf[32:], 0x49) // surface type id
+	xgb.Put16(buf[36:], 1)    // chroma format
+	xgb.Put16(buf[40:], 720)
+	xgb.Put16(buf[42:], 576)
+	xgb.Put16(buf[44:], 360)
+	xgb.Put16(buf[46:], 288)
+	xgb.Put32(buf[48:], 2) // mc type
+	xgb.Put32(buf[52:], 4) // flags
+
+	reply := listSurfaceTypesReply(buf)
+	want := []SurfaceInfo{{
+		Id:                  0x49,
+		ChromaFormat:        1,
+		MaxWidth:            720,
+		MaxHeight:           576,
+		SubpictureMaxWidth:  360,
+		SubpictureMaxHeight: 288,
+		McType:              2,
+		Flags:               4,
+	}}
+	if reply.Num != 1 || !reflect.DeepEqual(reply.Surfaces, want) {
+		t.Fatalf("Expected %v, but got %v", want, reply.Surfaces)
+	}
+}
+
+// TestCreateContextReply decodes a CreateContext reply, whose private data
+// is as long as the reply's length field says.
+func TestCreateContextReply(t *testing.T) {
+	buf := make([]byte, 32+8)
+	buf[0] = 1
+	xgb.Put32(buf[4:], 2)
+	xgb.Put16(buf[8:], 704)
+	xgb.Put16(buf[10:], 480)
+	xgb.Put32(buf[12:], 1)
+	xgb.Put32(buf[32:], 0xdead)
+	xgb.Put32(buf[36:], 0xbeef)
+
+	reply := createContextReply(buf)
+	if reply.WidthActual != 704 || reply.HeightActual != 480 ||
+		reply.FlagsReturn != 1 {
+		t.Fatalf("Expected a 704x480 context with flags 1, but got "+
+			"%dx%d with flags %d", reply.WidthActual,
+			reply.HeightActual, reply.FlagsReturn)
+	}
+	want := []uint32{0xdead, 0xbeef}
+	if !reflect.DeepEqual(reply.PrivData, want) {
+		t.Fatalf("Expected private data %x, but got %x", want,
+			reply.PrivData)
+	}
+}